@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	tmcrypto "github.com/okex/exchain/libs/tendermint/crypto"
@@ -298,3 +299,32 @@ func (suite *AnteTestSuite) TestEthInvalidChainID() {
 	ctx := suite.ctx.WithChainID("bad-chain-id")
 	requireInvalidTx(suite.T(), suite.anteHandler, ctx, tx, false)
 }
+
+func (suite *AnteTestSuite) TestEthAccessListTx() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	chainIDEpoch, err := types.ParseChainID(suite.ctx.ChainID())
+	suite.Require().NoError(err)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	accesses := ethtypes.AccessList{{Address: to, StorageKeys: []ethcmn.Hash{{0x1}}}}
+	ethMsg := evmtypes.NewMsgEthereumTxAccessList(chainIDEpoch, 0, &to, big.NewInt(32), 30000, big.NewInt(20), []byte("test"), accesses)
+
+	tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+
+	// access list txs are rejected before the Berlin fork
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	config, _ := suite.app.EvmKeeper.GetChainConfig(suite.ctx)
+	config.BerlinBlock = sdk.OneInt()
+	suite.app.EvmKeeper.SetChainConfig(suite.ctx, config)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+}
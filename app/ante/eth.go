@@ -2,7 +2,6 @@ package ante
 
 import (
 	"fmt"
-	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"math/big"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/baseapp"
@@ -14,6 +13,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/types"
 	"github.com/ethereum/go-ethereum/common"
 	ethcore "github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethermint "github.com/okex/exchain/app/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 )
@@ -22,6 +22,8 @@ import (
 type EVMKeeper interface {
 	GetParams(ctx sdk.Context) evmtypes.Params
 	IsAddressBlocked(ctx sdk.Context, addr sdk.AccAddress) bool
+	GetBaseFee(ctx sdk.Context) *big.Int
+	IsBerlin(ctx sdk.Context) bool
 }
 
 // EthSetupContextDecorator sets the infinite GasMeter in the Context and wraps
@@ -100,8 +102,10 @@ func (emfd EthMempoolFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 
 	evmDenom := sdk.DefaultBondDenom
 
-	// fee = gas price * gas limit
-	fee := sdk.NewDecCoinFromDec(evmDenom, sdk.NewDecFromBigIntWithPrec(msgEthTx.Fee(), sdk.Precision))
+	// fee = effective gas price * gas limit
+	gasPrice := msgEthTx.EffectiveGasPrice(emfd.evmKeeper.GetBaseFee(ctx))
+	feeAmt := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(msgEthTx.Data.GasLimit))
+	fee := sdk.NewDecCoinFromDec(evmDenom, sdk.NewDecFromBigIntWithPrec(feeAmt, sdk.Precision))
 
 	minGasPrices := ctx.MinGasPrices()
 	minFees := minGasPrices.AmountOf(evmDenom).MulInt64(int64(msgEthTx.Data.GasLimit))
@@ -259,7 +263,8 @@ func (nvd NonceVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, sim
 	// if multiple transactions are submitted in succession with increasing nonces,
 	// all will be rejected except the first, since the first needs to be included in a block
 	// before the sequence increments
-	if ctx.IsCheckTx() {
+	// txs replayed for tracing are verified as in deliverTx mode
+	if ctx.IsCheckTx() && !ctx.IsTraceTx() {
 		ctx = ctx.WithAccountNonce(seq)
 		// will be checkTx and RecheckTx mode
 		if ctx.IsReCheckTx() {
@@ -378,8 +383,15 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 		)
 	}
 
+	// typed txs are accepted once their fork is active, and their max fee per gas
+	// must cover the base fee of the block
+	baseFee := egcd.evmKeeper.GetBaseFee(ctx)
+	if err := checkBaseFee(msgEthTx, egcd.evmKeeper.IsBerlin(ctx), baseFee); err != nil {
+		return ctx, err
+	}
+
 	gasLimit := msgEthTx.GetGas()
-	gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, []ethtypes.AccessTuple{}, msgEthTx.To() == nil, true, false)
+	gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, msgEthTx.Data.Accesses, msgEthTx.To() == nil, true, false)
 	if err != nil {
 		return ctx, sdkerrors.Wrap(err, "failed to compute intrinsic gas cost")
 	}
@@ -391,8 +403,8 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 
 	// Charge sender for gas up to limit
 	if gasLimit != 0 {
-		// Cost calculates the fees paid to validators based on gas limit and price
-		cost := new(big.Int).Mul(msgEthTx.Data.Price, new(big.Int).SetUint64(gasLimit))
+		// Cost calculates the fees paid to validators based on gas limit and effective price
+		cost := new(big.Int).Mul(msgEthTx.EffectiveGasPrice(baseFee), new(big.Int).SetUint64(gasLimit))
 
 		evmDenom := sdk.DefaultBondDenom
 
@@ -411,6 +423,24 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 	return next(newCtx, tx, simulate)
 }
 
+func checkBaseFee(msgEthTx evmtypes.MsgEthereumTx, isBerlin bool, baseFee *big.Int) error {
+	if msgEthTx.Data.Type == ethtypes.AccessListTxType && !isBerlin {
+		return sdkerrors.Wrapf(evmtypes.ErrTxTypeNotSupported, "tx type %d is not supported before the Berlin fork", msgEthTx.Data.Type)
+	}
+
+	if baseFee == nil {
+		if msgEthTx.Data.IsDynamicFee() {
+			return sdkerrors.Wrapf(evmtypes.ErrTxTypeNotSupported, "tx type %d is not supported before the London fork", msgEthTx.Data.Type)
+		}
+		return nil
+	}
+
+	if msgEthTx.Data.Price.Cmp(baseFee) < 0 {
+		return sdkerrors.Wrapf(evmtypes.ErrFeeCapTooLow, "max fee per gas: %s, base fee: %s", msgEthTx.Data.Price, baseFee)
+	}
+	return nil
+}
+
 // IncrementSenderSequenceDecorator increments the sequence of the signers. The
 // main difference with the SDK's IncrementSequenceDecorator is that the MsgEthereumTx
 // doesn't implement the SigVerifiableTx interface.
@@ -434,7 +464,7 @@ func (issd IncrementSenderSequenceDecorator) AnteHandle(ctx sdk.Context, tx sdk.
 	// when mempool is not in enableRecheck mode, we should not increment the nonce
 
 	// when IsCheckTx() is true, it will means checkTx and recheckTx mode, but IsReCheckTx() is true it must be recheckTx mode
-	if ctx.IsCheckTx() && !ctx.IsReCheckTx() && !baseapp.IsMempoolEnableRecheck() {
+	if ctx.IsCheckTx() && !ctx.IsReCheckTx() && !ctx.IsTraceTx() && !baseapp.IsMempoolEnableRecheck() {
 		return next(ctx, tx, simulate)
 	}
 
@@ -18,12 +18,14 @@ func updateFeeCollectorHandler(bk bank.Keeper, sk supply.Keeper) sdk.UpdateFeeCo
 }
 
 // evmTxFeeHandler get tx fee for evm tx
-func evmTxFeeHandler() sdk.GetTxFeeHandler {
+func evmTxFeeHandler(ek *evm.Keeper) sdk.GetTxFeeHandler {
 	return func(ctx sdk.Context, tx sdk.Tx) (fee sdk.Coins, isEvm bool, signCache sdk.SigCache) {
 		if evmTx, ok := tx.(evmtypes.MsgEthereumTx); ok {
 			isEvm = true
 			signCache, _ = evmTx.VerifySig(evmTx.ChainID(), ctx.BlockHeight(), nil)
-
+			// evm txs are charged at their effective gas price
+			fee = evmTx.GetEffectiveFee(ek.GetBaseFee(ctx))
+			return
 		}
 		if feeTx, ok := tx.(authante.FeeTx); ok {
 			fee = feeTx.GetFee()
@@ -57,6 +57,7 @@ func setValidatorConfig() {
 
 func setArchiveConfig() {
 	viper.SetDefault(server.FlagPruning, "nothing")
+	viper.SetDefault(evmtypes.FlagEnableStateHistory, true)
 	viper.SetDefault(abcitypes.FlagDisableCheckTxMutex, true)
 	viper.SetDefault(abcitypes.FlagDisableQueryMutex, true)
 	viper.SetDefault(evmtypes.FlagEnableBloomFilter, true)
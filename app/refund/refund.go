@@ -15,14 +15,19 @@ import (
 	evmtypes "github.com/okex/exchain/x/evm/types"
 )
 
-func NewGasRefundHandler(ak auth.AccountKeeper, sk types.SupplyKeeper) sdk.GasRefundHandler {
+// EVMKeeper defines the expected evm keeper interface used to refund evm txs
+type EVMKeeper interface {
+	GetBaseFee(ctx sdk.Context) *big.Int
+}
+
+func NewGasRefundHandler(ak auth.AccountKeeper, sk types.SupplyKeeper, ek EVMKeeper) sdk.GasRefundHandler {
 	return func(
 		ctx sdk.Context, tx sdk.Tx,
 	) (refundFee sdk.Coins, err error) {
 		var gasRefundHandler sdk.GasRefundHandler
 		switch tx.(type) {
 		case evmtypes.MsgEthereumTx:
-			gasRefundHandler = NewGasRefundDecorator(ak, sk)
+			gasRefundHandler = NewGasRefundDecorator(ak, sk, ek)
 		default:
 			return nil, nil
 		}
@@ -33,6 +38,7 @@ func NewGasRefundHandler(ak auth.AccountKeeper, sk types.SupplyKeeper) sdk.GasRe
 type Handler struct {
 	ak           keeper.AccountKeeper
 	supplyKeeper types.SupplyKeeper
+	evmKeeper    EVMKeeper
 }
 
 func (handler Handler) GasRefund(ctx sdk.Context, tx sdk.Tx) (refundGasFee sdk.Coins, err error) {
@@ -65,6 +71,10 @@ func (handler Handler) GasRefund(ctx sdk.Context, tx sdk.Tx) (refundGasFee sdk.C
 
 	gas := feeTx.GetGas()
 	fees := feeTx.GetFee()
+	if msgEthTx, ok := tx.(evmtypes.MsgEthereumTx); ok && handler.evmKeeper != nil {
+		// evm txs are charged at their effective gas price, which depends on the base fee
+		fees = msgEthTx.GetEffectiveFee(handler.evmKeeper.GetBaseFee(ctx))
+	}
 	gasFees := caculateRefundFees(ctx, gasUsed, gas, fees)
 	err = refund.RefundFees(handler.supplyKeeper, ctx, feePayerAcc.GetAddress(), gasFees)
 	if err != nil {
@@ -74,10 +84,11 @@ func (handler Handler) GasRefund(ctx sdk.Context, tx sdk.Tx) (refundGasFee sdk.C
 	return gasFees, nil
 }
 
-func NewGasRefundDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper) sdk.GasRefundHandler {
+func NewGasRefundDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper, ek EVMKeeper) sdk.GasRefundHandler {
 	chandler := Handler{
 		ak:           ak,
 		supplyKeeper: sk,
+		evmKeeper:    ek,
 	}
 
 	return func(ctx sdk.Context, tx sdk.Tx) (refund sdk.Coins, err error) {
@@ -18,6 +18,7 @@ import (
 
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
 	"github.com/okex/exchain/app/rpc/backend"
+	"github.com/okex/exchain/app/rpc/namespaces/debug"
 	"github.com/okex/exchain/app/rpc/monitor"
 	"github.com/okex/exchain/app/rpc/namespaces/eth"
 	"github.com/okex/exchain/app/rpc/namespaces/eth/filters"
@@ -34,6 +35,7 @@ const (
 	PersonalNamespace = "personal"
 	NetNamespace      = "net"
 	TxpoolNamespace   = "txpool"
+	DebugNamespace    = "debug"
 
 	apiVersion = "1.0"
 )
@@ -99,6 +101,15 @@ func GetAPIs(clientCtx context.CLIContext, log log.Logger, keys ...ethsecp256k1.
 		})
 	}
 
+	if viper.GetBool(FlagDebugAPI) {
+		apis = append(apis, rpc.API{
+			Namespace: DebugNamespace,
+			Version:   apiVersion,
+			Service:   debug.NewAPI(clientCtx, log, ethBackend),
+			Public:    true,
+		})
+	}
+
 	if viper.GetBool(FlagEnableMonitor) {
 		for _, api := range apis {
 			makeMonitorMetrics(api.Namespace, api.Service)
@@ -0,0 +1,240 @@
+package debug
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/app/rpc/backend"
+	"github.com/okex/exchain/app/rpc/monitor"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	ethermint "github.com/okex/exchain/app/types"
+	clientcontext "github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	authclient "github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
+	authtypes "github.com/okex/exchain/libs/cosmos-sdk/x/auth/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// PublicDebugAPI is the debug_ prefixed set of APIs in the geth JSON-RPC spec.
+// Txs are traced on demand, by re-executing them against historical state.
+type PublicDebugAPI struct {
+	clientCtx clientcontext.CLIContext
+	logger    log.Logger
+	backend   backend.Backend
+	Metrics   map[string]*monitor.RpcMetrics
+}
+
+// NewAPI creates an instance of the public debug API.
+func NewAPI(clientCtx clientcontext.CLIContext, log log.Logger, backend backend.Backend) *PublicDebugAPI {
+	return &PublicDebugAPI{
+		clientCtx: clientCtx,
+		logger:    log.With("module", "json-rpc", "namespace", "debug"),
+		backend:   backend,
+	}
+}
+
+// TraceTransaction returns the trace of the tx execution by its hash, re-executing
+// it on top of the state of its block right before the tx.
+func (api *PublicDebugAPI) TraceTransaction(txHash common.Hash, config *evmtypes.TraceConfig) (interface{}, error) {
+	monitor := monitor.GetMonitor("debug_traceTransaction", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("hash", txHash)
+
+	tx, err := api.clientCtx.Client.Tx(txHash.Bytes(), false)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.clientCtx.Client.Block(&tx.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := api.traceTxs(block.Block.Txs[:tx.Index+1], int(tx.Index), tx.Height, config, false)
+	if err != nil {
+		return nil, err
+	}
+	if results[0].Error != "" {
+		return nil, errors.New(results[0].Error)
+	}
+	return results[0].Result, nil
+}
+
+// TraceBlockByNumber returns the traces of all the evm txs of the block by its number.
+func (api *PublicDebugAPI) TraceBlockByNumber(blockNum rpctypes.BlockNumber, config *evmtypes.TraceConfig) ([]sdk.TraceTxResult, error) {
+	monitor := monitor.GetMonitor("debug_traceBlockByNumber", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("number", blockNum)
+
+	return api.traceBlock(blockNum, config)
+}
+
+// TraceBlockByHash returns the traces of all the evm txs of the block by its hash.
+func (api *PublicDebugAPI) TraceBlockByHash(hash common.Hash, config *evmtypes.TraceConfig) ([]sdk.TraceTxResult, error) {
+	monitor := monitor.GetMonitor("debug_traceBlockByHash", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("hash", hash)
+
+	blockNum, err := api.backend.ConvertToBlockNumber(rpctypes.BlockNumberOrHashWithHash(hash, false))
+	if err != nil {
+		return nil, err
+	}
+	return api.traceBlock(blockNum, config)
+}
+
+// TraceCall returns the trace of a call executed on top of the state of the given block.
+func (api *PublicDebugAPI) TraceCall(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, config *evmtypes.TraceConfig) (interface{}, error) {
+	monitor := monitor.GetMonitor("debug_traceCall", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("args", args, "block number", blockNrOrHash)
+
+	blockNum, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	height, err := api.getHeight(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	txBytes, err := api.buildCallTx(args)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := api.traceTxs([]tmtypes.Tx{txBytes}, 0, height, config, true)
+	if err != nil {
+		return nil, err
+	}
+	if results[0].Error != "" {
+		return nil, errors.New(results[0].Error)
+	}
+	return results[0].Result, nil
+}
+
+func (api *PublicDebugAPI) traceBlock(blockNum rpctypes.BlockNumber, config *evmtypes.TraceConfig) ([]sdk.TraceTxResult, error) {
+	height, err := api.getHeight(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.clientCtx.Client.Block(&height)
+	if err != nil {
+		return nil, err
+	}
+	if len(block.Block.Txs) == 0 {
+		return []sdk.TraceTxResult{}, nil
+	}
+
+	results, err := api.traceTxs(block.Block.Txs, 0, height, config, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the evm txs are part of the block of the web3 spec
+	evmResults := make([]sdk.TraceTxResult, 0, len(results))
+	for i, tx := range block.Block.Txs {
+		if _, err := rpctypes.RawTxToEthTx(api.clientCtx, tx); err != nil {
+			continue
+		}
+		evmResults = append(evmResults, results[i])
+	}
+	return evmResults, nil
+}
+
+// traceTxs queries the app to replay txs at the given height and trace the ones
+// from traceIndex onwards
+func (api *PublicDebugAPI) traceTxs(txs []tmtypes.Tx, traceIndex int, height int64,
+	config *evmtypes.TraceConfig, isCall bool) ([]sdk.TraceTxResult, error) {
+	queryTrace := sdk.QueryTraceTx{
+		Txs:        make([][]byte, len(txs)),
+		TraceIndex: traceIndex,
+	}
+	for i, tx := range txs {
+		queryTrace.Txs[i] = tx
+	}
+	if config != nil {
+		configBytes, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		queryTrace.Config = configBytes
+	}
+
+	queryBytes, err := json.Marshal(queryTrace)
+	if err != nil {
+		return nil, err
+	}
+
+	path := "app/trace"
+	if isCall {
+		path = "app/trace/call"
+	}
+	res, _, err := api.clientCtx.WithHeight(height).QueryWithData(path, queryBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []sdk.TraceTxResult
+	if err := json.Unmarshal(res, &results); err != nil {
+		return nil, err
+	}
+	if len(results) != len(txs)-traceIndex {
+		return nil, fmt.Errorf("unexpected count of trace results: %d", len(results))
+	}
+	return results, nil
+}
+
+func (api *PublicDebugAPI) getHeight(blockNum rpctypes.BlockNumber) (int64, error) {
+	switch blockNum {
+	case rpctypes.PendingBlockNumber:
+		return 0, errors.New("tracing on the pending block is not supported")
+	case rpctypes.LatestBlockNumber:
+		return api.backend.LatestBlockNumber()
+	default:
+		return blockNum.Int64(), nil
+	}
+}
+
+// buildCallTx generates the unsigned tx of a call, the same as the one simulated by eth_call
+func (api *PublicDebugAPI) buildCallTx(args rpctypes.CallArgs) ([]byte, error) {
+	var from common.Address
+	if args.From != nil {
+		from = *args.From
+	}
+
+	gas := uint64(ethermint.DefaultRPCGasLimit)
+	if args.Gas != nil && uint64(*args.Gas) < gas {
+		gas = uint64(*args.Gas)
+	}
+
+	gasPrice := new(big.Int).SetUint64(ethermint.DefaultGasPrice)
+	if args.GasPrice != nil {
+		gasPrice = args.GasPrice.ToInt()
+	}
+
+	value := new(big.Int)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+
+	var data []byte
+	if args.Data != nil {
+		data = *args.Data
+	}
+
+	var to *sdk.AccAddress
+	if args.To != nil {
+		toAddr := sdk.AccAddress(args.To.Bytes())
+		to = &toAddr
+	}
+
+	msg := evmtypes.NewMsgEthermint(0, to, sdk.NewIntFromBigInt(value), gas,
+		sdk.NewIntFromBigInt(gasPrice), data, sdk.AccAddress(from.Bytes()))
+
+	tx := authtypes.NewStdTx([]sdk.Msg{msg}, authtypes.StdFee{}, []authtypes.StdSignature{{}}, "")
+	if err := tx.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	return authclient.GetTxEncoder(api.clientCtx.Codec)(tx)
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"strconv"
 	"sync"
@@ -17,8 +18,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/spf13/viper"
 
@@ -52,6 +54,11 @@ const (
 	CacheOfEthCallLru = 40960
 
 	FlagEnableMultiCall = "rpc.enable-multi-call"
+
+	// maxFeeHistory is the max number of blocks returned by eth_feeHistory
+	maxFeeHistory = 1024
+	// maxPriorityFeeBlocks is the number of latest blocks sampled by eth_maxPriorityFeePerGas
+	maxPriorityFeeBlocks = 20
 )
 
 // PublicEthereumAPI is the eth_ prefixed set of APIs in the Web3 JSON-RPC spec.
@@ -228,6 +235,10 @@ func (api *PublicEthereumAPI) GasPrice() *hexutil.Big {
 	monitor := monitor.GetMonitor("eth_gasPrice", api.logger, api.Metrics).OnBegin()
 	defer monitor.OnEnd()
 
+	return api.suggestGasPrice()
+}
+
+func (api *PublicEthereumAPI) suggestGasPrice() *hexutil.Big {
 	if app.GlobalGpIndex.RecommendGp != nil {
 		return (*hexutil.Big)(app.GlobalGpIndex.RecommendGp)
 	}
@@ -235,6 +246,136 @@ func (api *PublicEthereumAPI) GasPrice() *hexutil.Big {
 	return api.gasPrice
 }
 
+// MaxPriorityFeePerGas returns a priority fee per gas suggested for dynamic fee txs. It's the priority fee paid at
+// the dynamic gas price weight of the txs in the latest blocks, or the gas price suggested by eth_gasPrice minus the
+// base fee of the next block if they have no txs.
+func (api *PublicEthereumAPI) MaxPriorityFeePerGas() (*hexutil.Big, error) {
+	monitor := monitor.GetMonitor("eth_maxPriorityFeePerGas", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd()
+
+	latest, err := api.backend.LatestBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	weight := config.GetOecConfig().GetDynamicGpWeight()
+	var tips []*big.Int
+	for height := latest; height > 0 && height > latest-maxPriorityFeeBlocks; height-- {
+		gasPrice, err := api.wrappedBackend.GetBlockGasPrice(uint64(height))
+		if err != nil {
+			break
+		}
+		if len(gasPrice.Txs) > 0 {
+			tips = append(tips, gasPrice.Rewards([]float64{float64(weight)})[0])
+		}
+	}
+	if tip := app.CalBlockGasPriceIndex(tips, weight).RecommendGp; tip != nil {
+		return (*hexutil.Big)(tip), nil
+	}
+
+	baseFee, err := rpctypes.BaseFeeFromTendermint(api.clientCtx, latest+1)
+	if err != nil {
+		return nil, err
+	}
+	tip := new(big.Int).Set(api.suggestGasPrice().ToInt())
+	if baseFee != nil {
+		tip.Sub(tip, baseFee)
+		if tip.Sign() < 0 {
+			tip.SetInt64(0)
+		}
+	}
+	return (*hexutil.Big)(tip), nil
+}
+
+// FeeHistory returns the base fees, the gas used ratios and the priority fees paid at the given percentiles of the
+// gas used of up to blockCount blocks ending at newestBlock. The base fee of the block after the range is returned
+// as well. The blocks are read from the gas price index of the watcher, so the range is truncated to the blocks
+// indexed since the watcher was enabled.
+func (api *PublicEthereumAPI) FeeHistory(blockCount rpc.DecimalOrHex, newestBlock rpctypes.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error) {
+	monitor := monitor.GetMonitor("eth_feeHistory", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("count", blockCount, "newest", newestBlock, "percentiles", rewardPercentiles)
+
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid reward percentile %f", p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("invalid reward percentile %f, percentiles must be in ascending order", p)
+		}
+	}
+	if blockCount == 0 {
+		return &rpctypes.FeeHistoryResult{OldestBlock: (*hexutil.Big)(new(big.Int))}, nil
+	}
+	if blockCount > maxFeeHistory {
+		blockCount = maxFeeHistory
+	}
+
+	latest, err := api.backend.LatestBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	newest := newestBlock.Int64()
+	if newestBlock == rpctypes.LatestBlockNumber || newestBlock == rpctypes.PendingBlockNumber {
+		newest = latest
+	}
+	if newest > latest {
+		return nil, fmt.Errorf("requested block %d is in the future, latest block is %d", newest, latest)
+	}
+	oldest := newest - int64(blockCount) + 1
+	if oldest < 1 {
+		oldest = 1
+	}
+
+	// blocks are collected from the newest one
+	var blocks []*watcher.BlockGasPrice
+	for height := newest; height >= oldest; height-- {
+		gasPrice, err := api.wrappedBackend.GetBlockGasPrice(uint64(height))
+		if err != nil {
+			if len(blocks) == 0 {
+				return nil, fmt.Errorf("fee history of block %d not found: %s", height, err)
+			}
+			break
+		}
+		blocks = append(blocks, gasPrice)
+	}
+	oldest = newest - int64(len(blocks)) + 1
+
+	var nextBaseFee *big.Int
+	if newest == latest {
+		nextBaseFee, err = rpctypes.BaseFeeFromTendermint(api.clientCtx, latest+1)
+		if err != nil {
+			return nil, err
+		}
+	} else if next, err := api.wrappedBackend.GetBlockGasPrice(uint64(newest + 1)); err == nil {
+		nextBaseFee = next.BaseFee.ToInt()
+	}
+
+	result := &rpctypes.FeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(big.NewInt(oldest)),
+		BaseFee:      make([]*hexutil.Big, len(blocks)+1),
+		GasUsedRatio: make([]float64, len(blocks)),
+	}
+	if len(rewardPercentiles) > 0 {
+		result.Reward = make([][]*hexutil.Big, len(blocks))
+	}
+	for i := range blocks {
+		gasPrice := blocks[len(blocks)-1-i]
+		result.BaseFee[i] = feeOrZero(gasPrice.BaseFee.ToInt())
+		result.GasUsedRatio[i] = gasPrice.GasUsedRatio()
+		if result.Reward == nil {
+			continue
+		}
+		rewards := gasPrice.Rewards(rewardPercentiles)
+		result.Reward[i] = make([]*hexutil.Big, len(rewards))
+		for j, reward := range rewards {
+			result.Reward[i][j] = (*hexutil.Big)(reward)
+		}
+	}
+	result.BaseFee[len(blocks)] = feeOrZero(nextBaseFee)
+
+	return result, nil
+}
+
 // Accounts returns the list of accounts available to this node.
 func (api *PublicEthereumAPI) Accounts() ([]common.Address, error) {
 	monitor := monitor.GetMonitor("eth_accounts", api.logger, api.Metrics).OnBegin()
@@ -272,6 +413,17 @@ func (api *PublicEthereumAPI) BlockNumber() (hexutil.Uint64, error) {
 func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*hexutil.Big, error) {
 	monitor := monitor.GetMonitor("eth_getBalance", api.logger, api.Metrics).OnBegin()
 	defer monitor.OnEnd("address", address, "block number", blockNrOrHash)
+	blockNum, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if acc, found := historicalAccount(address, blockNum); found {
+		if acc == nil {
+			return (*hexutil.Big)(sdk.ZeroInt().BigInt()), nil
+		}
+		return (*hexutil.Big)(acc.Balance), nil
+	}
+
 	acc, err := api.wrappedBackend.MustGetAccount(address.Bytes())
 	if err == nil {
 		balance := acc.GetCoins().AmountOf(sdk.DefaultBondDenom).BigInt()
@@ -281,10 +433,6 @@ func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNrOrHash r
 		return (*hexutil.Big)(balance), nil
 	}
 
-	blockNum, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
-	if err != nil {
-		return nil, err
-	}
 	clientCtx := api.clientCtx
 	if !(blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber) {
 		clientCtx = api.clientCtx.WithHeight(blockNum.Int64())
@@ -363,6 +511,14 @@ func (api *PublicEthereumAPI) GetAccount(address common.Address) (*ethermint.Eth
 }
 
 func (api *PublicEthereumAPI) getStorageAt(address common.Address, key []byte, blockNum rpctypes.BlockNumber, directlyKey bool) (hexutil.Bytes, error) {
+	storeKey := common.BytesToHash(key)
+	if !directlyKey {
+		storeKey = crypto.Keccak256Hash(address.Bytes(), key)
+	}
+	if value, found := historicalState(address, storeKey, blockNum); found {
+		return value, nil
+	}
+
 	clientCtx := api.clientCtx.WithHeight(blockNum.Int64())
 	res, err := api.wrappedBackend.MustGetState(address, key)
 	if err == nil {
@@ -412,6 +568,14 @@ func (api *PublicEthereumAPI) GetTransactionCount(address common.Address, blockN
 	if err != nil {
 		return nil, err
 	}
+	if acc, found := historicalAccount(address, blockNum); found {
+		n := hexutil.Uint64(0)
+		if acc != nil {
+			n = hexutil.Uint64(acc.Nonce)
+		}
+		return &n, nil
+	}
+
 	clientCtx := api.clientCtx
 	pending := blockNum == rpctypes.PendingBlockNumber
 	// pass the given block height to the context if the height is not pending or latest
@@ -520,6 +684,13 @@ func (api *PublicEthereumAPI) GetCode(address common.Address, blockNrOrHash rpct
 		return nil, err
 	}
 
+	if acc, found := historicalAccount(address, blockNumber); found {
+		if acc == nil {
+			return hexutil.Bytes{}, nil
+		}
+		return api.GetCodeByHash(common.BytesToHash(acc.CodeHash))
+	}
+
 	code, err := api.wrappedBackend.GetCode(address, uint64(blockNumber))
 	if err == nil {
 		return code, nil
@@ -656,8 +827,8 @@ func (api *PublicEthereumAPI) SendRawTransaction(data hexutil.Bytes) (common.Has
 	defer monitor.OnEnd("data", data)
 	tx := new(evmtypes.MsgEthereumTx)
 
-	// RLP decode raw transaction bytes
-	if err := rlp.DecodeBytes(data, tx); err != nil {
+	// decode raw transaction bytes, either RLP encoded or an EIP-2718 typed tx
+	if err := tx.UnmarshalBinary(data); err != nil {
 		// Return nil is for when gasLimit overflows uint64
 		return common.Hash{}, err
 	}
@@ -721,20 +892,25 @@ func (api *PublicEthereumAPI) addCallCache(key common.Hash, data []byte) {
 	api.callCache.Add(key, data)
 }
 
-// Call performs a raw contract call.
-func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, _ *map[common.Address]rpctypes.Account) (hexutil.Bytes, error) {
+// Call performs a raw contract call. The accounts of the state override are
+// overridden before the call is executed.
+func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, overrides *rpctypes.StateOverride) (hexutil.Bytes, error) {
 	monitor := monitor.GetMonitor("eth_call", api.logger, api.Metrics).OnBegin()
 	defer monitor.OnEnd("args", args, "block number", blockNrOrHash)
-	key := api.buildKey(args)
-	cacheData, ok := api.getFromCallCache(key)
-	if ok {
-		return cacheData, nil
-	}
 	blockNr, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
 	if err != nil {
 		return nil, err
 	}
-	simRes, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit), false)
+	// the results of the calls overriding the state or on a past state aren't cached
+	useCache := (overrides == nil || len(*overrides) == 0) && !isHistorical(blockNr)
+	key := api.buildKey(args)
+	if useCache {
+		cacheData, ok := api.getFromCallCache(key)
+		if ok {
+			return cacheData, nil
+		}
+	}
+	simRes, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit), false, overrides)
 	if err != nil {
 		return []byte{}, TransformDataError(err, "eth_call")
 	}
@@ -743,12 +919,14 @@ func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNrOrHash rpctype
 	if err != nil {
 		return []byte{}, TransformDataError(err, "eth_call")
 	}
-	api.addCallCache(key, data.Ret)
+	if useCache {
+		api.addCallCache(key, data.Ret)
+	}
 	return data.Ret, nil
 }
 
 // MultiCall performs multiple raw contract call.
-func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctypes.BlockNumber, _ *map[common.Address]rpctypes.Account) ([]hexutil.Bytes, error) {
+func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctypes.BlockNumber, overrides *rpctypes.StateOverride) ([]hexutil.Bytes, error) {
 	if !viper.GetBool(FlagEnableMultiCall) {
 		return nil, errors.New("the method is not allowed")
 	}
@@ -759,7 +937,7 @@ func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctyp
 	blockNrOrHash := rpctypes.BlockNumberOrHashWithNumber(blockNr)
 	rets := make([]hexutil.Bytes, 0, len(args))
 	for _, arg := range args {
-		ret, err := api.Call(arg, blockNrOrHash, nil)
+		ret, err := api.Call(arg, blockNrOrHash, overrides)
 		if err != nil {
 			return rets, err
 		}
@@ -771,7 +949,7 @@ func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctyp
 // DoCall performs a simulated call operation through the evmtypes. It returns the
 // estimated gas used on the operation or an error if fails.
 func (api *PublicEthereumAPI) doCall(
-	args rpctypes.CallArgs, blockNum rpctypes.BlockNumber, globalGasCap *big.Int, isEstimate bool,
+	args rpctypes.CallArgs, blockNum rpctypes.BlockNumber, globalGasCap *big.Int, isEstimate bool, overrides *rpctypes.StateOverride,
 ) (*sdk.SimulationResponse, error) {
 
 	clientCtx := api.clientCtx
@@ -792,6 +970,75 @@ func (api *PublicEthereumAPI) doCall(
 		nonce, _ = api.accountNonce(api.clientCtx, addr, true)
 	}
 
+	var msgs []sdk.Msg
+	// Create new call message
+	msg := api.newCallMsg(args, addr, nonce, globalGasCap)
+	msgs = append(msgs, msg)
+
+	// the access list and the state overrides aren't part of the msg, they're passed to the simulator
+	var call evmtypes.SimulatedCall
+	if args.AccessList != nil {
+		call.AccessList = *args.AccessList
+	}
+	if overrides != nil {
+		call.StateOverrides = evmtypes.StateOverrides(*overrides)
+	}
+
+	var sim *simulation.EvmSimulator
+	if isHistorical(blockNum) {
+		sim = api.evmFactory.BuildSimulatorAt(historicalQuerier{api: api, blockNum: blockNum}, uint64(blockNum))
+	} else {
+		sim = api.evmFactory.BuildSimulator(api)
+	}
+	//only worked when fast-query has been enabled
+	if sim != nil {
+		return sim.DoCall(msg, call)
+	}
+	if len(call.AccessList) > 0 || len(call.StateOverrides) > 0 {
+		return nil, errors.New("access list and state overrides are only supported when fast-query is enabled")
+	}
+
+	//convert the pending transactions into ethermint msgs
+	if blockNum == rpctypes.PendingBlockNumber {
+		pendingMsgs, err := api.pendingMsgs()
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, pendingMsgs...)
+	}
+
+	//Generate tx to be used to simulate (signature isn't needed)
+	var stdSig authtypes.StdSignature
+	stdSigs := []authtypes.StdSignature{stdSig}
+
+	tx := authtypes.NewStdTx(msgs, authtypes.StdFee{}, stdSigs, "")
+	if err := tx.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	txEncoder := authclient.GetTxEncoder(clientCtx.Codec)
+	txBytes, err := txEncoder(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transaction simulation through query
+	res, _, err := clientCtx.QueryWithData("app/simulate", txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var simResponse sdk.SimulationResponse
+	if err := clientCtx.Codec.UnmarshalBinaryBare(res, &simResponse); err != nil {
+		return nil, err
+	}
+
+	return &simResponse, nil
+}
+
+// newCallMsg builds the message of a simulated call from the call args, filling
+// the gas, the gas price and the value with defaults if they aren't set.
+func (api *PublicEthereumAPI) newCallMsg(args rpctypes.CallArgs, addr common.Address, nonce uint64, globalGasCap *big.Int) evmtypes.MsgEthermint {
 	// Set default gas & gas price if none were set
 	// Change this to uint64(math.MaxUint64 / 2) if gas cap can be configured
 	gas := uint64(ethermint.DefaultRPCGasLimit)
@@ -828,74 +1075,225 @@ func (api *PublicEthereumAPI) doCall(
 		toAddr = &pTemp
 	}
 
-	var msgs []sdk.Msg
-	// Create new call message
-	msg := evmtypes.NewMsgEthermint(nonce, toAddr, sdk.NewIntFromBigInt(value), gas,
+	return evmtypes.NewMsgEthermint(nonce, toAddr, sdk.NewIntFromBigInt(value), gas,
 		sdk.NewIntFromBigInt(gasPrice), data, sdk.AccAddress(addr.Bytes()))
-	msgs = append(msgs, msg)
+}
 
-	sim := api.evmFactory.BuildSimulator(api)
-	//only worked when fast-query has been enabled
-	if sim != nil {
-		return sim.DoCall(msg)
+// EstimateGas returns an estimate of gas usage for the given smart contract call.
+// It adds 1,000 gas to the returned value instead of using the gas adjustment
+// param from the SDK. The accounts of the state override are overridden before
+// the call is estimated.
+func (api *PublicEthereumAPI) EstimateGas(args rpctypes.CallArgs, blockNrOrHash *rpctypes.BlockNumberOrHash, overrides *rpctypes.StateOverride) (hexutil.Uint64, error) {
+	monitor := monitor.GetMonitor("eth_estimateGas", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("args", args, "block number", blockNrOrHash)
+
+	var blockNr rpctypes.BlockNumber
+	if blockNrOrHash != nil {
+		var err error
+		if blockNr, err = api.backend.ConvertToBlockNumber(*blockNrOrHash); err != nil {
+			return 0, err
+		}
 	}
 
-	//convert the pending transactions into ethermint msgs
-	if blockNum == rpctypes.PendingBlockNumber {
-		pendingMsgs, err := api.pendingMsgs()
+	simResponse, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit), true, overrides)
+	if err != nil {
+		return 0, TransformDataError(err, "eth_estimateGas")
+	}
+
+	// TODO: change 1000 buffer for more accurate buffer (eg: SDK's gasAdjusted)
+	estimatedGas := simResponse.GasInfo.GasUsed
+	gasBuffer := estimatedGas / 100 * config.GetOecConfig().GetGasLimitBuffer()
+	gas := estimatedGas + gasBuffer
+
+	return hexutil.Uint64(gas), nil
+}
+
+// CreateAccessList returns the access list of the given call with the gas it uses once
+// the list is applied. It runs on the evm simulator, so fast-query must be enabled and
+// only the latest state is supported.
+func (api *PublicEthereumAPI) CreateAccessList(args rpctypes.CallArgs, blockNrOrHash *rpctypes.BlockNumberOrHash) (*rpctypes.AccessListResult, error) {
+	monitor := monitor.GetMonitor("eth_createAccessList", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("args", args, "block number", blockNrOrHash)
+
+	if blockNrOrHash != nil {
+		blockNr, err := api.backend.ConvertToBlockNumber(*blockNrOrHash)
 		if err != nil {
 			return nil, err
 		}
-		msgs = append(msgs, pendingMsgs...)
+		latest, err := api.backend.LatestBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		if blockNr != rpctypes.LatestBlockNumber && blockNr != rpctypes.PendingBlockNumber && blockNr.Int64() != latest {
+			return nil, errors.New("access lists can only be created on the latest block")
+		}
 	}
 
-	//Generate tx to be used to simulate (signature isn't needed)
-	var stdSig authtypes.StdSignature
-	stdSigs := []authtypes.StdSignature{stdSig}
+	sim := api.evmFactory.BuildSimulator(api)
+	if sim == nil {
+		return nil, errors.New("eth_createAccessList is only supported when fast-query is enabled")
+	}
 
-	tx := authtypes.NewStdTx(msgs, authtypes.StdFee{}, stdSigs, "")
-	if err := tx.ValidateBasic(); err != nil {
-		return nil, err
+	var addr common.Address
+	if args.From != nil {
+		addr = *args.From
+	}
+	// the address of a created contract depends on the nonce of the sender
+	nonce := uint64(0)
+	if args.To == nil {
+		nonce, _ = api.accountNonce(api.clientCtx, addr, true)
 	}
 
-	txEncoder := authclient.GetTxEncoder(clientCtx.Codec)
-	txBytes, err := txEncoder(tx)
+	var accessList ethtypes.AccessList
+	if args.AccessList != nil {
+		accessList = *args.AccessList
+	}
+	msg := api.newCallMsg(args, addr, nonce, big.NewInt(ethermint.DefaultRPCGasLimit))
+	accessList, simRes, err := sim.CreateAccessList(msg, accessList)
+	result := &rpctypes.AccessListResult{
+		Accesslist: &accessList,
+		GasUsed:    hexutil.Uint64(simRes.GasInfo.GasUsed),
+	}
 	if err != nil {
-		return nil, err
+		result.Error = err.Error()
 	}
+	return result, nil
+}
 
-	// Transaction simulation through query
-	res, _, err := clientCtx.QueryWithData("app/simulate", txBytes)
+// CallBundle simulates an ordered bundle of signed raw txs, every tx sees the state
+// changes of the txs before it. On the pending state the mempool txs a block can hold are
+// applied before the bundle. It runs on the evm simulator, so fast-query must be enabled and only
+// the latest state is supported.
+func (api *PublicEthereumAPI) CallBundle(args rpctypes.CallBundleArgs) (*rpctypes.CallBundleResult, error) {
+	monitor := monitor.GetMonitor("eth_callBundle", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("args", args)
+
+	if len(args.Txs) == 0 {
+		return nil, errors.New("bundle missing txs")
+	}
+
+	latest, err := api.backend.LatestBlockNumber()
 	if err != nil {
 		return nil, err
 	}
+	stateBlockNr := args.StateBlockNumber
+	if stateBlockNr != rpctypes.LatestBlockNumber && stateBlockNr != rpctypes.PendingBlockNumber && stateBlockNr.Int64() != latest {
+		return nil, errors.New("bundles can only be simulated on the latest or the pending state")
+	}
 
-	var simResponse sdk.SimulationResponse
-	if err := clientCtx.Codec.UnmarshalBinaryBare(res, &simResponse); err != nil {
+	txs := make([]simulation.BundleTx, 0, len(args.Txs))
+	txEncoder := authclient.GetTxEncoder(api.clientCtx.Codec)
+	for i, rawTx := range args.Txs {
+		tx := new(evmtypes.MsgEthereumTx)
+		if err := tx.UnmarshalBinary(rawTx); err != nil {
+			return nil, fmt.Errorf("invalid tx %d of the bundle: %s", i, err)
+		}
+		txBytes, err := txEncoder(tx)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, simulation.BundleTx{Msg: tx, Hash: common.BytesToHash(tmtypes.Tx(txBytes).Hash())})
+	}
+
+	sim := api.evmFactory.BuildSimulator(api)
+	if sim == nil {
+		return nil, errors.New("eth_callBundle is only supported when fast-query is enabled")
+	}
+
+	// the bundle is simulated in the next block by default
+	block := simulation.BundleBlock{Number: args.BlockNumber.Int64()}
+	if args.BlockNumber == rpctypes.LatestBlockNumber || args.BlockNumber == rpctypes.PendingBlockNumber {
+		block.Number = latest + 1
+	}
+	if args.Coinbase != nil {
+		block.Coinbase = *args.Coinbase
+	}
+	if args.Timestamp != nil {
+		block.Time = int64(*args.Timestamp)
+	}
+	bundle, err := sim.NewBundleSimulation(block)
+	if err != nil {
 		return nil, err
 	}
 
-	return &simResponse, nil
-}
+	if stateBlockNr == rpctypes.PendingBlockNumber {
+		// the pending state is made of the mempool txs a block can hold, as many as the mempool reaps for a
+		// block and within its gas limit, the rest are skipped. So are the txs that can't be included.
+		pendingTxs, err := api.clientCtx.Client.UnconfirmedTxs(int(config.GetOecConfig().GetMaxTxNumPerBlock()))
+		if err != nil {
+			return nil, err
+		}
+		maxGas := config.GetOecConfig().GetMaxGasUsedPerBlock()
+		var gasWanted int64
+		for _, pendingTx := range pendingTxs.Txs {
+			ethTx, err := rpctypes.RawTxToEthTx(api.clientCtx, pendingTx)
+			if err != nil {
+				continue
+			}
+			if maxGas > -1 && gasWanted+int64(ethTx.GetGas()) > maxGas {
+				break
+			}
+			if _, err := bundle.ApplyTx(simulation.BundleTx{Msg: ethTx, Hash: common.BytesToHash(pendingTx.Hash())}); err == nil {
+				gasWanted += int64(ethTx.GetGas())
+			}
+		}
+	}
 
-// EstimateGas returns an estimate of gas usage for the given smart contract call.
-// It adds 1,000 gas to the returned value instead of using the gas adjustment
-// param from the SDK.
-func (api *PublicEthereumAPI) EstimateGas(args rpctypes.CallArgs) (hexutil.Uint64, error) {
-	monitor := monitor.GetMonitor("eth_estimateGas", api.logger, api.Metrics).OnBegin()
-	defer monitor.OnEnd("args", args)
+	result := &rpctypes.CallBundleResult{
+		Results:          make([]rpctypes.BundleTxResult, 0, len(txs)),
+		StateBlockNumber: hexutil.Uint64(latest),
+	}
+	var (
+		hashes       []byte
+		gasFees      = new(big.Int)
+		coinbaseDiff = new(big.Int)
+	)
+	for _, tx := range txs {
+		txRes, err := bundle.ApplyTx(tx)
+		if err != nil {
+			return nil, err
+		}
 
-	simResponse, err := api.doCall(args, 0, big.NewInt(ethermint.DefaultRPCGasLimit), true)
-	if err != nil {
-		return 0, TransformDataError(err, "eth_estimateGas")
+		res := rpctypes.BundleTxResult{
+			TxHash:       txRes.TxHash,
+			FromAddress:  txRes.From,
+			ToAddress:    txRes.To,
+			GasUsed:      hexutil.Uint64(txRes.GasUsed),
+			GasPrice:     (*hexutil.Big)(txRes.GasPrice),
+			GasFees:      (*hexutil.Big)(txRes.GasFees),
+			CoinbaseDiff: (*hexutil.Big)(txRes.CoinbaseDiff),
+			Logs:         txRes.Logs,
+			ReturnData:   txRes.ReturnData,
+		}
+		if txRes.Err != nil {
+			res.Error = txRes.Err.Error()
+			if reason, _, ok := evmtypes.UnpackRevertError(txRes.Err); ok {
+				res.Error = vm.ErrExecutionReverted.Error()
+				res.Revert = reason
+			}
+		}
+		result.Results = append(result.Results, res)
+
+		hashes = append(hashes, txRes.TxHash.Bytes()...)
+		result.TotalGasUsed += res.GasUsed
+		gasFees.Add(gasFees, txRes.GasFees)
+		coinbaseDiff.Add(coinbaseDiff, txRes.CoinbaseDiff)
 	}
 
-	// TODO: change 1000 buffer for more accurate buffer (eg: SDK's gasAdjusted)
-	estimatedGas := simResponse.GasInfo.GasUsed
-	gasBuffer := estimatedGas / 100 * config.GetOecConfig().GetGasLimitBuffer()
-	gas := estimatedGas + gasBuffer
+	result.BundleHash = common.BytesToHash(crypto.Keccak256(hashes))
+	result.GasFees = (*hexutil.Big)(gasFees)
+	result.CoinbaseDiff = (*hexutil.Big)(coinbaseDiff)
+	result.BundleGasPrice = (*hexutil.Big)(new(big.Int))
+	if result.TotalGasUsed > 0 {
+		bundleGasPrice := new(big.Int).Add(gasFees, coinbaseDiff)
+		result.BundleGasPrice = (*hexutil.Big)(bundleGasPrice.Div(bundleGasPrice, new(big.Int).SetUint64(uint64(result.TotalGasUsed))))
+	}
+	return result, nil
+}
 
-	return hexutil.Uint64(gas), nil
+// SimulateBundle is an alias of CallBundle
+func (api *PublicEthereumAPI) SimulateBundle(args rpctypes.CallBundleArgs) (*rpctypes.CallBundleResult, error) {
+	return api.CallBundle(args)
 }
 
 // GetBlockByHash returns the block identified by hash.
@@ -946,6 +1344,11 @@ func (api *PublicEthereumAPI) GetBlockByNumber(blockNum rpctypes.BlockNumber, fu
 		blockTxs = pendingTxs
 	}
 
+	baseFee, err := rpctypes.BaseFeeFromTendermint(api.clientCtx, height+1)
+	if err != nil {
+		return nil, err
+	}
+
 	return rpctypes.FormatBlock(
 		tmtypes.Header{
 			Version:         latestBlock.Block.Version,
@@ -960,6 +1363,7 @@ func (api *PublicEthereumAPI) GetBlockByNumber(blockNum rpctypes.BlockNumber, fu
 		latestBlock.Block.Hash(),
 		0,
 		gasUsed,
+		baseFee,
 		blockTxs,
 		ethtypes.Bloom{},
 	), nil
@@ -1214,16 +1618,56 @@ func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (*watcher.
 		TransactionIndex:  hexutil.Uint64(tx.Index),
 		From:              from.String(),
 		To:                ethTx.To(),
+		Type:              hexutil.Uint64(ethTx.Data.Type),
 	}
 
 	return receipt, nil
 }
 
+// GetInternalTransactions returns the calls, creates and selfdestructs run by the transaction identified by hash,
+// the transaction itself is the first one. The internal transactions are recorded by the nodes running with the
+// watcher and the inner tx recorder enabled.
+func (api *PublicEthereumAPI) GetInternalTransactions(hash common.Hash) ([]*evmtypes.InnerTx, error) {
+	monitor := monitor.GetMonitor("eth_getInternalTransactions", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("hash", hash)
+
+	if !watcher.IsWatcherEnabled() {
+		return nil, errors.New(watcher.MsgFunctionDisable)
+	}
+	innerTxs, err := api.wrappedBackend.GetInnerTxs(hash)
+	if err != nil {
+		// Return nil for the internal transactions when not found
+		return nil, nil
+	}
+	return innerTxs, nil
+}
+
+// GetBlockReceipts returns all the transaction receipts of the block identified by hash or number.
+func (api *PublicEthereumAPI) GetBlockReceipts(blockNrOrHash rpctypes.BlockNumberOrHash) ([]*watcher.TransactionReceipt, error) {
+	monitor := monitor.GetMonitor("eth_getBlockReceipts", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("block number", blockNrOrHash)
+
+	receipts, err := api.getBlockReceipts(blockNrOrHash, 0, math.MaxUint32)
+	if err != nil {
+		return nil, err
+	}
+	if receipts == nil {
+		receipts = []*watcher.TransactionReceipt{}
+	}
+	return receipts, nil
+}
+
 // GetTransactionReceiptsByBlock returns the transaction receipt identified by block hash or number.
+// It is the paginated alias of eth_getBlockReceipts.
 func (api *PublicEthereumAPI) GetTransactionReceiptsByBlock(blockNrOrHash rpctypes.BlockNumberOrHash, offset, limit hexutil.Uint) ([]*watcher.TransactionReceipt, error) {
 	monitor := monitor.GetMonitor("eth_getTransactionReceiptsByBlock", api.logger, api.Metrics).OnBegin()
 	defer monitor.OnEnd("block number", blockNrOrHash, "offset", offset, "limit", limit)
 
+	return api.getBlockReceipts(blockNrOrHash, offset, limit)
+}
+
+// getBlockReceipts returns the receipts of the transactions of the block from offset to offset+limit
+func (api *PublicEthereumAPI) getBlockReceipts(blockNrOrHash rpctypes.BlockNumberOrHash, offset, limit hexutil.Uint) ([]*watcher.TransactionReceipt, error) {
 	txs, err := api.GetTransactionsByBlock(blockNrOrHash, offset, limit)
 	if err != nil || len(txs) == 0 {
 		return nil, err
@@ -1303,6 +1747,7 @@ func (api *PublicEthereumAPI) GetTransactionReceiptsByBlock(blockNrOrHash rpctyp
 			TransactionIndex:  hexutil.Uint64(tx.Index),
 			From:              from.String(),
 			To:                ethTx.To(),
+			Type:              hexutil.Uint64(ethTx.Data.Type),
 		}
 		receipts = append(receipts, receipt)
 	}
@@ -1467,7 +1912,7 @@ func (api *PublicEthereumAPI) generateFromArgs(args rpctypes.SendTxArgs) (*evmty
 			Value:    args.Value,
 			Data:     &input,
 		}
-		gl, err := api.EstimateGas(callArgs)
+		gl, err := api.EstimateGas(callArgs, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -25,10 +25,11 @@ import (
 )
 
 var (
-	txEvents        = tmtypes.QueryForEvent(tmtypes.EventTx).String()
-	pendingtxEvents = tmtypes.QueryForEvent(tmtypes.EventPendingTx).String()
-	evmEvents       = tmquery.MustParse(fmt.Sprintf("%s='%s' AND %s.%s='%s'", tmtypes.EventTypeKey, tmtypes.EventTx, sdk.EventTypeMessage, sdk.AttributeKeyModule, evmtypes.ModuleName)).String()
-	headerEvents    = tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String()
+	txEvents          = tmtypes.QueryForEvent(tmtypes.EventTx).String()
+	pendingtxEvents   = tmtypes.QueryForEvent(tmtypes.EventPendingTx).String()
+	rmPendingtxEvents = tmtypes.QueryForEvent(tmtypes.EventRmPendingTx).String()
+	evmEvents         = tmquery.MustParse(fmt.Sprintf("%s='%s' AND %s.%s='%s'", tmtypes.EventTypeKey, tmtypes.EventTx, sdk.EventTypeMessage, sdk.AttributeKeyModule, evmtypes.ModuleName)).String()
+	headerEvents      = tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String()
 )
 
 // EventSystem creates subscriptions, processes events and broadcasts them to the
@@ -238,6 +239,20 @@ func (es EventSystem) SubscribePendingTxs() (*Subscription, context.CancelFunc,
 	return es.subscribe(sub)
 }
 
+// SubscribeRmPendingTxs subscribes to the pending transactions dropped or replaced in the mempool.
+func (es EventSystem) SubscribeRmPendingTxs() (*Subscription, context.CancelFunc, error) {
+	sub := &Subscription{
+		id:        rpc.NewID(),
+		typ:       filters.PendingTransactionsSubscription,
+		event:     rmPendingtxEvents,
+		created:   time.Now().UTC(),
+		hashes:    make(chan []common.Hash),
+		installed: make(chan struct{}, 1),
+		err:       make(chan error, 1),
+	}
+	return es.subscribe(sub)
+}
+
 type filterIndex map[filters.Type]map[rpc.ID]*Subscription
 
 func (es *EventSystem) handleLogs(ev coretypes.ResultEvent) {
@@ -0,0 +1,226 @@
+package simulation
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethermint "github.com/okex/exchain/app/types"
+	store "github.com/okex/exchain/libs/cosmos-sdk/store/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/evm"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// BundleBlock defines the block a bundle is simulated in, the zero fields are
+// taken from the latest block.
+type BundleBlock struct {
+	Number   int64
+	Time     int64
+	Coinbase common.Address
+}
+
+// BundleTx is a signed tx of a bundle with the hash it gets on chain
+type BundleTx struct {
+	Msg  *evmtypes.MsgEthereumTx
+	Hash common.Hash
+}
+
+// BundleTxResult is the result of a tx applied by a bundle simulation. Err is the
+// execution error of the tx, a failed tx still pays for the gas it used.
+// NOTE: the fees go to the fee collector, so CoinbaseDiff only counts the direct
+// payments to the coinbase.
+type BundleTxResult struct {
+	TxHash       common.Hash
+	From         common.Address
+	To           *common.Address
+	GasUsed      uint64
+	GasPrice     *big.Int
+	GasFees      *big.Int
+	CoinbaseDiff *big.Int
+	Logs         []*ethtypes.Log
+	ReturnData   []byte
+	Err          error
+}
+
+// BundleSimulation applies txs one after another on a single state db, so every tx
+// sees the state changes of the txs applied before it. Nothing is written to the chain.
+type BundleSimulation struct {
+	keeper   *evm.Keeper
+	ctx      sdk.Context
+	csdb     *evmtypes.CommitStateDB
+	config   evmtypes.ChainConfig
+	chainID  *big.Int
+	coinbase common.Address
+	txIndex  int
+}
+
+// NewBundleSimulation starts a bundle simulation on the state of the simulator
+func (es *EvmSimulator) NewBundleSimulation(block BundleBlock) (*BundleSimulation, error) {
+	ctx := es.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	if block.Number != 0 {
+		ctx = ctx.WithBlockHeight(block.Number)
+	}
+	if block.Time != 0 {
+		ctx = ctx.WithBlockTime(time.Unix(block.Time, 0))
+	}
+	if block.Coinbase != (common.Address{}) {
+		ctx = ctx.WithProposer(sdk.ConsAddress(block.Coinbase.Bytes()))
+	}
+
+	chainID, err := ethermint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		return nil, err
+	}
+	config, found := es.keeper.GetChainConfig(ctx)
+	if !found {
+		return nil, evmtypes.ErrChainConfigNotFound
+	}
+
+	csdbParams := es.keeper.GenerateCSDBParams()
+	csdbParams.Ada = newBundleDba(csdbParams.Ada)
+	return &BundleSimulation{
+		keeper:   es.keeper,
+		ctx:      ctx,
+		csdb:     evmtypes.CreateEmptyCommitStateDB(csdbParams, ctx),
+		config:   config,
+		chainID:  chainID,
+		coinbase: common.BytesToAddress(ctx.BlockHeader().ProposerAddress),
+	}, nil
+}
+
+// ApplyTx applies the tx on top of the txs applied before it. The returned error is
+// set if the tx can't be included in a block, in which case the state is untouched.
+func (bs *BundleSimulation) ApplyTx(tx BundleTx) (*BundleTxResult, error) {
+	csdb := bs.csdb.WithContext(bs.ctx)
+
+	senderSigCache, err := tx.Msg.VerifySig(bs.chainID, bs.ctx.BlockHeight(), nil)
+	if err != nil {
+		return nil, err
+	}
+	sender := senderSigCache.GetFrom()
+
+	if nonce := csdb.GetNonce(sender); nonce != tx.Msg.Data.AccountNonce {
+		return nil, fmt.Errorf("invalid nonce of tx %s: got %d, expected %d", tx.Hash.Hex(), tx.Msg.Data.AccountNonce, nonce)
+	}
+
+	intrinsicGas, err := core.IntrinsicGas(tx.Msg.Data.Payload, tx.Msg.Data.Accesses, tx.Msg.Data.Recipient == nil,
+		bs.config.IsHomestead(), bs.config.IsIstanbul())
+	if err != nil {
+		return nil, err
+	}
+	if tx.Msg.Data.GasLimit < intrinsicGas {
+		return nil, fmt.Errorf("intrinsic gas too low of tx %s: have %d, want %d", tx.Hash.Hex(), tx.Msg.Data.GasLimit, intrinsicGas)
+	}
+
+	if balance := csdb.GetBalance(sender); balance.Cmp(tx.Msg.Cost()) < 0 {
+		return nil, fmt.Errorf("insufficient funds of tx %s: balance %s, cost %s", tx.Hash.Hex(), balance, tx.Msg.Cost())
+	}
+
+	baseFee := bs.keeper.GetBaseFee(bs.ctx)
+	price := tx.Msg.EffectiveGasPrice(baseFee)
+	coinbaseBalance := csdb.GetBalance(bs.coinbase)
+
+	// the gas is bought before the execution and the unused gas is refunded after it
+	csdb.SubBalance(sender, new(big.Int).Mul(price, new(big.Int).SetUint64(tx.Msg.Data.GasLimit)))
+	csdb.Prepare(tx.Hash, common.Hash{}, bs.txIndex)
+	_ = csdb.SetLogs(tx.Hash, []*ethtypes.Log{})
+
+	st := evmtypes.StateTransition{
+		AccountNonce: tx.Msg.Data.AccountNonce,
+		Price:        price,
+		GasLimit:     tx.Msg.Data.GasLimit,
+		Recipient:    tx.Msg.Data.Recipient,
+		Amount:       tx.Msg.Data.Amount,
+		Payload:      tx.Msg.Data.Payload,
+		AccessList:   tx.Msg.Data.Accesses,
+		BaseFee:      baseFee,
+		Csdb:         csdb,
+		ChainID:      bs.chainID,
+		TxHash:       &tx.Hash,
+		Sender:       sender,
+		Simulate:     true,
+	}
+	txCtx := bs.ctx.WithGasMeter(sdk.NewGasMeter(tx.Msg.Data.GasLimit))
+	_, resData, execErr, _, _ := st.TransitionDb(txCtx, bs.config)
+	gasUsed := txCtx.GasMeter().GasConsumed()
+
+	csdb = csdb.WithContext(bs.ctx)
+	csdb.AddBalance(sender, new(big.Int).Mul(price, new(big.Int).SetUint64(tx.Msg.Data.GasLimit-gasUsed)))
+	csdb.SetNonce(sender, tx.Msg.Data.AccountNonce+1)
+
+	logs, _ := csdb.GetLogs(tx.Hash)
+	if err := csdb.Finalise(true); err != nil {
+		return nil, err
+	}
+	bs.txIndex++
+
+	result := &BundleTxResult{
+		TxHash:       tx.Hash,
+		From:         sender,
+		To:           tx.Msg.To(),
+		GasUsed:      gasUsed,
+		GasPrice:     price,
+		GasFees:      new(big.Int).Mul(price, new(big.Int).SetUint64(gasUsed)),
+		CoinbaseDiff: new(big.Int).Sub(csdb.GetBalance(bs.coinbase), coinbaseBalance),
+		Logs:         logs,
+		Err:          execErr,
+	}
+	if resData != nil {
+		result.ReturnData = resData.Ret
+	}
+	if _, data, ok := evmtypes.UnpackRevertError(execErr); ok {
+		result.ReturnData = data
+	}
+	return result, nil
+}
+
+// bundleDba keeps the storage committed by the txs of a bundle, as the stores of the
+// simulator ignore writes. A nil value marks a deleted slot.
+type bundleDba struct {
+	evmtypes.DbAdapter
+	storage map[string][]byte
+}
+
+func newBundleDba(ada evmtypes.DbAdapter) bundleDba {
+	return bundleDba{DbAdapter: ada, storage: make(map[string][]byte)}
+}
+
+func (d bundleDba) NewStore(parent store.KVStore, prefix []byte) evmtypes.StoreProxy {
+	proxy := d.DbAdapter.NewStore(parent, prefix)
+	if proxy == nil || len(prefix) == 0 || prefix[0] != evmtypes.KeyPrefixStorage[0] {
+		return proxy
+	}
+	return bundleStateStore{StoreProxy: proxy, prefix: string(prefix), storage: d.storage}
+}
+
+type bundleStateStore struct {
+	evmtypes.StoreProxy
+	prefix  string
+	storage map[string][]byte
+}
+
+func (s bundleStateStore) Set(key, value []byte) {
+	s.storage[s.prefix+string(key)] = value
+}
+
+func (s bundleStateStore) Get(key []byte) []byte {
+	if value, ok := s.storage[s.prefix+string(key)]; ok {
+		return value
+	}
+	return s.StoreProxy.Get(key)
+}
+
+func (s bundleStateStore) Has(key []byte) bool {
+	if value, ok := s.storage[s.prefix+string(key)]; ok {
+		return value != nil
+	}
+	return s.StoreProxy.Has(key)
+}
+
+func (s bundleStateStore) Delete(key []byte) {
+	s.storage[s.prefix+string(key)] = nil
+}
@@ -0,0 +1,97 @@
+package simulation
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	okexchain "github.com/okex/exchain/app"
+	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	store "github.com/okex/exchain/libs/cosmos-sdk/store/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// storageReadOnlyDba ignores the storage writes like the stores of the simulator do
+type storageReadOnlyDba struct {
+	evmtypes.DefaultPrefixDb
+}
+
+func (d storageReadOnlyDba) NewStore(parent store.KVStore, prefix []byte) evmtypes.StoreProxy {
+	proxy := d.DefaultPrefixDb.NewStore(parent, prefix)
+	if prefix[0] != evmtypes.KeyPrefixStorage[0] {
+		return proxy
+	}
+	return readOnlyStore{proxy}
+}
+
+type readOnlyStore struct {
+	evmtypes.StoreProxy
+}
+
+func (s readOnlyStore) Set(key, value []byte) {}
+
+func (s readOnlyStore) Delete(key []byte) {}
+
+func TestBundleSimulationApplyTx(t *testing.T) {
+	app := okexchain.Setup(false)
+	ctx := app.BaseApp.NewContext(false, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
+	params := evmtypes.DefaultParams()
+	params.EnableCall = true
+	app.EvmKeeper.SetParams(ctx, params)
+
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	sender := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	slot := ethcmn.Hash{}
+
+	// the contract stores its call data in slot 0: PUSH1 0 CALLDATALOAD PUSH1 0 SSTORE STOP
+	csdb := evmtypes.CreateEmptyCommitStateDB(app.EvmKeeper.GenerateCSDBParams(), ctx)
+	csdb.SetBalance(sender, big.NewInt(1000000000))
+	csdb.SetCode(contract, ethcmn.FromHex("0x60003560005500"))
+	csdb.SetState(contract, slot, ethcmn.BigToHash(big.NewInt(5)))
+	require.NoError(t, csdb.Finalise(false))
+	_, err = csdb.Commit(false)
+	require.NoError(t, err)
+
+	app.EvmKeeper.Ada = storageReadOnlyDba{}
+	sim := &EvmSimulator{keeper: app.EvmKeeper, ctx: ctx}
+	bundle, err := sim.NewBundleSimulation(BundleBlock{})
+	require.NoError(t, err)
+
+	newTx := func(nonce uint64, value int64) BundleTx {
+		msg := evmtypes.NewMsgEthereumTx(nonce, &contract, nil, 100000, big.NewInt(1), ethcmn.BigToHash(big.NewInt(value)).Bytes())
+		require.NoError(t, msg.Sign(big.NewInt(3), priv.ToECDSA()))
+		return BundleTx{Msg: &msg, Hash: ethcmn.BytesToHash([]byte{byte(nonce + 1)})}
+	}
+	getState := func() ethcmn.Hash {
+		return bundle.csdb.WithContext(bundle.ctx).GetState(contract, slot)
+	}
+
+	// a tx that can't be included yet leaves the state untouched
+	_, err = bundle.ApplyTx(newTx(1, 1))
+	require.Error(t, err)
+	require.Equal(t, ethcmn.BigToHash(big.NewInt(5)), getState())
+
+	// every tx sees the storage written by the txs before it, including deleted slots
+	res, err := bundle.ApplyTx(newTx(0, 1))
+	require.NoError(t, err)
+	require.NoError(t, res.Err)
+	require.Equal(t, ethcmn.BigToHash(big.NewInt(1)), getState())
+
+	res, err = bundle.ApplyTx(newTx(1, 0))
+	require.NoError(t, err)
+	require.NoError(t, res.Err)
+	require.Equal(t, ethcmn.Hash{}, getState())
+
+	res, err = bundle.ApplyTx(newTx(2, 7))
+	require.NoError(t, err)
+	require.NoError(t, res.Err)
+	require.Equal(t, ethcmn.BigToHash(big.NewInt(7)), getState())
+	require.Equal(t, uint64(3), bundle.csdb.WithContext(bundle.ctx).GetNonce(sender))
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/params"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/okex/exchain/x/evm"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/evm/watcher"
@@ -27,15 +30,20 @@ func NewEvmFactory(chainId string, q *watcher.Querier) EvmFactory {
 }
 
 func (ef EvmFactory) BuildSimulator(qoc QueryOnChainProxy) *EvmSimulator {
-	keeper := ef.makeEvmKeeper(qoc)
+	latest, _ := ef.WrappedQuerier.GetLatestBlockNumber()
+	return ef.BuildSimulatorAt(qoc, latest)
+}
 
+// BuildSimulatorAt builds a simulator on the block at height, qoc must serve the
+// state at that height.
+func (ef EvmFactory) BuildSimulatorAt(qoc QueryOnChainProxy, height uint64) *EvmSimulator {
 	if !watcher.IsWatcherEnabled() {
 		return nil
 	}
+	keeper := ef.makeEvmKeeper(qoc)
 	timestamp := time.Now()
 
-	latest, _ := ef.WrappedQuerier.GetLatestBlockNumber()
-	hash, e := ef.WrappedQuerier.GetBlockHashByNumber(latest)
+	hash, e := ef.WrappedQuerier.GetBlockHashByNumber(height)
 	if e != nil {
 		hash = common.HexToHash("0x000000000000000000000000000000")
 	}
@@ -51,7 +59,7 @@ func (ef EvmFactory) BuildSimulator(qoc QueryOnChainProxy) *EvmSimulator {
 			LastBlockId: abci.BlockID{
 				Hash: hash.Bytes(),
 			},
-			Height: int64(latest),
+			Height: int64(height),
 			Time:   timestamp,
 		},
 		Hash: hash.Bytes(),
@@ -63,17 +71,21 @@ func (ef EvmFactory) BuildSimulator(qoc QueryOnChainProxy) *EvmSimulator {
 
 	return &EvmSimulator{
 		handler: evm.NewHandler(keeper),
+		keeper:  keeper,
 		ctx:     ctx,
 	}
 }
 
 type EvmSimulator struct {
 	handler sdk.Handler
+	keeper  *evm.Keeper
 	ctx     sdk.Context
 }
 
-func (es *EvmSimulator) DoCall(msg evmtypes.MsgEthermint) (*sdk.SimulationResponse, error) {
-	r, e := es.handler(es.ctx, msg)
+// DoCall runs the call with the access list and the state overrides of the simulated call, which
+// are passed to the handler through the context.
+func (es *EvmSimulator) DoCall(msg evmtypes.MsgEthermint, call evmtypes.SimulatedCall) (*sdk.SimulationResponse, error) {
+	r, e := es.handler(evmtypes.WithSimulatedCall(es.ctx, call), msg)
 	if e != nil {
 		return nil, e
 	}
@@ -86,6 +98,43 @@ func (es *EvmSimulator) DoCall(msg evmtypes.MsgEthermint) (*sdk.SimulationRespon
 	}, nil
 }
 
+// CreateAccessList runs the call with an access list tracer until the access list
+// it collects stops changing, it returns the list and the result of the call that
+// applies it. The state changes of every run are discarded.
+func (es *EvmSimulator) CreateAccessList(msg evmtypes.MsgEthermint, accessList ethtypes.AccessList) (ethtypes.AccessList, *sdk.SimulationResponse, error) {
+	from := common.BytesToAddress(msg.From.Bytes())
+	to := crypto.CreateAddress(from, msg.AccountNonce)
+	if msg.Recipient != nil {
+		to = common.BytesToAddress(msg.Recipient.Bytes())
+	}
+
+	prevTracer := vm.NewAccessListTracer(accessList, from, to, vm.PrecompiledAddressesBerlin)
+	for {
+		accessList = prevTracer.AccessList()
+		tracer := vm.NewAccessListTracer(accessList, from, to, vm.PrecompiledAddressesBerlin)
+
+		ctx, _ := es.ctx.CacheContext()
+		ctx = ctx.WithGasMeter(sdk.NewGasMeter(es.ctx.GasMeter().Limit())).
+			WithIsTraceTx(true).
+			WithTraceTx(&sdk.TraceTxConfig{Tracer: tracer})
+		r, e := es.handler(evmtypes.WithSimulatedCall(ctx, evmtypes.SimulatedCall{AccessList: accessList}), msg)
+		res := &sdk.SimulationResponse{
+			GasInfo: sdk.GasInfo{
+				GasWanted: ctx.GasMeter().Limit(),
+				GasUsed:   ctx.GasMeter().GasConsumed(),
+			},
+			Result: r,
+		}
+		if e != nil {
+			return accessList, res, e
+		}
+		if tracer.Equal(prevTracer) {
+			return accessList, res, nil
+		}
+		prevTracer = tracer
+	}
+}
+
 func (ef EvmFactory) makeEvmKeeper(qoc QueryOnChainProxy) *evm.Keeper {
 	module := evm.AppModuleBasic{}
 	cdc := codec.New()
@@ -5,12 +5,13 @@ import (
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/evm/types"
+	"github.com/okex/exchain/x/evm/watcher"
 )
 
 func TestEvmFactory(t *testing.T) {
-	ef := EvmFactory{ChainId: "ok-1"}
+	ef := EvmFactory{ChainId: "ok-1", WrappedQuerier: &watcher.Querier{}}
 
-	sr := ef.BuildSimulator()
+	sr := ef.BuildSimulator(nil)
 	if sr != nil {
 		sr.DoCall(types.MsgEthermint{
 			AccountNonce: 0,
@@ -20,6 +21,6 @@ func TestEvmFactory(t *testing.T) {
 			Amount:       sdk.NewInt(100),
 			Payload:      nil,
 			From:         nil,
-		})
+		}, types.SimulatedCall{})
 	}
 }
@@ -146,6 +146,7 @@ func NewBankKeeperProxy() BankKeeperProxy {
 		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
 		gov.ModuleName:            nil,
 		token.ModuleName:          {supply.Minter, supply.Burner},
+		token.VestingAccountName:  nil,
 		dex.ModuleName:            nil,
 		order.ModuleName:          nil,
 		backend.ModuleName:        nil,
@@ -0,0 +1,110 @@
+package eth
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// isHistorical tells if the state at blockNum is served by the state history, which
+// is the case for the heights below or at the latest height of the history.
+func isHistorical(blockNum rpctypes.BlockNumber) bool {
+	history := evmtypes.GetStateHistory()
+	if history == nil || blockNum == rpctypes.LatestBlockNumber || blockNum == rpctypes.PendingBlockNumber {
+		return false
+	}
+	return blockNum.Int64() <= history.LatestHeight()
+}
+
+// historicalAccount returns the account at blockNum from the state history, the account
+// is nil if it doesn't exist. found is false if the history doesn't know the account.
+func historicalAccount(address common.Address, blockNum rpctypes.BlockNumber) (acc *evmtypes.HistoricalAccount, found bool) {
+	if !isHistorical(blockNum) {
+		return nil, false
+	}
+	acc, found, err := evmtypes.GetStateHistory().GetAccount(address, blockNum.Int64())
+	if err != nil {
+		return nil, false
+	}
+	return acc, found
+}
+
+// historicalState returns the storage value of the KVStore key at blockNum from the
+// state history. found is false if the history doesn't know the value.
+func historicalState(address common.Address, key common.Hash, blockNum rpctypes.BlockNumber) (hexutil.Bytes, bool) {
+	if !isHistorical(blockNum) {
+		return nil, false
+	}
+	value, found, err := evmtypes.GetStateHistory().GetState(address, key, blockNum.Int64())
+	if err != nil || !found {
+		return nil, false
+	}
+	return value.Bytes(), true
+}
+
+// historicalQuerier serves the state at a past height to the evm simulator. The state
+// unknown to the state history is queried from the IAVL versions.
+type historicalQuerier struct {
+	api      *PublicEthereumAPI
+	blockNum rpctypes.BlockNumber
+}
+
+func (q historicalQuerier) GetAccount(address common.Address) (*ethermint.EthAccount, error) {
+	if acc, found := historicalAccount(address, q.blockNum); found {
+		if acc == nil {
+			return nil, fmt.Errorf("account %s doesn't exist at height %d", address.Hex(), q.blockNum)
+		}
+		coins := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewDecFromBigIntWithPrec(acc.Balance, sdk.Precision)))
+		return &ethermint.EthAccount{
+			BaseAccount: &auth.BaseAccount{
+				Address:  address.Bytes(),
+				Coins:    coins,
+				Sequence: acc.Nonce,
+			},
+			CodeHash: acc.CodeHash,
+		}, nil
+	}
+
+	clientCtx := q.api.clientCtx.WithHeight(q.blockNum.Int64())
+	bs, err := clientCtx.Codec.MarshalJSON(auth.NewQueryAccountParams(address.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", auth.QuerierRoute, auth.QueryAccount), bs)
+	if err != nil {
+		return nil, err
+	}
+
+	var account ethermint.EthAccount
+	if err := clientCtx.Codec.UnmarshalJSON(res, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (q historicalQuerier) GetStorageAtInternal(address common.Address, key []byte) (hexutil.Bytes, error) {
+	if value, found := historicalState(address, common.BytesToHash(key), q.blockNum); found {
+		return value, nil
+	}
+
+	clientCtx := q.api.clientCtx.WithHeight(q.blockNum.Int64())
+	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/storageKey/%s/%X", evmtypes.ModuleName, address.Hex(), key), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out evmtypes.QueryResStorage
+	clientCtx.Codec.MustUnmarshalJSON(res, &out)
+	return out.Value, nil
+}
+
+// GetCodeByHash returns the code of the hash, the code stored under a hash never changes
+func (q historicalQuerier) GetCodeByHash(hash common.Hash) (hexutil.Bytes, error) {
+	return q.api.GetCodeByHash(hash)
+}
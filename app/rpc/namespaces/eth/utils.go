@@ -236,3 +236,11 @@ func getStorageByAddressKey(addr common.Address, key []byte) common.Hash {
 
 	return ethcrypto.Keccak256Hash(compositeKey)
 }
+
+// feeOrZero returns the fee, or zero before the London fork as go-ethereum does
+func feeOrZero(fee *big.Int) *hexutil.Big {
+	if fee == nil {
+		return (*hexutil.Big)(new(big.Int))
+	}
+	return (*hexutil.Big)(fee)
+}
@@ -25,6 +25,14 @@ const (
 
 	// PendingBlockNumber mapping from "pending" to -1 for tm query
 	PendingBlockNumber = BlockNumber(-1)
+
+	// SafeBlockNumber mapping from "safe" to the latest block, a committed block is
+	// final with the instant finality of tendermint
+	SafeBlockNumber = LatestBlockNumber
+
+	// FinalizedBlockNumber mapping from "finalized" to the latest block, a committed
+	// block is final with the instant finality of tendermint
+	FinalizedBlockNumber = LatestBlockNumber
 )
 
 var ErrResourceNotFound = errors.New("resource not found")
@@ -35,7 +43,7 @@ func NewBlockNumber(n *big.Int) BlockNumber {
 }
 
 // UnmarshalJSON parses the given JSON fragment into a BlockNumber. It supports:
-// - "latest", "earliest" or "pending" as string arguments
+// - "latest", "earliest", "pending", "safe" or "finalized" as string arguments
 // - the block number
 // Returned errors:
 // - an invalid block number error when the given argument isn't a known strings
@@ -56,6 +64,12 @@ func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
 	case "pending":
 		*bn = PendingBlockNumber
 		return nil
+	case "safe":
+		*bn = SafeBlockNumber
+		return nil
+	case "finalized":
+		*bn = FinalizedBlockNumber
+		return nil
 	}
 
 	blckNum, err := hexutil.DecodeUint64(input)
@@ -123,6 +137,14 @@ func (bnh *BlockNumberOrHash) UnmarshalJSON(data []byte) error {
 		bn := PendingBlockNumber
 		bnh.BlockNumber = &bn
 		return nil
+	case "safe":
+		bn := SafeBlockNumber
+		bnh.BlockNumber = &bn
+		return nil
+	case "finalized":
+		bn := FinalizedBlockNumber
+		bnh.BlockNumber = &bn
+		return nil
 	default:
 		if len(input) == 66 {
 			hash := common.Hash{}
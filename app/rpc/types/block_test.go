@@ -0,0 +1,79 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockNumberUnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected BlockNumber
+		expPass  bool
+	}{
+		{`"earliest"`, EarliestBlockNumber, true},
+		{`"latest"`, LatestBlockNumber, true},
+		{`"pending"`, PendingBlockNumber, true},
+		{`"safe"`, LatestBlockNumber, true},
+		{`"finalized"`, LatestBlockNumber, true},
+		{`"0x10"`, BlockNumber(16), true},
+		{`"Safe"`, 0, false},
+		{`"final"`, 0, false},
+		{`"16"`, 0, false},
+		{`"0x8000000000000000"`, 0, false},
+	}
+
+	for _, tc := range testCases {
+		var bn BlockNumber
+		err := json.Unmarshal([]byte(tc.input), &bn)
+		if !tc.expPass {
+			require.Error(t, err, tc.input)
+			continue
+		}
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.expected, bn, tc.input)
+	}
+}
+
+func TestBlockNumberOrHashUnmarshalJSON(t *testing.T) {
+	hash := common.HexToHash("0x5ae0f13b7a5fc6d7c1c9b2d94ec6b35c1c16f2d3dc1b2f3f58a3ad28cbe8b1e4")
+
+	testCases := []struct {
+		input     string
+		expNumber *BlockNumber
+		expHash   *common.Hash
+		expPass   bool
+	}{
+		{`"earliest"`, blockNumberPtr(EarliestBlockNumber), nil, true},
+		{`"latest"`, blockNumberPtr(LatestBlockNumber), nil, true},
+		{`"pending"`, blockNumberPtr(PendingBlockNumber), nil, true},
+		{`"safe"`, blockNumberPtr(LatestBlockNumber), nil, true},
+		{`"finalized"`, blockNumberPtr(LatestBlockNumber), nil, true},
+		{`"0x10"`, blockNumberPtr(16), nil, true},
+		{`"` + hash.Hex() + `"`, nil, &hash, true},
+		{`{"blockNumber":"safe"}`, blockNumberPtr(LatestBlockNumber), nil, true},
+		{`{"blockNumber":"finalized"}`, blockNumberPtr(LatestBlockNumber), nil, true},
+		{`{"blockHash":"` + hash.Hex() + `"}`, nil, &hash, true},
+		{`{"blockNumber":"finalized","blockHash":"` + hash.Hex() + `"}`, nil, nil, false},
+		{`"final"`, nil, nil, false},
+	}
+
+	for _, tc := range testCases {
+		var bnh BlockNumberOrHash
+		err := json.Unmarshal([]byte(tc.input), &bnh)
+		if !tc.expPass {
+			require.Error(t, err, tc.input)
+			continue
+		}
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.expNumber, bnh.BlockNumber, tc.input)
+		require.Equal(t, tc.expHash, bnh.BlockHash, tc.input)
+	}
+}
+
+func blockNumberPtr(bn BlockNumber) *BlockNumber {
+	return &bn
+}
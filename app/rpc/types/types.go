@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
 )
 
 // Copied the Account and StorageResult types since they are registered under an
@@ -46,6 +47,13 @@ type Transaction struct {
 	V                *hexutil.Big    `json:"v"`
 	R                *hexutil.Big    `json:"r"`
 	S                *hexutil.Big    `json:"s"`
+
+	// typed tx fields
+	Type      hexutil.Uint64       `json:"type"`
+	ChainID   *hexutil.Big         `json:"chainId,omitempty"`
+	GasFeeCap *hexutil.Big         `json:"maxFeePerGas,omitempty"`
+	GasTipCap *hexutil.Big         `json:"maxPriorityFeePerGas,omitempty"`
+	Accesses  *ethtypes.AccessList `json:"accessList,omitempty"`
 }
 
 // SendTxArgs represents the arguments to submit a new transaction into the transaction pool.
@@ -95,12 +103,13 @@ func (ca SendTxArgs) String() string {
 
 // CallArgs represents the arguments for a call.
 type CallArgs struct {
-	From     *common.Address `json:"from"`
-	To       *common.Address `json:"to"`
-	Gas      *hexutil.Uint64 `json:"gas"`
-	GasPrice *hexutil.Big    `json:"gasPrice"`
-	Value    *hexutil.Big    `json:"value"`
-	Data     *hexutil.Bytes  `json:"data"`
+	From       *common.Address      `json:"from"`
+	To         *common.Address      `json:"to"`
+	Gas        *hexutil.Uint64      `json:"gas"`
+	GasPrice   *hexutil.Big         `json:"gasPrice"`
+	Value      *hexutil.Big         `json:"value"`
+	Data       *hexutil.Bytes       `json:"data"`
+	AccessList *ethtypes.AccessList `json:"accessList"`
 }
 
 func (ca CallArgs) String() string {
@@ -123,22 +132,18 @@ func (ca CallArgs) String() string {
 	if ca.Data != nil {
 		arg += fmt.Sprintf("Data: %s, ", ca.Data.String())
 	}
+	if ca.AccessList != nil {
+		arg += fmt.Sprintf("AccessList: %v, ", *ca.AccessList)
+	}
 	return strings.TrimRight(arg, ", ")
 }
 
 // Account indicates the overriding fields of account during the execution of
-// a message call.
-// NOTE: state and stateDiff can't be specified at the same time. If state is
-// set, message execution will only use the data in the given state. Otherwise
-// if statDiff is set, all diff will be applied first and then execute the call
-// message.
-type Account struct {
-	Nonce     *hexutil.Uint64              `json:"nonce"`
-	Code      *hexutil.Bytes               `json:"code"`
-	Balance   **hexutil.Big                `json:"balance"`
-	State     *map[common.Hash]common.Hash `json:"state"`
-	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
-}
+// a message call, see evmtypes.AccountOverride.
+type Account = evmtypes.AccountOverride
+
+// StateOverride is the set of accounts overridden during the execution of a message call
+type StateOverride = map[common.Address]Account
 
 // EthHeaderWithBlockHash represents a block header in the Ethereum blockchain with block hash generated from Tendermint Block
 type EthHeaderWithBlockHash struct {
@@ -159,3 +164,54 @@ type EthHeaderWithBlockHash struct {
 	Nonce       ethtypes.BlockNonce `json:"nonce"`
 	Hash        common.Hash         `json:"hash"`
 }
+
+// AccessListResult represents the access list and the gas used by a call returned by eth_createAccessList
+type AccessListResult struct {
+	Accesslist *ethtypes.AccessList `json:"accessList"`
+	Error      string               `json:"error,omitempty"`
+	GasUsed    hexutil.Uint64       `json:"gasUsed"`
+}
+
+// FeeHistoryResult represents the fee history of a range of blocks returned by eth_feeHistory
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+// CallBundleArgs represents the arguments of eth_callBundle. The block number is the next
+// block by default, the zero coinbase and timestamp are taken from the latest block.
+type CallBundleArgs struct {
+	Txs              []hexutil.Bytes `json:"txs"`
+	BlockNumber      BlockNumber     `json:"blockNumber"`
+	StateBlockNumber BlockNumber     `json:"stateBlockNumber"`
+	Coinbase         *common.Address `json:"coinbase"`
+	Timestamp        *hexutil.Uint64 `json:"timestamp"`
+}
+
+// BundleTxResult represents the result of a tx of a bundle returned by eth_callBundle
+type BundleTxResult struct {
+	TxHash       common.Hash     `json:"txHash"`
+	FromAddress  common.Address  `json:"fromAddress"`
+	ToAddress    *common.Address `json:"toAddress"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	GasPrice     *hexutil.Big    `json:"gasPrice"`
+	GasFees      *hexutil.Big    `json:"gasFees"`
+	CoinbaseDiff *hexutil.Big    `json:"coinbaseDiff"`
+	Logs         []*ethtypes.Log `json:"logs"`
+	ReturnData   hexutil.Bytes   `json:"returnData"`
+	Error        string          `json:"error,omitempty"`
+	Revert       string          `json:"revert,omitempty"`
+}
+
+// CallBundleResult represents the results of a bundle returned by eth_callBundle
+type CallBundleResult struct {
+	BundleHash       common.Hash      `json:"bundleHash"`
+	Results          []BundleTxResult `json:"results"`
+	TotalGasUsed     hexutil.Uint64   `json:"totalGasUsed"`
+	GasFees          *hexutil.Big     `json:"gasFees"`
+	CoinbaseDiff     *hexutil.Big     `json:"coinbaseDiff"`
+	BundleGasPrice   *hexutil.Big     `json:"bundleGasPrice"`
+	StateBlockNumber hexutil.Uint64   `json:"stateBlockNumber"`
+}
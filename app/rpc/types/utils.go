@@ -65,6 +65,16 @@ func NewTransaction(tx *evmtypes.MsgEthereumTx, txHash, blockHash common.Hash, b
 		V:        (*hexutil.Big)(tx.Data.V),
 		R:        (*hexutil.Big)(tx.Data.R),
 		S:        (*hexutil.Big)(tx.Data.S),
+		Type:     hexutil.Uint64(tx.Data.Type),
+	}
+
+	if tx.Data.IsTyped() {
+		rpcTx.ChainID = (*hexutil.Big)(tx.Data.ChainID)
+		rpcTx.Accesses = &tx.Data.Accesses
+	}
+	if tx.Data.IsDynamicFee() {
+		rpcTx.GasFeeCap = (*hexutil.Big)(tx.Data.Price)
+		rpcTx.GasTipCap = (*hexutil.Big)(tx.Data.GasTipCap)
 	}
 
 	if blockHash != (common.Hash{}) {
@@ -95,7 +105,9 @@ func EthBlockFromTendermint(clientCtx clientcontext.CLIContext, block *tmtypes.B
 	}
 
 	var bloomRes evmtypes.QueryBloomFilter
-	clientCtx.Codec.MustUnmarshalJSON(res, &bloomRes)
+	if err := clientCtx.Codec.UnmarshalJSON(res, &bloomRes); err != nil {
+		return nil, err
+	}
 
 	bloom := bloomRes.Bloom
 	if fullTx {
@@ -104,7 +116,39 @@ func EthBlockFromTendermint(clientCtx clientcontext.CLIContext, block *tmtypes.B
 		blockTxs = transactions
 	}
 
-	return FormatBlock(block.Header, block.Size(), block.Hash(), gasLimit, gasUsed, blockTxs, bloom), nil
+	baseFee, err := BaseFeeFromTendermint(clientCtx, block.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return FormatBlock(block.Header, block.Size(), block.Hash(), gasLimit, gasUsed, baseFee, blockTxs, bloom), nil
+}
+
+// BaseFeeFromTendermint returns the base fee per gas of the block at the given height,
+// which is nil if the London fork is not active at the block. The base fee of a block is
+// set by its parent block, so it's queried from the state of the parent.
+func BaseFeeFromTendermint(clientCtx clientcontext.CLIContext, height int64) (*big.Int, error) {
+	if height <= 1 {
+		return nil, nil
+	}
+	res, _, err := clientCtx.WithHeight(height-1).Query(fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBaseFee, height))
+	if err != nil {
+		return nil, err
+	}
+
+	var baseFeeRes evmtypes.QueryResBaseFee
+	if err := clientCtx.Codec.UnmarshalJSON(res, &baseFeeRes); err != nil {
+		return nil, err
+	}
+	if baseFeeRes.BaseFee == "" {
+		return nil, nil
+	}
+
+	baseFee, ok := new(big.Int).SetString(baseFeeRes.BaseFee, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid base fee %s", baseFeeRes.BaseFee)
+	}
+	return baseFee, nil
 }
 
 // EthHeaderFromTendermint is an util function that returns an Ethereum Header
@@ -177,7 +221,7 @@ func BlockMaxGasFromConsensusParams(_ context.Context, clientCtx clientcontext.C
 // transactions.
 func FormatBlock(
 	header tmtypes.Header, size int, curBlockHash tmbytes.HexBytes, gasLimit int64,
-	gasUsed *big.Int, transactions interface{}, bloom ethtypes.Bloom,
+	gasUsed, baseFee *big.Int, transactions interface{}, bloom ethtypes.Bloom,
 ) map[string]interface{} {
 	if len(header.DataHash) == 0 {
 		header.DataHash = tmbytes.HexBytes(common.Hash{}.Bytes())
@@ -207,6 +251,9 @@ func FormatBlock(
 		"uncles":           []common.Hash{},
 		"receiptsRoot":     ethtypes.EmptyRootHash,
 	}
+	if baseFee != nil {
+		ret["baseFeePerGas"] = (*hexutil.Big)(baseFee)
+	}
 	if !reflect.ValueOf(transactions).IsNil() {
 		switch transactions.(type) {
 		case []common.Hash:
@@ -56,7 +56,16 @@ func (api *PubSubAPI) subscribe(conn *wsConn, params []interface{}) (rpc.ID, err
 
 		return api.subscribeLogs(conn, nil)
 	case "newPendingTransactions":
-		return api.subscribePendingTransactions(conn)
+		var fullTx bool
+		if len(params) > 1 {
+			if fullTx, ok = params[1].(bool); !ok {
+				return "0", fmt.Errorf("invalid parameters")
+			}
+		}
+
+		return api.subscribePendingTransactions(conn, fullTx)
+	case "droppedPendingTransactions":
+		return api.subscribeDroppedPendingTransactions(conn)
 	case "syncing":
 		return api.subscribeSyncing(conn)
 	default:
@@ -353,7 +362,9 @@ func isHex(str string) bool {
 	return true
 }
 
-func (api *PubSubAPI) subscribePendingTransactions(conn *wsConn) (rpc.ID, error) {
+// subscribePendingTransactions notifies the hashes of the txs entering the mempool, or
+// the full tx objects of the evm txs if fullTx is set.
+func (api *PubSubAPI) subscribePendingTransactions(conn *wsConn, fullTx bool) (rpc.ID, error) {
 	sub, _, err := api.events.SubscribePendingTxs()
 	if err != nil {
 		return "", fmt.Errorf("error creating block filter: %s", err.Error())
@@ -378,6 +389,20 @@ func (api *PubSubAPI) subscribePendingTransactions(conn *wsConn) (rpc.ID, error)
 					continue
 				}
 				txHash := common.BytesToHash(data.Tx.Hash())
+				var result interface{} = txHash
+				if fullTx {
+					ethTx, err := rpctypes.RawTxToEthTx(api.clientCtx, data.Tx)
+					if err != nil {
+						// ignore non Ethermint EVM transactions
+						continue
+					}
+					rpcTx, err := rpctypes.NewTransaction(ethTx, txHash, common.Hash{}, 0, 0)
+					if err != nil {
+						api.logger.Error("failed to build pending tx", "ID", sub.ID(), "txhash", txHash, "error", err)
+						continue
+					}
+					result = rpcTx
+				}
 
 				api.filtersMu.RLock()
 				if f, found := api.filters[sub.ID()]; found {
@@ -387,7 +412,7 @@ func (api *PubSubAPI) subscribePendingTransactions(conn *wsConn) (rpc.ID, error)
 						Method:  "eth_subscription",
 						Params: &SubscriptionResult{
 							Subscription: sub.ID(),
-							Result:       txHash,
+							Result:       result,
 						},
 					}
 
@@ -419,6 +444,76 @@ func (api *PubSubAPI) subscribePendingTransactions(conn *wsConn) (rpc.ID, error)
 	return sub.ID(), nil
 }
 
+// subscribeDroppedPendingTransactions notifies the txs that left the mempool without
+// being included in a block, either dropped or replaced by a tx with the same nonce.
+func (api *PubSubAPI) subscribeDroppedPendingTransactions(conn *wsConn) (rpc.ID, error) {
+	sub, _, err := api.events.SubscribeRmPendingTxs()
+	if err != nil {
+		return "", fmt.Errorf("error creating dropped tx filter: %s", err.Error())
+	}
+
+	unsubscribed := make(chan struct{})
+	api.filtersMu.Lock()
+	api.filters[sub.ID()] = &wsSubscription{
+		sub:          sub,
+		conn:         conn,
+		unsubscribed: unsubscribed,
+	}
+	api.filtersMu.Unlock()
+
+	go func(txsCh <-chan coretypes.ResultEvent, errCh <-chan error) {
+		for {
+			select {
+			case ev := <-txsCh:
+				data, ok := ev.Data.(tmtypes.EventDataRmPendingTx)
+				if !ok {
+					api.logger.Error(fmt.Sprintf("invalid data type %T, expected EventDataRmPendingTx", ev.Data), "ID", sub.ID())
+					continue
+				}
+				droppedTx := NewDroppedPendingTx(data)
+
+				api.filtersMu.RLock()
+				if f, found := api.filters[sub.ID()]; found {
+					// write to ws conn
+					res := &SubscriptionNotification{
+						Jsonrpc: "2.0",
+						Method:  "eth_subscription",
+						Params: &SubscriptionResult{
+							Subscription: sub.ID(),
+							Result:       droppedTx,
+						},
+					}
+
+					err = f.conn.WriteJSON(res)
+					if err != nil {
+						api.logger.Error("failed to write dropped tx", "ID", sub.ID(), "error", err)
+					} else {
+						api.logger.Debug("successfully write dropped tx", "ID", sub.ID(), "txhash", droppedTx.Hash)
+					}
+				}
+				api.filtersMu.RUnlock()
+
+				if err != nil {
+					api.unsubscribe(sub.ID())
+				}
+			case err := <-errCh:
+				if err != nil {
+					api.unsubscribe(sub.ID())
+					api.logger.Error("websocket recv error, close the conn", "ID", sub.ID(), "error", err)
+				}
+				return
+			case <-unsubscribed:
+				api.logger.Debug("DroppedPendingTransactions channel is closed", "ID", sub.ID())
+				return
+			}
+		}
+	}(sub.Event(), sub.Err())
+
+	return sub.ID(), nil
+}
+
+// subscribeSyncing notifies the sync status when the node starts or stops catching up,
+// and the progress on every new block while it is catching up.
 func (api *PubSubAPI) subscribeSyncing(conn *wsConn) (rpc.ID, error) {
 	sub, _, err := api.events.SubscribeNewHeads()
 	if err != nil {
@@ -439,7 +534,8 @@ func (api *PubSubAPI) subscribeSyncing(conn *wsConn) (rpc.ID, error) {
 		return "", fmt.Errorf("error get sync status: %s", err.Error())
 	}
 	startingBlock := hexutil.Uint64(status.SyncInfo.EarliestBlockHeight)
-	highestBlock := hexutil.Uint64(0)
+	highestBlock := hexutil.Uint64(0) // NA
+	catchingUp := status.SyncInfo.CatchingUp
 
 	var result interface{}
 
@@ -454,6 +550,15 @@ func (api *PubSubAPI) subscribeSyncing(conn *wsConn) (rpc.ID, error) {
 					continue
 				}
 
+				// a synced node only notifies once it stops catching up
+				if !newStatus.SyncInfo.CatchingUp && !catchingUp {
+					continue
+				}
+				if newStatus.SyncInfo.CatchingUp && !catchingUp {
+					startingBlock = hexutil.Uint64(newStatus.SyncInfo.LatestBlockHeight)
+				}
+				catchingUp = newStatus.SyncInfo.CatchingUp
+
 				if !newStatus.SyncInfo.CatchingUp {
 					result = false
 				} else {
@@ -3,11 +3,19 @@ package websockets
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	rpcfilters "github.com/okex/exchain/app/rpc/namespaces/eth/filters"
 )
 
+const (
+	droppedReasonDropped  = "dropped"
+	droppedReasonReplaced = "replaced"
+)
+
 type SubscriptionResponseJSON struct {
 	Jsonrpc string      `json:"jsonrpc"`
 	Result  interface{} `json:"result"`
@@ -41,3 +49,25 @@ type wsSubscription struct {
 	unsubscribed chan struct{} // closed when unsubscribing
 	conn         *wsConn
 }
+
+// DroppedPendingTx is the notification of a pending tx that left the mempool without
+// being included in a block
+type DroppedPendingTx struct {
+	Hash   common.Hash    `json:"hash"`
+	From   string         `json:"from"`
+	Nonce  hexutil.Uint64 `json:"nonce"`
+	Reason string         `json:"reason"`
+}
+
+func NewDroppedPendingTx(data tmtypes.EventDataRmPendingTx) *DroppedPendingTx {
+	reason := droppedReasonDropped
+	if data.Reason == tmtypes.RmPendingTxReplaced {
+		reason = droppedReasonReplaced
+	}
+	return &DroppedPendingTx{
+		Hash:   common.BytesToHash(data.Hash),
+		From:   data.From,
+		Nonce:  hexutil.Uint64(data.Nonce),
+		Reason: reason,
+	}
+}
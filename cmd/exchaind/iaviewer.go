@@ -143,63 +143,68 @@ func IaviewerPrintDiff(cdc *codec.Codec, dataDir string, compareDir string, modu
 			log.Println("Error reading compareTree data: ", err)
 			os.Exit(1)
 		}
-		if bytes.Equal(tree.Hash(), compareTree.Hash()) {
-			continue
-		}
+		printTreeDiff(cdc, module, tree, compareTree)
+	}
+}
 
-		var wg sync.WaitGroup
-		wg.Add(2)
-		dataMap := make(map[string][32]byte, tree.Size())
-		compareDataMap := make(map[string][32]byte, compareTree.Size())
-		go getKVs(tree, dataMap, &wg)
-		go getKVs(compareTree, compareDataMap, &wg)
-		wg.Wait()
-
-		//get all keys
-		keySize := tree.Size()
-		if compareTree.Size() > keySize {
-			keySize = compareTree.Size()
-		}
-		allKeys := make(map[string]bool, keySize)
-		for k, _ := range dataMap {
-			allKeys[k] = false
-		}
-		for k, _ := range compareDataMap {
-			allKeys[k] = false
-		}
+// printTreeDiff prints the key-values which are different between two versions of a module tree
+func printTreeDiff(cdc *codec.Codec, module string, tree *iavl.MutableTree, compareTree *iavl.MutableTree) {
+	if bytes.Equal(tree.Hash(), compareTree.Hash()) {
+		return
+	}
 
-		log.Println(fmt.Sprintf("==================================== %s begin ====================================", module))
-		//find diff value by each key
-		for key, _ := range allKeys {
-			value, ok := dataMap[key]
-			compareValue, compareOK := compareDataMap[key]
-			keyByte, _ := hex.DecodeString(key)
-			if ok && compareOK {
-				if value == compareValue {
-					continue
-				}
-				log.Println("\nvalue is different--------------------------------------------------------------------")
-				log.Println("dir key-value :")
-				printByKey(cdc, tree, module, keyByte)
-				log.Println("compareDir key-value :")
-				printByKey(cdc, compareTree, module, keyByte)
-				log.Println("value is different--------------------------------------------------------------------")
-				continue
-			}
-			if ok {
-				log.Println("\nOnly be in dir--------------------------------------------------------------------")
-				printByKey(cdc, tree, module, keyByte)
-				continue
-			}
-			if compareOK {
-				log.Println("\nOnly be in compare dir--------------------------------------------------------------------")
-				printByKey(cdc, compareTree, module, keyByte)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	dataMap := make(map[string][32]byte, tree.Size())
+	compareDataMap := make(map[string][32]byte, compareTree.Size())
+	go getKVs(tree, dataMap, &wg)
+	go getKVs(compareTree, compareDataMap, &wg)
+	wg.Wait()
+
+	//get all keys
+	keySize := tree.Size()
+	if compareTree.Size() > keySize {
+		keySize = compareTree.Size()
+	}
+	allKeys := make(map[string]bool, keySize)
+	for k, _ := range dataMap {
+		allKeys[k] = false
+	}
+	for k, _ := range compareDataMap {
+		allKeys[k] = false
+	}
+
+	log.Println(fmt.Sprintf("==================================== %s begin ====================================", module))
+	//find diff value by each key
+	for key, _ := range allKeys {
+		value, ok := dataMap[key]
+		compareValue, compareOK := compareDataMap[key]
+		keyByte, _ := hex.DecodeString(key)
+		if ok && compareOK {
+			if value == compareValue {
 				continue
 			}
-
+			log.Println("\nvalue is different--------------------------------------------------------------------")
+			log.Println("dir key-value :")
+			printByKey(cdc, tree, module, keyByte)
+			log.Println("compareDir key-value :")
+			printByKey(cdc, compareTree, module, keyByte)
+			log.Println("value is different--------------------------------------------------------------------")
+			continue
 		}
-		log.Println(fmt.Sprintf("==================================== %s end ====================================", module))
+		if ok {
+			log.Println("\nOnly be in dir--------------------------------------------------------------------")
+			printByKey(cdc, tree, module, keyByte)
+			continue
+		}
+		if compareOK {
+			log.Println("\nOnly be in compare dir--------------------------------------------------------------------")
+			printByKey(cdc, compareTree, module, keyByte)
+			continue
+		}
+
 	}
+	log.Println(fmt.Sprintf("==================================== %s end ====================================", module))
 }
 
 // IaviewerReadData reads key-value from leveldb
@@ -489,6 +494,11 @@ func ReadTree(dir string, version int, prefix []byte, cacheSize int) (*iavl.Muta
 	if err != nil {
 		return nil, err
 	}
+	return readTreeFromDB(db, version, prefix, cacheSize)
+}
+
+// readTreeFromDB loads an iavl tree from an opened database, see ReadTree
+func readTreeFromDB(db dbm.DB, version int, prefix []byte, cacheSize int) (*iavl.MutableTree, error) {
 	if len(prefix) != 0 {
 		db = dbm.NewPrefixDB(db, prefix)
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
 
 	"github.com/okex/exchain/app/rpc"
 	evmtypes "github.com/okex/exchain/x/evm/types"
@@ -23,6 +24,7 @@ import (
 	clientkeys "github.com/okex/exchain/libs/cosmos-sdk/client/keys"
 	"github.com/okex/exchain/libs/cosmos-sdk/crypto/keys"
 	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	"github.com/okex/exchain/libs/cosmos-sdk/snapshots"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
 
@@ -81,11 +83,12 @@ func main() {
 		genutilcli.ValidateGenesisCmd(ctx, cdc, app.ModuleBasics),
 		client.TestnetCmd(ctx, cdc, app.ModuleBasics, auth.GenesisAccountIterator{}),
 		replayCmd(ctx),
-		repairStateCmd(ctx),
+		repairStateCmd(ctx, cdc),
 		// AddGenesisAccountCmd allows users to add accounts to the genesis file
 		AddGenesisAccountCmd(ctx, cdc, app.DefaultNodeHome, app.DefaultCLIHome),
 		flags.NewCompletionCmd(rootCmd, true),
 		dataCmd(ctx),
+		snapshotCmd(ctx),
 		exportAppCmd(ctx),
 		iaviewerCmd(cdc),
 	)
@@ -109,6 +112,7 @@ func closeApp(iApp abci.Application) {
 	app := iApp.(*app.OKExChainApp)
 	app.StopStore()
 	evmtypes.CloseIndexer()
+	evmtypes.CloseStateHistory()
 	evmtypes.CloseTracer()
 	rpc.CloseEthBackend()
 }
@@ -129,9 +133,32 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 		baseapp.SetPruning(pruningOpts),
 		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
 		baseapp.SetHaltHeight(uint64(viper.GetInt(server.FlagHaltHeight))),
+		baseapp.SetSnapshotStore(newSnapshotStore()),
+		baseapp.SetSnapshotInterval(viper.GetUint64(server.FlagStateSyncSnapshotInterval)),
+		baseapp.SetSnapshotKeepRecent(viper.GetUint32(server.FlagStateSyncSnapshotKeepRecent)),
+		baseapp.SetMinRetainBlocks(viper.GetUint64(server.FlagMinRetainBlocks)),
 	)
 }
 
+// newSnapshotStore opens the state sync snapshot store only if snapshots are taken, so the commands
+// replaying blocks don't open the snapshot db of the node
+func newSnapshotStore() *snapshots.Store {
+	if viper.GetUint64(server.FlagStateSyncSnapshotInterval) == 0 {
+		return nil
+	}
+
+	snapshotDir := filepath.Join(viper.GetString(cli.HomeFlag), "data", "snapshots")
+	snapshotDB, err := sdk.NewLevelDB("metadata", snapshotDir)
+	if err != nil {
+		panic(err)
+	}
+	snapshotStore, err := snapshots.NewStore(snapshotDB, snapshotDir)
+	if err != nil {
+		panic(err)
+	}
+	return snapshotStore
+}
+
 func exportAppStateAndTMValidators(
 	logger log.Logger, db dbm.DB, traceStore io.Writer, height int64, forZeroHeight bool, jailWhiteList []string,
 ) (json.RawMessage, []tmtypes.GenesisValidator, error) {
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/okex/exchain/app"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
 	"github.com/okex/exchain/libs/cosmos-sdk/server"
 	"github.com/okex/exchain/libs/iavl"
 	tmlog "github.com/okex/exchain/libs/tendermint/libs/log"
@@ -25,21 +26,41 @@ import (
 
 const (
 	FlagStartHeight string = "start-height"
+	FlagOnline      string = "online"
+	FlagShadowDir   string = "shadow-dir"
 )
 
-func repairStateCmd(ctx *server.Context) *cobra.Command {
+func repairStateCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "repair-state",
 		Short: "Repair the SMB(state machine broken) data of node",
+		Long: `Repair the SMB(state machine broken) data of node.
+
+With --online, the node doesn't need to be stopped. The blocks after the start height are replayed
+into a shadow copy of the node data, and the app hash and module store hashes are compared with the
+commit info of the node after every block. The first divergent height and module stores are
+reported, together with the differing keys of those stores.
+
+The shadow copy is taken through LevelDB snapshots, only the goleveldb backend is supported. The
+databases of a running node are locked by it, their files are copied first and recovered by LevelDB
+as after a crash, so each copy is the state of its database at some point while copying, not at the
+same block for all of them. The replay is bounded by the heights the copies have in common. Stop the
+node while the shadow copy is taken for an exact copy of its data.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			log.Println("--------- repair data start ---------")
 
-			repairState(ctx)
+			if viper.GetBool(FlagOnline) {
+				repairStateOnline(ctx, cdc)
+			} else {
+				repairState(ctx)
+			}
 			log.Println("--------- repair data success ---------")
 		},
 	}
 	cmd.Flags().Bool(sm.FlagParalleledTx, false, "parallel execution for evm txs")
 	cmd.Flags().Int64(FlagStartHeight, 0, "Set the start block height for repair")
+	cmd.Flags().Bool(FlagOnline, false, "Replay into a shadow copy of the data while the node is running, and report the first divergent height")
+	cmd.Flags().String(FlagShadowDir, "", "Directory of the shadow data for --online (default <home>/data/repair-shadow)")
 	return cmd
 }
 
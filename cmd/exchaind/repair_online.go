@@ -0,0 +1,473 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/iavl"
+	"github.com/okex/exchain/libs/tendermint/mock"
+	"github.com/okex/exchain/libs/tendermint/proxy"
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/store"
+)
+
+const (
+	checkpointRetries    = 20
+	checkpointRetryDelay = 500 * time.Millisecond
+	checkpointBatchSize  = 10000
+)
+
+// stateDivergence describes the first height at which the replayed state differs from the state
+// committed by the node. The keys are compared at the diff height, the first version persisted by
+// the node since the divergent height, or not at all if the diff height is zero.
+type stateDivergence struct {
+	height              int64
+	diffHeight          int64
+	appHash             []byte
+	replayedAppHash     []byte
+	storeHashes         map[string][]byte
+	replayedStoreHashes map[string][]byte
+}
+
+// stores returns the names of the module stores whose root hashes differ, sorted by name.
+func (d *stateDivergence) stores() []string {
+	var names []string
+	for name, hash := range d.storeHashes {
+		if !bytes.Equal(hash, d.replayedStoreHashes[name]) {
+			names = append(names, name)
+		}
+	}
+	for name := range d.replayedStoreHashes {
+		if _, ok := d.storeHashes[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// repairStateOnline replays blocks into a shadow copy of the node data while the node keeps
+// running. After every block the replayed app hash is compared with the commit info stored by the
+// node, and the first divergent height is reported with the module stores and keys that differ.
+func repairStateOnline(ctx *server.Context, cdc *codec.Codec) {
+	// set ignore smb check, divergence is detected by comparing the commit info instead
+	sm.SetIgnoreSmbCheck(true)
+	iavl.SetIgnoreVersionCheck(true)
+
+	// the databases are checkpointed through goleveldb
+	if sdk.DBBackend != "" && dbm.BackendType(sdk.DBBackend) != dbm.GoLevelDBBackend {
+		panic(fmt.Sprintf("The online repair only supports the %s backend", dbm.GoLevelDBBackend))
+	}
+
+	dataDir := filepath.Join(ctx.Config.RootDir, "data")
+	shadowDir := viper.GetString(FlagShadowDir)
+	if shadowDir == "" {
+		shadowDir = filepath.Join(dataDir, "repair-shadow")
+	}
+	if _, err := os.Stat(shadowDir); err == nil {
+		panic(fmt.Sprintf("The shadow directory %s already exists, please remove it first", shadowDir))
+	}
+
+	// Checkpoint the databases of the running node, and copy the application and state databases
+	// once more for replaying, so that the commit info of the node stays readable.
+	originDir := filepath.Join(shadowDir, "origin")
+	replayDir := filepath.Join(shadowDir, "data")
+	for _, name := range []string{applicationDB, blockStoreDB, stateDB} {
+		panicError(checkpointDB(name, dataDir, originDir))
+	}
+	for _, name := range []string{applicationDB, stateDB} {
+		panicError(checkpointDB(name, originDir, replayDir))
+	}
+	log.Println("Checkpointed node data to", shadowDir)
+
+	// the app opens its optional databases (bloom filter, state history, ...) below the home
+	// directory, which are locked by the running node
+	viper.Set(flags.FlagHome, shadowDir)
+
+	divergence, err := replayShadow(ctx, originDir, replayDir)
+	panicError(err)
+	if divergence == nil {
+		log.Println("No divergence found, removing", shadowDir)
+		panicError(os.RemoveAll(shadowDir))
+		return
+	}
+
+	stores := divergence.stores()
+	log.Println("First divergent height", divergence.height)
+	log.Println("Node app hash", fmt.Sprintf("%X", divergence.appHash))
+	log.Println("Replayed app hash", fmt.Sprintf("%X", divergence.replayedAppHash))
+	for _, name := range stores {
+		log.Println(fmt.Sprintf("Divergent store %s, node hash %X, replayed hash %X",
+			name, divergence.storeHashes[name], divergence.replayedStoreHashes[name]))
+	}
+	if divergence.diffHeight == 0 {
+		log.Println("The node has not persisted any version since the divergent height, the keys are not compared")
+	} else {
+		printShadowDiff(cdc, originDir, replayDir, stores, divergence.diffHeight)
+	}
+	log.Println("The shadow data is kept in", shadowDir)
+}
+
+// replayShadow replays the blocks after the start height into the replay copy of the node data,
+// and returns the first height at which the app hash differs from the one committed by the node,
+// or nil if the replayed state is the same. The start height defaults to the last version persisted
+// by the iavl trees of the node, as the versions after it may only exist in the commit info.
+func replayShadow(ctx *server.Context, originDir, replayDir string) (*stateDivergence, error) {
+	originAppDB, err := openDB(applicationDB, originDir)
+	if err != nil {
+		return nil, err
+	}
+	defer originAppDB.Close()
+	originBlockStoreDB, err := openDB(blockStoreDB, originDir)
+	if err != nil {
+		return nil, err
+	}
+	defer originBlockStoreDB.Close()
+	blockStore := store.NewBlockStore(originBlockStoreDB)
+	stateStoreDB, err := openDB(stateDB, replayDir)
+	if err != nil {
+		return nil, err
+	}
+	defer stateStoreDB.Close()
+
+	// the block store may be one block ahead of the committed app state
+	latestHeight := rootmulti.NewStore(originAppDB).GetLatestVersion()
+	if blockStore.Height() < latestHeight {
+		latestHeight = blockStore.Height()
+	}
+	latestStoreHashes, _, err := rootmulti.GetCommitStoreHashes(originAppDB, latestHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the node commit info at height %d: %w", latestHeight, err)
+	}
+	persisted, err := persistedVersions(originAppDB, latestStoreHashes)
+	if err != nil {
+		return nil, err
+	}
+	startHeight := viper.GetInt64(FlagStartHeight)
+	if startHeight == 0 {
+		for _, version := range persisted {
+			if version < latestHeight {
+				startHeight = version
+			}
+		}
+	}
+	if startHeight <= 0 || startHeight >= latestHeight {
+		return nil, fmt.Errorf("start height %d must be between 1 and the latest height %d", startHeight, latestHeight)
+	}
+	if firstVersionFrom(persisted, startHeight) != startHeight {
+		return nil, fmt.Errorf("start height %d is not persisted by the iavl trees of the node", startHeight)
+	}
+	state, err := loadStateAtHeight(stateStoreDB, blockStore, startHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	replayAppDB, err := openDB(applicationDB, replayDir)
+	if err != nil {
+		return nil, err
+	}
+	defer replayAppDB.Close()
+	repairApp := newRepairApp(ctx.Logger, replayAppDB, nil)
+	defer repairApp.StopStore()
+	if err := repairApp.LoadStartVersion(startHeight); err != nil {
+		return nil, err
+	}
+	proxyApp, err := createAndStartProxyAppConns(proxy.NewLocalClientCreator(repairApp))
+	if err != nil {
+		return nil, err
+	}
+	defer proxyApp.Stop()
+
+	blockExec := sm.NewBlockExecutor(stateStoreDB, ctx.Logger, proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
+	blockExec.SetIsAsyncDeliverTx(viper.GetBool(sm.FlagParalleledTx))
+	var divergence *stateDivergence
+	for height := startHeight + 1; height <= latestHeight; height++ {
+		block := blockStore.LoadBlock(height)
+		meta := blockStore.LoadBlockMeta(height)
+		if block == nil || meta == nil {
+			return nil, fmt.Errorf("block %d is not in the block store", height)
+		}
+		state, _, err = blockExec.ApplyBlock(state, meta.BlockID, block)
+		if err != nil {
+			return nil, err
+		}
+		// the replayed version is persisted when the repair app is stopped
+		if divergence != nil {
+			if height == divergence.diffHeight {
+				return divergence, nil
+			}
+			continue
+		}
+
+		storeHashes, appHash, err := rootmulti.GetCommitStoreHashes(originAppDB, height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the node commit info at height %d: %w", height, err)
+		}
+		replayedStoreHashes, replayedAppHash, err := rootmulti.GetCommitStoreHashes(replayAppDB, height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the replayed commit info at height %d: %w", height, err)
+		}
+		if !bytes.Equal(appHash, replayedAppHash) {
+			divergence = &stateDivergence{
+				height:              height,
+				appHash:             appHash,
+				replayedAppHash:     replayedAppHash,
+				storeHashes:         storeHashes,
+				replayedStoreHashes: replayedStoreHashes,
+			}
+			if diffHeight := firstVersionFrom(persisted, height); diffHeight <= latestHeight {
+				divergence.diffHeight = diffHeight
+			}
+			if divergence.diffHeight == 0 || divergence.diffHeight == height {
+				return divergence, nil
+			}
+			log.Println("Divergent height", height, "replaying to the version persisted by the node", divergence.diffHeight)
+			continue
+		}
+		log.Println("Replayed block height", height)
+		log.Println("Replayed app hash", fmt.Sprintf("%X", replayedAppHash))
+	}
+	return divergence, nil
+}
+
+// persistedVersions returns the versions persisted by the iavl trees of all the given module
+// stores in ascending order. The trees are persisted every iavl.CommitIntervalHeight blocks when
+// committing asynchronously, while the commit info is written every block. The stores without any
+// persisted version, e.g. the transient ones, are skipped.
+func persistedVersions(db dbm.DB, stores map[string][]byte) ([]int64, error) {
+	var versions []int64
+	var trees int
+	counts := make(map[int64]int)
+	for name := range stores {
+		tree, err := iavl.NewMutableTree(dbm.NewPrefixDB(db, []byte(fmt.Sprintf("s/k:%s/", name))), DefaultCacheSize)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tree.Load(); err != nil {
+			return nil, fmt.Errorf("failed to load the iavl tree of store %s: %w", name, err)
+		}
+		available := tree.AvailableVersions()
+		if len(available) == 0 {
+			continue
+		}
+		trees++
+		for _, version := range available {
+			counts[int64(version)]++
+		}
+	}
+	for version, count := range counts {
+		if count == trees {
+			versions = append(versions, version)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions, nil
+}
+
+// firstVersionFrom returns the first of the ascending versions not below the height, or zero if
+// there is none.
+func firstVersionFrom(versions []int64, height int64) int64 {
+	i := sort.Search(len(versions), func(i int) bool { return versions[i] >= height })
+	if i == len(versions) {
+		return 0
+	}
+	return versions[i]
+}
+
+// printShadowDiff prints the differing keys of the given module stores at the given height, the
+// dir being the node data and the compare dir the replayed data.
+func printShadowDiff(cdc *codec.Codec, originDir, replayDir string, stores []string, height int64) {
+	originAppDB, err := openDB(applicationDB, originDir)
+	panicError(err)
+	defer originAppDB.Close()
+	replayAppDB, err := openDB(applicationDB, replayDir)
+	panicError(err)
+	defer replayAppDB.Close()
+
+	for _, name := range stores {
+		module := fmt.Sprintf("s/k:%s/", name)
+		tree, err := readTreeFromDB(originAppDB, int(height), []byte(module), DefaultCacheSize)
+		if err != nil {
+			log.Println("Error reading node data of", module, err)
+			continue
+		}
+		compareTree, err := readTreeFromDB(replayAppDB, int(height), []byte(module), DefaultCacheSize)
+		if err != nil {
+			log.Println("Error reading replayed data of", module, err)
+			continue
+		}
+		printTreeDiff(cdc, module, tree, compareTree)
+	}
+}
+
+// checkpointDB copies the goleveldb database dir/name.db to toDir/name.db through a LevelDB snapshot,
+// so the copy is a consistent state of the database. A running node locks its database, then the
+// files are staged first, the table files being hard linked, and the snapshot is taken from the
+// staged copy once LevelDB recovers it. The copy is then the state at some point while staging, as
+// if the node had crashed at that point. The staging is retried if LevelDB can't recover it, e.g.
+// a table file was removed by a compaction meanwhile.
+func checkpointDB(name, dir, toDir string) error {
+	from := filepath.Join(dir, name+".db")
+	to := filepath.Join(toDir, name+".db")
+	if _, err := os.Stat(from); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(to); err != nil {
+		return err
+	}
+
+	if db, err := leveldb.OpenFile(from, &opt.Options{ReadOnly: true, ErrorIfMissing: true}); err == nil {
+		defer db.Close()
+		return copyLevelDB(db, to)
+	}
+
+	staging := to + ".staging"
+	defer os.RemoveAll(staging)
+	var err error
+	for i := 0; i < checkpointRetries; i++ {
+		var db *leveldb.DB
+		if db, err = stageDB(from, staging); err == nil {
+			defer db.Close()
+			return copyLevelDB(db, to)
+		}
+		time.Sleep(checkpointRetryDelay)
+	}
+	return fmt.Errorf("failed to checkpoint %s: %w", from, err)
+}
+
+// stageDB copies the files of the database to the staging dir and opens the copy. The current and
+// manifest files are copied before the table files and the journals last, so that the tables
+// referenced by the manifest are either copied or missing, which fails the recovery, and the
+// journals hold the writes after them. A journal record torn by the copy is dropped by LevelDB.
+func stageDB(from, staging string) (*leveldb.DB, error) {
+	if err := os.RemoveAll(staging); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(staging, 0755); err != nil {
+		return nil, err
+	}
+	files, err := listDBFiles(from)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return dbFileOrder(files[i]) < dbFileOrder(files[j]) })
+	if err := copyDBFiles(from, staging, files); err != nil {
+		return nil, err
+	}
+	return leveldb.OpenFile(staging, &opt.Options{
+		ErrorIfMissing: true,
+		Strict:         opt.DefaultStrict | opt.StrictManifest,
+	})
+}
+
+// dbFileOrder returns the order in which the database files are staged.
+func dbFileOrder(name string) int {
+	switch {
+	case name == "CURRENT":
+		return 0
+	case strings.HasPrefix(name, "MANIFEST"):
+		return 1
+	case strings.HasSuffix(name, ".log"):
+		return 3
+	default:
+		return 2
+	}
+}
+
+// copyLevelDB writes the entries of a snapshot of the database into a new database at dir.
+func copyLevelDB(db *leveldb.DB, dir string) error {
+	snapshot, err := db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+	to, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return err
+	}
+	defer to.Close()
+
+	iter := snapshot.NewIterator(nil, nil)
+	defer iter.Release()
+	batch := new(leveldb.Batch)
+	for iter.Next() {
+		batch.Put(iter.Key(), iter.Value())
+		if batch.Len() >= checkpointBatchSize {
+			if err := to.Write(batch, nil); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return to.Write(batch, nil)
+}
+
+// listDBFiles returns the names of the database files, except the lock and log files.
+func listDBFiles(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, info := range infos {
+		if info.IsDir() || info.Name() == "LOCK" || strings.HasPrefix(info.Name(), "LOG") {
+			continue
+		}
+		files = append(files, info.Name())
+	}
+	return files, nil
+}
+
+func copyDBFiles(from, to string, files []string) error {
+	for _, name := range files {
+		src := filepath.Join(from, name)
+		dst := filepath.Join(to, name)
+		if strings.HasSuffix(name, ".ldb") || strings.HasSuffix(name, ".sst") {
+			if err := os.Link(src, dst); err == nil {
+				continue
+			}
+		}
+		if err := copyFile(src, dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
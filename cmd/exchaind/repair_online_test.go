@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/okex/exchain/libs/iavl"
+)
+
+func TestCheckpointDB(t *testing.T) {
+	dir, toDir := t.TempDir(), t.TempDir()
+	// the database is kept open as by the running node
+	db, err := dbm.NewGoLevelDB(applicationDB, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, db.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))))
+	}
+
+	require.NoError(t, checkpointDB(applicationDB, dir, toDir))
+	checkpoint, err := dbm.NewGoLevelDB(applicationDB, toDir)
+	require.NoError(t, err)
+	defer checkpoint.Close()
+	for i := 0; i < 1000; i++ {
+		value, err := checkpoint.Get([]byte(fmt.Sprintf("key%d", i)))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value%d", i)), value)
+	}
+
+	// the node keeps writing to its own database
+	require.NoError(t, db.Set([]byte("key1000"), []byte("value1000")))
+	value, err := checkpoint.Get([]byte("key1000"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// the database of a stopped node is copied through a snapshot
+	require.NoError(t, db.Close())
+	stoppedDir := t.TempDir()
+	require.NoError(t, checkpointDB(applicationDB, dir, stoppedDir))
+	stopped, err := dbm.NewGoLevelDB(applicationDB, stoppedDir)
+	require.NoError(t, err)
+	defer stopped.Close()
+	value, err = stopped.Get([]byte("key1000"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1000"), value)
+
+	require.Error(t, checkpointDB(blockStoreDB, dir, toDir))
+}
+
+func TestDBFileOrder(t *testing.T) {
+	files := []string{"000005.log", "000004.ldb", "MANIFEST-000003", "CURRENT", "000002.ldb"}
+	sort.Slice(files, func(i, j int) bool { return dbFileOrder(files[i]) < dbFileOrder(files[j]) })
+	require.Equal(t, "CURRENT", files[0])
+	require.Equal(t, "MANIFEST-000003", files[1])
+	require.Equal(t, "000005.log", files[4])
+}
+
+func TestStateDivergenceStores(t *testing.T) {
+	testCases := map[string]struct {
+		storeHashes         map[string][]byte
+		replayedStoreHashes map[string][]byte
+		expected            []string
+	}{
+		"no divergent store": {
+			storeHashes:         map[string][]byte{"acc": {1}, "staking": {2}},
+			replayedStoreHashes: map[string][]byte{"acc": {1}, "staking": {2}},
+			expected:            nil,
+		},
+		"divergent hashes": {
+			storeHashes:         map[string][]byte{"acc": {1}, "staking": {2}, "evm": {3}},
+			replayedStoreHashes: map[string][]byte{"acc": {1}, "staking": {4}, "evm": {5}},
+			expected:            []string{"evm", "staking"},
+		},
+		"store missing in the replayed state": {
+			storeHashes:         map[string][]byte{"acc": {1}, "farm": {2}},
+			replayedStoreHashes: map[string][]byte{"acc": {1}},
+			expected:            []string{"farm"},
+		},
+		"store missing in the node state": {
+			storeHashes:         map[string][]byte{"acc": {1}},
+			replayedStoreHashes: map[string][]byte{"acc": {1}, "farm": {2}},
+			expected:            []string{"farm"},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			divergence := &stateDivergence{storeHashes: tc.storeHashes, replayedStoreHashes: tc.replayedStoreHashes}
+			require.Equal(t, tc.expected, divergence.stores())
+		})
+	}
+}
+
+func TestPersistedVersions(t *testing.T) {
+	db := dbm.NewMemDB()
+	// the acc tree is persisted up to version 5, the staking tree up to version 3
+	for name, latest := range map[string]int{"acc": 5, "staking": 3} {
+		tree, err := iavl.NewMutableTree(dbm.NewPrefixDB(db, []byte(fmt.Sprintf("s/k:%s/", name))), DefaultCacheSize)
+		require.NoError(t, err)
+		for version := 1; version <= latest; version++ {
+			tree.Set([]byte(name), []byte{byte(version)})
+			_, _, err := tree.SaveVersion()
+			require.NoError(t, err)
+		}
+	}
+
+	// the params store has no iavl tree
+	versions, err := persistedVersions(db, map[string][]byte{"acc": nil, "staking": nil, "params": nil})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 3}, versions)
+
+	require.Equal(t, int64(2), firstVersionFrom(versions, 2))
+	require.Equal(t, int64(1), firstVersionFrom(versions, 0))
+	require.Equal(t, int64(0), firstVersionFrom(versions, 4))
+}
@@ -41,6 +41,9 @@ const (
 
 	saveBlock = "save_block"
 
+	replayRangeFlag  = "range"
+	replayReportFlag = "report"
+
 	defaulPprofFileFlags = os.O_RDWR | os.O_CREATE | os.O_APPEND
 	defaultPprofFilePerm = 0644
 )
@@ -91,12 +94,19 @@ func replayCmd(ctx *server.Context) *cobra.Command {
 	cmd.Flags().Bool(runWithPprofFlag, false, "Dump the pprof of the entire replay process")
 	cmd.Flags().Bool(sm.FlagParalleledTx, false, "pall Tx")
 	cmd.Flags().Bool(saveBlock, false, "save block when replay")
+	cmd.Flags().String(replayRangeFlag, "", "Replay the blocks in the range A:B, the app state must be lower than height A")
+	cmd.Flags().String(replayReportFlag, "", "Write a JSON report of the per-block and per-tx execution of the replayed blocks to this file")
 	return cmd
 }
 
 // replayBlock replays blocks from db, if something goes wrong, it will panic with error message.
 func replayBlock(ctx *server.Context, originDataDir string) {
-	proxyApp, err := createProxyApp(ctx)
+	var recorder *replayRecorder
+	reportFile := viper.GetString(replayReportFlag)
+	if reportFile != "" {
+		recorder = newReplayRecorder(viper.GetBool(sm.FlagParalleledTx))
+	}
+	proxyApp, err := createProxyApp(ctx, recorder)
 	panicError(err)
 
 	res, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
@@ -123,10 +133,14 @@ func replayBlock(ctx *server.Context, originDataDir string) {
 	}
 
 	// replay
-	doReplay(ctx, state, stateStoreDB, proxyApp, originDataDir, currentAppHash, currentBlockHeight)
+	doReplay(ctx, state, stateStoreDB, proxyApp, originDataDir, currentAppHash, currentBlockHeight, recorder)
 	if viper.GetBool(sm.FlagParalleledTx) {
 		baseapp.ParaLog.PrintLog()
 	}
+	if recorder != nil {
+		panicError(recorder.writeReport(reportFile))
+		log.Println("replay report written to", reportFile)
+	}
 }
 
 // panic if error is not nil
@@ -140,12 +154,15 @@ func openDB(dbName string, dataDir string) (db dbm.DB, err error) {
 	return sdk.NewLevelDB(dbName, dataDir)
 }
 
-func createProxyApp(ctx *server.Context) (proxy.AppConns, error) {
+func createProxyApp(ctx *server.Context, recorder *replayRecorder) (proxy.AppConns, error) {
 	rootDir := ctx.Config.RootDir
 	dataDir := filepath.Join(rootDir, "data")
 	db, err := openDB(applicationDB, dataDir)
 	panicError(err)
 	app := newApp(ctx.Logger, db, nil)
+	if recorder != nil {
+		app = recorder.wrap(app)
+	}
 	clientCreator := proxy.NewLocalClientCreator(app)
 	return createAndStartProxyAppConns(clientCreator)
 }
@@ -227,7 +244,7 @@ func SaveBlock(ctx *server.Context, originDB *store.BlockStore, height int64) {
 }
 
 func doReplay(ctx *server.Context, state sm.State, stateStoreDB dbm.DB,
-	proxyApp proxy.AppConns, originDataDir string, lastAppHash []byte, lastBlockHeight int64) {
+	proxyApp proxy.AppConns, originDataDir string, lastAppHash []byte, lastBlockHeight int64, recorder *replayRecorder) {
 	originBlockStoreDB, err := openDB(blockStoreDB, originDataDir)
 	panicError(err)
 	originBlockStore := store.NewBlockStore(originBlockStoreDB)
@@ -235,11 +252,27 @@ func doReplay(ctx *server.Context, state sm.State, stateStoreDB dbm.DB,
 	log.Println("origin latest block height", "height", originLatestBlockHeight)
 
 	haltheight := viper.GetInt64(server.FlagHaltHeight)
-	if haltheight == 0 {
-		haltheight = originLatestBlockHeight
+	rangeStart := lastBlockHeight + 1
+	if blockRange := viper.GetString(replayRangeFlag); blockRange != "" {
+		from, to, err := parseReplayRange(blockRange)
+		panicError(err)
+		if from <= lastBlockHeight {
+			panic(fmt.Sprintf("the app state is at height %d, it must be lower than the range start %d", lastBlockHeight, from))
+		}
+		if to > originLatestBlockHeight {
+			panic(fmt.Sprintf("the range end %d is beyond the origin latest block height %d", to, originLatestBlockHeight))
+		}
+		rangeStart, haltheight = from, to
+	} else {
+		if haltheight == 0 {
+			haltheight = originLatestBlockHeight
+		}
+		if haltheight <= lastBlockHeight+1 {
+			panic("haltheight <= startBlockHeight please check data or height")
+		}
 	}
-	if haltheight <= lastBlockHeight+1 {
-		panic("haltheight <= startBlockHeight please check data or height")
+	if recorder != nil {
+		recorder.setRange(rangeStart, haltheight)
 	}
 
 	log.Println("replay stop block height", "height", haltheight)
@@ -268,8 +301,15 @@ func doReplay(ctx *server.Context, state sm.State, stateStoreDB dbm.DB,
 		block := originBlockStore.LoadBlock(height)
 		meta := originBlockStore.LoadBlockMeta(height)
 		blockExec.SetIsAsyncDeliverTx(viper.GetBool(sm.FlagParalleledTx))
+		if recorder != nil {
+			recorder.beginBlock(height)
+		}
+		start := time.Now()
 		state, _, err = blockExec.ApplyBlock(state, meta.BlockID, block)
 		panicError(err)
+		if recorder != nil {
+			recorder.endBlock(time.Since(start))
+		}
 		if needSaveBlock {
 			SaveBlock(ctx, originBlockStore, height)
 		}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/okex/exchain/app"
+	"github.com/okex/exchain/libs/cosmos-sdk/baseapp"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/okex/exchain/libs/cosmos-sdk/store/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+// replayReport is the report of a replayed block range written by replay --report. Apart from the
+// execution times, everything in it is deterministic, so that the reports of two builds can be
+// diffed.
+type replayReport struct {
+	From       int64          `json:"from"`
+	To         int64          `json:"to"`
+	ParallelTx bool           `json:"parallel_tx"`
+	Summary    replaySummary  `json:"summary"`
+	Blocks     []*blockReport `json:"blocks"`
+}
+
+type replaySummary struct {
+	Blocks   int             `json:"blocks"`
+	Txs      int             `json:"txs"`
+	ReRunTxs int             `json:"rerun_txs"`
+	GasUsed  int64           `json:"gas_used"`
+	ExecTime int64           `json:"exec_time_us"`
+	Modules  []*moduleReport `json:"modules"`
+}
+
+type blockReport struct {
+	Height         int64           `json:"height"`
+	TxCount        int             `json:"tx_count"`
+	ReRunTxs       int             `json:"rerun_txs"`
+	GasUsed        int64           `json:"gas_used"`
+	ExecTime       int64           `json:"exec_time_us"`
+	BeginBlockTime int64           `json:"begin_block_time_us"`
+	DeliverTxsTime int64           `json:"deliver_txs_time_us"`
+	EndBlockTime   int64           `json:"end_block_time_us"`
+	CommitTime     int64           `json:"commit_time_us"`
+	Modules        []*moduleReport `json:"modules"`
+	Txs            []*txReport     `json:"txs"`
+}
+
+// txReport is the execution of a tx. The execution time is left out when txs are executed in
+// parallel, since they are executed together.
+type txReport struct {
+	Hash      string `json:"hash"`
+	Code      uint32 `json:"code"`
+	GasWanted int64  `json:"gas_wanted"`
+	GasUsed   int64  `json:"gas_used"`
+	ExecTime  *int64 `json:"exec_time_us,omitempty"`
+}
+
+// moduleReport is the IAVL store access of a module.
+type moduleReport struct {
+	Module     string `json:"module"`
+	NodeReads  int    `json:"node_reads"`
+	DBReads    int    `json:"db_reads"`
+	DBReadTime int64  `json:"db_read_time_us"`
+	DBWrites   int    `json:"db_writes"`
+}
+
+func (m *moduleReport) add(o *moduleReport) {
+	m.NodeReads += o.NodeReads
+	m.DBReads += o.DBReads
+	m.DBReadTime += o.DBReadTime
+	m.DBWrites += o.DBWrites
+}
+
+// parseReplayRange parses a block range of the form A:B.
+func parseReplayRange(s string) (int64, int64, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid range %q, expected A:B", s)
+	}
+	from, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid range start %q: %w", parts[0], err)
+	}
+	to, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid range end %q: %w", parts[1], err)
+	}
+	if from <= 0 || to < from {
+		return 0, 0, fmt.Errorf("invalid range %q, expected 0 < A <= B", s)
+	}
+	return from, to, nil
+}
+
+// replayRecorder records the execution of the blocks in the replayed range.
+type replayRecorder struct {
+	report  replayReport
+	current *blockReport
+}
+
+func newReplayRecorder(parallelTx bool) *replayRecorder {
+	return &replayRecorder{
+		report: replayReport{ParallelTx: parallelTx},
+	}
+}
+
+// setRange sets the range of the recorded blocks, the blocks replayed before it are not recorded.
+func (r *replayRecorder) setRange(from, to int64) {
+	r.report.From = from
+	r.report.To = to
+}
+
+// wrap returns the app which records the execution of blocks into the recorder.
+func (r *replayRecorder) wrap(application abci.Application) abci.Application {
+	if okexApp, ok := application.(*app.OKExChainApp); ok {
+		okexApp.SetStoreAnalysisHandler(r.recordStores)
+	}
+	return &recordingApp{Application: application, recorder: r}
+}
+
+// beginBlock starts recording the block at the given height, if it is in the range.
+func (r *replayRecorder) beginBlock(height int64) {
+	r.current = nil
+	if height >= r.report.From && height <= r.report.To {
+		r.current = &blockReport{Height: height, Txs: []*txReport{}}
+	}
+}
+
+// endBlock finishes recording the current block, which took the given time to apply.
+func (r *replayRecorder) endBlock(execTime time.Duration) {
+	block := r.current
+	if block == nil {
+		return
+	}
+	r.current = nil
+
+	block.ExecTime = execTime.Microseconds()
+	block.TxCount = len(block.Txs)
+	for _, tx := range block.Txs {
+		block.GasUsed += tx.GasUsed
+	}
+	if height, _, reRunTxs := baseapp.ParaLog.LastBlock(); int64(height) == block.Height {
+		block.ReRunTxs = reRunTxs
+	}
+	r.report.Blocks = append(r.report.Blocks, block)
+}
+
+// recordStores records the IAVL store access of the modules, before the counters are reset on
+// commit.
+func (r *replayRecorder) recordStores(ms sdk.CommitMultiStore) {
+	rs, ok := ms.(*rootmulti.Store)
+	if !ok || r.current == nil {
+		return
+	}
+	for key, store := range rs.GetStores() {
+		if store.GetStoreType() != storetypes.StoreTypeIAVL {
+			continue
+		}
+		r.current.Modules = append(r.current.Modules, &moduleReport{
+			Module:     key.Name(),
+			NodeReads:  store.GetNodeReadCount(),
+			DBReads:    store.GetDBReadCount(),
+			DBReadTime: time.Duration(store.GetDBReadTime()).Microseconds(),
+			DBWrites:   store.GetDBWriteCount(),
+		})
+	}
+	sort.Slice(r.current.Modules, func(i, j int) bool {
+		return r.current.Modules[i].Module < r.current.Modules[j].Module
+	})
+}
+
+// recordTx records the result of the tx in the current block, it returns nil if the block isn't recorded.
+func (r *replayRecorder) recordTx(tx []byte, res *abci.ResponseDeliverTx) *txReport {
+	if r.current == nil {
+		return nil
+	}
+	txRep := &txReport{Hash: fmt.Sprintf("%X", types.Tx(tx).Hash())}
+	if res != nil {
+		txRep.Code = res.Code
+		txRep.GasWanted = res.GasWanted
+		txRep.GasUsed = res.GasUsed
+	}
+	r.current.Txs = append(r.current.Txs, txRep)
+	return txRep
+}
+
+// writeReport summarizes the recorded blocks and writes the report to the file as JSON.
+func (r *replayRecorder) writeReport(file string) error {
+	summary := replaySummary{Blocks: len(r.report.Blocks)}
+	modules := make(map[string]*moduleReport)
+	for _, block := range r.report.Blocks {
+		summary.Txs += block.TxCount
+		summary.ReRunTxs += block.ReRunTxs
+		summary.GasUsed += block.GasUsed
+		summary.ExecTime += block.ExecTime
+		for _, m := range block.Modules {
+			if modules[m.Module] == nil {
+				modules[m.Module] = &moduleReport{Module: m.Module}
+			}
+			modules[m.Module].add(m)
+		}
+	}
+	for _, m := range modules {
+		summary.Modules = append(summary.Modules, m)
+	}
+	sort.Slice(summary.Modules, func(i, j int) bool {
+		return summary.Modules[i].Module < summary.Modules[j].Module
+	})
+	r.report.Summary = summary
+
+	bz, err := json.MarshalIndent(r.report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, bz, 0644)
+}
+
+// recordingApp times the ABCI calls of the app for the replay recorder.
+type recordingApp struct {
+	abci.Application
+	recorder *replayRecorder
+}
+
+func (a *recordingApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	start := time.Now()
+	res := a.Application.BeginBlock(req)
+	if block := a.recorder.current; block != nil {
+		block.BeginBlockTime = time.Since(start).Microseconds()
+	}
+	return res
+}
+
+func (a *recordingApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	start := time.Now()
+	res := a.Application.DeliverTx(req)
+	execTime := time.Since(start)
+	if block := a.recorder.current; block != nil {
+		block.DeliverTxsTime += execTime.Microseconds()
+	}
+	if txRep := a.recorder.recordTx(req.Tx, &res); txRep != nil {
+		us := execTime.Microseconds()
+		txRep.ExecTime = &us
+	}
+	return res
+}
+
+func (a *recordingApp) ParallelTxs(txs [][]byte) []*abci.ResponseDeliverTx {
+	start := time.Now()
+	res := a.Application.ParallelTxs(txs)
+	if block := a.recorder.current; block != nil {
+		block.DeliverTxsTime = time.Since(start).Microseconds()
+	}
+	for i, tx := range txs {
+		var txRes *abci.ResponseDeliverTx
+		if i < len(res) {
+			txRes = res[i]
+		}
+		a.recorder.recordTx(tx, txRes)
+	}
+	return res
+}
+
+func (a *recordingApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
+	start := time.Now()
+	res := a.Application.EndBlock(req)
+	if block := a.recorder.current; block != nil {
+		block.EndBlockTime = time.Since(start).Microseconds()
+	}
+	return res
+}
+
+func (a *recordingApp) Commit() abci.ResponseCommit {
+	start := time.Now()
+	res := a.Application.Commit()
+	if block := a.recorder.current; block != nil {
+		block.CommitTime = time.Since(start).Microseconds()
+	}
+	return res
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+)
+
+func TestParseReplayRange(t *testing.T) {
+	testCases := []struct {
+		s        string
+		from, to int64
+		expPass  bool
+	}{
+		{"1:10", 1, 10, true},
+		{"5:5", 5, 5, true},
+		{"10:1", 0, 0, false},
+		{"0:10", 0, 0, false},
+		{"-1:10", 0, 0, false},
+		{"1:", 0, 0, false},
+		{":10", 0, 0, false},
+		{"10", 0, 0, false},
+		{"1:2:3", 0, 0, false},
+		{"a:b", 0, 0, false},
+		{"", 0, 0, false},
+	}
+
+	for _, tc := range testCases {
+		from, to, err := parseReplayRange(tc.s)
+		if !tc.expPass {
+			require.Error(t, err, tc.s)
+			continue
+		}
+		require.NoError(t, err, tc.s)
+		require.Equal(t, tc.from, from, tc.s)
+		require.Equal(t, tc.to, to, tc.s)
+	}
+}
+
+// replayTestApp returns the index of the tx in the block as the gas used
+type replayTestApp struct {
+	abci.BaseApplication
+	txs int64
+}
+
+func (a *replayTestApp) BeginBlock(abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	a.txs = 0
+	return abci.ResponseBeginBlock{}
+}
+
+func (a *replayTestApp) DeliverTx(abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	a.txs++
+	return abci.ResponseDeliverTx{GasWanted: 100, GasUsed: a.txs}
+}
+
+func (a *replayTestApp) ParallelTxs(txs [][]byte) []*abci.ResponseDeliverTx {
+	res := make([]*abci.ResponseDeliverTx, len(txs))
+	for i := range txs {
+		res[i] = &abci.ResponseDeliverTx{GasWanted: 100, GasUsed: int64(i + 1)}
+	}
+	return res
+}
+
+// replayTestBlocks replays the blocks of 1 to 3 txs at the heights 1 to 3 and returns the report
+func replayTestBlocks(t *testing.T, parallelTx bool) replayReport {
+	recorder := newReplayRecorder(parallelTx)
+	recorder.setRange(2, 3)
+	app := recorder.wrap(&replayTestApp{})
+
+	for height := int64(1); height <= 3; height++ {
+		recorder.beginBlock(height)
+		app.BeginBlock(abci.RequestBeginBlock{})
+		var txs [][]byte
+		for i := int64(0); i < height; i++ {
+			txs = append(txs, []byte{byte(height), byte(i)})
+		}
+		if parallelTx {
+			app.ParallelTxs(txs)
+		} else {
+			for _, tx := range txs {
+				app.DeliverTx(abci.RequestDeliverTx{Tx: tx})
+			}
+		}
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+		recorder.endBlock(time.Millisecond)
+	}
+
+	file := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, recorder.writeReport(file))
+	bz, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	var report replayReport
+	require.NoError(t, json.Unmarshal(bz, &report))
+	return report
+}
+
+func TestReplayReport(t *testing.T) {
+	for _, parallelTx := range []bool{false, true} {
+		report := replayTestBlocks(t, parallelTx)
+		require.Equal(t, parallelTx, report.ParallelTx)
+		require.EqualValues(t, 2, report.From)
+		require.EqualValues(t, 3, report.To)
+
+		// the block before the range isn't recorded
+		require.Len(t, report.Blocks, 2)
+		require.Equal(t, replaySummary{Blocks: 2, Txs: 5, GasUsed: 9, ExecTime: 2000}, report.Summary)
+		for i, block := range report.Blocks {
+			require.EqualValues(t, i+2, block.Height)
+			require.Equal(t, i+2, block.TxCount)
+			require.Len(t, block.Txs, i+2)
+			require.EqualValues(t, 1000, block.ExecTime)
+			for j, tx := range block.Txs {
+				require.EqualValues(t, j+1, tx.GasUsed)
+				require.EqualValues(t, 100, tx.GasWanted)
+				// the txs executed in parallel aren't timed one by one
+				require.Equal(t, parallelTx, tx.ExecTime == nil)
+			}
+		}
+	}
+}
@@ -0,0 +1,661 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	amino "github.com/tendermint/go-amino"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	snapshottypes "github.com/okex/exchain/libs/cosmos-sdk/snapshots/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/store"
+	"github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/evm/watcher"
+)
+
+const (
+	flagBlockTail     = "block-tail"
+	flagTrustedHeader = "trusted-header"
+
+	// snapshotArchiveVersion must be bumped whenever the archive layout changes.
+	snapshotArchiveVersion = uint32(1)
+	// snapshotArchiveMaxItemSize bounds a single archive item, the largest being a multistore chunk.
+	snapshotArchiveMaxItemSize = int64(64e6)
+)
+
+// snapshotArchiveMagic prefixes every snapshot archive.
+var snapshotArchiveMagic = []byte("EXCHAIN-SNAPSHOT")
+
+var archiveCdc = amino.NewCodec()
+
+func init() {
+	types.RegisterBlockAmino(archiveCdc)
+}
+
+// snapshotArchiveHeader describes the content of a snapshot archive. It is stored uncompressed
+// right after the magic bytes so that it can be inspected without reading the whole archive.
+type snapshotArchiveHeader struct {
+	Version   uint32    `json:"version"`
+	ChainID   string    `json:"chain_id"`
+	Height    int64     `json:"height"`
+	AppHash   []byte    `json:"app_hash"`
+	Format    uint32    `json:"format"`
+	BlockTail int64     `json:"block_tail"`
+	State     []byte    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// snapshotArchiveItem is an item of the compressed archive body. Exactly one field is set. Items
+// are ordered as multistore chunks, then watcher db entries, then blocks in ascending height.
+type snapshotArchiveItem struct {
+	StoreChunk []byte              `json:"store_chunk"`
+	Watcher    *snapshotArchiveKV  `json:"watcher"`
+	Block      *snapshotArchiveBlk `json:"block"`
+}
+
+type snapshotArchiveKV struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+type snapshotArchiveBlk struct {
+	PartsHeader types.PartSetHeader `json:"parts_header"`
+	Parts       []*types.Part       `json:"parts"`
+	SeenCommit  *types.Commit       `json:"seen_commit"`
+}
+
+func snapshotCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Export or import portable, verifiable state archives",
+	}
+
+	cmd.AddCommand(
+		snapshotExportCmd(ctx),
+		snapshotImportCmd(ctx),
+	)
+
+	return cmd
+}
+
+func snapshotExportCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <archive-file>",
+		Short: "Export the application state at a height into a single archive",
+		Long: `Export the application state at a height into a single versioned, checksummed and
+compressed archive. Besides the multistore, the archive contains the watcher db and the
+last --block-tail blocks of the block store, so that a node restored from it can start
+directly at the exported height. The watcher db only holds the latest state, so it is
+left out when exporting an earlier --height. The node must be stopped while exporting.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(flags.FlagHome))
+
+			log.Println("--------- export snapshot start ---------")
+			start := time.Now()
+			if err := exportSnapshotArchive(ctx, args[0], viper.GetInt64(flagHeight), viper.GetInt64(flagBlockTail)); err != nil {
+				return err
+			}
+			log.Printf("--------- export snapshot done in %v ---------\n", time.Since(start))
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagHeight, 0, "Height to export, defaults to the latest application version")
+	cmd.Flags().Int64(flagBlockTail, 100, "Number of most recent blocks up to the exported height to include")
+	return cmd
+}
+
+func snapshotImportCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <archive-file>",
+		Short: "Import a snapshot archive into an empty home directory",
+		Long: `Import a snapshot archive into an empty home directory, created e.g. by 'exchaind init'.
+The archive checksum is verified first. The state is then verified against a trusted
+header at height H+1, where H is the archive height, given as a JSON file with
+--trusted-header (e.g. the block.header field of the /block?height=H+1 RPC response).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(flags.FlagHome))
+
+			headerFile := viper.GetString(flagTrustedHeader)
+			if headerFile == "" {
+				return fmt.Errorf("--%s is required", flagTrustedHeader)
+			}
+			trustedHeader, err := loadTrustedHeader(headerFile)
+			if err != nil {
+				return err
+			}
+
+			log.Println("--------- import snapshot start ---------")
+			start := time.Now()
+			if err := importSnapshotArchive(ctx, args[0], trustedHeader); err != nil {
+				return err
+			}
+			log.Printf("--------- import snapshot done in %v ---------\n", time.Since(start))
+			return nil
+		},
+	}
+	cmd.Flags().String(flagTrustedHeader, "", "JSON file with the trusted block header at the archive height + 1")
+	return cmd
+}
+
+// exportSnapshotArchive writes the state at the given height into an archive at path. A zero
+// height exports the latest application version. The partial archive is removed on failure.
+func exportSnapshotArchive(ctx *server.Context, path string, height, blockTail int64) (err error) {
+	if blockTail < 1 {
+		return fmt.Errorf("--%s must be at least 1", flagBlockTail)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("archive %s already exists", path)
+	}
+
+	dataDir := filepath.Join(ctx.Config.RootDir, "data")
+	appDB, err := openDB(applicationDB, dataDir)
+	if err != nil {
+		return err
+	}
+	defer appDB.Close()
+	stateStoreDB := initDB(ctx.Config, stateDBName)
+	defer stateStoreDB.Close()
+	blockStoreDB := initDB(ctx.Config, blockDBName)
+	defer blockStoreDB.Close()
+	blockStore := store.NewBlockStore(blockStoreDB)
+
+	rs := initAppStore(appDB)
+	latest := rs.GetLatestVersion()
+	if height == 0 {
+		height = latest
+	}
+	state, err := loadStateAtHeight(stateStoreDB, blockStore, height)
+	if err != nil {
+		return err
+	}
+	from := height - blockTail + 1
+	if base := blockStore.Base(); from < base {
+		from = base
+	}
+	if blockStore.Height() < height || blockStore.LoadSeenCommit(height) == nil {
+		return fmt.Errorf("block %d with its seen commit is not in the block store", height)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		file.Close()
+		// the archive is created exclusively, so a partial one would make the retry fail
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+	hasher := sha256.New()
+	bufWriter := bufio.NewWriter(io.MultiWriter(file, hasher))
+
+	if _, err := bufWriter.Write(snapshotArchiveMagic); err != nil {
+		return err
+	}
+	header := snapshotArchiveHeader{
+		Version:   snapshotArchiveVersion,
+		ChainID:   state.ChainID,
+		Height:    height,
+		AppHash:   state.AppHash,
+		Format:    snapshottypes.CurrentFormat,
+		BlockTail: height - from + 1,
+		State:     state.Bytes(),
+		CreatedAt: time.Now().UTC(),
+	}
+	if _, err := archiveCdc.MarshalBinaryLengthPrefixedWriter(bufWriter, header); err != nil {
+		return err
+	}
+
+	zWriter, err := zlib.NewWriterLevel(bufWriter, zlib.BestSpeed)
+	if err != nil {
+		return err
+	}
+	if err := exportMultiStore(rs, height, zWriter); err != nil {
+		return err
+	}
+	// the watcher db can't be rolled back, so it only matches the latest version
+	if height == latest {
+		if err := exportWatcherDB(ctx, zWriter); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("Watcher db skipped, it only holds the state of the latest height %d\n", latest)
+	}
+	if err := exportBlocks(blockStore, from, height, zWriter); err != nil {
+		return err
+	}
+	if err := zWriter.Close(); err != nil {
+		return err
+	}
+	if err := bufWriter.Flush(); err != nil {
+		return err
+	}
+
+	// The checksum trails the archive and covers everything before it.
+	if _, err := file.Write(hasher.Sum(nil)); err != nil {
+		return err
+	}
+	log.Printf("Exported height %d, app hash %X, blocks [%d,%d] to %s\n", height, state.AppHash, from, height, path)
+	return file.Sync()
+}
+
+// loadStateAtHeight returns the tendermint state right after the block at the given height was
+// committed. Older states are rebuilt from the saved validator sets, consensus params and the
+// header of the next block.
+func loadStateAtHeight(stateDB dbm.DB, blockStore *store.BlockStore, height int64) (sm.State, error) {
+	state := sm.LoadState(stateDB)
+	if state.IsEmpty() {
+		return state, errors.New("no state found in the state db")
+	}
+	switch {
+	case height == state.LastBlockHeight:
+		return state, nil
+	case height > state.LastBlockHeight:
+		return state, fmt.Errorf("height %d is beyond the latest state height %d", height, state.LastBlockHeight)
+	}
+
+	meta := blockStore.LoadBlockMeta(height)
+	nextMeta := blockStore.LoadBlockMeta(height + 1)
+	if meta == nil || nextMeta == nil {
+		return state, fmt.Errorf("blocks %d and %d must be in the block store", height, height+1)
+	}
+	lastValidators, err := sm.LoadValidators(stateDB, height)
+	if err != nil {
+		return state, err
+	}
+	validators, err := sm.LoadValidators(stateDB, height+1)
+	if err != nil {
+		return state, err
+	}
+	nextValidators, err := sm.LoadValidators(stateDB, height+2)
+	if err != nil {
+		return state, err
+	}
+	consensusParams, err := sm.LoadConsensusParams(stateDB, height+1)
+	if err != nil {
+		return state, err
+	}
+
+	state.Version.Consensus = nextMeta.Header.Version
+	state.LastBlockHeight = height
+	state.LastBlockID = nextMeta.Header.LastBlockID
+	state.LastBlockTime = meta.Header.Time
+	state.LastValidators = lastValidators
+	state.Validators = validators
+	state.NextValidators = nextValidators
+	state.ConsensusParams = consensusParams
+	state.LastResultsHash = nextMeta.Header.LastResultsHash
+	state.AppHash = nextMeta.Header.AppHash
+	return state, nil
+}
+
+// exportMultiStore writes the multistore snapshot chunks at the given height.
+func exportMultiStore(rs *rootmulti.Store, height int64, w io.Writer) error {
+	chunks, err := rs.Snapshot(uint64(height), snapshottypes.CurrentFormat)
+	if err != nil {
+		return err
+	}
+	var count int
+	for reader := range chunks {
+		chunk, err := ioutil.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			// drain the remaining chunks so that the snapshot goroutine can exit
+			for reader := range chunks {
+				reader.Close()
+			}
+			return err
+		}
+		if err := writeArchiveItem(w, snapshotArchiveItem{StoreChunk: chunk}); err != nil {
+			return err
+		}
+		count++
+	}
+	log.Printf("Exported multistore in %d chunks\n", count)
+	return nil
+}
+
+// exportWatcherDB writes all entries of the watcher db, if the node has one.
+func exportWatcherDB(ctx *server.Context, w io.Writer) error {
+	dataDir := filepath.Join(ctx.Config.RootDir, watcher.WatchDbDir)
+	if _, err := os.Stat(filepath.Join(dataDir, watcher.WatchDBName+".db")); os.IsNotExist(err) {
+		log.Println("No watcher db found, skipped")
+		return nil
+	}
+	db := dbm.NewDB(watcher.WatchDBName, dbm.BackendType(ctx.Config.DBBackend), dataDir)
+	defer db.Close()
+
+	iter, err := db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	var count int
+	for ; iter.Valid(); iter.Next() {
+		err := writeArchiveItem(w, snapshotArchiveItem{
+			Watcher: &snapshotArchiveKV{Key: iter.Key(), Value: iter.Value()},
+		})
+		if err != nil {
+			return err
+		}
+		count++
+	}
+	log.Printf("Exported %d watcher db entries\n", count)
+	return nil
+}
+
+// exportBlocks writes the blocks between the given heights (both included) as raw block parts.
+func exportBlocks(blockStore *store.BlockStore, from, to int64, w io.Writer) error {
+	for height := from; height <= to; height++ {
+		meta := blockStore.LoadBlockMeta(height)
+		if meta == nil {
+			return fmt.Errorf("block %d not found in the block store", height)
+		}
+		blk := &snapshotArchiveBlk{
+			PartsHeader: meta.BlockID.PartsHeader,
+			Parts:       make([]*types.Part, meta.BlockID.PartsHeader.Total),
+			SeenCommit:  blockStore.LoadSeenCommit(height),
+		}
+		for i := range blk.Parts {
+			blk.Parts[i] = blockStore.LoadBlockPart(height, i)
+		}
+		if err := writeArchiveItem(w, snapshotArchiveItem{Block: blk}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeArchiveItem(w io.Writer, item snapshotArchiveItem) error {
+	_, err := archiveCdc.MarshalBinaryLengthPrefixedWriter(w, item)
+	return err
+}
+
+// loadTrustedHeader reads a JSON encoded block header from file.
+func loadTrustedHeader(file string) (*types.Header, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	header := new(types.Header)
+	if err := archiveCdc.UnmarshalJSON(bz, header); err != nil {
+		return nil, fmt.Errorf("failed to parse trusted header: %w", err)
+	}
+	if err := header.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid trusted header: %w", err)
+	}
+	return header, nil
+}
+
+// verifyArchiveChecksum checks the trailing sha256 checksum of the archive at path.
+func verifyArchiveChecksum(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size() - sha256.Size
+	if size < int64(len(snapshotArchiveMagic)) {
+		return fmt.Errorf("archive %s is truncated", path)
+	}
+
+	hasher := sha256.New()
+	if _, err := io.CopyN(hasher, file, size); err != nil {
+		return err
+	}
+	expected := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(file, expected); err != nil {
+		return err
+	}
+	if actual := hasher.Sum(nil); !bytes.Equal(actual, expected) {
+		return fmt.Errorf("archive checksum mismatch: expected %X, got %X", expected, actual)
+	}
+	return nil
+}
+
+// verifyArchiveState checks the archived state against the trusted header of the next height.
+func verifyArchiveState(state sm.State, header *types.Header) error {
+	switch {
+	case header.ChainID != state.ChainID:
+		return fmt.Errorf("trusted header chain id %s does not match archive chain id %s", header.ChainID, state.ChainID)
+	case header.Height != state.LastBlockHeight+1:
+		return fmt.Errorf("trusted header height must be %d, got %d", state.LastBlockHeight+1, header.Height)
+	case !bytes.Equal(header.AppHash, state.AppHash):
+		return fmt.Errorf("archive app hash %X does not match trusted app hash %X", state.AppHash, header.AppHash)
+	case !header.LastBlockID.Equals(state.LastBlockID):
+		return fmt.Errorf("archive last block id %v does not match trusted %v", state.LastBlockID, header.LastBlockID)
+	case !bytes.Equal(header.LastResultsHash, state.LastResultsHash):
+		return errors.New("archive last results hash does not match trusted header")
+	case !bytes.Equal(header.ValidatorsHash, state.Validators.Hash()):
+		return errors.New("archive validators do not match trusted header")
+	case !bytes.Equal(header.NextValidatorsHash, state.NextValidators.Hash()):
+		return errors.New("archive next validators do not match trusted header")
+	case !bytes.Equal(header.ConsensusHash, state.ConsensusParams.Hash()):
+		return errors.New("archive consensus params do not match trusted header")
+	}
+	return nil
+}
+
+// importSnapshotArchive restores the archive at path into the (empty) data directory of the node
+// and verifies it against the trusted header.
+func importSnapshotArchive(ctx *server.Context, path string, trustedHeader *types.Header) error {
+	if err := verifyArchiveChecksum(path); err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+
+	magic := make([]byte, len(snapshotArchiveMagic))
+	if _, err := io.ReadFull(reader, magic); err != nil || !bytes.Equal(magic, snapshotArchiveMagic) {
+		return fmt.Errorf("%s is not a snapshot archive", path)
+	}
+	var header snapshotArchiveHeader
+	if _, err := archiveCdc.UnmarshalBinaryLengthPrefixedReader(reader, &header, snapshotArchiveMaxItemSize); err != nil {
+		return fmt.Errorf("invalid archive header: %w", err)
+	}
+	if header.Version != snapshotArchiveVersion {
+		return fmt.Errorf("unsupported archive version %d, expected %d", header.Version, snapshotArchiveVersion)
+	}
+	var state sm.State
+	if err := archiveCdc.UnmarshalBinaryBare(header.State, &state); err != nil {
+		return fmt.Errorf("invalid archive state: %w", err)
+	}
+	if state.LastBlockHeight != header.Height || !bytes.Equal(state.AppHash, header.AppHash) {
+		return errors.New("archive state does not match archive header")
+	}
+	if err := verifyArchiveState(state, trustedHeader); err != nil {
+		return err
+	}
+	log.Printf("Importing height %d, app hash %X, created at %v\n", header.Height, header.AppHash, header.CreatedAt)
+
+	dataDir := filepath.Join(ctx.Config.RootDir, "data")
+	for _, name := range []string{appDBName, blockDBName, stateDBName, watcher.WatchDBName} {
+		if _, err := os.Stat(filepath.Join(dataDir, name+".db")); !os.IsNotExist(err) {
+			return fmt.Errorf("%s.db already exists in %s, import requires an empty home directory", name, dataDir)
+		}
+	}
+
+	zReader, err := zlib.NewReader(reader)
+	if err != nil {
+		return err
+	}
+	defer zReader.Close()
+
+	appDB, err := openDB(applicationDB, dataDir)
+	if err != nil {
+		return err
+	}
+	defer appDB.Close()
+	rs := initAppStore(appDB)
+	item, err := importMultiStore(rs, header, zReader)
+	if err != nil {
+		return err
+	}
+	if appHash := rs.LastCommitID().Hash; !bytes.Equal(appHash, trustedHeader.AppHash) {
+		return fmt.Errorf("restored app hash %X does not match trusted app hash %X", appHash, trustedHeader.AppHash)
+	}
+	log.Printf("Restored multistore at height %d\n", rs.LastCommitID().Version)
+
+	item, err = importWatcherDB(ctx, item, zReader)
+	if err != nil {
+		return err
+	}
+
+	blockStoreDB := initDB(ctx.Config, blockDBName)
+	defer blockStoreDB.Close()
+	if err := importBlocks(store.NewBlockStore(blockStoreDB), state, item, zReader); err != nil {
+		return err
+	}
+
+	// Only the validator sets and consensus params around the archive height are bootstrapped, so
+	// the state must not point to earlier heights at which they changed.
+	state.LastHeightValidatorsChanged = state.LastBlockHeight + 2
+	state.LastHeightConsensusParamsChanged = state.LastBlockHeight + 1
+	stateStoreDB := initDB(ctx.Config, stateDBName)
+	defer stateStoreDB.Close()
+	return sm.BootstrapState(stateStoreDB, state)
+}
+
+// readArchiveItem reads the next body item, returning nil at the end of the archive.
+func readArchiveItem(r io.Reader) (*snapshotArchiveItem, error) {
+	item := new(snapshotArchiveItem)
+	_, err := archiveCdc.UnmarshalBinaryLengthPrefixedReader(r, item, snapshotArchiveMaxItemSize)
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid archive item: %w", err)
+	}
+	return item, nil
+}
+
+// importMultiStore feeds the multistore chunks of the archive to the store restore. It returns the
+// first item following the chunks.
+func importMultiStore(rs *rootmulti.Store, header snapshotArchiveHeader, r io.Reader) (*snapshotArchiveItem, error) {
+	chChunks := make(chan io.ReadCloser, 1)
+	chDone := make(chan error, 1)
+	go func() {
+		chDone <- rs.Restore(uint64(header.Height), header.Format, chChunks, nil)
+	}()
+
+	var item *snapshotArchiveItem
+	var err error
+	for {
+		item, err = readArchiveItem(r)
+		if err != nil || item == nil || item.StoreChunk == nil {
+			break
+		}
+		select {
+		case chChunks <- ioutil.NopCloser(bytes.NewReader(item.StoreChunk)):
+		case err = <-chDone:
+			if err == nil {
+				err = errors.New("multistore restore ended before all chunks were applied")
+			}
+			return nil, err
+		}
+	}
+	close(chChunks)
+	if restoreErr := <-chDone; restoreErr != nil {
+		return nil, fmt.Errorf("failed to restore multistore: %w", restoreErr)
+	}
+	return item, err
+}
+
+// importWatcherDB writes the watcher db entries starting at item, and returns the first item
+// following them.
+func importWatcherDB(ctx *server.Context, item *snapshotArchiveItem, r io.Reader) (*snapshotArchiveItem, error) {
+	if item == nil || item.Watcher == nil {
+		return item, nil
+	}
+	dataDir := filepath.Join(ctx.Config.RootDir, watcher.WatchDbDir)
+	db := dbm.NewDB(watcher.WatchDBName, dbm.BackendType(ctx.Config.DBBackend), dataDir)
+	defer db.Close()
+
+	var err error
+	var count int
+	batch := db.NewBatch()
+	defer batch.Close()
+	for ; item != nil && item.Watcher != nil; item, err = readArchiveItem(r) {
+		batch.Set(item.Watcher.Key, item.Watcher.Value)
+		count++
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return nil, err
+	}
+	log.Printf("Imported %d watcher db entries\n", count)
+	return item, nil
+}
+
+// importBlocks saves the blocks starting at item. The blocks must be contiguous, link to each
+// other by hash and end at the state height.
+func importBlocks(blockStore *store.BlockStore, state sm.State, item *snapshotArchiveItem, r io.Reader) error {
+	var err error
+	var last *types.Block
+	for ; item != nil; item, err = readArchiveItem(r) {
+		if item.Block == nil {
+			return errors.New("unexpected archive item after blocks")
+		}
+		parts := types.NewPartSetFromHeader(item.Block.PartsHeader)
+		for _, part := range item.Block.Parts {
+			if _, err := parts.AddPart(part); err != nil {
+				return fmt.Errorf("invalid block part: %w", err)
+			}
+		}
+		if !parts.IsComplete() {
+			return errors.New("archive contains an incomplete block")
+		}
+		bz, err := ioutil.ReadAll(parts.GetReader())
+		if err != nil {
+			return err
+		}
+		block := new(types.Block)
+		if err := archiveCdc.UnmarshalBinaryLengthPrefixed(bz, block); err != nil {
+			return fmt.Errorf("invalid block: %w", err)
+		}
+		if last != nil && (block.Height != last.Height+1 || !bytes.Equal(block.LastBlockID.Hash, last.Hash())) {
+			return fmt.Errorf("block %d does not follow block %d", block.Height, last.Height)
+		}
+		blockStore.SaveBlock(block, parts, item.Block.SeenCommit)
+		last = block
+	}
+	if err != nil {
+		return err
+	}
+	if last == nil || last.Height != state.LastBlockHeight || !bytes.Equal(last.Hash(), state.LastBlockID.Hash) {
+		return fmt.Errorf("archive blocks do not end at the trusted block %d", state.LastBlockHeight)
+	}
+	log.Printf("Imported blocks [%d,%d]\n", blockStore.Base(), blockStore.Height())
+	return nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	bam "github.com/okex/exchain/libs/cosmos-sdk/baseapp"
+	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	"github.com/okex/exchain/libs/tendermint/libs/cli"
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/store"
+	"github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/evm/watcher"
+)
+
+var (
+	snapshotTestKey     = []byte("key")
+	snapshotTestWatcher = []byte("watcher")
+)
+
+func newSnapshotTestContext(t *testing.T) *server.Context {
+	ctx := server.NewDefaultContext()
+	ctx.Config.SetRoot(t.TempDir())
+	require.NoError(t, os.MkdirAll(filepath.Join(ctx.Config.RootDir, "data"), 0755))
+	return ctx
+}
+
+// setupSnapshotTestNode commits the given number of blocks into the home directory of ctx, and
+// returns the state after the last block.
+func setupSnapshotTestNode(t *testing.T, ctx *server.Context, height int64) sm.State {
+	dataDir := filepath.Join(ctx.Config.RootDir, "data")
+	appDB, err := openDB(applicationDB, dataDir)
+	require.NoError(t, err)
+	defer appDB.Close()
+	stateStoreDB := initDB(ctx.Config, stateDBName)
+	defer stateStoreDB.Close()
+	blockStoreDB := initDB(ctx.Config, blockDBName)
+	defer blockStoreDB.Close()
+	blockStore := store.NewBlockStore(blockStoreDB)
+
+	vals, _ := types.RandValidatorSet(1, 10)
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:     "exchain-65",
+		GenesisTime: time.Unix(1600000000, 0).UTC(),
+		Validators: []types.GenesisValidator{
+			{Address: vals.Validators[0].Address, PubKey: vals.Validators[0].PubKey, Power: 10},
+		},
+	})
+	require.NoError(t, err)
+	sm.SaveState(stateStoreDB, state)
+
+	rs := initAppStore(appDB)
+	lastCommit := new(types.Commit)
+	for h := int64(1); h <= height; h++ {
+		block, parts := makeSnapshotTestBlock(state, h, lastCommit)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+		lastCommit = types.NewCommit(h, 0, blockID, []types.CommitSig{types.NewCommitSigAbsent()})
+		blockStore.SaveBlock(block, parts, lastCommit)
+
+		for key, kv := range rs.GetStores() {
+			if key.Name() == bam.MainStoreKey {
+				kv.Set(snapshotTestKey, []byte{byte(h)})
+			}
+		}
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+		state.LastBlockTime = block.Time
+		state.LastValidators = state.Validators.Copy()
+		state.AppHash = rs.Commit().Hash
+		sm.SaveState(stateStoreDB, state)
+	}
+
+	watcherDB := dbm.NewDB(watcher.WatchDBName, dbm.BackendType(ctx.Config.DBBackend),
+		filepath.Join(ctx.Config.RootDir, watcher.WatchDbDir))
+	defer watcherDB.Close()
+	watcherDB.SetSync(snapshotTestWatcher, []byte{byte(height)})
+	return state
+}
+
+func makeSnapshotTestBlock(state sm.State, height int64, lastCommit *types.Commit) (*types.Block, *types.PartSet) {
+	block := types.MakeBlock(height, nil, lastCommit, nil)
+	block.Header.Populate(
+		state.Version.Consensus, state.ChainID,
+		state.LastBlockTime.Add(time.Second), state.LastBlockID,
+		state.Validators.Hash(), state.NextValidators.Hash(),
+		state.ConsensusParams.Hash(), state.AppHash, state.LastResultsHash,
+		state.Validators.Validators[0].Address,
+	)
+	return block, block.MakePartSet(types.BlockPartSizeBytes)
+}
+
+// loadSnapshotTestHeader returns the header of the block at the given height, which is trusted by
+// the import of the archive at height - 1.
+func loadSnapshotTestHeader(t *testing.T, ctx *server.Context, height int64) *types.Header {
+	blockStoreDB := initDB(ctx.Config, blockDBName)
+	defer blockStoreDB.Close()
+	meta := store.NewBlockStore(blockStoreDB).LoadBlockMeta(height)
+	require.NotNil(t, meta)
+	return &meta.Header
+}
+
+func requireSnapshotTestNode(t *testing.T, ctx *server.Context, height int64, watcherValue []byte) {
+	dataDir := filepath.Join(ctx.Config.RootDir, "data")
+	appDB, err := openDB(applicationDB, dataDir)
+	require.NoError(t, err)
+	defer appDB.Close()
+	rs := initAppStore(appDB)
+	require.Equal(t, height, rs.LastCommitID().Version)
+	for key, kv := range rs.GetStores() {
+		if key.Name() == bam.MainStoreKey {
+			require.Equal(t, []byte{byte(height)}, kv.Get(snapshotTestKey))
+		}
+	}
+
+	stateStoreDB := initDB(ctx.Config, stateDBName)
+	defer stateStoreDB.Close()
+	require.Equal(t, height, sm.LoadState(stateStoreDB).LastBlockHeight)
+	blockStoreDB := initDB(ctx.Config, blockDBName)
+	defer blockStoreDB.Close()
+	require.Equal(t, height, store.NewBlockStore(blockStoreDB).Height())
+
+	watcherFile := filepath.Join(ctx.Config.RootDir, watcher.WatchDbDir, watcher.WatchDBName+".db")
+	if watcherValue == nil {
+		_, err := os.Stat(watcherFile)
+		require.True(t, os.IsNotExist(err))
+		return
+	}
+	watcherDB := dbm.NewDB(watcher.WatchDBName, dbm.BackendType(ctx.Config.DBBackend),
+		filepath.Join(ctx.Config.RootDir, watcher.WatchDbDir))
+	defer watcherDB.Close()
+	value, err := watcherDB.Get(snapshotTestWatcher)
+	require.NoError(t, err)
+	require.Equal(t, watcherValue, value)
+}
+
+func TestSnapshotArchiveRoundTrip(t *testing.T) {
+	source := newSnapshotTestContext(t)
+	state := setupSnapshotTestNode(t, source, 3)
+
+	// the latest height is exported with the watcher db
+	archive := filepath.Join(t.TempDir(), "latest.snapshot")
+	require.NoError(t, exportSnapshotArchive(source, archive, 0, 2))
+	nextBlock, _ := makeSnapshotTestBlock(state, 4, nil)
+	target := newSnapshotTestContext(t)
+	require.NoError(t, importSnapshotArchive(target, archive, &nextBlock.Header))
+	requireSnapshotTestNode(t, target, 3, []byte{3})
+
+	// the archive of an earlier height leaves the watcher db out
+	archive = filepath.Join(t.TempDir(), "earlier.snapshot")
+	require.NoError(t, exportSnapshotArchive(source, archive, 2, 2))
+	target = newSnapshotTestContext(t)
+	require.NoError(t, importSnapshotArchive(target, archive, loadSnapshotTestHeader(t, source, 3)))
+	requireSnapshotTestNode(t, target, 2, nil)
+
+	// the archive doesn't match a header of another height
+	target = newSnapshotTestContext(t)
+	require.Error(t, importSnapshotArchive(target, archive, &nextBlock.Header))
+}
+
+func TestSnapshotArchiveExportFailure(t *testing.T) {
+	source := newSnapshotTestContext(t)
+	setupSnapshotTestNode(t, source, 3)
+
+	// the export fails once the block 2 is missing
+	blockStoreDB := initDB(source.Config, blockDBName)
+	blockStoreDB.DeleteSync([]byte("H:2"))
+	blockStoreDB.Close()
+
+	archive := filepath.Join(t.TempDir(), "failed.snapshot")
+	require.Error(t, exportSnapshotArchive(source, archive, 0, 3))
+	// the partial archive is removed so that the export can be retried
+	_, err := os.Stat(archive)
+	require.True(t, os.IsNotExist(err))
+	require.NoError(t, exportSnapshotArchive(source, archive, 0, 1))
+}
+
+func TestNewSnapshotStore(t *testing.T) {
+	home := t.TempDir()
+	viper.Set(cli.HomeFlag, home)
+	defer viper.Set(cli.HomeFlag, nil)
+	defer viper.Set(server.FlagStateSyncSnapshotInterval, nil)
+
+	// the snapshot db isn't opened if no snapshot is taken
+	viper.Set(server.FlagStateSyncSnapshotInterval, 0)
+	require.Nil(t, newSnapshotStore())
+	_, err := os.Stat(filepath.Join(home, "data", "snapshots"))
+	require.True(t, os.IsNotExist(err))
+
+	viper.Set(server.FlagStateSyncSnapshotInterval, 100)
+	require.NotNil(t, newSnapshotStore())
+	_, err = os.Stat(filepath.Join(home, "data", "snapshots", "metadata.db"))
+	require.NoError(t, err)
+}
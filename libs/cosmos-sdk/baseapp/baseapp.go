@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/okex/exchain/libs/cosmos-sdk/snapshots"
 	"github.com/okex/exchain/libs/cosmos-sdk/store"
 	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
 	storetypes "github.com/okex/exchain/libs/cosmos-sdk/store/types"
@@ -161,11 +162,28 @@ type BaseApp struct { // nolint: maligned
 	// end record handle
 	endLog recordHandle
 
+	// store analysis handle, called on Commit before the analysis counters are reset
+	storeAnalysis storeAnalysisHandle
+
 	parallelTxManage *parallelTxManager
+
+	// manages snapshots, i.e. dumps of app state at certain intervals
+	snapshotManager    *snapshots.Manager
+	snapshotInterval   uint64 // block interval between state sync snapshots
+	snapshotKeepRecent uint32 // recent state sync snapshots to keep
+
+	// minRetainBlocks defines the minimum block height offset from the current
+	// block being committed, such that all blocks past this offset are pruned
+	// from Tendermint. It is used as part of the process of determining the
+	// ResponseCommit.RetainHeight value during ABCI Commit. A value of 0 indicates
+	// that no blocks should be pruned.
+	minRetainBlocks uint64
 }
 
 type recordHandle func(string)
 
+type storeAnalysisHandle func(sdk.CommitMultiStore)
+
 // NewBaseApp returns a reference to an initialized BaseApp. It accepts a
 // variadic number of option functions, which act on the BaseApp to set
 // configuration choices.
@@ -228,6 +246,12 @@ func (app *BaseApp) SetEndLogHandler(handle recordHandle) {
 	app.endLog = handle
 }
 
+// SetStoreAnalysisHandler set the storeAnalysis of the BaseApp, it is called with the
+// multistore on Commit, before the store analysis counters are reset.
+func (app *BaseApp) SetStoreAnalysisHandler(handle storeAnalysisHandle) {
+	app.storeAnalysis = handle
+}
+
 // MountStores mounts all IAVL or DB stores to the provided keys in the BaseApp
 // multistore.
 func (app *BaseApp) MountStores(keys ...sdk.StoreKey) {
@@ -412,6 +436,21 @@ func (app *BaseApp) initFromMainStore(baseKey *sdk.KVStoreKey) error {
 	return nil
 }
 
+// reloadRestoredState reloads the consensus params and the check state from the state restored by a snapshot
+func (app *BaseApp) reloadRestoredState() {
+	if app.baseKey != nil {
+		consensusParamsBz := app.cms.GetKVStore(app.baseKey).Get(mainConsensusParamsKey)
+		if consensusParamsBz != nil {
+			var consensusParams = &abci.ConsensusParams{}
+			if err := proto.Unmarshal(consensusParamsBz, consensusParams); err != nil {
+				panic(err)
+			}
+			app.setConsensusParams(consensusParams)
+		}
+	}
+	app.setCheckState(abci.Header{Height: app.cms.LastCommitID().Version})
+}
+
 func (app *BaseApp) setMinGasPrices(gasPrices sdk.DecCoins) {
 	app.minGasPrices = gasPrices
 }
@@ -422,6 +422,7 @@ type LogForParallel struct {
 
 	bestBlock     parallelBlockInfo
 	terribleBlock parallelBlockInfo
+	lastBlock     parallelBlockInfo
 }
 
 func NewLogForParallel() *LogForParallel {
@@ -447,11 +448,12 @@ func (l *LogForParallel) Update(height uint64, txs int, reRunCnt int) {
 	l.reRunTx += reRunCnt
 	l.blockNumbers++
 
+	info := parallelBlockInfo{height: height, txs: txs, reRunTxs: reRunCnt}
+	l.lastBlock = info
 	if txs < 20 {
 		return
 	}
 
-	info := parallelBlockInfo{height: height, txs: txs, reRunTxs: reRunCnt}
 	if !l.init {
 		l.bestBlock = info
 		l.terribleBlock = info
@@ -467,6 +469,11 @@ func (l *LogForParallel) Update(height uint64, txs int, reRunCnt int) {
 	}
 }
 
+// LastBlock returns the height, txs and re-run txs of the last block executed in parallel
+func (l *LogForParallel) LastBlock() (uint64, int, int) {
+	return l.lastBlock.height, l.lastBlock.txs, l.lastBlock.reRunTxs
+}
+
 func (l *LogForParallel) PrintLog() {
 	fmt.Println("BlockNumbers", l.blockNumbers)
 	fmt.Println("AllTxs", l.sumTx)
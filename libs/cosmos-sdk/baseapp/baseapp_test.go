@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	tmiavl "github.com/okex/exchain/libs/iavl"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
@@ -439,6 +440,28 @@ func TestInfo(t *testing.T) {
 	// TODO
 }
 
+func TestStoreAnalysisHandler(t *testing.T) {
+	app := setupBaseApp(t)
+	app.InitChain(abci.RequestInitChain{})
+
+	var analysed sdk.CommitMultiStore
+	writeCount := 0
+	app.SetStoreAnalysisHandler(func(ms sdk.CommitMultiStore) {
+		analysed = ms
+		writeCount = ms.GetDBWriteCount()
+	})
+
+	header := abci.Header{Height: 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	app.deliverState.ctx.KVStore(capKey1).Set([]byte("key"), []byte("value"))
+	app.Commit()
+
+	// the handler sees the counters of the block before they are reset
+	require.Equal(t, app.cms, analysed)
+	require.NotZero(t, writeCount)
+	require.Zero(t, app.cms.GetDBWriteCount())
+}
+
 func TestBaseAppOptionSeal(t *testing.T) {
 	app := setupBaseApp(t)
 
@@ -1601,3 +1624,118 @@ func TestWithRouter(t *testing.T) {
 		app.Commit()
 	}
 }
+
+func TestGetBlockRetentionHeight(t *testing.T) {
+	testCases := map[string]struct {
+		options      []func(*BaseApp)
+		maxAgeBlocks int64
+		asyncCommit  bool
+		commitHeight int64
+		expected     int64
+	}{
+		"defaults": {
+			maxAgeBlocks: 0,
+			commitHeight: 499000,
+			expected:     0,
+		},
+		"pruning unbonding time only": {
+			options:      []func(*BaseApp){SetMinRetainBlocks(1)},
+			maxAgeBlocks: 362880,
+			commitHeight: 499000,
+			expected:     136120,
+		},
+		"pruning iavl snapshot only": {
+			options: []func(*BaseApp){
+				SetPruning(sdk.PruningOptions{KeepEvery: 10000}),
+				SetMinRetainBlocks(1),
+			},
+			maxAgeBlocks: 0,
+			commitHeight: 499000,
+			expected:     490000,
+		},
+		"pruning state sync snapshot only": {
+			options: []func(*BaseApp){
+				SetSnapshotInterval(50000),
+				SetSnapshotKeepRecent(3),
+				SetMinRetainBlocks(1),
+			},
+			maxAgeBlocks: 0,
+			commitHeight: 499000,
+			expected:     349000,
+		},
+		"pruning min retention only": {
+			options:      []func(*BaseApp){SetMinRetainBlocks(400000)},
+			maxAgeBlocks: 0,
+			commitHeight: 499000,
+			expected:     99000,
+		},
+		"pruning all conditions": {
+			options: []func(*BaseApp){
+				SetPruning(sdk.PruningOptions{KeepEvery: 10000}),
+				SetMinRetainBlocks(400000),
+				SetSnapshotInterval(50000),
+				SetSnapshotKeepRecent(3),
+			},
+			maxAgeBlocks: 362880,
+			commitHeight: 499000,
+			expected:     99000,
+		},
+		"no pruning due to no persisted state": {
+			options: []func(*BaseApp){
+				SetPruning(sdk.PruningOptions{KeepEvery: 10000}),
+				SetMinRetainBlocks(400000),
+				SetSnapshotInterval(50000),
+				SetSnapshotKeepRecent(3),
+			},
+			maxAgeBlocks: 362880,
+			commitHeight: 10000,
+			expected:     0,
+		},
+		"pruning async iavl commit only": {
+			options:      []func(*BaseApp){SetMinRetainBlocks(1)},
+			maxAgeBlocks: 0,
+			asyncCommit:  true,
+			commitHeight: 499000,
+			expected:     498800,
+		},
+		"pruning async iavl commit and min retention": {
+			options:      []func(*BaseApp){SetMinRetainBlocks(400000)},
+			maxAgeBlocks: 362880,
+			asyncCommit:  true,
+			commitHeight: 499000,
+			expected:     99000,
+		},
+		"no pruning due to no persisted iavl state": {
+			options:      []func(*BaseApp){SetMinRetainBlocks(1)},
+			maxAgeBlocks: 0,
+			asyncCommit:  true,
+			commitHeight: 150,
+			expected:     0,
+		},
+		"disable pruning": {
+			options: []func(*BaseApp){
+				SetPruning(sdk.PruningOptions{KeepEvery: 10000}),
+				SetMinRetainBlocks(0),
+				SetSnapshotInterval(50000),
+				SetSnapshotKeepRecent(3),
+			},
+			maxAgeBlocks: 362880,
+			commitHeight: 499000,
+			expected:     0,
+		},
+	}
+
+	defer func(enabled bool) { tmiavl.EnableAsyncCommit = enabled }(tmiavl.EnableAsyncCommit)
+	for name, tc := range testCases {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			tmiavl.EnableAsyncCommit = tc.asyncCommit
+			app := NewBaseApp(name, defaultLogger(), dbm.NewMemDB(), nil, tc.options...)
+			app.setConsensusParams(&abci.ConsensusParams{
+				Evidence: &abci.EvidenceParams{MaxAgeNumBlocks: tc.maxAgeBlocks},
+			})
+			require.Equal(t, tc.expected, app.GetBlockRetentionHeight(tc.commitHeight))
+		})
+	}
+}
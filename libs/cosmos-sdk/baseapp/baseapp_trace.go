@@ -0,0 +1,165 @@
+package baseapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+)
+
+// TraceTxs replays queryTrace.Txs in order and returns the trace results of the
+// txs from queryTrace.TraceIndex onwards.
+//
+// When onBlock is true, the txs are the ones of the block at height and are
+// replayed on top of the state of its parent block, otherwise they are run on
+// top of the state at height, like a simulation. State changes of the block's
+// BeginBlock are not replayed, since module begin blockers touch in-memory
+// keeper state shared with consensus.
+func (app *BaseApp) TraceTxs(queryTrace sdk.QueryTraceTx, height int64, onBlock bool) ([]sdk.TraceTxResult, error) {
+	if queryTrace.TraceIndex < 0 || queryTrace.TraceIndex >= len(queryTrace.Txs) {
+		return nil, fmt.Errorf("invalid trace index %d of %d txs", queryTrace.TraceIndex, len(queryTrace.Txs))
+	}
+
+	ctx, err := app.getContextForTrace(height, onBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]sdk.TraceTxResult, 0, len(queryTrace.Txs)-queryTrace.TraceIndex)
+	for i, txBytes := range queryTrace.Txs {
+		tx, err := app.txDecoder(txBytes)
+		if err != nil {
+			if i < queryTrace.TraceIndex {
+				return nil, sdkerrors.Wrap(err, "failed to decode tx")
+			}
+			results = append(results, sdk.TraceTxResult{Error: err.Error()})
+			continue
+		}
+
+		if i < queryTrace.TraceIndex {
+			// the result of a preceding tx is not relevant, only its state changes are
+			app.runTxForTrace(ctx.WithTxBytes(txBytes), tx, !onBlock)
+			continue
+		}
+
+		traceTx := &sdk.TraceTxConfig{Config: queryTrace.Config}
+		_, err = app.runTxForTrace(ctx.WithTxBytes(txBytes).WithTraceTx(traceTx), tx, !onBlock)
+		switch {
+		case traceTx.Output != nil:
+			results = append(results, sdk.TraceTxResult{Result: traceTx.Output})
+		case err != nil:
+			results = append(results, sdk.TraceTxResult{Error: err.Error()})
+		default:
+			results = append(results, sdk.TraceTxResult{Error: "no evm execution in tx"})
+		}
+	}
+
+	return results, nil
+}
+
+// retrieve the context for replaying txs in trace mode
+func (app *BaseApp) getContextForTrace(height int64, onBlock bool) (sdk.Context, error) {
+	cms, ok := app.cms.(*rootmulti.Store)
+	if !ok {
+		return sdk.Context{}, fmt.Errorf("get context for trace tx failed")
+	}
+
+	if height == 0 {
+		height = app.LastBlockHeight()
+	}
+	if height <= tmtypes.GetStartBlockHeight() || height > app.LastBlockHeight() {
+		return sdk.Context{}, fmt.Errorf("height(%d) should be in the range of (%d, %d]",
+			height, tmtypes.GetStartBlockHeight(), app.LastBlockHeight())
+	}
+
+	version := height
+	if onBlock {
+		version = height - 1
+	}
+	ms, err := cms.CacheMultiStoreWithVersion(version)
+	if err != nil {
+		return sdk.Context{}, err
+	}
+
+	abciHeader, err := GetABCIHeader(height)
+	if err != nil {
+		return sdk.Context{}, err
+	}
+
+	ctx := sdk.NewContext(ms, abciHeader, true, app.logger).
+		WithConsensusParams(app.consensusParams).
+		WithIsTraceTx(true)
+	return ctx, nil
+}
+
+// runTxForTrace runs a tx in trace mode and writes its state changes into the
+// multistore of ctx. It mirrors runTx in deliver mode without touching the
+// block gas meter or any other per-block state of the app.
+func (app *BaseApp) runTxForTrace(ctx sdk.Context, tx sdk.Tx, simulate bool) (result *sdk.Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case sdk.ErrorOutOfGas:
+				err = sdkerrors.Wrap(sdkerrors.ErrOutOfGas, fmt.Sprintf("out of gas in location: %v", rType.Descriptor))
+			default:
+				err = sdkerrors.Wrap(sdkerrors.ErrPanic, fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack())))
+			}
+			result = nil
+		}
+	}()
+
+	msgs := tx.GetMsgs()
+	if err := validateBasicTxMsgs(msgs); err != nil {
+		return nil, err
+	}
+
+	if app.anteHandler != nil {
+		anteCtx, msCacheAnte := app.cacheTxContext(ctx, ctx.TxBytes())
+		anteCtx = anteCtx.WithEventManager(sdk.NewEventManager())
+		newCtx, err := app.anteHandler(anteCtx, tx, simulate)
+		if !newCtx.IsZero() {
+			ctx = newCtx.WithMultiStore(ctx.MultiStore())
+		}
+		if err != nil {
+			return nil, err
+		}
+		msCacheAnte.Write()
+	}
+
+	defer func() {
+		if app.GasRefundHandler == nil {
+			return
+		}
+		refundCtx, msCache := app.cacheTxContext(ctx, ctx.TxBytes())
+		if _, err := app.GasRefundHandler(refundCtx, tx); err != nil {
+			panic(err)
+		}
+		msCache.Write()
+	}()
+
+	runMsgCtx, msCache := app.cacheTxContext(ctx, ctx.TxBytes())
+	result, err = app.runMsgs(runMsgCtx, msgs, runTxModeDeliver)
+	if err == nil {
+		msCache.Write()
+	}
+	return result, err
+}
+
+func handleQueryTrace(app *BaseApp, path []string, data []byte, height int64) ([]byte, error) {
+	var queryTrace sdk.QueryTraceTx
+	if err := json.Unmarshal(data, &queryTrace); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	// "app/trace/call" traces on top of the state at height instead of replaying a block
+	onBlock := !(len(path) >= 3 && path[2] == "call")
+	results, err := app.TraceTxs(queryTrace, height, onBlock)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(results)
+}
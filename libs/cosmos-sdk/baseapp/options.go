@@ -6,6 +6,8 @@ import (
 
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/okex/exchain/libs/cosmos-sdk/snapshots"
+	snapshottypes "github.com/okex/exchain/libs/cosmos-sdk/snapshots/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/store"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
@@ -44,6 +46,26 @@ func SetInterBlockCache(cache sdk.MultiStorePersistentCache) func(*BaseApp) {
 	return func(app *BaseApp) { app.setInterBlockCache(cache) }
 }
 
+// SetSnapshotStore sets the snapshot store.
+func SetSnapshotStore(snapshotStore *snapshots.Store) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetSnapshotStore(snapshotStore) }
+}
+
+// SetSnapshotInterval sets the snapshot interval.
+func SetSnapshotInterval(interval uint64) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetSnapshotInterval(interval) }
+}
+
+// SetSnapshotKeepRecent sets the recent snapshots to keep.
+func SetSnapshotKeepRecent(keepRecent uint32) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetSnapshotKeepRecent(keepRecent) }
+}
+
+// SetMinRetainBlocks sets the minimum number of blocks Tendermint retains.
+func SetMinRetainBlocks(minRetainBlocks uint64) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetMinRetainBlocks(minRetainBlocks) }
+}
+
 // SetTrace will turn on or off trace flag
 func SetTrace(trace bool) func(*BaseApp) {
 	return func(app *BaseApp) { app.setTrace(trace) }
@@ -64,6 +86,46 @@ func (app *BaseApp) SetAppVersion(v string) {
 	app.appVersion = v
 }
 
+// SetSnapshotStore sets the snapshot store.
+func (app *BaseApp) SetSnapshotStore(snapshotStore *snapshots.Store) {
+	if app.sealed {
+		panic("SetSnapshotStore() on sealed BaseApp")
+	}
+	if snapshotStore == nil {
+		app.snapshotManager = nil
+		return
+	}
+	snapshotter, ok := app.cms.(snapshottypes.Snapshotter)
+	if !ok {
+		panic(fmt.Sprintf("multistore %T doesn't support snapshots", app.cms))
+	}
+	app.snapshotManager = snapshots.NewManager(snapshotStore, snapshotter)
+}
+
+// SetSnapshotInterval sets the snapshot interval.
+func (app *BaseApp) SetSnapshotInterval(snapshotInterval uint64) {
+	if app.sealed {
+		panic("SetSnapshotInterval() on sealed BaseApp")
+	}
+	app.snapshotInterval = snapshotInterval
+}
+
+// SetSnapshotKeepRecent sets the number of recent snapshots to keep.
+func (app *BaseApp) SetSnapshotKeepRecent(snapshotKeepRecent uint32) {
+	if app.sealed {
+		panic("SetSnapshotKeepRecent() on sealed BaseApp")
+	}
+	app.snapshotKeepRecent = snapshotKeepRecent
+}
+
+// SetMinRetainBlocks sets the minimum number of blocks Tendermint retains.
+func (app *BaseApp) SetMinRetainBlocks(minRetainBlocks uint64) {
+	if app.sealed {
+		panic("SetMinRetainBlocks() on sealed BaseApp")
+	}
+	app.minRetainBlocks = minRetainBlocks
+}
+
 func (app *BaseApp) SetDB(db dbm.DB) {
 	if app.sealed {
 		panic("SetDB() on sealed BaseApp")
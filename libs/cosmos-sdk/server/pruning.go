@@ -43,3 +43,19 @@ func GetPruningOptionsFromFlags() (types.PruningOptions, error) {
 		return store.PruningOptions{}, fmt.Errorf("unknown pruning strategy %s", strategy)
 	}
 }
+
+// ValidateSnapshotInterval checks that state sync snapshots can be taken at the given interval,
+// i.e. that the snapshotted heights are not pruned before the snapshots are taken.
+func ValidateSnapshotInterval(pruningOpts types.PruningOptions, interval uint64) error {
+	if interval == 0 {
+		return nil
+	}
+	if pruningOpts.KeepEvery == 0 {
+		return fmt.Errorf("state sync snapshots require pruning keep-every to be set")
+	}
+	if interval%pruningOpts.KeepEvery != 0 {
+		return fmt.Errorf("state sync snapshot interval %v must be a multiple of pruning keep-every %v",
+			interval, pruningOpts.KeepEvery)
+	}
+	return nil
+}
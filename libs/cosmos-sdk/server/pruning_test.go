@@ -62,3 +62,31 @@ func TestGetPruningOptionsFromFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSnapshotInterval(t *testing.T) {
+	tests := []struct {
+		name        string
+		pruningOpts types.PruningOptions
+		interval    uint64
+		wantErr     bool
+	}{
+		{"snapshots disabled", types.PruneEverything, 0, false},
+		{"pruning nothing", types.PruneNothing, 7, false},
+		{"multiple of keep-every", types.PruneDefault, 20000, false},
+		{"not a multiple of keep-every", types.PruneDefault, 1000, true},
+		{"pruning everything", types.PruneEverything, 1000, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSnapshotInterval(tt.pruningOpts, tt.interval)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -56,6 +56,10 @@ const (
 	FlagGoroutineNum      = "goroutine-num"
 
 	FlagPruningMaxWsNum = "pruning-max-worldstate-num"
+	FlagMinRetainBlocks = "min-retain-blocks"
+
+	FlagStateSyncSnapshotInterval   = "state-sync.snapshot-interval"
+	FlagStateSyncSnapshotKeepRecent = "state-sync.snapshot-keep-recent"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -87,6 +91,16 @@ the halt-height or if the current block time is greater than or equal to the hal
 node will attempt to gracefully shutdown and the block will not be committed. In addition, the node
 will not be able to commit subsequent blocks.
 
+State sync snapshots of the application state can be taken every '--state-sync.snapshot-interval'
+heights, keeping the '--state-sync.snapshot-keep-recent' most recent ones on disk. Peers can bootstrap
+from them using the Tendermint state sync. The snapshot interval must be a multiple of the pruning
+keep-every height, so that the snapshotted states are not pruned while the snapshot is being taken.
+
+Blocks, ABCI results and tx index entries can be pruned with '--min-retain-blocks', which keeps at
+least that many recent blocks. The retain height is also bounded by the evidence max age, the pruning
+keep-every height and the state sync snapshots still being served. Pruning runs in the background
+without stopping the node. 0 keeps all blocks.
+
 For profiling and benchmarking purposes, CPU profiling can be enabled via the '--cpu-profile' flag
 which accepts a path for the resulting pprof file.
 `,
@@ -96,8 +110,12 @@ which accepts a path for the resulting pprof file.
 			// app pre run
 			appPreRun(ctx)
 			// pruning options
-			_, err := GetPruningOptionsFromFlags()
-			return err
+			pruningOpts, err := GetPruningOptionsFromFlags()
+			if err != nil {
+				return err
+			}
+			// state sync snapshot options
+			return ValidateSnapshotInterval(pruningOpts, viper.GetUint64(FlagStateSyncSnapshotInterval))
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !viper.GetBool(flagWithTendermint) {
@@ -136,6 +154,9 @@ which accepts a path for the resulting pprof file.
 	cmd.Flags().Uint64(FlagPruningKeepEvery, 0, "Offset heights to keep on disk after 'keep-every' (ignored if pruning is not 'custom')")
 	cmd.Flags().Uint64(FlagPruningInterval, 0, "Height interval at which pruned heights are removed from disk (ignored if pruning is not 'custom')")
 	cmd.Flags().Uint64(FlagPruningMaxWsNum, 0, "Max number of historic states to keep on disk (ignored if pruning is not 'custom')")
+	cmd.Flags().Uint64(FlagMinRetainBlocks, 0, "Minimum block height offset during ABCI commit to prune Tendermint blocks (0 keeps all blocks)")
+	cmd.Flags().Uint64(FlagStateSyncSnapshotInterval, 0, "State sync snapshot interval (0 disables snapshots)")
+	cmd.Flags().Uint32(FlagStateSyncSnapshotKeepRecent, 2, "Number of recent state sync snapshots to keep (0 keeps all)")
 	cmd.Flags().String(FlagLocalRpcPort, "", "Local rpc port for mempool and block monitor on cosmos layer(ignored if mempool/block monitoring is not required)")
 	cmd.Flags().String(FlagPortMonitor, "", "Local target ports for connecting number monitoring(ignored if connecting number monitoring is not required)")
 	cmd.Flags().String(FlagEvmImportMode, "default", "Select import mode for evm state (default|files|db)")
@@ -170,6 +191,9 @@ which accepts a path for the resulting pprof file.
 	viper.BindPFlag(FlagPruningKeepEvery, cmd.Flags().Lookup(FlagPruningKeepEvery))
 	viper.BindPFlag(FlagPruningInterval, cmd.Flags().Lookup(FlagPruningInterval))
 	viper.BindPFlag(FlagPruningMaxWsNum, cmd.Flags().Lookup(FlagPruningMaxWsNum))
+	viper.BindPFlag(FlagMinRetainBlocks, cmd.Flags().Lookup(FlagMinRetainBlocks))
+	viper.BindPFlag(FlagStateSyncSnapshotInterval, cmd.Flags().Lookup(FlagStateSyncSnapshotInterval))
+	viper.BindPFlag(FlagStateSyncSnapshotKeepRecent, cmd.Flags().Lookup(FlagStateSyncSnapshotKeepRecent))
 	viper.BindPFlag(FlagLocalRpcPort, cmd.Flags().Lookup(FlagLocalRpcPort))
 	viper.BindPFlag(FlagPortMonitor, cmd.Flags().Lookup(FlagPortMonitor))
 	viper.BindPFlag(FlagEvmImportMode, cmd.Flags().Lookup(FlagEvmImportMode))
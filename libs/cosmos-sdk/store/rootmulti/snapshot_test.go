@@ -0,0 +1,164 @@
+package rootmulti
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/snapshots"
+	snapshottypes "github.com/okex/exchain/libs/cosmos-sdk/snapshots/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/types"
+)
+
+func newMultiStoreWithGeneratedData(db dbm.DB, versions, keys int) *Store {
+	store := newMultiStoreWithMounts(db, types.PruneNothing)
+	if err := store.LoadLatestVersion(); err != nil {
+		panic(err)
+	}
+
+	for v := 1; v <= versions; v++ {
+		for _, name := range []string{"store1", "store2", "store3"} {
+			kv := store.getStoreByName(name).(types.KVStore)
+			for k := 0; k < keys; k++ {
+				kv.Set([]byte(fmt.Sprintf("key%03d", k)), []byte(fmt.Sprintf("%s-v%d-%03d", name, v, k)))
+			}
+			kv.Delete([]byte(fmt.Sprintf("key%03d", v)))
+		}
+		store.Commit()
+	}
+	return store
+}
+
+// readSnapshotChunks buffers all chunks of a snapshot, so that they can be restored independently
+// of the store they were taken from.
+func readSnapshotChunks(t *testing.T, chunks <-chan io.ReadCloser) <-chan io.ReadCloser {
+	bodies := [][]byte{}
+	for chunk := range chunks {
+		body, err := ioutil.ReadAll(chunk)
+		require.NoError(t, err)
+		require.NoError(t, chunk.Close())
+		bodies = append(bodies, body)
+	}
+
+	ch := make(chan io.ReadCloser, len(bodies))
+	for _, body := range bodies {
+		ch <- ioutil.NopCloser(bytes.NewReader(body))
+	}
+	close(ch)
+	return ch
+}
+
+func TestMultistoreSnapshot_Checksum(t *testing.T) {
+	// Chunks from different nodes must fit together, so all nodes must produce identical chunks.
+	source := newMultiStoreWithGeneratedData(dbm.NewMemDB(), 3, 20)
+	other := newMultiStoreWithGeneratedData(dbm.NewMemDB(), 3, 20)
+
+	chunks, err := source.Snapshot(3, snapshottypes.CurrentFormat)
+	require.NoError(t, err)
+	otherChunks, err := other.Snapshot(3, snapshottypes.CurrentFormat)
+	require.NoError(t, err)
+
+	store, err := snapshots.NewStore(dbm.NewMemDB(), t.TempDir())
+	require.NoError(t, err)
+	snapshot, err := store.Save(3, snapshottypes.CurrentFormat, chunks)
+	require.NoError(t, err)
+
+	otherStore, err := snapshots.NewStore(dbm.NewMemDB(), t.TempDir())
+	require.NoError(t, err)
+	otherSnapshot, err := otherStore.Save(3, snapshottypes.CurrentFormat, otherChunks)
+	require.NoError(t, err)
+
+	assert.Equal(t, snapshot.Hash, otherSnapshot.Hash)
+	assert.Equal(t, snapshot.Metadata.ChunkHashes, otherSnapshot.Metadata.ChunkHashes)
+}
+
+func TestMultistoreSnapshot_Errors(t *testing.T) {
+	store := newMultiStoreWithGeneratedData(dbm.NewMemDB(), 3, 4)
+
+	testcases := map[string]struct {
+		height     uint64
+		format     uint32
+		expectType error
+	}{
+		"0 height":       {0, snapshottypes.CurrentFormat, nil},
+		"0 format":       {1, 0, snapshottypes.ErrUnknownFormat},
+		"unknown height": {9, snapshottypes.CurrentFormat, nil},
+		"unknown format": {1, 9, snapshottypes.ErrUnknownFormat},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := store.Snapshot(tc.height, tc.format)
+			require.Error(t, err)
+			if tc.expectType != nil {
+				assert.True(t, errors.Is(err, tc.expectType))
+			}
+		})
+	}
+}
+
+func TestMultistoreRestore_Errors(t *testing.T) {
+	store := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	require.NoError(t, store.LoadLatestVersion())
+
+	testcases := map[string]struct {
+		height     uint64
+		format     uint32
+		expectType error
+	}{
+		"0 height":       {0, snapshottypes.CurrentFormat, nil},
+		"0 format":       {1, 0, snapshottypes.ErrUnknownFormat},
+		"unknown format": {1, 9, snapshottypes.ErrUnknownFormat},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := store.Restore(tc.height, tc.format, nil, nil)
+			require.Error(t, err)
+			if tc.expectType != nil {
+				assert.True(t, errors.Is(err, tc.expectType))
+			}
+		})
+	}
+}
+
+func TestMultistoreSnapshotRestore(t *testing.T) {
+	source := newMultiStoreWithGeneratedData(dbm.NewMemDB(), 3, 20)
+	target := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	require.NoError(t, target.LoadLatestVersion())
+
+	version := uint64(source.LastCommitID().Version)
+	require.EqualValues(t, 3, version)
+
+	chunks, err := source.Snapshot(version, snapshottypes.CurrentFormat)
+	require.NoError(t, err)
+	ready := make(chan struct{})
+	err = target.Restore(version, snapshottypes.CurrentFormat, readSnapshotChunks(t, chunks), ready)
+	require.NoError(t, err)
+	assert.EqualValues(t, struct{}{}, <-ready)
+
+	assert.Equal(t, source.LastCommitID(), target.LastCommitID())
+	for _, name := range []string{"store1", "store2", "store3"} {
+		sourceStore := source.getStoreByName(name).(types.KVStore)
+		targetStore := target.getStoreByName(name).(types.KVStore)
+
+		sourceIter := sourceStore.Iterator(nil, nil)
+		targetIter := targetStore.Iterator(nil, nil)
+		for ; sourceIter.Valid(); sourceIter.Next() {
+			require.True(t, targetIter.Valid())
+			assert.Equal(t, sourceIter.Key(), targetIter.Key())
+			assert.Equal(t, sourceIter.Value(), targetIter.Value())
+			targetIter.Next()
+		}
+		assert.False(t, targetIter.Valid())
+		sourceIter.Close()
+		targetIter.Close()
+	}
+}
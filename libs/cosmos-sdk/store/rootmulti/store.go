@@ -82,6 +82,11 @@ func (rs *Store) SetPruning(pruningOpts types.PruningOptions) {
 	rs.pruningOpts = pruningOpts
 }
 
+// GetPruning fetches the pruning strategy from the root store.
+func (rs *Store) GetPruning() types.PruningOptions {
+	return rs.pruningOpts
+}
+
 // SetLazyLoading sets if the iavl store should be loaded lazily or not
 func (rs *Store) SetLazyLoading(lazyLoading bool) {
 	rs.lazyLoading = lazyLoading
@@ -819,6 +824,21 @@ func getCommitInfo(db dbm.DB, ver int64) (commitInfo, error) {
 	return cInfo, nil
 }
 
+// GetCommitStoreHashes returns the root hashes of the substores committed at the given version,
+// keyed by store name, together with the app hash of that version.
+func GetCommitStoreHashes(db dbm.DB, ver int64) (map[string][]byte, []byte, error) {
+	cInfo, err := getCommitInfo(db, ver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make(map[string][]byte, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		hashes[storeInfo.Name] = storeInfo.Core.CommitID.Hash
+	}
+	return hashes, cInfo.Hash(), nil
+}
+
 func setCommitInfo(batch dbm.Batch, version int64, cInfo commitInfo) {
 	cInfoBytes := cdc.MustMarshalBinaryLengthPrefixed(cInfo)
 	cInfoKey := fmt.Sprintf(commitInfoKeyFmt, version)
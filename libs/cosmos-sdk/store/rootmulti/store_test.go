@@ -174,6 +174,25 @@ func TestMultistoreCommitLoad(t *testing.T) {
 	checkStore(t, store, commitID, commitID)
 }
 
+func TestGetCommitStoreHashes(t *testing.T) {
+	var db dbm.DB = dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db, types.PruneNothing)
+	require.NoError(t, store.LoadLatestVersion())
+
+	store.getStoreByName("store1").(types.KVStore).Set([]byte("key"), []byte("value"))
+	commitID := store.Commit()
+
+	hashes, appHash, err := GetCommitStoreHashes(db, commitID.Version)
+	require.NoError(t, err)
+	require.Equal(t, commitID.Hash, appHash)
+	require.Len(t, hashes, 3)
+	require.Equal(t, store.getStoreByName("store1").(types.CommitKVStore).LastCommitID().Hash, hashes["store1"])
+	require.NotEqual(t, hashes["store1"], hashes["store2"])
+
+	_, _, err = GetCommitStoreHashes(db, commitID.Version+1)
+	require.Error(t, err)
+}
+
 func TestMultistoreLoadWithUpgrade(t *testing.T) {
 	var db dbm.DB = dbm.NewMemDB()
 	store := newMultiStoreWithMounts(db, types.PruneNothing)
@@ -32,6 +32,11 @@ func (ts *Store) Commit() (id types.CommitID) {
 func (ts *Store) SetPruning(pruning types.PruningOptions) {
 }
 
+// Implements CommitStore
+func (ts *Store) GetPruning() types.PruningOptions {
+	return types.PruningOptions{}
+}
+
 // Implements CommitStore
 func (ts *Store) LastCommitID() (id types.CommitID) {
 	return
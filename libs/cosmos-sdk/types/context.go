@@ -38,6 +38,8 @@ type Context struct {
 	accountNonce  uint64
 	sigCache      SigCache
 	isAsync       bool
+	isTraceTx     bool
+	traceTx       *TraceTxConfig
 }
 
 // Proposed rename, not done to avoid API breakage
@@ -61,6 +63,8 @@ func (c Context) EventManager() *EventManager { return c.eventManager }
 func (c Context) IsAsync() bool               { return c.isAsync }
 func (c Context) AccountNonce() uint64        { return c.accountNonce }
 func (c Context) SigCache() SigCache          { return c.sigCache }
+func (c Context) IsTraceTx() bool             { return c.isTraceTx }
+func (c Context) TraceTx() *TraceTxConfig     { return c.traceTx }
 
 // clone the header before returning
 func (c Context) BlockHeader() abci.Header {
@@ -195,6 +199,19 @@ func (c Context) WithAccountNonce(nonce uint64) Context {
 	return c
 }
 
+// WithIsTraceTx marks the context as replaying txs for the debug trace query,
+// which runs them with check-tx side effects but keeps their state changes.
+func (c Context) WithIsTraceTx(isTraceTx bool) Context {
+	c.isTraceTx = isTraceTx
+	return c
+}
+
+// WithTraceTx sets the tracer config of the tx to be traced, nil disables tracing.
+func (c Context) WithTraceTx(traceTx *TraceTxConfig) Context {
+	c.traceTx = traceTx
+	return c
+}
+
 // TODO: remove???
 func (c Context) IsZero() bool {
 	return c.ms == nil
@@ -10,10 +10,18 @@ import (
 // 2. ChangeEvmDenomByProposal
 // 3. BankTransferBlock
 
+// Enable followings after milestoneVenusHeight
+// 1. order and dex messages
+// 2. the twap of the swap token pairs and its system contract
+// 3. the delegator rewards of the distribution and MsgEditValidatorCommissionRate
+
 var (
 	MILESTONE_MERCURY_HEIGHT     string
 	milestoneMercuryHeight       int64
 
+	MILESTONE_VENUS_HEIGHT string
+	milestoneVenusHeight   int64
+
 	once                         sync.Once
 )
 
@@ -31,6 +39,7 @@ func string2number(input string) int64 {
 func initVersionBlockHeight() {
 	once.Do(func() {
 		milestoneMercuryHeight = string2number(MILESTONE_MERCURY_HEIGHT)
+		milestoneVenusHeight = string2number(MILESTONE_VENUS_HEIGHT)
 	})
 }
 
@@ -47,6 +56,20 @@ func HigherThanMercury(height int64) bool {
 	return height > milestoneMercuryHeight
 }
 
+//enable the order and dex messages and the twap of the swap token pairs
+func HigherThanVenus(height int64) bool {
+	if milestoneVenusHeight == 0 {
+		// milestoneVenusHeight not enabled
+		return false
+	}
+	return height > milestoneVenusHeight
+}
+
+// UnittestOnlySetMilestoneVenusHeight sets the venus height, it's only used by the unit tests
+func UnittestOnlySetMilestoneVenusHeight(height int64) {
+	milestoneVenusHeight = height
+}
+
 ////disable transfer tokens to contract address by cli
 //func IsDisableTransferToContractBlock(height int64) bool {
 //	return higherThanMercury(height)
@@ -0,0 +1,28 @@
+package types
+
+import "encoding/json"
+
+// QueryTraceTx defines the data of the "app/trace" query. Txs are replayed in
+// order on top of the state the query runs against, and every tx from
+// TraceIndex onwards is executed with the tracer described by Config.
+type QueryTraceTx struct {
+	Txs        [][]byte        `json:"txs"`
+	TraceIndex int             `json:"trace_index"`
+	Config     json.RawMessage `json:"config"`
+}
+
+// TraceTxResult is the outcome of tracing a single tx of a QueryTraceTx
+type TraceTxResult struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// TraceTxConfig carries the tracer config of a traced tx down to the vm module,
+// which fills Output with the tracer result once the tx has been executed.
+// Tracer is a tracer built by the caller, it is used instead of Config when set
+// and the caller reads the result from it, so Output is left empty.
+type TraceTxConfig struct {
+	Config json.RawMessage
+	Output json.RawMessage
+	Tracer interface{}
+}
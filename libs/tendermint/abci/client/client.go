@@ -1,6 +1,7 @@
 package abcicli
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
@@ -48,8 +49,16 @@ type Client interface {
 	BeginBlockSync(types.RequestBeginBlock) (*types.ResponseBeginBlock, error)
 	EndBlockSync(types.RequestEndBlock) (*types.ResponseEndBlock, error)
 	ParallelTxs([][]byte) []*types.ResponseDeliverTx
+
+	ListSnapshotsSync(types.RequestListSnapshots) (*types.ResponseListSnapshots, error)
+	OfferSnapshotSync(types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error)
+	LoadSnapshotChunkSync(types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error)
+	ApplySnapshotChunkSync(types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error)
 }
 
+// errStateSyncNotSupported is returned by the clients which don't support the state sync connection
+var errStateSyncNotSupported = errors.New("state sync is only supported by the local client")
+
 //----------------------------------------
 
 // NewClient returns a new ABCI client of the specified transport type.
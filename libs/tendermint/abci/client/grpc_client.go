@@ -227,6 +227,24 @@ func (cli *grpcClient) ParallelTxs(txs [][]byte) []*types.ResponseDeliverTx {
 	return nil
 }
 
+func (cli *grpcClient) ListSnapshotsSync(_ types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	return nil, errStateSyncNotSupported
+}
+
+func (cli *grpcClient) OfferSnapshotSync(_ types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	return nil, errStateSyncNotSupported
+}
+
+func (cli *grpcClient) LoadSnapshotChunkSync(
+	_ types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	return nil, errStateSyncNotSupported
+}
+
+func (cli *grpcClient) ApplySnapshotChunkSync(
+	_ types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	return nil, errStateSyncNotSupported
+}
+
 func (cli *grpcClient) finishAsyncCall(req *types.Request, res *types.Response) *ReqRes {
 	reqres := NewReqRes(req)
 	reqres.Response = res // Set response
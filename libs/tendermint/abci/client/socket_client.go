@@ -270,6 +270,24 @@ func (cli *socketClient) ParallelTxs(_ [][]byte) []*types.ResponseDeliverTx {
 	return nil
 }
 
+func (cli *socketClient) ListSnapshotsSync(_ types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	return nil, errStateSyncNotSupported
+}
+
+func (cli *socketClient) OfferSnapshotSync(_ types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	return nil, errStateSyncNotSupported
+}
+
+func (cli *socketClient) LoadSnapshotChunkSync(
+	_ types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	return nil, errStateSyncNotSupported
+}
+
+func (cli *socketClient) ApplySnapshotChunkSync(
+	_ types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	return nil, errStateSyncNotSupported
+}
+
 //----------------------------------------
 
 func (cli *socketClient) FlushSync() error {
@@ -143,6 +143,9 @@ type State struct {
 	// for reporting metrics
 	metrics *Metrics
 
+	// prunes old heights in the background, if set
+	pruner *sm.Pruner
+
 	trc *trace.Tracer
 }
 
@@ -213,6 +216,12 @@ func StateMetrics(metrics *Metrics) StateOption {
 	return func(cs *State) { cs.metrics = metrics }
 }
 
+// StatePruner sets the pruner. If set, heights below the retain height requested by the ABCI
+// app are pruned in the background instead of while committing the block.
+func StatePruner(pruner *sm.Pruner) StateOption {
+	return func(cs *State) { cs.pruner = pruner }
+}
+
 // String returns a string.
 func (cs *State) String() string {
 	// better not to access shared variables
@@ -1519,7 +1528,9 @@ func (cs *State) finalizeCommit(height int64) {
 	fail.Fail() // XXX
 
 	// Prune old heights, if requested by ABCI app.
-	if retainHeight > 0 {
+	if retainHeight > 0 && cs.pruner != nil {
+		cs.pruner.SetRetainHeight(retainHeight)
+	} else if retainHeight > 0 {
 		pruned, err := cs.pruneBlocks(retainHeight)
 		if err != nil {
 			cs.Logger.Error("Failed to prune blocks", "retainHeight", retainHeight, "err", err)
@@ -458,6 +458,17 @@ func (mem *CListMempool) addTx(memTx *mempoolTx, info ExTxInfo) error {
 	return nil
 }
 
+// publishRmPendingTx notifies the subscribers that the tx left the mempool without
+// being included in a block
+func (mem *CListMempool) publishRmPendingTx(tx types.Tx, info ExTxInfo, reason int) {
+	mem.eventBus.PublishEventRmPendingTx(types.EventDataRmPendingTx{
+		Hash:   tx.Hash(),
+		From:   info.Sender,
+		Nonce:  info.Nonce,
+		Reason: reason,
+	})
+}
+
 // Called from:
 //  - Update (lock held) if tx was committed
 // 	- resCbRecheck (lock not held) if tx was invalidated
@@ -517,7 +528,9 @@ func (mem *CListMempool) addPendingTx(memTx *mempoolTx, exTxInfo ExTxInfo) error
 		mempoolTx: memTx,
 		exTxInfo:  exTxInfo,
 	}
-	mem.pendingPool.addTx(pendingTx)
+	if replaced := mem.pendingPool.addTx(pendingTx); replaced != nil {
+		mem.publishRmPendingTx(replaced.mempoolTx.tx, replaced.exTxInfo, types.RmPendingTxReplaced)
+	}
 	mem.logger.Debug("pending pool addTx", "tx", pendingTx)
 
 	return nil
@@ -539,6 +552,7 @@ func (mem *CListMempool) consumePendingTx(address string, nonce uint64) {
 		if err := mem.addTx(mempoolTx, pendingTx.exTxInfo); err != nil {
 			mem.logger.Error(fmt.Sprintf("Pending Pool add tx failed:%s", err.Error()))
 			mem.pendingPool.removeTx(address, nonce)
+			mem.publishRmPendingTx(mempoolTx.tx, pendingTx.exTxInfo, types.RmPendingTxDropped)
 			return
 		}
 
@@ -969,6 +983,7 @@ func (mem *CListMempool) checkRepeatedElement(info ExTxInfo) int {
 				}
 
 				mem.removeTx(node.Value.(*mempoolTx).tx, node, true)
+				mem.publishRmPendingTx(node.Value.(*mempoolTx).tx, info, types.RmPendingTxReplaced)
 
 				repeatElement = 1
 				break
@@ -1128,11 +1143,16 @@ func (mem *CListMempool) pendingPoolJob() {
 	for addressNonce := range mem.pendingPoolNotify {
 		timeStart := time.Now()
 		mem.logger.Debug("pending pool job begin", "poolSize", mem.pendingPool.Size())
-		addrNonceMap := mem.pendingPool.handlePendingTx(addressNonce)
+		addrNonceMap, invalidTxs := mem.pendingPool.handlePendingTx(addressNonce)
+		for _, pendingTx := range invalidTxs {
+			mem.publishRmPendingTx(pendingTx.mempoolTx.tx, pendingTx.exTxInfo, types.RmPendingTxDropped)
+		}
 		for addr, nonce := range addrNonceMap {
 			mem.consumePendingTx(addr, nonce)
 		}
-		mem.pendingPool.handlePeriodCounter()
+		for _, pendingTx := range mem.pendingPool.handlePeriodCounter() {
+			mem.publishRmPendingTx(pendingTx.mempoolTx.tx, pendingTx.exTxInfo, types.RmPendingTxDropped)
+		}
 		timeElapse := time.Since(timeStart).Microseconds()
 		mem.logger.Debug("pending pool job end", "interval(ms)", timeElapse,
 			"poolSize", mem.pendingPool.Size(),
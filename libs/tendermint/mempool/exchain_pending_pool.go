@@ -64,14 +64,21 @@ func (p *PendingPool) hasTx(tx types.Tx) bool {
 	return exist
 }
 
-func (p *PendingPool) addTx(pendingTx *PendingTx) {
+// addTx adds the tx to the pool, the tx of the same sender and nonce already in the
+// pool is replaced and returned.
+func (p *PendingPool) addTx(pendingTx *PendingTx) (replaced *PendingTx) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 	if _, ok := p.addressTxsMap[pendingTx.exTxInfo.Sender]; !ok {
 		p.addressTxsMap[pendingTx.exTxInfo.Sender] = make(map[uint64]*PendingTx)
 	}
+	if old, ok := p.addressTxsMap[pendingTx.exTxInfo.Sender][pendingTx.exTxInfo.Nonce]; ok {
+		delete(p.txsMap, txID(old.mempoolTx.tx))
+		replaced = old
+	}
 	p.addressTxsMap[pendingTx.exTxInfo.Sender][pendingTx.exTxInfo.Nonce] = pendingTx
 	p.txsMap[txID(pendingTx.mempoolTx.tx)] = pendingTx
+	return replaced
 }
 
 func (p *PendingPool) removeTx(address string, nonce uint64) {
@@ -114,10 +121,13 @@ func (p *PendingPool) removeTxByHash(txHash string) {
 	}
 }
 
-func (p *PendingPool) handlePendingTx(addressNonce map[string]uint64) map[string]uint64 {
+// handlePendingTx returns the next nonce to consume of every address, the txs whose
+// nonce is already used are removed from the pool and returned.
+func (p *PendingPool) handlePendingTx(addressNonce map[string]uint64) (map[string]uint64, []*PendingTx) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 	addrMap := make(map[string]uint64)
+	var removed []*PendingTx
 	for addr, accountNonce := range addressNonce {
 		if txsMap, ok := p.addressTxsMap[addr]; ok {
 			for nonce, pendingTx := range txsMap {
@@ -125,6 +135,7 @@ func (p *PendingPool) handlePendingTx(addressNonce map[string]uint64) map[string
 				if nonce <= accountNonce {
 					delete(p.addressTxsMap[addr], nonce)
 					delete(p.txsMap, txID(pendingTx.mempoolTx.tx))
+					removed = append(removed, pendingTx)
 				} else if nonce == accountNonce+1 {
 					addrMap[addr] = nonce
 				}
@@ -134,24 +145,29 @@ func (p *PendingPool) handlePendingTx(addressNonce map[string]uint64) map[string
 			}
 		}
 	}
-	return addrMap
+	return addrMap, removed
 }
 
-func (p *PendingPool) handlePeriodCounter() {
+// handlePeriodCounter removes and returns the txs of the addresses that stayed in the
+// pool for more than reserveBlocks periods.
+func (p *PendingPool) handlePeriodCounter() []*PendingTx {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
+	var removed []*PendingTx
 	for addr, txMap := range p.addressTxsMap {
 		count := p.periodCounter[addr]
 		if count >= p.reserveBlocks {
 			delete(p.addressTxsMap, addr)
 			for _, pendingTx := range txMap {
 				delete(p.txsMap, txID(pendingTx.mempoolTx.tx))
+				removed = append(removed, pendingTx)
 			}
 			delete(p.periodCounter, addr)
 		} else {
 			p.periodCounter[addr] = count + 1
 		}
 	}
+	return removed
 }
 
 func (p *PendingPool) validate(address string, tx types.Tx) error {
@@ -1,7 +1,46 @@
 package mempool
 
-import "testing"
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPendingTx(sender string, nonce uint64, tx string) *PendingTx {
+	return &PendingTx{
+		mempoolTx: &mempoolTx{height: 1, gasWanted: 1, tx: []byte(tx)},
+		exTxInfo:  ExTxInfo{Sender: sender, GasPrice: big.NewInt(1), Nonce: nonce},
+	}
+}
 
 func TestPendingPool(t *testing.T) {
+	pool := newPendingPool(100, 1, 2, 10)
+
+	require.Nil(t, pool.addTx(newTestPendingTx("1", 2, "tx1-2")))
+	require.Nil(t, pool.addTx(newTestPendingTx("1", 3, "tx1-3")))
+	require.Nil(t, pool.addTx(newTestPendingTx("2", 5, "tx2-5")))
+	require.Equal(t, 3, pool.Size())
+
+	// the tx of the same sender and nonce is replaced
+	replaced := pool.addTx(newTestPendingTx("1", 3, "tx1-3-new"))
+	require.NotNil(t, replaced)
+	require.Equal(t, "tx1-3", string(replaced.mempoolTx.tx))
+	require.Equal(t, 3, pool.Size())
+	require.False(t, pool.hasTx([]byte("tx1-3")))
+	require.True(t, pool.hasTx([]byte("tx1-3-new")))
+
+	// the txs below the account nonce are removed
+	addrNonce, removed := pool.handlePendingTx(map[string]uint64{"1": 2})
+	require.Equal(t, map[string]uint64{"1": 3}, addrNonce)
+	require.Len(t, removed, 1)
+	require.Equal(t, "tx1-2", string(removed[0].mempoolTx.tx))
+	require.Equal(t, 2, pool.Size())
 
+	// the txs are removed once they stayed for reserveBlocks periods
+	require.Empty(t, pool.handlePeriodCounter())
+	require.Empty(t, pool.handlePeriodCounter())
+	removed = pool.handlePeriodCounter()
+	require.Len(t, removed, 2)
+	require.Equal(t, 0, pool.Size())
 }
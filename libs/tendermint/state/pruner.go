@@ -0,0 +1,142 @@
+package state
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/okex/exchain/libs/tendermint/libs/service"
+	"github.com/okex/exchain/libs/tendermint/state/txindex"
+)
+
+const (
+	// pruneInterval is how often the pruner checks for a new retain height.
+	pruneInterval = 10 * time.Second
+	// pruneBatchSize is the number of heights pruned at once, so that a large backlog (e.g. when
+	// retention is first enabled on an old node) is removed in steps.
+	pruneBatchSize = int64(1000)
+)
+
+// Pruner prunes blocks, ABCI responses and tx index entries below a retain height, usually
+// requested by the ABCI application via ResponseCommit.RetainHeight. Pruning runs in the
+// background, so that it doesn't stall consensus. The tx index is only pruned if the indexer
+// implements txindex.Pruner.
+type Pruner struct {
+	service.BaseService
+
+	stateDB    dbm.DB
+	blockStore BlockStore
+	txIndexer  txindex.TxIndexer
+
+	mtx          sync.Mutex
+	retainHeight int64
+}
+
+// NewPruner returns a new Pruner. txIndexer may be nil.
+func NewPruner(stateDB dbm.DB, blockStore BlockStore, txIndexer txindex.TxIndexer) *Pruner {
+	p := &Pruner{
+		stateDB:    stateDB,
+		blockStore: blockStore,
+		txIndexer:  txIndexer,
+	}
+	p.BaseService = *service.NewBaseService(nil, "Pruner", p)
+	return p
+}
+
+// OnStart implements service.Service.
+func (p *Pruner) OnStart() error {
+	go p.pruneRoutine()
+	return nil
+}
+
+// SetRetainHeight sets the height below which data is pruned. Lower heights than the current
+// retain height are ignored.
+func (p *Pruner) SetRetainHeight(height int64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if height > p.retainHeight {
+		p.retainHeight = height
+	}
+}
+
+// RetainHeight returns the current retain height.
+func (p *Pruner) RetainHeight() int64 {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.retainHeight
+}
+
+func (p *Pruner) pruneRoutine() {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.Quit():
+			return
+		case <-ticker.C:
+			retainHeight := p.RetainHeight()
+			if retainHeight <= p.blockStore.Base() {
+				continue
+			}
+			pruned, err := p.Prune(retainHeight)
+			if err != nil {
+				p.Logger.Error("Failed to prune blocks", "retainHeight", retainHeight, "err", err)
+				continue
+			}
+			p.Logger.Info("Pruned blocks", "pruned", pruned, "retainHeight", retainHeight)
+		}
+	}
+}
+
+// Prune deletes the tx index entries, blocks and ABCI responses below the retain height, and
+// returns the number of pruned blocks. The tx index is pruned first, since it needs the block
+// txs to find its entries.
+func (p *Pruner) Prune(retainHeight int64) (uint64, error) {
+	base := p.blockStore.Base()
+	if base <= 0 || retainHeight <= base {
+		return 0, nil
+	}
+
+	pruned := uint64(0)
+	for from := base; from < retainHeight; from += pruneBatchSize {
+		to := from + pruneBatchSize
+		if to > retainHeight {
+			to = retainHeight
+		}
+
+		if err := p.pruneTxIndex(from, to); err != nil {
+			return pruned, fmt.Errorf("failed to prune tx index: %w", err)
+		}
+		n, err := p.blockStore.PruneBlocks(to)
+		if err != nil {
+			return pruned, fmt.Errorf("failed to prune block store: %w", err)
+		}
+		if err := PruneStates(p.stateDB, from, to); err != nil {
+			return pruned, fmt.Errorf("failed to prune state database: %w", err)
+		}
+		pruned += n
+	}
+	return pruned, nil
+}
+
+// pruneTxIndex deletes the tx index entries of the blocks between the given heights (including
+// from, excluding to).
+func (p *Pruner) pruneTxIndex(from, to int64) error {
+	txPruner, ok := p.txIndexer.(txindex.Pruner)
+	if !ok {
+		return nil
+	}
+	for h := from; h < to; h++ {
+		block := p.blockStore.LoadBlock(h)
+		if block == nil || len(block.Txs) == 0 {
+			continue
+		}
+		if _, err := txPruner.Prune(h, block.Txs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/state/txindex/kv"
+	"github.com/okex/exchain/libs/tendermint/store"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+func TestPrunerPrune(t *testing.T) {
+	const height = 20
+	state, stateDB, _ := makeState(1, height+1)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	txIndexer := kv.NewTxIndex(dbm.NewMemDB())
+
+	for h := int64(1); h <= height; h++ {
+		block, partSet := state.MakeBlock(h, makeTxs(h), new(types.Commit), nil,
+			state.Validators.GetProposer().Address)
+		blockStore.SaveBlock(block, partSet, &types.Commit{Height: h})
+		sm.SaveABCIResponses(stateDB, h, sm.NewABCIResponses(block))
+		for i, tx := range block.Txs {
+			err := txIndexer.Index(&types.TxResult{
+				Height: h,
+				Index:  uint32(i),
+				Tx:     tx,
+				Result: abci.ResponseDeliverTx{Code: abci.CodeTypeOK},
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	pruner := sm.NewPruner(stateDB, blockStore, txIndexer)
+
+	// nothing to prune below the base
+	pruned, err := pruner.Prune(1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, pruned)
+
+	pruned, err = pruner.Prune(11)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, pruned)
+	assert.EqualValues(t, 11, blockStore.Base())
+
+	for h := int64(1); h <= height; h++ {
+		_, abciErr := sm.LoadABCIResponses(stateDB, h)
+		for _, tx := range makeTxs(h) {
+			result, err := txIndexer.Get(tx.Hash())
+			require.NoError(t, err)
+			if h < 11 {
+				assert.Nil(t, result, "tx at height %v", h)
+			} else {
+				assert.NotNil(t, result, "tx at height %v", h)
+			}
+		}
+		if h < 11 {
+			assert.Nil(t, blockStore.LoadBlock(h), "block at height %v", h)
+			assert.Error(t, abciErr, "ABCI responses at height %v", h)
+		} else {
+			assert.NotNil(t, blockStore.LoadBlock(h), "block at height %v", h)
+			assert.NoError(t, abciErr, "ABCI responses at height %v", h)
+		}
+	}
+
+	// the retain height only moves forward
+	pruner.SetRetainHeight(15)
+	pruner.SetRetainHeight(12)
+	assert.EqualValues(t, 15, pruner.RetainHeight())
+}
@@ -25,6 +25,14 @@ type TxIndexer interface {
 	Search(ctx context.Context, q *query.Query) ([]*types.TxResult, error)
 }
 
+// Pruner is implemented by TxIndexers which can delete indexed transactions, so that the tx index
+// can be pruned together with the block store. Indexers which don't implement it are never pruned.
+type Pruner interface {
+	// Prune deletes the index entries of the given transactions, included in the block at the
+	// given height, and returns the number of deleted transactions.
+	Prune(height int64, txs types.Txs) (uint64, error)
+}
+
 //----------------------------------------------------
 // Txs are written as a batch
 
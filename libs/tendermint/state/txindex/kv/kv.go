@@ -24,6 +24,7 @@ const (
 )
 
 var _ txindex.TxIndexer = (*TxIndex)(nil)
+var _ txindex.Pruner = (*TxIndex)(nil)
 
 // TxIndex is the simplest possible indexer, backed by key-value storage (levelDB).
 type TxIndex struct {
@@ -141,6 +142,14 @@ func (txi *TxIndex) Index(result *types.TxResult) error {
 }
 
 func (txi *TxIndex) indexEvents(result *types.TxResult, hash []byte, store dbm.SetDeleter) {
+	for _, key := range txi.eventKeys(result) {
+		store.Set(key, hash)
+	}
+}
+
+// eventKeys returns the keys under which the events of the given transaction are indexed.
+func (txi *TxIndex) eventKeys(result *types.TxResult) [][]byte {
+	var keys [][]byte
 	for _, event := range result.Result.Events {
 		// only index events with a non-empty type
 		if len(event.Type) == 0 {
@@ -154,10 +163,42 @@ func (txi *TxIndex) indexEvents(result *types.TxResult, hash []byte, store dbm.S
 
 			compositeTag := fmt.Sprintf("%s.%s", event.Type, string(attr.Key))
 			if txi.indexAllEvents || tmstring.StringInSlice(compositeTag, txi.compositeKeysToIndex) {
-				store.Set(keyForEvent(compositeTag, attr.Value, result), hash)
+				keys = append(keys, keyForEvent(compositeTag, attr.Value, result))
 			}
 		}
 	}
+	return keys
+}
+
+// Prune deletes the index entries of the given transactions, included in the block at the given
+// height. Transactions which are not indexed, or were indexed again at another height, are skipped.
+func (txi *TxIndex) Prune(height int64, txs types.Txs) (uint64, error) {
+	b := txi.store.NewBatch()
+	defer b.Close()
+
+	pruned := uint64(0)
+	for _, tx := range txs {
+		hash := tx.Hash()
+		result, err := txi.Get(hash)
+		if err != nil {
+			return 0, err
+		}
+		if result == nil || result.Height != height {
+			continue
+		}
+
+		for _, key := range txi.eventKeys(result) {
+			b.Delete(key)
+		}
+		b.Delete(keyForHeight(result))
+		b.Delete(hash)
+		pruned++
+	}
+
+	if err := b.WriteSync(); err != nil {
+		return 0, err
+	}
+	return pruned, nil
 }
 
 // Search performs a search using the given query.
@@ -302,6 +302,57 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxIndexPrune(t *testing.T) {
+	store := db.NewMemDB()
+	indexer := NewTxIndex(store, IndexAllEvents())
+
+	txResult := txResultWithEvents([]abci.Event{
+		{Type: "account", Attributes: []kv.Pair{{Key: []byte("number"), Value: []byte("1")}}},
+	})
+	err := indexer.Index(txResult)
+	require.NoError(t, err)
+
+	txResult2 := txResultWithEvents([]abci.Event{
+		{Type: "account", Attributes: []kv.Pair{{Key: []byte("number"), Value: []byte("2")}}},
+	})
+	txResult2.Tx = types.Tx("BYE BYE WORLD")
+	txResult2.Height = 2
+	err = indexer.Index(txResult2)
+	require.NoError(t, err)
+
+	// pruning a tx at another height than it was indexed at is a noop
+	pruned, err := indexer.Prune(3, types.Txs{txResult2.Tx})
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, pruned)
+
+	pruned, err = indexer.Prune(1, types.Txs{txResult.Tx, types.Tx("NOT INDEXED")})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, pruned)
+
+	loaded, err := indexer.Get(txResult.Tx.Hash())
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	results, err := indexer.Search(context.Background(), query.MustParse("account.number >= 1"))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, txResult2, results[0])
+
+	results, err = indexer.Search(context.Background(), query.MustParse("tx.height = 1"))
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// only the index entries of the remaining tx are left: its hash, height and event keys
+	iter, err := store.Iterator(nil, nil)
+	require.NoError(t, err)
+	defer iter.Close()
+	keys := 0
+	for ; iter.Valid(); iter.Next() {
+		keys++
+	}
+	assert.Equal(t, 3, keys)
+}
+
 func txResultWithEvents(events []abci.Event) *types.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &types.TxResult{
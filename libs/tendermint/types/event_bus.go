@@ -190,6 +190,10 @@ func (b *EventBus) PublishEventPendingTx(data EventDataTx) error {
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
 
+func (b *EventBus) PublishEventRmPendingTx(data EventDataRmPendingTx) error {
+	return b.Publish(EventRmPendingTx, data)
+}
+
 func (b *EventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
 	return b.Publish(EventNewRoundStep, data)
 }
@@ -270,6 +274,10 @@ func (NopEventBus) PublishEventPendingTx(data EventDataTx) error {
 	return nil
 }
 
+func (NopEventBus) PublishEventRmPendingTx(data EventDataRmPendingTx) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
 	return nil
 }
@@ -21,6 +21,7 @@ const (
 	EventNewBlockHeader      = "NewBlockHeader"
 	EventTx                  = "Tx"
 	EventPendingTx           = "PendingTx"
+	EventRmPendingTx         = "RmPendingTx"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
 
 	// Internal consensus events.
@@ -53,6 +54,7 @@ func RegisterEventDatas(cdc *amino.Codec) {
 	cdc.RegisterConcrete(EventDataNewBlock{}, "tendermint/event/NewBlock", nil)
 	cdc.RegisterConcrete(EventDataNewBlockHeader{}, "tendermint/event/NewBlockHeader", nil)
 	cdc.RegisterConcrete(EventDataTx{}, "tendermint/event/Tx", nil)
+	cdc.RegisterConcrete(EventDataRmPendingTx{}, "tendermint/event/RmPendingTx", nil)
 	cdc.RegisterConcrete(EventDataRoundState{}, "tendermint/event/RoundState", nil)
 	cdc.RegisterConcrete(EventDataNewRound{}, "tendermint/event/NewRound", nil)
 	cdc.RegisterConcrete(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal", nil)
@@ -84,6 +86,21 @@ type EventDataTx struct {
 	TxResult
 }
 
+// Reasons of a tx removed from the mempool before it is included in a block
+const (
+	RmPendingTxDropped = iota
+	RmPendingTxReplaced
+)
+
+// EventDataRmPendingTx is fired when a pending tx leaves the mempool without being
+// included in a block, either dropped or replaced by a tx with the same nonce.
+type EventDataRmPendingTx struct {
+	Hash   []byte `json:"hash"`
+	From   string `json:"from"`
+	Nonce  uint64 `json:"nonce"`
+	Reason int    `json:"reason"`
+}
+
 // NOTE: This goes into the replay WAL
 type EventDataRoundState struct {
 	Height int64  `json:"height"`
@@ -169,10 +186,12 @@ type BlockEventPublisher interface {
 	PublishEventNewBlockHeader(header EventDataNewBlockHeader) error
 	PublishEventTx(EventDataTx) error
 	PublishEventPendingTx(EventDataTx) error
+	PublishEventRmPendingTx(EventDataRmPendingTx) error
 	PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates) error
 }
 
 type TxEventPublisher interface {
 	PublishEventTx(EventDataTx) error
 	PublishEventPendingTx(EventDataTx) error
+	PublishEventRmPendingTx(EventDataRmPendingTx) error
 }
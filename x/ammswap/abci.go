@@ -4,9 +4,13 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
-// BeginBlocker check for infraction evidence or downtime of validators
-// on every begin block
+// BeginBlocker accumulates the prices of the swap token pairs at the end of the previous block
 func BeginBlocker(ctx sdk.Context, k Keeper) {
+	// the prices are accumulated after the venus height, so the blocks before it don't write the state
+	if !sdk.HigherThanVenus(ctx.BlockHeight()) {
+		return
+	}
+	k.UpdatePriceCumulatives(ctx)
 }
 
 // EndBlocker called every block, process inflation, update validator set.
@@ -32,6 +32,8 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			GetCmdAllSwapTokenPairs(queryRoute, cdc),
 			GetCmdRedeemableAssets(queryRoute, cdc),
 			GetCmdQueryBuyAmount(queryRoute, cdc),
+			GetCmdQueryRoute(queryRoute, cdc),
+			GetCmdQueryTWAP(queryRoute, cdc),
 		)...,
 	)
 
@@ -109,6 +111,76 @@ $ %s query swap amount 100eth-245 xxb`, version.ClientName,
 	}
 }
 
+// GetCmdQueryRoute queries the route which buys the most by the given amount of token to sell
+func GetCmdQueryRoute(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var maxHops int
+	cmd := &cobra.Command{
+		Use:   "route [token-to-sell] [token-name-to-buy]",
+		Short: "Query the route which buys the most by the given amount of token to sell",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the route which buys the most across all the pools, with the price impact and the fees.
+
+Example:
+$ %s query swap route 100eth-245 xxb --max-hops 3`, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			params := types.NewQuerySwapRouteParams(args[0], args[1], maxHops)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QuerySwapRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&maxHops, "max-hops", types.MaxSwapHops, "the max number of pools the route goes through")
+	return cmd
+}
+
+// GetCmdQueryTWAP queries the time weighted average prices of a pool
+func GetCmdQueryTWAP(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var window int64
+	cmd := &cobra.Command{
+		Use:   "twap [base-token] [quote-token]",
+		Short: "Query the time weighted average prices of a pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the time weighted average prices of a pool in the window of seconds before its last update.
+
+Example:
+$ %s query swap twap eth-245 xxb --window 3600`, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			params := types.NewQueryTWAPParams(types.GetSwapTokenPairName(args[0], args[1]), window)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryTWAP), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().Int64Var(&window, "window", 3600, "the window of the twap in seconds")
+	return cmd
+}
+
 // GetCmdQueryParams queries the parameters of the AMM swap system
 func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -30,6 +30,11 @@ const (
 	flagRecipient        = "recipient"
 	flagToken0           = "token0"
 	flagToken1           = "token1"
+	flagCurveType        = "curve-type"
+	flagFeeRate          = "fee-rate"
+	flagAmplification    = "amplification"
+	flagToken0Weight     = "token0-weight"
+	flagPath             = "path"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -163,14 +168,21 @@ func getCmdCreateExchange(cdc *codec.Codec) *cobra.Command {
 	// flags
 	var token0 string
 	var token1 string
+	var curveType string
+	var feeRate string
+	var amplification int64
+	var token0Weight string
 	cmd := &cobra.Command{
 		Use:   "create-pair",
 		Short: "create token pair",
 		Long: strings.TrimSpace(
-			fmt.Sprintf(`create token pair.
+			fmt.Sprintf(`create token pair. The pool prices on the constant product curve by default, the pegged tokens
+may use the STABLE_SWAP curve with an amplification, and the WEIGHTED curve takes the weight of token0.
 
 Example:
 $ exchaincli tx swap create-pair --token0 eth-355 --token1 btc-366 --fees 0.01okt 
+$ exchaincli tx swap create-pair --token0 usdk-017 --token1 usdt-a2b --curve-type STABLE_SWAP --amplification 100 --fee-rate 0.0004 --fees 0.01okt
+$ exchaincli tx swap create-pair --token0 eth-355 --token1 usdk-017 --curve-type WEIGHTED --token0-weight 0.8 --fees 0.01okt
 
 `),
 		),
@@ -180,6 +192,22 @@ $ exchaincli tx swap create-pair --token0 eth-355 --token1 btc-366 --fees 0.01ok
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
 			msg := types.NewMsgCreateExchange(token0, token1, cliCtx.FromAddress)
+			msg.CurveType = strings.ToUpper(curveType)
+			msg.Amplification = amplification
+			if feeRate != "" {
+				feeRateDec, err := sdk.NewDecFromStr(feeRate)
+				if err != nil {
+					return err
+				}
+				msg.FeeRate = feeRateDec
+			}
+			if token0Weight != "" {
+				token0WeightDec, err := sdk.NewDecFromStr(token0Weight)
+				if err != nil {
+					return err
+				}
+				msg.Token0Weight = token0WeightDec
+			}
 
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
 		},
@@ -187,6 +215,10 @@ $ exchaincli tx swap create-pair --token0 eth-355 --token1 btc-366 --fees 0.01ok
 
 	cmd.Flags().StringVar(&token0, flagToken0, "", "the base token name is required to create an AMM swap pair")
 	cmd.Flags().StringVar(&token1, flagToken1, "", "the quote token name is required to create an AMM swap pair")
+	cmd.Flags().StringVar(&curveType, flagCurveType, "", "the pricing curve of the pool: CONSTANT_PRODUCT|STABLE_SWAP|WEIGHTED")
+	cmd.Flags().StringVar(&feeRate, flagFeeRate, "", "the swap fee rate of the pool, the fee rate of params if it's not set")
+	cmd.Flags().Int64Var(&amplification, flagAmplification, 0, "the amplification coefficient of the STABLE_SWAP pool")
+	cmd.Flags().StringVar(&token0Weight, flagToken0Weight, "", "the weight of token0 in the WEIGHTED pool, e.g. 0.8 for 80/20")
 	cmd.MarkFlagRequired(flagToken0)
 	cmd.MarkFlagRequired(flagToken1)
 	return cmd
@@ -198,14 +230,17 @@ func getCmdTokenSwap(cdc *codec.Codec) *cobra.Command {
 	var minBoughtTokenAmount string
 	var deadline string
 	var recipient string
+	var path []string
 	cmd := &cobra.Command{
 		Use:   "token",
 		Short: "swap token",
 		Long: strings.TrimSpace(
-			fmt.Sprintf(`swap token.
+			fmt.Sprintf(`swap token. The swap goes through the pool of the two tokens, or through the native token if
+the pool doesn't exist, or through the intermediate tokens of --path.
 
 Example:
 $ exchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
+$ exchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366 --path okt,usdk-017
 
 `),
 		),
@@ -239,6 +274,7 @@ $ exchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
 
 			msg := types.NewMsgTokenToToken(soldTokenAmount, minBoughtTokenAmount,
 				deadline, recip, cliCtx.FromAddress)
+			msg.Path = path
 
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
 		},
@@ -250,6 +286,7 @@ $ exchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
 		"Minimum amount expected to buy")
 	cmd.Flags().StringVarP(&recipient, flagRecipient, "", "",
 		"The address to receive the amount bought")
+	cmd.Flags().StringSliceVar(&path, flagPath, nil, "The intermediate tokens to route through, in order")
 	cmd.Flags().StringVarP(&deadline, flagDeadlineDuration, "", "100s",
 		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
 	cmd.MarkFlagRequired(flagSellAmount)
@@ -31,6 +31,9 @@ func ValidateGenesis(data GenesisState) error {
 		if !tokentypes.NotAllowedOriginSymbol(record.PoolTokenName) {
 			return fmt.Errorf("invalid SwapTokenPairRecord: PoolToken: %s. Error: invalid PoolToken", record.PoolTokenName)
 		}
+		if err := record.ValidateCurve(); err != nil {
+			return fmt.Errorf("invalid SwapTokenPairRecord: %s. Error: %s", record.TokenPairName(), err)
+		}
 	}
 	return nil
 }
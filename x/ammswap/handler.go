@@ -1,6 +1,8 @@
 package ammswap
 
 import (
+	"strings"
+
 	"github.com/okex/exchain/x/ammswap/keeper"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/okex/exchain/x/common"
@@ -49,9 +51,14 @@ func NewHandler(k Keeper) sdk.Handler {
 }
 
 func handleMsgTokenToToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) (*sdk.Result, error) {
+	if len(msg.Path) > 0 {
+		return swapTokenByRouter(ctx, k, msg, msg.GetPath())
+	}
 	_, err := k.GetSwapTokenPair(ctx, msg.GetSwapTokenPairName())
 	if err != nil {
-		return swapTokenByRouter(ctx, k, msg)
+		// route through the native token by default
+		path := []string{msg.SoldTokenAmount.Denom, sdk.DefaultBondDenom, msg.MinBoughtTokenAmount.Denom}
+		return swapTokenByRouter(ctx, k, msg, path)
 	} else {
 		return swapToken(ctx, k, msg)
 	}
@@ -90,6 +97,7 @@ func handleMsgCreateExchange(ctx sdk.Context, k Keeper, msg types.MsgCreateExcha
 
 	// 4. create the token pair
 	swapTokenPair := types.NewSwapPair(msg.Token0Name, msg.Token1Name)
+	swapTokenPair.SetCurve(msg.CurveType, msg.FeeRate, msg.Amplification, msg.GetBaseWeight())
 	k.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
 
 	// 5. notify backend module
@@ -97,6 +105,7 @@ func handleMsgCreateExchange(ctx sdk.Context, k Keeper, msg types.MsgCreateExcha
 
 	event = event.AppendAttributes(sdk.NewAttribute("pool-token-name", poolTokenName))
 	event = event.AppendAttributes(sdk.NewAttribute("token-pair", tokenPairName))
+	event = event.AppendAttributes(sdk.NewAttribute("curve-type", swapTokenPair.GetCurveType()))
 	ctx.EventManager().EmitEvent(event)
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
@@ -118,7 +127,9 @@ func handleMsgAddLiquidity(ctx sdk.Context, k Keeper, msg types.MsgAddLiquidity)
 	}
 	if swapTokenPair.QuotePooledCoin.Amount.IsZero() && swapTokenPair.BasePooledCoin.Amount.IsZero() {
 		baseTokens.Amount = msg.MaxBaseAmount.Amount
-		liquidity = sdk.NewDec(1)
+		// no fee is charged on the deposit
+		curve := keeper.GetCurve(swapTokenPair, baseTokens.Denom, sdk.ZeroDec())
+		liquidity = curve.GetInitialLiquidity(baseTokens.Amount, msg.QuoteAmount.Amount)
 	} else if swapTokenPair.BasePooledCoin.IsPositive() && swapTokenPair.QuotePooledCoin.IsPositive() {
 		baseTokens.Amount = common.MulAndQuo(msg.QuoteAmount.Amount, swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount)
 		totalSupply := k.GetPoolTokenAmount(ctx, swapTokenPair.PoolTokenName)
@@ -189,6 +200,7 @@ func handleMsgRemoveLiquidity(ctx sdk.Context, k Keeper, msg types.MsgRemoveLiqu
 		return types.ErrLessThan("pool token amount", "liquidity").Result()
 	}
 
+	// the redemption is proportional to the pooled coins on all the curves, which keeps the spot price
 	baseDec := common.MulAndQuo(swapTokenPair.BasePooledCoin.Amount, liquidity, poolTokenAmount)
 	quoteDec := common.MulAndQuo(swapTokenPair.QuotePooledCoin.Amount, liquidity, poolTokenAmount)
 
@@ -266,7 +278,7 @@ func swapToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) (*sdk.Resul
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
-func swapTokenByRouter(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) (*sdk.Result, error) {
+func swapTokenByRouter(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken, path []string) (*sdk.Result, error) {
 	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
 
 	if msg.Deadline < ctx.BlockTime().Unix() {
@@ -276,54 +288,44 @@ func swapTokenByRouter(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) (*s
 		sdk.SysCoins{msg.SoldTokenAmount}); err != nil {
 		return common.ErrInsufficientCoins(DefaultParamspace, err.Error()).Result()
 	}
-	tokenPairOne := types.GetSwapTokenPairName(msg.SoldTokenAmount.Denom, sdk.DefaultBondDenom)
-	swapTokenPairOne, err := k.GetSwapTokenPair(ctx, tokenPairOne)
-	if err != nil {
-		return nil, types.ErrNonExistSwapTokenPair(msg.GetSwapTokenPairName())
-	}
-	if swapTokenPairOne.BasePooledCoin.IsZero() || swapTokenPairOne.QuotePooledCoin.IsZero() {
-		return types.ErrIsZeroValue("base pooled coin or quote pooled coin").Result()
-	}
-	tokenPairTwo := types.GetSwapTokenPairName(msg.MinBoughtTokenAmount.Denom, sdk.DefaultBondDenom)
-	swapTokenPairTwo, err := k.GetSwapTokenPair(ctx, tokenPairTwo)
+	swapTokenPairs, err := k.GetSwapTokenPairsOfPath(ctx, path)
 	if err != nil {
-		return nil, types.ErrNonExistSwapTokenPair(msg.GetSwapTokenPairName())
-	}
-	if swapTokenPairTwo.BasePooledCoin.IsZero() || swapTokenPairTwo.QuotePooledCoin.IsZero() {
-		return types.ErrIsZeroValue("base pooled coin or quote pooled coin").Result()
+		return nil, err
 	}
 
-	nativeAmount := sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.MustNewDecFromStr("0"))
 	params := k.GetParams(ctx)
-	msgOne := msg
-	msgOne.MinBoughtTokenAmount = nativeAmount
-	tokenNative := keeper.CalculateTokenToBuy(swapTokenPairOne, msgOne.SoldTokenAmount, msgOne.MinBoughtTokenAmount.Denom, params)
-	if tokenNative.IsZero() {
-		return types.ErrIsZeroValue("token native").Result()
-	}
-	msgTwo := msg
-	msgTwo.SoldTokenAmount = tokenNative
-	tokenBuy := keeper.CalculateTokenToBuy(swapTokenPairTwo, msgTwo.SoldTokenAmount, msgTwo.MinBoughtTokenAmount.Denom, params)
+	tokenBuys := keeper.CalculateTokenToBuyByPath(swapTokenPairs, msg.SoldTokenAmount, path, params)
 	// sanity check. user may set MinBoughtTokenAmount to zero on front end.
 	// if set zero,this will not return err
-	if tokenBuy.IsZero() {
-		return types.ErrIsZeroValue("token to buy amount").Result()
+	for _, tokenBuy := range tokenBuys {
+		if tokenBuy.IsZero() {
+			return types.ErrIsZeroValue("token to buy amount").Result()
+		}
 	}
+	tokenBuy := tokenBuys[len(tokenBuys)-1]
 	if tokenBuy.Amount.LT(msg.MinBoughtTokenAmount.Amount) {
 		return types.ErrLessThan("token buy amount", "min bought token amount").Result()
 	}
 
-	res, err := swapTokenNativeToken(ctx, k, swapTokenPairOne, tokenNative, msgOne)
+	// transfer coins, the intermediate tokens stay in the pools
+	err = k.SendCoinsToPool(ctx, sdk.SysCoins{msg.SoldTokenAmount}, msg.Sender)
 	if err != nil {
-		return res, err
+		return types.ErrSendCoinsToPoolFailed(err.Error()).Result()
 	}
-	res, err = swapTokenNativeToken(ctx, k, swapTokenPairTwo, tokenBuy, msgTwo)
+	err = k.SendCoinsFromPoolToAccount(ctx, sdk.SysCoins{tokenBuy}, msg.Recipient)
 	if err != nil {
-		return res, err
+		return types.ErrSendCoinsFromPoolToAccountFailed(err.Error()).Result()
+	}
+
+	soldToken := msg.SoldTokenAmount
+	for i, swapTokenPair := range swapTokenPairs {
+		updateSwapTokenPair(ctx, k, swapTokenPair, soldToken, tokenBuys[i], msg.Recipient)
+		soldToken = tokenBuys[i]
 	}
 
 	event.AppendAttributes(sdk.NewAttribute("bought_token_amount", tokenBuy.String()))
 	event.AppendAttributes(sdk.NewAttribute("recipient", msg.Recipient.String()))
+	event.AppendAttributes(sdk.NewAttribute("path", strings.Join(path, ",")))
 	ctx.EventManager().EmitEvent(event)
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
@@ -343,17 +345,22 @@ func swapTokenNativeToken(
 		return types.ErrSendCoinsFromPoolToAccountFailed(err.Error()).Result()
 	}
 
-	// update swapTokenPair
-	if msg.MinBoughtTokenAmount.Denom < msg.SoldTokenAmount.Denom {
-		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(msg.SoldTokenAmount)
+	updateSwapTokenPair(ctx, k, swapTokenPair, msg.SoldTokenAmount, tokenBuy, msg.Recipient)
+	return &sdk.Result{}, nil
+}
+
+// updateSwapTokenPair updates the pooled coins of the swap token pair after the sold token is swapped for tokenBuy
+func updateSwapTokenPair(ctx sdk.Context, k Keeper, swapTokenPair SwapTokenPair, soldToken, tokenBuy sdk.SysCoin,
+	recipient sdk.AccAddress) {
+	if tokenBuy.Denom < soldToken.Denom {
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(soldToken)
 		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(tokenBuy)
 	} else {
 		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(tokenBuy)
-		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(msg.SoldTokenAmount)
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(soldToken)
 	}
-	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPairName(), swapTokenPair)
-	k.OnSwapToken(ctx, msg.Recipient, swapTokenPair, msg.SoldTokenAmount, tokenBuy)
-	return &sdk.Result{}, nil
+	k.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+	k.OnSwapToken(ctx, recipient, swapTokenPair, soldToken, tokenBuy)
 }
 
 func coinSort(coins sdk.SysCoins) sdk.SysCoins {
@@ -454,6 +454,122 @@ func TestHandleMsgTokenToTokenDirectly(t *testing.T) {
 	}
 }
 
+func TestHandleMsgTokenToTokenByPath(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	swapKeeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(swapKeeper)
+	addr := addrKeysSlice[0].Address
+	for _, symbol := range []string{types.TestBasePooledToken, types.TestBasePooledToken2, types.TestBasePooledToken3,
+		types.TestQuotePooledToken} {
+		mapp.tokenKeeper.NewToken(ctx, token.InitTestToken(symbol))
+	}
+
+	// pools of aab_okt, ccb_okt and ccb_ddb
+	pools := [][2]string{
+		{types.TestBasePooledToken, types.TestQuotePooledToken},
+		{types.TestBasePooledToken2, types.TestQuotePooledToken},
+		{types.TestBasePooledToken2, types.TestBasePooledToken3},
+	}
+	for _, pool := range pools {
+		_, err := handler(ctx, types.NewMsgCreateExchange(pool[0], pool[1], addr))
+		require.Nil(t, err)
+		_, err = handler(ctx, types.NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(pool[0], sdk.NewDec(10000)),
+			sdk.NewDecCoinFromDec(pool[1], sdk.NewDec(10000)), time.Now().Unix(), addr))
+		require.Nil(t, err)
+	}
+
+	soldTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100))
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken3, sdk.NewDec(90))
+	msg := types.NewMsgTokenToToken(soldTokenAmount, minBoughtTokenAmount, time.Now().Unix(), addr, addr)
+
+	// the path doesn't exist
+	msg.Path = []string{types.TestBasePooledToken2}
+	_, err := handler(ctx, msg)
+	require.NotNil(t, err)
+
+	// aab -> okt -> ccb -> ddb
+	msg.Path = []string{types.TestQuotePooledToken, types.TestBasePooledToken2}
+	swapTokenPairs, err := swapKeeper.GetSwapTokenPairsOfPath(ctx, msg.GetPath())
+	require.Nil(t, err)
+	tokenBuys := keeper.CalculateTokenToBuyByPath(swapTokenPairs, soldTokenAmount, msg.GetPath(), swapKeeper.GetParams(ctx))
+	_, err = handler(ctx, msg)
+	require.Nil(t, err)
+
+	acc := mapp.AccountKeeper.GetAccount(ctx, addr)
+	require.Equal(t, sdk.NewDec(89900), acc.GetCoins().AmountOf(types.TestBasePooledToken))
+	require.Equal(t, sdk.NewDec(80000), acc.GetCoins().AmountOf(types.TestBasePooledToken2))
+	require.Equal(t, sdk.NewDec(90000).Add(tokenBuys[2].Amount), acc.GetCoins().AmountOf(types.TestBasePooledToken3))
+	require.Equal(t, sdk.NewDec(80000), acc.GetCoins().AmountOf(types.TestQuotePooledToken))
+
+	// every pool of the path is updated with the token sold and bought at its hop
+	soldToken := soldTokenAmount
+	for i, swapTokenPair := range swapTokenPairs {
+		updated, err := swapKeeper.GetSwapTokenPair(ctx, swapTokenPair.TokenPairName())
+		require.Nil(t, err)
+		pooled := sdk.NewDecCoinsFromDec(updated.BasePooledCoin.Denom, updated.BasePooledCoin.Amount).
+			Add(sdk.NewDecCoinFromDec(updated.QuotePooledCoin.Denom, updated.QuotePooledCoin.Amount))
+		require.Equal(t, sdk.NewDec(10000).Add(soldToken.Amount), pooled.AmountOf(soldToken.Denom))
+		require.Equal(t, sdk.NewDec(10000).Sub(tokenBuys[i].Amount), pooled.AmountOf(tokenBuys[i].Denom))
+		soldToken = tokenBuys[i]
+	}
+}
+
+func TestHandleMsgTokenToTokenStableSwap(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	testToken := token.InitTestToken(types.TestBasePooledToken)
+	secondTestToken := token.InitTestToken(types.TestBasePooledToken2)
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	mapp.tokenKeeper.NewToken(ctx, testToken)
+	mapp.tokenKeeper.NewToken(ctx, secondTestToken)
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+
+	msgCreateExchange := types.NewMsgCreateExchange(testToken.Symbol, secondTestToken.Symbol, addr)
+	msgCreateExchange.CurveType = types.CurveTypeStableSwap
+	msgCreateExchange.Amplification = 100
+	msgCreateExchange.FeeRate = sdk.NewDecWithPrec(4, 4)
+	_, err := handler(ctx, msgCreateExchange)
+	require.Nil(t, err)
+	swapTokenPair, err := keeper.GetSwapTokenPair(ctx, msgCreateExchange.GetSwapTokenPairName())
+	require.Nil(t, err)
+	require.Equal(t, types.CurveTypeStableSwap, swapTokenPair.GetCurveType())
+	require.Equal(t, sdk.NewDecWithPrec(4, 4), swapTokenPair.GetFeeRate(sdk.ZeroDec()))
+
+	// the first deposit mints the invariant of the stable swap curve
+	addLiquidityMsg := types.NewMsgAddLiquidity(sdk.ZeroDec(), sdk.NewDecCoinFromDec(testToken.Symbol, sdk.NewDec(10000)),
+		sdk.NewDecCoinFromDec(secondTestToken.Symbol, sdk.NewDec(10000)), time.Now().Unix(), addr)
+	_, err = handler(ctx, addLiquidityMsg)
+	require.Nil(t, err)
+	poolTokenAmount := keeper.GetPoolTokenAmount(ctx, swapTokenPair.PoolTokenName)
+	require.True(t, poolTokenAmount.Sub(sdk.NewDec(20000)).Abs().LT(sdk.NewDecWithPrec(1, 9)))
+
+	// swapping 1% of the pool of the pegged tokens barely slips
+	soldTokenAmount := sdk.NewDecCoinFromDec(testToken.Symbol, sdk.NewDec(100))
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(secondTestToken.Symbol, sdk.MustNewDecFromStr("99.9"))
+	swapMsg := types.NewMsgTokenToToken(soldTokenAmount, minBoughtTokenAmount, time.Now().Unix(), addr, addr)
+	_, err = handler(ctx, swapMsg)
+	require.Nil(t, err)
+	swapTokenPair, err = keeper.GetSwapTokenPair(ctx, msgCreateExchange.GetSwapTokenPairName())
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(10100), swapTokenPair.BasePooledCoin.Amount)
+	require.True(t, swapTokenPair.QuotePooledCoin.Amount.LT(sdk.MustNewDecFromStr("9900.1")))
+
+	// the liquidity is removed in proportion
+	removeLiquidityMsg := types.NewMsgRemoveLiquidity(poolTokenAmount.QuoInt64(2),
+		sdk.NewDecCoinFromDec(testToken.Symbol, sdk.NewDec(5050)),
+		sdk.NewDecCoinFromDec(secondTestToken.Symbol, sdk.NewDec(4950)), time.Now().Unix(), addr)
+	_, err = handler(ctx, removeLiquidityMsg)
+	require.Nil(t, err)
+}
+
 func TestGetInputPrice(t *testing.T) {
 	tests := []struct {
 		testCase           string
@@ -566,3 +682,45 @@ func buildRandomMsgTokenToToken(addr sdk.AccAddress) types.MsgTokenToToken {
 
 	return msg
 }
+
+func TestHandleMsgCreateExchangeDecodedFeeRate(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	params := types.DefaultParams()
+	keeper.SetParams(ctx, params)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	testToken := token.InitTestToken(types.TestBasePooledToken)
+	testToken2 := token.InitTestToken(types.TestBasePooledToken2)
+	testQuoteToken := token.InitTestToken(types.TestQuotePooledToken)
+	mapp.tokenKeeper.NewToken(ctx, testToken)
+	mapp.tokenKeeper.NewToken(ctx, testToken2)
+	mapp.tokenKeeper.NewToken(ctx, testQuoteToken)
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+
+	decode := func(msg sdk.Msg) sdk.Msg {
+		var decoded sdk.Msg
+		mapp.Cdc.MustUnmarshalBinaryLengthPrefixed(mapp.Cdc.MustMarshalBinaryLengthPrefixed(msg), &decoded)
+		return decoded
+	}
+
+	// the pool created without a fee rate takes the fee rate of params
+	msg := types.NewMsgCreateExchange(testToken.Symbol, types.TestQuotePooledToken, addr)
+	_, err := handler(ctx, decode(msg))
+	require.Nil(t, err)
+	swapTokenPair, err := keeper.GetSwapTokenPair(ctx, msg.GetSwapTokenPairName())
+	require.Nil(t, err)
+	require.Empty(t, swapTokenPair.FeeRate)
+	require.Equal(t, params.FeeRate, swapTokenPair.GetFeeRate(params.FeeRate))
+
+	// the fee rate set on the msg is kept through the codec
+	msg = types.NewMsgCreateExchange(testToken2.Symbol, types.TestQuotePooledToken, addr)
+	msg.FeeRate = sdk.NewDecWithPrec(1, 3)
+	_, err = handler(ctx, decode(msg))
+	require.Nil(t, err)
+	swapTokenPair, err = keeper.GetSwapTokenPair(ctx, msg.GetSwapTokenPairName())
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDecWithPrec(1, 3), swapTokenPair.GetFeeRate(params.FeeRate))
+}
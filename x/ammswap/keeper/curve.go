@@ -0,0 +1,207 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+)
+
+const (
+	// maxCurveIterations bounds the newton iterations of the stable swap curve and the power series of the weighted one
+	maxCurveIterations = 255
+)
+
+var (
+	// powPrecision is the precision of the power series of the weighted curve
+	powPrecision = sdk.NewDecWithPrec(1, 10)
+	// maxInRatio is the max ratio of the input amount to the input reserve in the weighted pool
+	maxInRatio = sdk.NewDecWithPrec(5, 1)
+)
+
+// Curve is the pricing function of a swap token pair, from the input token to the output token
+type Curve interface {
+	// GetOutputAmount returns the amount of the output token bought by the input amount, the fee is charged on input
+	GetOutputAmount(inputAmount, inputReserve, outputReserve sdk.Dec) sdk.Dec
+	// GetSpotPrice returns the marginal price of the input token in the output token without fee
+	GetSpotPrice(inputReserve, outputReserve sdk.Dec) sdk.Dec
+	// GetInitialLiquidity returns the amount of pool token minted by the first deposit
+	GetInitialLiquidity(inputAmount, outputAmount sdk.Dec) sdk.Dec
+}
+
+// GetCurve returns the curve of the swap token pair which sells the input token, the default fee rate applies
+// if the swap token pair has no fee rate of its own
+func GetCurve(swapTokenPair types.SwapTokenPair, inputDenom string, defaultFeeRate sdk.Dec) Curve {
+	feeRate := swapTokenPair.GetFeeRate(defaultFeeRate)
+	switch swapTokenPair.GetCurveType() {
+	case types.CurveTypeStableSwap:
+		return stableSwapCurve{feeRate: feeRate, amplification: swapTokenPair.Amplification}
+	case types.CurveTypeWeighted:
+		baseWeight, quoteWeight := swapTokenPair.GetWeights()
+		if inputDenom == swapTokenPair.BasePooledCoin.Denom {
+			return weightedCurve{feeRate: feeRate, inputWeight: baseWeight, outputWeight: quoteWeight}
+		}
+		return weightedCurve{feeRate: feeRate, inputWeight: quoteWeight, outputWeight: baseWeight}
+	default:
+		return constantProductCurve{feeRate: feeRate}
+	}
+}
+
+// GetSpotPrice returns the marginal price of the sell token in the other token of the swap token pair
+func GetSpotPrice(swapTokenPair types.SwapTokenPair, sellTokenDenom string, params types.Params) sdk.Dec {
+	inputReserve, outputReserve := swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount
+	if sellTokenDenom != swapTokenPair.BasePooledCoin.Denom {
+		inputReserve, outputReserve = outputReserve, inputReserve
+	}
+	if !inputReserve.IsPositive() || !outputReserve.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	return GetCurve(swapTokenPair, sellTokenDenom, params.FeeRate).GetSpotPrice(inputReserve, outputReserve)
+}
+
+// constantProductCurve is the x*y=k curve
+type constantProductCurve struct {
+	feeRate sdk.Dec
+}
+
+func (c constantProductCurve) GetOutputAmount(inputAmount, inputReserve, outputReserve sdk.Dec) sdk.Dec {
+	return GetInputPrice(inputAmount, inputReserve, outputReserve, c.feeRate)
+}
+
+func (c constantProductCurve) GetSpotPrice(inputReserve, outputReserve sdk.Dec) sdk.Dec {
+	return outputReserve.Quo(inputReserve)
+}
+
+func (c constantProductCurve) GetInitialLiquidity(inputAmount, outputAmount sdk.Dec) sdk.Dec {
+	return sdk.OneDec()
+}
+
+// stableSwapCurve is the StableSwap curve of 2 pegged tokens, A*n^n*(x+y) + D = A*D*n^n + D^3/(n^n*x*y)
+type stableSwapCurve struct {
+	feeRate       sdk.Dec
+	amplification int64
+}
+
+func (c stableSwapCurve) GetOutputAmount(inputAmount, inputReserve, outputReserve sdk.Dec) sdk.Dec {
+	return GetStableSwapInputPrice(inputAmount, inputReserve, outputReserve, c.amplification, c.feeRate)
+}
+
+// GetSpotPrice returns -dy/dx of the invariant, (Ann + D^3/(4x^2y)) / (Ann + D^3/(4xy^2))
+func (c stableSwapCurve) GetSpotPrice(inputReserve, outputReserve sdk.Dec) sdk.Dec {
+	ann := sdk.NewDec(c.amplification * 4)
+	d := getStableSwapInvariant(inputReserve, outputReserve, c.amplification)
+	dP := d.Quo(inputReserve.MulInt64(2)).Mul(d).Quo(outputReserve.MulInt64(2)).Mul(d)
+	return ann.Add(dP.Quo(inputReserve)).Quo(ann.Add(dP.Quo(outputReserve)))
+}
+
+func (c stableSwapCurve) GetInitialLiquidity(inputAmount, outputAmount sdk.Dec) sdk.Dec {
+	return getStableSwapInvariant(inputAmount, outputAmount, c.amplification)
+}
+
+// GetStableSwapInputPrice returns the amount bought on the stable swap curve
+func GetStableSwapInputPrice(inputAmount, inputReserve, outputReserve sdk.Dec, amplification int64,
+	feeRate sdk.Dec) sdk.Dec {
+	inputAmountWithFee := inputAmount.MulTruncate(sdk.OneDec().Sub(feeRate))
+	d := getStableSwapInvariant(inputReserve, outputReserve, amplification)
+	newOutputReserve := getStableSwapReserve(inputReserve.Add(inputAmountWithFee), d, amplification)
+	outputAmount := outputReserve.Sub(newOutputReserve)
+	if !outputAmount.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	return outputAmount
+}
+
+// getStableSwapInvariant solves D by newton's method, D = (Ann*S + 2*D_P)*D / ((Ann-1)*D + 3*D_P)
+// where D_P = D^3/(4xy)
+func getStableSwapInvariant(x, y sdk.Dec, amplification int64) sdk.Dec {
+	if !x.IsPositive() || !y.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	sum := x.Add(y)
+	ann := sdk.NewDec(amplification * 4)
+	d := sum
+	for i := 0; i < maxCurveIterations; i++ {
+		dP := d.Quo(x.MulInt64(2)).Mul(d).Quo(y.MulInt64(2)).Mul(d)
+		prevD := d
+		numerator := ann.Mul(sum).Add(dP.MulInt64(2)).Mul(d)
+		denominator := ann.Sub(sdk.OneDec()).Mul(d).Add(dP.MulInt64(3))
+		d = numerator.Quo(denominator)
+		if d.Sub(prevD).Abs().LTE(sdk.SmallestDec()) {
+			break
+		}
+	}
+	return d
+}
+
+// getStableSwapReserve solves the reserve y of the other token by newton's method when the reserve of one token
+// changes to x, y = (y^2 + c) / (2y + b - D) where c = D^3/(4x*Ann) and b = x + D/Ann
+func getStableSwapReserve(x, d sdk.Dec, amplification int64) sdk.Dec {
+	ann := sdk.NewDec(amplification * 4)
+	c := d.Quo(x.MulInt64(2)).Mul(d).Quo(ann.MulInt64(2)).Mul(d)
+	b := x.Add(d.Quo(ann))
+	y := d
+	for i := 0; i < maxCurveIterations; i++ {
+		prevY := y
+		y = y.Mul(y).Add(c).Quo(y.MulInt64(2).Add(b).Sub(d))
+		if y.Sub(prevY).Abs().LTE(sdk.SmallestDec()) {
+			break
+		}
+	}
+	return y
+}
+
+// weightedCurve is the Balancer-style weighted curve, x^wx * y^wy = k
+type weightedCurve struct {
+	feeRate      sdk.Dec
+	inputWeight  sdk.Dec
+	outputWeight sdk.Dec
+}
+
+func (c weightedCurve) GetOutputAmount(inputAmount, inputReserve, outputReserve sdk.Dec) sdk.Dec {
+	return GetWeightedInputPrice(inputAmount, inputReserve, outputReserve, c.inputWeight, c.outputWeight, c.feeRate)
+}
+
+// GetSpotPrice returns (y/wy) / (x/wx)
+func (c weightedCurve) GetSpotPrice(inputReserve, outputReserve sdk.Dec) sdk.Dec {
+	return outputReserve.Mul(c.inputWeight).Quo(inputReserve.Mul(c.outputWeight))
+}
+
+func (c weightedCurve) GetInitialLiquidity(inputAmount, outputAmount sdk.Dec) sdk.Dec {
+	return sdk.OneDec()
+}
+
+// GetWeightedInputPrice returns the amount bought on the weighted curve, y * (1 - (x/(x+dx))^(wx/wy)).
+// Like Balancer, the input amount is limited to half of the input reserve, zero is returned beyond it
+func GetWeightedInputPrice(inputAmount, inputReserve, outputReserve, inputWeight, outputWeight,
+	feeRate sdk.Dec) sdk.Dec {
+	if inputAmount.GT(inputReserve.Mul(maxInRatio)) {
+		return sdk.ZeroDec()
+	}
+	inputAmountWithFee := inputAmount.MulTruncate(sdk.OneDec().Sub(feeRate))
+	base := inputReserve.Quo(inputReserve.Add(inputAmountWithFee))
+	ratio := pow(base, inputWeight.Quo(outputWeight))
+	if ratio.GTE(sdk.OneDec()) {
+		return sdk.ZeroDec()
+	}
+	return outputReserve.MulTruncate(sdk.OneDec().Sub(ratio))
+}
+
+// pow returns base^exp for the base in (0, 2), the fraction of exp is approximated by the binomial series
+func pow(base, exp sdk.Dec) sdk.Dec {
+	whole := exp.TruncateInt64()
+	result := base.Power(uint64(whole))
+	frac := exp.Sub(sdk.NewDec(whole))
+	if frac.IsZero() {
+		return result
+	}
+
+	// (1+x)^a = 1 + a*x + a(a-1)/2!*x^2 + ...
+	x := base.Sub(sdk.OneDec())
+	term, sum := sdk.OneDec(), sdk.OneDec()
+	for k := int64(1); k <= maxCurveIterations; k++ {
+		term = term.Mul(frac.Sub(sdk.NewDec(k - 1))).Mul(x).QuoInt64(k)
+		sum = sum.Add(term)
+		if term.Abs().LT(powPrecision) {
+			break
+		}
+	}
+	return result.Mul(sum)
+}
@@ -0,0 +1,91 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStableSwapCurve(t *testing.T) {
+	reserve := sdk.NewDec(1000000)
+	inputAmount := sdk.NewDec(10000)
+	feeRate := sdk.ZeroDec()
+
+	// the invariant of a balanced pool is the sum of the reserves
+	d := getStableSwapInvariant(reserve, reserve, 100)
+	require.True(t, d.Sub(sdk.NewDec(2000000)).Abs().LT(sdk.NewDecWithPrec(1, 9)))
+
+	// the stable swap curve loses far less to slippage than the constant product one
+	stableAmount := GetStableSwapInputPrice(inputAmount, reserve, reserve, 100, feeRate)
+	constantProductAmount := GetInputPrice(inputAmount, reserve, reserve, feeRate)
+	require.True(t, stableAmount.GT(constantProductAmount))
+	require.True(t, stableAmount.LT(inputAmount))
+	require.True(t, inputAmount.Sub(stableAmount).LT(sdk.OneDec()), stableAmount.String())
+
+	// the invariant holds after the swap
+	newD := getStableSwapInvariant(reserve.Add(inputAmount), reserve.Sub(stableAmount), 100)
+	require.True(t, newD.Sub(d).Abs().LT(sdk.NewDecWithPrec(1, 9)))
+
+	// the fee is charged on the input
+	withFeeAmount := GetStableSwapInputPrice(inputAmount, reserve, reserve, 100, sdk.NewDecWithPrec(4, 4))
+	require.True(t, withFeeAmount.LT(stableAmount))
+
+	// the spot price of a balanced pool is 1
+	curve := stableSwapCurve{feeRate: feeRate, amplification: 100}
+	require.True(t, curve.GetSpotPrice(reserve, reserve).Sub(sdk.OneDec()).Abs().LT(sdk.NewDecWithPrec(1, 9)))
+	require.True(t, curve.GetSpotPrice(reserve.MulInt64(2), reserve).LT(sdk.OneDec()))
+}
+
+func TestWeightedCurve(t *testing.T) {
+	reserve := sdk.NewDec(1000000)
+	inputAmount := sdk.NewDec(10000)
+	feeRate := sdk.NewDecWithPrec(3, 3)
+	half := sdk.NewDecWithPrec(5, 1)
+
+	// the 50/50 pool prices as the constant product curve
+	weightedAmount := GetWeightedInputPrice(inputAmount, reserve, reserve, half, half, feeRate)
+	constantProductAmount := GetInputPrice(inputAmount, reserve, reserve, feeRate)
+	require.True(t, weightedAmount.Sub(constantProductAmount).Abs().LT(sdk.NewDecWithPrec(1, 6)))
+
+	// selling the 80% token into a 80/20 pool at the spot price 1 of (4x/0.8) / (x/0.2)
+	baseWeight, quoteWeight := sdk.NewDecWithPrec(8, 1), sdk.NewDecWithPrec(2, 1)
+	baseReserve, quoteReserve := reserve.MulInt64(4), reserve
+	curve := weightedCurve{feeRate: feeRate, inputWeight: baseWeight, outputWeight: quoteWeight}
+	require.Equal(t, sdk.OneDec(), curve.GetSpotPrice(baseReserve, quoteReserve))
+	weightedAmount = curve.GetOutputAmount(inputAmount, baseReserve, quoteReserve)
+	// 1000000 * (1 - (4000000/4009970)^(0.8/0.2))
+	expected := sdk.MustNewDecFromStr("9908.182787941271427177")
+	require.True(t, weightedAmount.Sub(expected).Abs().LT(sdk.NewDecWithPrec(1, 9)), weightedAmount.String())
+
+	// the input is limited to half of the input reserve
+	require.True(t, curve.GetOutputAmount(baseReserve, baseReserve, quoteReserve).IsZero())
+}
+
+func TestPow(t *testing.T) {
+	require.Equal(t, sdk.MustNewDecFromStr("0.25"), pow(sdk.MustNewDecFromStr("0.5"), sdk.NewDec(2)))
+	sqrt := pow(sdk.MustNewDecFromStr("0.81"), sdk.MustNewDecFromStr("0.5"))
+	require.True(t, sqrt.Sub(sdk.MustNewDecFromStr("0.9")).Abs().LT(sdk.NewDecWithPrec(1, 9)), sqrt.String())
+	quarter := pow(sdk.MustNewDecFromStr("0.9"), sdk.MustNewDecFromStr("0.25"))
+	require.True(t, quarter.Power(4).Sub(sdk.MustNewDecFromStr("0.9")).Abs().LT(sdk.NewDecWithPrec(1, 9)))
+}
+
+func TestCalculateTokenToBuyOnCurves(t *testing.T) {
+	params := types.DefaultParams()
+	pair := types.NewSwapPair("usda", "usdb")
+	pair.BasePooledCoin.Amount = sdk.NewDec(1000000)
+	pair.QuotePooledCoin.Amount = sdk.NewDec(1000000)
+	sellToken := sdk.NewDecCoinFromDec("usda", sdk.NewDec(10000))
+
+	constantProductBuy := CalculateTokenToBuy(pair, sellToken, "usdb", params)
+	require.Equal(t, GetInputPrice(sellToken.Amount, pair.BasePooledCoin.Amount, pair.QuotePooledCoin.Amount,
+		params.FeeRate), constantProductBuy.Amount)
+
+	// the fee rate of the pool overrides the fee rate of params
+	pair.SetCurve(types.CurveTypeStableSwap, sdk.NewDecWithPrec(4, 4), 100, sdk.Dec{})
+	stableBuy := CalculateTokenToBuy(pair, sellToken, "usdb", params)
+	require.Equal(t, "usdb", stableBuy.Denom)
+	require.True(t, stableBuy.Amount.GT(constantProductBuy.Amount))
+	require.True(t, stableBuy.Amount.GT(sdk.NewDec(9990)), stableBuy.String())
+}
@@ -22,6 +22,7 @@ type Keeper struct {
 	cdc            *codec.Codec
 	paramSpace     types.ParamSubspace
 	ObserverKeeper []types.BackendKeeper
+	evmKeeper      types.EvmKeeper
 }
 
 // NewKeeper creates a swap keeper
@@ -174,7 +175,7 @@ func (k Keeper) GetRedeemableAssets(ctx sdk.Context, baseAmountName, quoteAmount
 	return baseAmount, quoteAmount, nil
 }
 
-//CalculateTokenToBuy calculates the amount to buy
+//CalculateTokenToBuy calculates the amount to buy on the curve of the swap token pair
 func CalculateTokenToBuy(swapTokenPair types.SwapTokenPair, sellToken sdk.SysCoin, buyTokenDenom string, params types.Params) sdk.SysCoin {
 	var inputReserve, outputReserve sdk.Dec
 	if buyTokenDenom < sellToken.Denom {
@@ -184,7 +185,8 @@ func CalculateTokenToBuy(swapTokenPair types.SwapTokenPair, sellToken sdk.SysCoi
 		inputReserve = swapTokenPair.BasePooledCoin.Amount
 		outputReserve = swapTokenPair.QuotePooledCoin.Amount
 	}
-	tokenBuyAmt := GetInputPrice(sellToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	curve := GetCurve(swapTokenPair, sellToken.Denom, params.FeeRate)
+	tokenBuyAmt := curve.GetOutputAmount(sellToken.Amount, inputReserve, outputReserve)
 	tokenBuy := sdk.NewDecCoinFromDec(buyTokenDenom, tokenBuyAmt)
 
 	return tokenBuy
@@ -200,6 +202,11 @@ func (k *Keeper) SetObserverKeeper(bk types.BackendKeeper) {
 	k.ObserverKeeper = append(k.ObserverKeeper, bk)
 }
 
+// SetEvmKeeper sets the evm keeper through which the price accumulators are exposed to the evm contracts
+func (k *Keeper) SetEvmKeeper(ek types.EvmKeeper) {
+	k.evmKeeper = ek
+}
+
 func (k Keeper) OnSwapToken(ctx sdk.Context, address sdk.AccAddress, swapTokenPair types.SwapTokenPair, sellAmount sdk.SysCoin, buyAmount sdk.SysCoin) {
 	for _, observer := range k.ObserverKeeper {
 		observer.OnSwapToken(ctx, address, swapTokenPair, sellAmount, buyAmount)
@@ -28,6 +28,10 @@ func NewQuerier(k Keeper) sdk.Querier {
 			res, err = querySwapQuoteInfo(ctx, req, k)
 		case types.QuerySwapAddLiquidityQuote:
 			res, err = querySwapAddLiquidityQuote(ctx, req, k)
+		case types.QuerySwapRoute:
+			res, err = querySwapRoute(ctx, req, k)
+		case types.QueryTWAP:
+			res, err = queryTWAP(ctx, req, k)
 
 		default:
 			return nil, types.ErrSwapUnknownQueryType()
@@ -186,13 +190,9 @@ func querySwapQuoteInfo(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (
 		}
 		buyAmount = CalculateTokenToBuy(tokenPair, sellAmount, queryParams.BuyToken, swapParams).Amount
 		// calculate market price
-		if tokenPair.BasePooledCoin.Denom == sellAmount.Denom {
-			marketPrice = tokenPair.QuotePooledCoin.Amount.Quo(tokenPair.BasePooledCoin.Amount)
-		} else {
-			marketPrice = tokenPair.BasePooledCoin.Amount.Quo(tokenPair.QuotePooledCoin.Amount)
-		}
+		marketPrice = GetSpotPrice(tokenPair, sellAmount.Denom, swapParams)
 		// calculate fee
-		fee = sdk.NewDecCoinFromDec(sellAmount.Denom, sellAmount.Amount.Mul(swapParams.FeeRate))
+		fee = sdk.NewDecCoinFromDec(sellAmount.Denom, sellAmount.Amount.Mul(tokenPair.GetFeeRate(swapParams.FeeRate)))
 	} else {
 		tokenPairName1 := types.GetSwapTokenPairName(sellAmount.Denom, common.NativeToken)
 		tokenPair1, err := keeper.GetSwapTokenPair(ctx, tokenPairName1)
@@ -214,25 +214,16 @@ func querySwapQuoteInfo(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (
 		buyAmount = CalculateTokenToBuy(tokenPair2, nativeToken, queryParams.BuyToken, swapParams).Amount
 
 		// calculate market price
-		var sellTokenMarketPrice sdk.Dec
-		var routeTokenMarketPrice sdk.Dec
-		if tokenPair1.BasePooledCoin.Denom == sellAmount.Denom {
-			sellTokenMarketPrice = tokenPair1.QuotePooledCoin.Amount.Quo(tokenPair1.BasePooledCoin.Amount)
-		} else {
-			sellTokenMarketPrice = tokenPair1.BasePooledCoin.Amount.Quo(tokenPair1.QuotePooledCoin.Amount)
-		}
-		if tokenPair2.BasePooledCoin.Denom == common.NativeToken {
-			routeTokenMarketPrice = tokenPair2.QuotePooledCoin.Amount.Quo(tokenPair2.BasePooledCoin.Amount)
-		} else {
-			routeTokenMarketPrice = tokenPair2.BasePooledCoin.Amount.Quo(tokenPair2.QuotePooledCoin.Amount)
-		}
+		sellTokenMarketPrice := GetSpotPrice(tokenPair1, sellAmount.Denom, swapParams)
+		routeTokenMarketPrice := GetSpotPrice(tokenPair2, common.NativeToken, swapParams)
 		if routeTokenMarketPrice.IsPositive() && sellTokenMarketPrice.IsPositive() {
 			marketPrice = sellTokenMarketPrice.Mul(routeTokenMarketPrice)
 		}
 
 		// calculate fee
-		fee1 := sdk.NewDecCoinFromDec(sellAmount.Denom, sellAmount.Amount.Mul(swapParams.FeeRate))
-		routeTokenFee := sdk.NewDecCoinFromDec(common.NativeToken, nativeToken.Amount.Mul(swapParams.FeeRate))
+		fee1 := sdk.NewDecCoinFromDec(sellAmount.Denom, sellAmount.Amount.Mul(tokenPair1.GetFeeRate(swapParams.FeeRate)))
+		routeTokenFee := sdk.NewDecCoinFromDec(common.NativeToken,
+			nativeToken.Amount.Mul(tokenPair2.GetFeeRate(swapParams.FeeRate)))
 		fee2 := CalculateTokenToBuy(tokenPair1, routeTokenFee, sellAmount.Denom, swapParams)
 		fee = fee1.Add(fee2)
 
@@ -273,6 +264,90 @@ func querySwapQuoteInfo(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (
 
 }
 
+// querySwapRoute returns the route which buys the most across all the swap token pairs
+func querySwapRoute(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QuerySwapRouteParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+	if queryParams.SellTokenAmount == "" || queryParams.BuyToken == "" {
+		return nil, types.ErrSellAmountOrBuyTokenIsEmpty()
+	}
+	sellAmount, err := sdk.ParseDecCoin(queryParams.SellTokenAmount)
+	if err != nil {
+		return nil, types.ErrConvertSellTokenAmount(queryParams.SellTokenAmount, err)
+	}
+	if sellAmount.Denom == queryParams.BuyToken {
+		return nil, types.ErrSellAmountEqualBuyToken()
+	}
+	maxHops := queryParams.MaxHops
+	if maxHops <= 0 || maxHops > types.MaxSwapHops {
+		maxHops = types.MaxSwapHops
+	}
+
+	swapParams := keeper.GetParams(ctx)
+	path, swapTokenPairs, tokenBuys := FindBestRoute(keeper.GetSwapTokenPairs(ctx), sellAmount, queryParams.BuyToken,
+		maxHops, swapParams)
+	if path == nil {
+		return nil, types.ErrNoSwapRoute(sellAmount.Denom, queryParams.BuyToken)
+	}
+
+	routeInfo := types.SwapRouteInfo{
+		Path:        path,
+		BuyAmount:   tokenBuys[len(tokenBuys)-1],
+		Price:       sdk.ZeroDec(),
+		MarketPrice: sdk.OneDec(),
+		PriceImpact: sdk.ZeroDec(),
+	}
+	sellToken := sellAmount
+	for i, swapTokenPair := range swapTokenPairs {
+		routeInfo.Hops = append(routeInfo.Hops, types.SwapRouteHop{
+			TokenPair:  swapTokenPair.TokenPairName(),
+			SellAmount: sellToken,
+			BuyAmount:  tokenBuys[i],
+			Fee:        sdk.NewDecCoinFromDec(sellToken.Denom, sellToken.Amount.Mul(swapTokenPair.GetFeeRate(swapParams.FeeRate))),
+		})
+		routeInfo.MarketPrice = routeInfo.MarketPrice.Mul(GetSpotPrice(swapTokenPair, sellToken.Denom, swapParams))
+		sellToken = tokenBuys[i]
+	}
+
+	// calculate price and price impact
+	if sellAmount.Amount.IsPositive() {
+		routeInfo.Price = routeInfo.BuyAmount.Amount.Quo(sellAmount.Amount)
+	}
+	if routeInfo.MarketPrice.IsPositive() {
+		routeInfo.PriceImpact = routeInfo.MarketPrice.Sub(routeInfo.Price).Abs().Quo(routeInfo.MarketPrice)
+	}
+
+	response := common.GetBaseResponse(routeInfo)
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
+
+// queryTWAP returns the time weighted average prices of a swap token pair
+func queryTWAP(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QueryTWAPParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+	twap, sdkErr := keeper.GetTWAP(ctx, queryParams.TokenPair, queryParams.Window)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	response := common.GetBaseResponse(twap)
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
+
 // querySwapAddLiquidityQuote returns swap information of adding liquidity
 func querySwapAddLiquidityQuote(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
 	var queryParams types.QuerySwapAddInfoParams
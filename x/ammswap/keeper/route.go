@@ -0,0 +1,93 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+)
+
+// GetSwapTokenPairsOfPath returns the swap token pairs between every two adjacent tokens of the path,
+// all of them are required to have liquidity
+func (k Keeper) GetSwapTokenPairsOfPath(ctx sdk.Context, path []string) ([]types.SwapTokenPair, error) {
+	swapTokenPairs := make([]types.SwapTokenPair, 0, len(path)-1)
+	for i := 0; i+1 < len(path); i++ {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(path[i], path[i+1]))
+		if err != nil {
+			return nil, err
+		}
+		if swapTokenPair.BasePooledCoin.IsZero() || swapTokenPair.QuotePooledCoin.IsZero() {
+			return nil, types.ErrIsZeroValue("base pooled coin or quote pooled coin")
+		}
+		swapTokenPairs = append(swapTokenPairs, swapTokenPair)
+	}
+	return swapTokenPairs, nil
+}
+
+// CalculateTokenToBuyByPath returns the amount bought at every hop of the path, the token bought at a hop is
+// sold at the next one
+func CalculateTokenToBuyByPath(swapTokenPairs []types.SwapTokenPair, sellToken sdk.SysCoin, path []string,
+	params types.Params) []sdk.SysCoin {
+	tokenBuys := make([]sdk.SysCoin, len(swapTokenPairs))
+	for i, swapTokenPair := range swapTokenPairs {
+		tokenBuys[i] = CalculateTokenToBuy(swapTokenPair, sellToken, path[i+1], params)
+		sellToken = tokenBuys[i]
+	}
+	return tokenBuys
+}
+
+// FindBestRoute searches the paths of at most maxHops pools among the swap token pairs, and returns the one which
+// buys the most with the amounts bought at every hop. The fewer hops win a tie. Nil is returned if no path is found
+func FindBestRoute(swapTokenPairs []types.SwapTokenPair, sellToken sdk.SysCoin, buyTokenDenom string, maxHops int,
+	params types.Params) (bestPath []string, bestPairs []types.SwapTokenPair, bestBuys []sdk.SysCoin) {
+	// the swap token pairs are kept in the order of their names, the search is deterministic
+	graph := make(map[string][]types.SwapTokenPair)
+	for _, swapTokenPair := range swapTokenPairs {
+		if swapTokenPair.BasePooledCoin.IsZero() || swapTokenPair.QuotePooledCoin.IsZero() {
+			continue
+		}
+		base, quote := swapTokenPair.BasePooledCoin.Denom, swapTokenPair.QuotePooledCoin.Denom
+		graph[base] = append(graph[base], swapTokenPair)
+		graph[quote] = append(graph[quote], swapTokenPair)
+	}
+
+	path := []string{sellToken.Denom}
+	var pairs []types.SwapTokenPair
+	var buys []sdk.SysCoin
+	visited := map[string]bool{sellToken.Denom: true}
+
+	var search func(tokenSell sdk.SysCoin)
+	search = func(tokenSell sdk.SysCoin) {
+		if len(pairs) == maxHops {
+			return
+		}
+		for _, swapTokenPair := range graph[tokenSell.Denom] {
+			next := swapTokenPair.BasePooledCoin.Denom
+			if next == tokenSell.Denom {
+				next = swapTokenPair.QuotePooledCoin.Denom
+			}
+			if visited[next] {
+				continue
+			}
+			tokenBuy := CalculateTokenToBuy(swapTokenPair, tokenSell, next, params)
+			if !tokenBuy.IsPositive() {
+				continue
+			}
+
+			path, pairs, buys = append(path, next), append(pairs, swapTokenPair), append(buys, tokenBuy)
+			if next == buyTokenDenom {
+				if bestBuys == nil || tokenBuy.Amount.GT(bestBuys[len(bestBuys)-1].Amount) ||
+					(tokenBuy.Amount.Equal(bestBuys[len(bestBuys)-1].Amount) && len(buys) < len(bestBuys)) {
+					bestPath = append([]string{}, path...)
+					bestPairs = append([]types.SwapTokenPair{}, pairs...)
+					bestBuys = append([]sdk.SysCoin{}, buys...)
+				}
+			} else {
+				visited[next] = true
+				search(tokenBuy)
+				visited[next] = false
+			}
+			path, pairs, buys = path[:len(path)-1], pairs[:len(pairs)-1], buys[:len(buys)-1]
+		}
+	}
+	search(sellToken)
+	return bestPath, bestPairs, bestBuys
+}
@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/okex/exchain/x/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSwapTokenPair(token0, token1 string, amount0, amount1 int64) types.SwapTokenPair {
+	swapTokenPair := types.NewSwapPair(token0, token1)
+	if swapTokenPair.BasePooledCoin.Denom == token0 {
+		swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(amount0), sdk.NewDec(amount1)
+	} else {
+		swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(amount1), sdk.NewDec(amount0)
+	}
+	return swapTokenPair
+}
+
+func TestFindBestRoute(t *testing.T) {
+	params := types.DefaultParams()
+	swapTokenPairs := []types.SwapTokenPair{
+		newTestSwapTokenPair("aaa", "bbb", 10, 10),
+		newTestSwapTokenPair("aaa", common.NativeToken, 1000, 1000),
+		newTestSwapTokenPair("bbb", common.NativeToken, 1000, 1000),
+		newTestSwapTokenPair("ccc", "ddd", 1000, 1000),
+	}
+	sellToken := sdk.NewDecCoinFromDec("aaa", sdk.NewDec(5))
+
+	// the deep pools through the native token buy more than the shallow direct pool
+	path, pairs, buys := FindBestRoute(swapTokenPairs, sellToken, "bbb", types.MaxSwapHops, params)
+	require.Equal(t, []string{"aaa", common.NativeToken, "bbb"}, path)
+	require.Equal(t, 2, len(pairs))
+	require.Equal(t, 2, len(buys))
+	require.Equal(t, buys, CalculateTokenToBuyByPath(pairs, sellToken, path, params))
+	direct := CalculateTokenToBuy(swapTokenPairs[0], sellToken, "bbb", params)
+	require.True(t, buys[1].Amount.GT(direct.Amount))
+
+	// the direct pool is the only route of one hop
+	path, _, buys = FindBestRoute(swapTokenPairs, sellToken, "bbb", 1, params)
+	require.Equal(t, []string{"aaa", "bbb"}, path)
+	require.Equal(t, direct, buys[0])
+
+	// no route
+	path, pairs, buys = FindBestRoute(swapTokenPairs, sellToken, "ddd", types.MaxSwapHops, params)
+	require.Nil(t, path)
+	require.Nil(t, pairs)
+	require.Nil(t, buys)
+}
+
+func TestQuerySwapRoute(t *testing.T) {
+	_, _, ctx, keeper, querier := initQurierTest(t)
+	for _, swapTokenPair := range []types.SwapTokenPair{
+		newTestSwapTokenPair("aaa", common.NativeToken, 1000, 1000),
+		newTestSwapTokenPair("bbb", common.NativeToken, 1000, 2000),
+	} {
+		keeper.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+	}
+	path := []string{types.QuerySwapRoute}
+
+	bz := keeper.cdc.MustMarshalJSON(types.NewQuerySwapRouteParams("10aaa", "bbb", 0))
+	res, err := querier(ctx, path, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	var routeInfo types.SwapRouteInfo
+	response := common.BaseResponse{Data: &routeInfo}
+	require.Nil(t, json.Unmarshal(res, &response))
+	require.Equal(t, []string{"aaa", common.NativeToken, "bbb"}, routeInfo.Path)
+	require.Equal(t, 2, len(routeInfo.Hops))
+	require.Equal(t, routeInfo.Hops[1].BuyAmount, routeInfo.BuyAmount)
+	require.Equal(t, sdk.NewDecCoinFromDec("aaa", sdk.MustNewDecFromStr("0.03")), routeInfo.Hops[0].Fee)
+	// 1 aaa is 1 okt, 1 okt is 0.5 bbb
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), routeInfo.MarketPrice)
+	require.True(t, routeInfo.PriceImpact.IsPositive())
+	require.True(t, routeInfo.Price.LT(routeInfo.MarketPrice))
+
+	// no route
+	bz = keeper.cdc.MustMarshalJSON(types.NewQuerySwapRouteParams("10aaa", "ccc", 0))
+	_, err = querier(ctx, path, abci.RequestQuery{Data: bz})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,149 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// GetPriceCumulative gets the latest price accumulator of the swap token pair
+func (k Keeper) GetPriceCumulative(ctx sdk.Context, tokenPairName string) (priceCumulative types.PriceCumulative,
+	found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetPriceCumulativeKey(tokenPairName))
+	if bz == nil {
+		return priceCumulative, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &priceCumulative)
+	return priceCumulative, true
+}
+
+// SetPriceCumulative sets the latest price accumulator of the swap token pair
+func (k Keeper) SetPriceCumulative(ctx sdk.Context, tokenPairName string, priceCumulative types.PriceCumulative) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPriceCumulativeKey(tokenPairName), k.cdc.MustMarshalBinaryLengthPrefixed(priceCumulative))
+}
+
+// SetPriceObservation records the price accumulator of the swap token pair at its timestamp
+func (k Keeper) SetPriceObservation(ctx sdk.Context, tokenPairName string, priceCumulative types.PriceCumulative) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPriceObservationKey(tokenPairName, priceCumulative.Timestamp),
+		k.cdc.MustMarshalBinaryLengthPrefixed(priceCumulative))
+}
+
+// GetPriceObservations gets all the observations of the swap token pair in the order of time
+func (k Keeper) GetPriceObservations(ctx sdk.Context, tokenPairName string) (observations []types.PriceCumulative) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GetPriceObservationsPrefix(tokenPairName))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var observation types.PriceCumulative
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &observation)
+		observations = append(observations, observation)
+	}
+	return observations
+}
+
+// getPriceObservationBefore gets the latest observation of the swap token pair at or before the timestamp
+func (k Keeper) getPriceObservationBefore(ctx sdk.Context, tokenPairName string, timestamp int64) (
+	observation types.PriceCumulative, found bool) {
+	if timestamp < 0 {
+		return observation, false
+	}
+	iterator := ctx.KVStore(k.storeKey).ReverseIterator(types.GetPriceObservationsPrefix(tokenPairName),
+		types.GetPriceObservationKey(tokenPairName, timestamp+1))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return observation, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &observation)
+	return observation, true
+}
+
+// prunePriceObservations deletes the observations of the swap token pair older than the timestamp, except the latest
+// one of them which still starts the windows up to the timestamp
+func (k Keeper) prunePriceObservations(ctx sdk.Context, tokenPairName string, timestamp int64) {
+	if timestamp < 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.GetPriceObservationsPrefix(tokenPairName),
+		types.GetPriceObservationKey(tokenPairName, timestamp+1))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for i := 0; i+1 < len(keys); i++ {
+		store.Delete(keys[i])
+	}
+}
+
+// UpdatePriceCumulatives accumulates the prices of all the swap token pairs with liquidity since their last update.
+// It's called at the beginning of a block, the prices accumulated are those at the end of the previous block so that
+// they can't be moved within a block. The pairs without liquidity aren't updated, the time without liquidity
+// accumulates the first price after it
+func (k Keeper) UpdatePriceCumulatives(ctx sdk.Context) {
+	now := ctx.BlockTime().Unix()
+	var storage evmtypes.Storage
+	for _, swapTokenPair := range k.GetSwapTokenPairs(ctx) {
+		if !swapTokenPair.BasePooledCoin.IsPositive() || !swapTokenPair.QuotePooledCoin.IsPositive() {
+			continue
+		}
+		tokenPairName := swapTokenPair.TokenPairName()
+		priceCumulative, found := k.GetPriceCumulative(ctx, tokenPairName)
+		if !found {
+			priceCumulative = types.NewPriceCumulative(now)
+		} else if elapsed := now - priceCumulative.Timestamp; elapsed > 0 {
+			// the fee rate doesn't affect the spot prices
+			params := types.DefaultParams()
+			basePrice := GetSpotPrice(swapTokenPair, swapTokenPair.BasePooledCoin.Denom, params)
+			quotePrice := GetSpotPrice(swapTokenPair, swapTokenPair.QuotePooledCoin.Denom, params)
+			priceCumulative.BasePriceCumulative = priceCumulative.BasePriceCumulative.Add(basePrice.MulInt64(elapsed))
+			priceCumulative.QuotePriceCumulative = priceCumulative.QuotePriceCumulative.Add(quotePrice.MulInt64(elapsed))
+			priceCumulative.Timestamp = now
+		} else {
+			continue
+		}
+		k.SetPriceCumulative(ctx, tokenPairName, priceCumulative)
+
+		latest, found := k.getPriceObservationBefore(ctx, tokenPairName, now)
+		if !found || now-latest.Timestamp >= types.TWAPObservationInterval {
+			k.SetPriceObservation(ctx, tokenPairName, priceCumulative)
+			k.prunePriceObservations(ctx, tokenPairName, now-types.MaxTWAPWindow)
+		}
+		storage = append(storage, types.GetTWAPContractStorage(tokenPairName, priceCumulative)...)
+	}
+
+	if k.evmKeeper == nil || len(storage) == 0 {
+		return
+	}
+	if err := k.evmKeeper.SetSystemContract(ctx, types.TWAPContractAddress, types.TWAPContractCode,
+		storage); err != nil {
+		k.Logger(ctx).Error("failed to update the twap contract", "error", err)
+	}
+}
+
+// GetTWAP returns the time weighted average prices of the swap token pair in the window of seconds before its last
+// update. The window starts at the latest observation at or before its beginning, so it may be longer by up to
+// TWAPObservationInterval
+func (k Keeper) GetTWAP(ctx sdk.Context, tokenPairName string, window int64) (types.TWAP, sdk.Error) {
+	if window <= 0 || window > types.MaxTWAPWindow {
+		return types.TWAP{}, types.ErrInvalidTWAPWindow(window)
+	}
+	end, found := k.GetPriceCumulative(ctx, tokenPairName)
+	if !found {
+		return types.TWAP{}, types.ErrTWAPNotAvailable(tokenPairName, window)
+	}
+	start, found := k.getPriceObservationBefore(ctx, tokenPairName, end.Timestamp-window)
+	if !found {
+		return types.TWAP{}, types.ErrTWAPNotAvailable(tokenPairName, window)
+	}
+
+	elapsed := end.Timestamp - start.Timestamp
+	return types.TWAP{
+		TokenPair:  tokenPairName,
+		BasePrice:  end.BasePriceCumulative.Sub(start.BasePriceCumulative).QuoInt64(elapsed),
+		QuotePrice: end.QuotePriceCumulative.Sub(start.QuotePriceCumulative).QuoInt64(elapsed),
+		StartTime:  start.Timestamp,
+		EndTime:    end.Timestamp,
+	}, nil
+}
@@ -0,0 +1,130 @@
+package keeper
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/okex/exchain/x/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdatePriceCumulatives(t *testing.T) {
+	_, _, ctx, keeper, querier := initQurierTest(t)
+	swapTokenPair := newTestSwapTokenPair("aaa", common.NativeToken, 1000, 2000)
+	tokenPairName := swapTokenPair.TokenPairName()
+	keeper.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+	// the pair without liquidity isn't updated
+	emptyPair := newTestSwapTokenPair("bbb", common.NativeToken, 0, 0)
+	keeper.SetSwapTokenPair(ctx, emptyPair.TokenPairName(), emptyPair)
+	params := types.DefaultParams()
+
+	start := int64(1600000000)
+	keeper.UpdatePriceCumulatives(ctx.WithBlockTime(time.Unix(start, 0)))
+	priceCumulative, found := keeper.GetPriceCumulative(ctx, tokenPairName)
+	require.True(t, found)
+	require.Equal(t, types.NewPriceCumulative(start), priceCumulative)
+	_, found = keeper.GetPriceCumulative(ctx, emptyPair.TokenPairName())
+	require.False(t, found)
+
+	// no observation within the interval
+	basePrice1 := GetSpotPrice(swapTokenPair, swapTokenPair.BasePooledCoin.Denom, params)
+	quotePrice1 := GetSpotPrice(swapTokenPair, swapTokenPair.QuotePooledCoin.Denom, params)
+	keeper.UpdatePriceCumulatives(ctx.WithBlockTime(time.Unix(start+30, 0)))
+	priceCumulative, _ = keeper.GetPriceCumulative(ctx, tokenPairName)
+	require.Equal(t, start+30, priceCumulative.Timestamp)
+	require.Equal(t, basePrice1.MulInt64(30), priceCumulative.BasePriceCumulative)
+	require.Equal(t, quotePrice1.MulInt64(30), priceCumulative.QuotePriceCumulative)
+	require.Equal(t, 1, len(keeper.GetPriceObservations(ctx, tokenPairName)))
+
+	// the price changes
+	swapTokenPair = newTestSwapTokenPair("aaa", common.NativeToken, 2000, 1000)
+	keeper.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+	basePrice2 := GetSpotPrice(swapTokenPair, swapTokenPair.BasePooledCoin.Denom, params)
+	keeper.UpdatePriceCumulatives(ctx.WithBlockTime(time.Unix(start+90, 0)))
+	require.Equal(t, 2, len(keeper.GetPriceObservations(ctx, tokenPairName)))
+
+	twap, err := keeper.GetTWAP(ctx, tokenPairName, 90)
+	require.Nil(t, err)
+	expectedBasePrice := basePrice1.MulInt64(30).Add(basePrice2.MulInt64(60)).QuoInt64(90)
+	require.Equal(t, expectedBasePrice, twap.BasePrice)
+	require.Equal(t, start, twap.StartTime)
+	require.Equal(t, start+90, twap.EndTime)
+	// the window starts at the observation before it
+	twap, err = keeper.GetTWAP(ctx, tokenPairName, 60)
+	require.Nil(t, err)
+	require.Equal(t, expectedBasePrice, twap.BasePrice)
+
+	_, err = keeper.GetTWAP(ctx, tokenPairName, 100)
+	require.NotNil(t, err)
+	_, err = keeper.GetTWAP(ctx, tokenPairName, 0)
+	require.NotNil(t, err)
+	_, err = keeper.GetTWAP(ctx, tokenPairName, types.MaxTWAPWindow+1)
+	require.NotNil(t, err)
+	_, err = keeper.GetTWAP(ctx, emptyPair.TokenPairName(), 60)
+	require.NotNil(t, err)
+
+	// query
+	bz := keeper.cdc.MustMarshalJSON(types.NewQueryTWAPParams(tokenPairName, 90))
+	res, err := querier(ctx, []string{types.QueryTWAP}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	var queried types.TWAP
+	require.Nil(t, json.Unmarshal(res, &common.BaseResponse{Data: &queried}))
+	require.Equal(t, expectedBasePrice, queried.BasePrice)
+
+	// the observations older than the max window are pruned except the one starting it
+	keeper.UpdatePriceCumulatives(ctx.WithBlockTime(time.Unix(start+types.MaxTWAPWindow+200, 0)))
+	observations := keeper.GetPriceObservations(ctx, tokenPairName)
+	require.Equal(t, 2, len(observations))
+	require.Equal(t, start+90, observations[0].Timestamp)
+	_, err = keeper.GetTWAP(ctx, tokenPairName, types.MaxTWAPWindow)
+	require.Nil(t, err)
+}
+
+func TestTWAPContract(t *testing.T) {
+	priceCumulative := types.PriceCumulative{
+		Timestamp:            1600000000,
+		BasePriceCumulative:  sdk.MustNewDecFromStr("123.456"),
+		QuotePriceCumulative: sdk.MustNewDecFromStr("0.000000000000000001"),
+	}
+	statedb, err := state.New(ethcmn.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.Nil(t, err)
+	statedb.SetCode(types.TWAPContractAddress, types.TWAPContractCode)
+	for _, s := range types.GetTWAPContractStorage("aaa_okt", priceCumulative) {
+		statedb.SetState(types.TWAPContractAddress, s.Key, s.Value)
+	}
+
+	contractABI, err := abi.JSON(strings.NewReader(`[{"name":"getCumulativePrices","type":"function",
+		"stateMutability":"view","inputs":[{"name":"tokenPair","type":"string"}],"outputs":[
+		{"name":"timestamp","type":"uint256"},{"name":"basePriceCumulative","type":"uint256"},
+		{"name":"quotePriceCumulative","type":"uint256"}]}]`))
+	require.Nil(t, err)
+	call := func(tokenPair string) []interface{} {
+		input, err := contractABI.Pack("getCumulativePrices", tokenPair)
+		require.Nil(t, err)
+		ret, _, err := runtime.Call(types.TWAPContractAddress, input, &runtime.Config{State: statedb})
+		require.Nil(t, err)
+		outputs, err := contractABI.Unpack("getCumulativePrices", ret)
+		require.Nil(t, err)
+		return outputs
+	}
+
+	outputs := call("aaa_okt")
+	require.Equal(t, big.NewInt(1600000000), outputs[0])
+	require.Equal(t, sdk.MustNewDecFromStr("123.456").BigInt(), outputs[1])
+	require.Equal(t, big.NewInt(1), outputs[2])
+
+	// unknown pair
+	outputs = call("bbb_okt")
+	require.Zero(t, outputs[0].(*big.Int).Sign())
+}
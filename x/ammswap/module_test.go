@@ -3,9 +3,11 @@ package ammswap
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	cliLcd "github.com/okex/exchain/libs/cosmos-sdk/client/lcd"
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
@@ -50,3 +52,25 @@ func TestAppModule(t *testing.T) {
 	module.RegisterInvariants(nil)
 	module.RegisterCodec(codec.New())
 }
+
+func TestBeginBlocker(t *testing.T) {
+	mapp, _ := getMockApp(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockTime(time.Unix(1600000000, 0))
+	swapTokenPair := types.GetTestSwapTokenPair()
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(100)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(100)
+	keeper.SetSwapTokenPair(ctx, types.TestSwapTokenPairName, swapTokenPair)
+
+	// the prices aren't accumulated before the venus height
+	sdk.UnittestOnlySetMilestoneVenusHeight(10)
+	defer sdk.UnittestOnlySetMilestoneVenusHeight(0)
+	BeginBlocker(ctx.WithBlockHeight(10), keeper)
+	_, found := keeper.GetPriceCumulative(ctx, types.TestSwapTokenPairName)
+	require.False(t, found)
+
+	BeginBlocker(ctx.WithBlockHeight(11), keeper)
+	_, found = keeper.GetPriceCumulative(ctx, types.TestSwapTokenPairName)
+	require.True(t, found)
+}
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// nolint
+const (
+	CurveTypeConstantProduct = "CONSTANT_PRODUCT"
+	CurveTypeStableSwap      = "STABLE_SWAP"
+	CurveTypeWeighted        = "WEIGHTED"
+)
+
+// bounds of the curve params
+var (
+	MaxAmplification int64 = 1000000
+	MinBaseWeight          = sdk.NewDecWithPrec(2, 2)
+	MaxBaseWeight          = sdk.NewDecWithPrec(98, 2)
+)
+
+// ValidateCurve checks the curve type and the params it needs, the nil or zero fee rate and weight mean unset
+func ValidateCurve(curveType string, feeRate sdk.Dec, amplification int64, baseWeight sdk.Dec) error {
+	if !feeRate.IsNil() && (feeRate.IsNegative() || feeRate.GTE(sdk.OneDec())) {
+		return ErrInvalidFeeRate(feeRate.String())
+	}
+	hasWeight := !baseWeight.IsNil() && !baseWeight.IsZero()
+
+	switch curveType {
+	case "", CurveTypeConstantProduct:
+		if amplification != 0 || hasWeight {
+			return ErrInvalidCurveParams("constant product pool takes neither amplification nor base weight")
+		}
+	case CurveTypeStableSwap:
+		if amplification < 1 || amplification > MaxAmplification {
+			return ErrInvalidCurveParams(fmt.Sprintf("amplification should be in [1, %d]", MaxAmplification))
+		}
+		if hasWeight {
+			return ErrInvalidCurveParams("stable swap pool takes no base weight")
+		}
+	case CurveTypeWeighted:
+		if !hasWeight || baseWeight.LT(MinBaseWeight) || baseWeight.GT(MaxBaseWeight) {
+			return ErrInvalidCurveParams(fmt.Sprintf("base weight should be in [%s, %s]", MinBaseWeight, MaxBaseWeight))
+		}
+		if amplification != 0 {
+			return ErrInvalidCurveParams("weighted pool takes no amplification")
+		}
+	default:
+		return ErrInvalidCurveType(curveType)
+	}
+	return nil
+}
+
+// SetCurve sets the curve of the swap token pair, the curve params are expected to be validated
+func (s *SwapTokenPair) SetCurve(curveType string, feeRate sdk.Dec, amplification int64, baseWeight sdk.Dec) {
+	if curveType == CurveTypeConstantProduct {
+		curveType = ""
+	}
+	s.CurveType = curveType
+	// the codec decodes an unset fee rate as zero, so zero means unset as well
+	s.FeeRate = ""
+	if !feeRate.IsNil() && !feeRate.IsZero() {
+		s.FeeRate = feeRate.String()
+	}
+	s.Amplification = amplification
+	s.BaseWeight = ""
+	if curveType == CurveTypeWeighted {
+		s.BaseWeight = baseWeight.String()
+	}
+}
+
+// ValidateCurve checks the curve settings stored on the swap token pair
+func (s SwapTokenPair) ValidateCurve() error {
+	var feeRate, baseWeight sdk.Dec
+	var err error
+	if s.FeeRate != "" {
+		if feeRate, err = sdk.NewDecFromStr(s.FeeRate); err != nil {
+			return ErrInvalidFeeRate(s.FeeRate)
+		}
+	}
+	if s.BaseWeight != "" {
+		if baseWeight, err = sdk.NewDecFromStr(s.BaseWeight); err != nil {
+			return ErrInvalidCurveParams(fmt.Sprintf("invalid base weight: %s", s.BaseWeight))
+		}
+	}
+	return ValidateCurve(s.CurveType, feeRate, s.Amplification, baseWeight)
+}
+
+// GetCurveType returns the curve type of the swap token pair, constant product by default
+func (s SwapTokenPair) GetCurveType() string {
+	if s.CurveType == "" {
+		return CurveTypeConstantProduct
+	}
+	return s.CurveType
+}
+
+// GetFeeRate returns the fee rate of the swap token pair, or the default fee rate if it's not set
+func (s SwapTokenPair) GetFeeRate(defaultFeeRate sdk.Dec) sdk.Dec {
+	feeRate, err := sdk.NewDecFromStr(s.FeeRate)
+	if err != nil {
+		return defaultFeeRate
+	}
+	return feeRate
+}
+
+// GetWeights returns the weights of base token and quote token in the weighted pool
+func (s SwapTokenPair) GetWeights() (baseWeight, quoteWeight sdk.Dec) {
+	baseWeight, err := sdk.NewDecFromStr(s.BaseWeight)
+	if err != nil {
+		baseWeight = sdk.NewDecWithPrec(5, 1)
+	}
+	return baseWeight, sdk.OneDec().Sub(baseWeight)
+}
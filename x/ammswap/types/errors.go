@@ -56,6 +56,13 @@ const (
 	CodeIsSwapTokenPairExist                 uint32 = 65043
 	CodeIsPoolTokenPairExist                 uint32 = 65044
 	CodeInternalError                        uint32 = 65045
+	CodeInvalidCurveType                     uint32 = 65046
+	CodeInvalidCurveParams                   uint32 = 65047
+	CodeInvalidFeeRate                       uint32 = 65048
+	CodeInvalidSwapPath                      uint32 = 65049
+	CodeNoSwapRoute                          uint32 = 65050
+	CodeInvalidTWAPWindow                    uint32 = 65051
+	CodeTWAPNotAvailable                     uint32 = 65052
 )
 
 func ErrNonExistSwapTokenPair(tokenPairName string) sdk.EnvelopedErr {
@@ -239,3 +246,31 @@ func ErrSwapTokenPairExist() sdk.EnvelopedErr {
 func ErrPoolTokenPairExist() sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeIsPoolTokenPairExist, "the pool token pair already exists")}
 }
+
+func ErrInvalidCurveType(curveType string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidCurveType, fmt.Sprintf("invalid curve type: %s", curveType))}
+}
+
+func ErrInvalidCurveParams(msg string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidCurveParams, fmt.Sprintf("invalid curve params: %s", msg))}
+}
+
+func ErrInvalidFeeRate(feeRate string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidFeeRate, fmt.Sprintf("fee rate should be in [0, 1): %s", feeRate))}
+}
+
+func ErrInvalidSwapPath(msg string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidSwapPath, fmt.Sprintf("invalid swap path: %s", msg))}
+}
+
+func ErrNoSwapRoute(sellToken, buyToken string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeNoSwapRoute, fmt.Sprintf("no route to swap %s for %s", sellToken, buyToken))}
+}
+
+func ErrInvalidTWAPWindow(window int64) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidTWAPWindow, fmt.Sprintf("twap window should be in (0, %d] seconds: %d", MaxTWAPWindow, window))}
+}
+
+func ErrTWAPNotAvailable(tokenPairName string, window int64) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeTWAPNotAvailable, fmt.Sprintf("no price observation of %s covers the window of %d seconds", tokenPairName, window))}
+}
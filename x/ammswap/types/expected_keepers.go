@@ -1,7 +1,9 @@
 package types
 
 import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/params"
 	token "github.com/okex/exchain/x/token/types"
 )
@@ -46,3 +48,8 @@ type BackendKeeper interface {
 	OnSwapToken(ctx sdk.Context, address sdk.AccAddress, swapTokenPair SwapTokenPair, sellAmount sdk.SysCoin, buyAmount sdk.SysCoin)
 	OnSwapCreateExchange(ctx sdk.Context, swapTokenPair SwapTokenPair)
 }
+
+// EvmKeeper defines the expected evm interface, the price accumulators are exposed to the evm contracts through it
+type EvmKeeper interface {
+	SetSystemContract(ctx sdk.Context, addr ethcmn.Address, code []byte, storage evmtypes.Storage) error
+}
@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the module
 	ModuleName = "ammswap"
@@ -21,14 +25,38 @@ const (
 	QueryBuyAmount             = "buy"
 	QuerySwapQuoteInfo         = "swapQuoteInfo"
 	QuerySwapAddLiquidityQuote = "swapAddLiquidityQuote"
+	QuerySwapRoute             = "route"
+	QueryTWAP                  = "twap"
 )
 
 var (
 	// TokenPairPrefixKey to be used for KVStore
 	TokenPairPrefixKey = []byte{0x01}
+	// PriceCumulativePrefixKey is the prefix of the latest price accumulator of a swap token pair
+	PriceCumulativePrefixKey = []byte{0x02}
+	// PriceObservationPrefixKey is the prefix of the price accumulators observed in the past
+	PriceObservationPrefixKey = []byte{0x03}
 )
 
 // nolint
 func GetTokenPairKey(key string) []byte {
 	return append(TokenPairPrefixKey, []byte(key)...)
 }
+
+// GetPriceCumulativeKey returns the key of the latest price accumulator of the swap token pair
+func GetPriceCumulativeKey(tokenPairName string) []byte {
+	return append(PriceCumulativePrefixKey, []byte(tokenPairName)...)
+}
+
+// GetPriceObservationsPrefix returns the prefix of the observations of the swap token pair, the name is length
+// prefixed so that the observations of a pair never share the prefix of another one
+func GetPriceObservationsPrefix(tokenPairName string) []byte {
+	prefix := append([]byte{}, PriceObservationPrefixKey...)
+	prefix = append(prefix, byte(len(tokenPairName)))
+	return append(prefix, []byte(tokenPairName)...)
+}
+
+// GetPriceObservationKey returns the key of the observation of the swap token pair at the timestamp
+func GetPriceObservationKey(tokenPairName string, timestamp int64) []byte {
+	return append(GetPriceObservationsPrefix(tokenPairName), sdk.Uint64ToBigEndian(uint64(timestamp))...)
+}
@@ -40,6 +40,52 @@ func TestMsgCreateExchange(t *testing.T) {
 
 	expectTokenPair := TestBasePooledToken + "_" + TestQuotePooledToken
 	require.Equal(t, expectTokenPair, msg.GetSwapTokenPairName())
+	// the constant product pairs are signed as before
+	require.NotContains(t, string(bytesMsg), "curve_type")
+	require.NotContains(t, string(bytesMsg), "fee_rate")
+}
+
+func TestMsgCreateExchangeCurve(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	tests := []struct {
+		testCase      string
+		curveType     string
+		feeRate       sdk.Dec
+		amplification int64
+		token0Weight  sdk.Dec
+		isValid       bool
+	}{
+		{"constant product", CurveTypeConstantProduct, sdk.Dec{}, 0, sdk.Dec{}, true},
+		{"constant product with fee rate", "", sdk.NewDecWithPrec(1, 3), 0, sdk.Dec{}, true},
+		{"constant product with amplification", "", sdk.Dec{}, 100, sdk.Dec{}, false},
+		{"stable swap", CurveTypeStableSwap, sdk.NewDecWithPrec(4, 4), 100, sdk.Dec{}, true},
+		{"stable swap without amplification", CurveTypeStableSwap, sdk.Dec{}, 0, sdk.Dec{}, false},
+		{"stable swap with too large amplification", CurveTypeStableSwap, sdk.Dec{}, MaxAmplification + 1, sdk.Dec{}, false},
+		{"weighted", CurveTypeWeighted, sdk.Dec{}, 0, sdk.NewDecWithPrec(8, 1), true},
+		{"weighted without weight", CurveTypeWeighted, sdk.Dec{}, 0, sdk.Dec{}, false},
+		{"weighted with too large weight", CurveTypeWeighted, sdk.Dec{}, 0, sdk.NewDecWithPrec(99, 2), false},
+		{"negative fee rate", "", sdk.NewDecWithPrec(-1, 3), 0, sdk.Dec{}, false},
+		{"fee rate of 1", "", sdk.OneDec(), 0, sdk.Dec{}, false},
+		{"unknown curve", "CONCENTRATED", sdk.Dec{}, 0, sdk.Dec{}, false},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgCreateExchange("aaa", "bbb", addr)
+		msg.CurveType = testCase.curveType
+		msg.FeeRate = testCase.feeRate
+		msg.Amplification = testCase.amplification
+		msg.Token0Weight = testCase.token0Weight
+		if testCase.isValid {
+			require.Nil(t, msg.ValidateBasic(), testCase.testCase)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), testCase.testCase)
+		}
+	}
+
+	// the weight is given for token0 which is the quote token here
+	msg := NewMsgCreateExchange("bbb", "aaa", addr)
+	msg.Token0Weight = sdk.NewDecWithPrec(8, 1)
+	require.Equal(t, sdk.NewDecWithPrec(2, 1), msg.GetBaseWeight())
 }
 
 func TestMsgCreateExchangeInvalid(t *testing.T) {
@@ -264,3 +310,37 @@ func TestMsgTokenToTokenInvalid(t *testing.T) {
 		testCode(t, err, testCase.exceptResultCode)
 	}
 }
+
+func TestMsgTokenToTokenPath(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	soldTokenAmount := sdk.NewDecCoinFromDec("aaa", sdk.NewDec(2))
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec("ddd", sdk.NewDec(1))
+	tests := []struct {
+		testCase string
+		path     []string
+		isValid  bool
+	}{
+		{"direct", nil, true},
+		{"two hops", []string{"bbb"}, true},
+		{"max hops", []string{"bbb", "ccc", "eee"}, true},
+		{"too many hops", []string{"bbb", "ccc", "eee", "fff"}, false},
+		{"duplicate token", []string{"bbb", "aaa"}, false},
+		{"invalid token", []string{"1bbb"}, false},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgTokenToToken(soldTokenAmount, minBoughtTokenAmount, time.Now().Unix(), addr, addr)
+		msg.Path = testCase.path
+		require.Equal(t, append(append([]string{"aaa"}, testCase.path...), "ddd"), msg.GetPath(), testCase.testCase)
+		err := msg.ValidateBasic()
+		if testCase.isValid {
+			require.Nil(t, err, testCase.testCase)
+		} else {
+			require.NotNil(t, err, testCase.testCase)
+		}
+	}
+
+	// the sign bytes of a direct swap are unchanged
+	msg := NewMsgTokenToToken(soldTokenAmount, minBoughtTokenAmount, time.Now().Unix(), addr, addr)
+	require.NotContains(t, string(msg.GetSignBytes()), "path")
+}
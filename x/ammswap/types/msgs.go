@@ -143,6 +143,11 @@ type MsgCreateExchange struct {
 	Token0Name string         `json:"token0_name"`
 	Token1Name string         `json:"token1_name"`
 	Sender     sdk.AccAddress `json:"sender"` // Sender
+
+	CurveType     string  `json:"curve_type,omitempty"`    // CONSTANT_PRODUCT by default, or STABLE_SWAP/WEIGHTED
+	FeeRate       sdk.Dec `json:"fee_rate,omitempty"`      // Swap fee rate of the pool, the fee rate of params if it's zero or not set
+	Amplification int64   `json:"amplification,omitempty"` // Amplification coefficient of the stable swap pool
+	Token0Weight  sdk.Dec `json:"token0_weight,omitempty"` // Weight of token0 in the weighted pool, e.g. 0.8 for 80/20
 }
 
 // NewMsgCreateExchange create a new exchange with token
@@ -176,6 +181,9 @@ func (msg MsgCreateExchange) ValidateBasic() sdk.Error {
 	if msg.Token0Name == msg.Token1Name {
 		return ErrToken0NameEqualToken1Name()
 	}
+	if err := ValidateCurve(msg.CurveType, msg.FeeRate, msg.Amplification, msg.GetBaseWeight()); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -194,6 +202,17 @@ func (msg MsgCreateExchange) GetSwapTokenPairName() string {
 	return GetSwapTokenPairName(msg.Token0Name, msg.Token1Name)
 }
 
+// GetBaseWeight returns the weight of the base token in the weighted pool, token0 may be the quote token
+func (msg MsgCreateExchange) GetBaseWeight() sdk.Dec {
+	if msg.Token0Weight.IsNil() {
+		return msg.Token0Weight
+	}
+	if baseName, _ := GetBaseQuoteTokenName(msg.Token0Name, msg.Token1Name); baseName != msg.Token0Name {
+		return sdk.OneDec().Sub(msg.Token0Weight)
+	}
+	return msg.Token0Weight
+}
+
 // MsgTokenToToken define the message for swap between token and DefaultBondDenom
 type MsgTokenToToken struct {
 	SoldTokenAmount      sdk.SysCoin    `json:"sold_token_amount"`       // Amount of Tokens sold.
@@ -201,6 +220,7 @@ type MsgTokenToToken struct {
 	Deadline             int64          `json:"deadline"`                // Time after which this transaction can no longer be executed.
 	Recipient            sdk.AccAddress `json:"recipient"`               // Recipient address,transfer Tokens to recipient.default recipient is sender.
 	Sender               sdk.AccAddress `json:"sender"`                  // Sender
+	Path                 []string       `json:"path,omitempty"`          // Intermediate tokens to route through, in order.
 }
 
 // NewMsgTokenToToken is a constructor function for MsgTokenOKTSwap
@@ -248,7 +268,7 @@ func (msg MsgTokenToToken) ValidateBasic() sdk.Error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return ValidateSwapPath(msg.GetPath())
 }
 
 // GetSignBytes encodes the message for signing
@@ -265,3 +285,11 @@ func (msg MsgTokenToToken) GetSigners() []sdk.AccAddress {
 func (msg MsgTokenToToken) GetSwapTokenPairName() string {
 	return GetSwapTokenPairName(msg.MinBoughtTokenAmount.Denom, msg.SoldTokenAmount.Denom)
 }
+
+// GetPath returns all the tokens the swap goes through, from the sold token to the bought token
+func (msg MsgTokenToToken) GetPath() []string {
+	path := make([]string, 0, len(msg.Path)+2)
+	path = append(path, msg.SoldTokenAmount.Denom)
+	path = append(path, msg.Path...)
+	return append(path, msg.MinBoughtTokenAmount.Denom)
+}
@@ -50,3 +50,51 @@ type QueryBuyAmountParams struct {
 	SoldToken  sdk.SysCoin
 	TokenToBuy string
 }
+
+// QuerySwapRouteParams is the params of the best route query, MaxHops is MaxSwapHops if it's not set
+type QuerySwapRouteParams struct {
+	SellTokenAmount string `json:"sell_token_amount"`
+	BuyToken        string `json:"buy_token"`
+	MaxHops         int    `json:"max_hops"`
+}
+
+// NewQuerySwapRouteParams creates a new instance of QuerySwapRouteParams
+func NewQuerySwapRouteParams(sellTokenAmount string, buyToken string, maxHops int) QuerySwapRouteParams {
+	return QuerySwapRouteParams{
+		SellTokenAmount: sellTokenAmount,
+		BuyToken:        buyToken,
+		MaxHops:         maxHops,
+	}
+}
+
+// SwapRouteHop is the swap through one pool of the route
+type SwapRouteHop struct {
+	TokenPair  string      `json:"token_pair"`
+	SellAmount sdk.SysCoin `json:"sell_amount"`
+	BuyAmount  sdk.SysCoin `json:"buy_amount"`
+	Fee        sdk.SysCoin `json:"fee"`
+}
+
+// SwapRouteInfo is the route which buys the most, Path is from the sell token to the buy token
+type SwapRouteInfo struct {
+	Path        []string       `json:"path"`
+	BuyAmount   sdk.SysCoin    `json:"buy_amount"`
+	Price       sdk.Dec        `json:"price"`
+	MarketPrice sdk.Dec        `json:"market_price"`
+	PriceImpact sdk.Dec        `json:"price_impact"`
+	Hops        []SwapRouteHop `json:"hops"`
+}
+
+// QueryTWAPParams is the params of the TWAP query, Window is in seconds
+type QueryTWAPParams struct {
+	TokenPair string `json:"token_pair"`
+	Window    int64  `json:"window"`
+}
+
+// NewQueryTWAPParams creates a new instance of QueryTWAPParams
+func NewQueryTWAPParams(tokenPair string, window int64) QueryTWAPParams {
+	return QueryTWAPParams{
+		TokenPair: tokenPair,
+		Window:    window,
+	}
+}
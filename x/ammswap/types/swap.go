@@ -12,20 +12,30 @@ import (
 // PoolTokenPrefix defines pool token prefix name
 const PoolTokenPrefix = "ammswap_"
 
+// MaxSwapHops is the max number of pools a swap goes through
+const MaxSwapHops = 4
+
 // SwapTokenPair defines token pair exchange
 type SwapTokenPair struct {
 	QuotePooledCoin sdk.SysCoin `json:"quote_pooled_coin"` // The volume of quote token in the token pair exchange pool
 	BasePooledCoin  sdk.SysCoin `json:"base_pooled_coin"`  // The volume of base token in the token pair exchange pool
 	PoolTokenName   string      `json:"pool_token_name"`   // The name of pool token
+
+	// the curve settings are kept as omitted-when-empty strings, so the constant product pairs created before
+	// pool types existed are encoded unchanged
+	CurveType     string `json:"curve_type,omitempty"`    // The pricing curve of the pool, constant product by default
+	FeeRate       string `json:"fee_rate,omitempty"`      // The swap fee rate of the pool, the fee rate of params by default
+	Amplification int64  `json:"amplification,omitempty"` // The amplification coefficient of the stable swap pool
+	BaseWeight    string `json:"base_weight,omitempty"`   // The weight of base token in the weighted pool
 }
 
 func NewSwapPair(token0, token1 string) SwapTokenPair {
 	base, quote := GetBaseQuoteTokenName(token0, token1)
 
 	swapTokenPair := SwapTokenPair{
-		sdk.NewDecCoinFromDec(quote, sdk.ZeroDec()),
-		sdk.NewDecCoinFromDec(base, sdk.ZeroDec()),
-		GetPoolTokenName(token0, token1),
+		QuotePooledCoin: sdk.NewDecCoinFromDec(quote, sdk.ZeroDec()),
+		BasePooledCoin:  sdk.NewDecCoinFromDec(base, sdk.ZeroDec()),
+		PoolTokenName:   GetPoolTokenName(token0, token1),
 	}
 	return swapTokenPair
 }
@@ -44,7 +54,8 @@ func NewSwapTokenPair(quotePooledCoin sdk.SysCoin, basePooledCoin sdk.SysCoin, p
 func (s SwapTokenPair) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`QuotePooledCoin: %s
 BasePooledCoin: %s
-PoolTokenName: %s`, s.QuotePooledCoin.String(), s.BasePooledCoin.String(), s.PoolTokenName))
+PoolTokenName: %s
+CurveType: %s`, s.QuotePooledCoin.String(), s.BasePooledCoin.String(), s.PoolTokenName, s.GetCurveType()))
 }
 
 // TokenPairName defines token pair
@@ -104,6 +115,24 @@ func ValidateSwapAmountName(amountName string) error {
 	return nil
 }
 
+// ValidateSwapPath checks the tokens a swap goes through, every token is swapped at most once
+func ValidateSwapPath(path []string) error {
+	if len(path) < 2 || len(path)-1 > MaxSwapHops {
+		return ErrInvalidSwapPath(fmt.Sprintf("a swap goes through 1 to %d pools", MaxSwapHops))
+	}
+	tokens := make(map[string]bool, len(path))
+	for _, token := range path {
+		if err := ValidateSwapAmountName(token); err != nil {
+			return err
+		}
+		if tokens[token] {
+			return ErrInvalidSwapPath(fmt.Sprintf("token %s appears more than once", token))
+		}
+		tokens[token] = true
+	}
+	return nil
+}
+
 func GetPoolTokenName(token1, token2 string) string {
 	return PoolTokenPrefix + GetSwapTokenPairName(token1, token2)
 }
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+const (
+	// TWAPObservationInterval is the min interval in seconds between two observations of a swap token pair
+	TWAPObservationInterval = 60
+	// MaxTWAPWindow is the max window in seconds of the TWAP, the observations older than it are pruned
+	MaxTWAPWindow = 24 * 60 * 60
+)
+
+var (
+	// TWAPContractAddress is the address of the system contract through which the evm contracts read the price
+	// accumulators
+	TWAPContractAddress = ethcmn.HexToAddress("0x000000000000000000000000000000000000a001")
+
+	// TWAPContractCode is the runtime code of the TWAP system contract, it implements
+	//
+	//   function getCumulativePrices(string tokenPair) external view
+	//       returns (uint256 timestamp, uint256 basePriceCumulative, uint256 quotePriceCumulative);
+	//
+	// by loading the slots keccak256(tokenPair), keccak256(tokenPair)+1 and keccak256(tokenPair)+2. The selector is
+	// not checked. The cumulative prices are scaled by 1e18, the TWAP between two calls is the difference of the
+	// cumulative prices divided by the difference of the timestamps
+	TWAPContractCode = ethcmn.FromHex("0x6004356004018035906020018190600037600020805460005280600101546020526002015460405260606000f3")
+
+	two256 = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+// PriceCumulative is the sum of the prices of a swap token pair weighted by the seconds they last until the timestamp.
+// BasePriceCumulative accumulates the price of the base token in the quote token, and QuotePriceCumulative the
+// reverse one
+type PriceCumulative struct {
+	Timestamp            int64   `json:"timestamp"`
+	BasePriceCumulative  sdk.Dec `json:"base_price_cumulative"`
+	QuotePriceCumulative sdk.Dec `json:"quote_price_cumulative"`
+}
+
+// NewPriceCumulative creates a new instance of PriceCumulative which starts at the timestamp
+func NewPriceCumulative(timestamp int64) PriceCumulative {
+	return PriceCumulative{
+		Timestamp:            timestamp,
+		BasePriceCumulative:  sdk.ZeroDec(),
+		QuotePriceCumulative: sdk.ZeroDec(),
+	}
+}
+
+// TWAP is the time weighted average prices of a swap token pair from StartTime to EndTime
+type TWAP struct {
+	TokenPair  string  `json:"token_pair"`
+	BasePrice  sdk.Dec `json:"base_price"`
+	QuotePrice sdk.Dec `json:"quote_price"`
+	StartTime  int64   `json:"start_time"`
+	EndTime    int64   `json:"end_time"`
+}
+
+// GetTWAPContractStorage returns the storage of the TWAP system contract which holds the price accumulator of the
+// swap token pair
+func GetTWAPContractStorage(tokenPairName string, priceCumulative PriceCumulative) evmtypes.Storage {
+	slot := new(big.Int).SetBytes(crypto.Keccak256([]byte(tokenPairName)))
+	values := []*big.Int{
+		big.NewInt(priceCumulative.Timestamp),
+		priceCumulative.BasePriceCumulative.BigInt(),
+		priceCumulative.QuotePriceCumulative.BigInt(),
+	}
+	storage := make(evmtypes.Storage, len(values))
+	for i, value := range values {
+		key := new(big.Int).Add(slot, big.NewInt(int64(i)))
+		storage[i] = evmtypes.NewState(ethcmn.BigToHash(key.Mod(key, two256)), ethcmn.BigToHash(value))
+	}
+	return storage
+}
@@ -210,6 +210,9 @@ func GetNewOrdersAtEndBlock(ctx sdk.Context, orderKeeper types.OrderKeeper) ([]*
 				FilledAvgPrice: order.FilledAvgPrice.String(),
 				RemainQuantity: order.RemainQuantity.String(),
 				Timestamp:      order.Timestamp,
+				Type:           order.GetOrderType(),
+				TimeInForce:    order.GetTimeInForce(),
+				StopPrice:      order.GetExtraInfoWithKey(orderTypes.OrderExtraInfoKeyStopPrice),
 			}
 			orders = append(orders, orderDb)
 		} else {
@@ -238,6 +241,9 @@ func GetUpdatedOrdersAtEndBlock(ctx sdk.Context, orderKeeper types.OrderKeeper)
 				FilledAvgPrice: order.FilledAvgPrice.String(),
 				RemainQuantity: order.RemainQuantity.String(),
 				Timestamp:      order.Timestamp,
+				Type:           order.GetOrderType(),
+				TimeInForce:    order.GetTimeInForce(),
+				StopPrice:      order.GetExtraInfoWithKey(orderTypes.OrderExtraInfoKeyStopPrice),
 			}
 			orders = append(orders, orderDb)
 		}
@@ -3,6 +3,7 @@ package keeper
 import (
 	"encoding/json"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/okex/exchain/x/ammswap"
@@ -62,15 +63,12 @@ func queryFarmPools(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]by
 		startAt := calculateFarmPoolStartAt(ctx, farmPool)
 		finishAt := calculateFarmPoolFinishAt(ctx, keeper, farmPool, startAt)
 		// calculate pool rate and farm apy
-		yieldedInDay := farmPool.YieldedTokenInfos[0].AmountYieldedPerBlock.MulInt64(int64(types.BlocksPerDay))
-		poolRate := sdk.NewDecCoinsFromDec(farmPool.YieldedTokenInfos[0].RemainingAmount.Denom, yieldedInDay)
-		apy := calculateFarmApy(ctx, keeper, farmPool, totalStakedDollars)
-		farmApy := sdk.NewDecCoinsFromDec(farmPool.YieldedTokenInfos[0].RemainingAmount.Denom, apy)
+		poolRate, farmApy := calculateFarmPoolRateAndApy(ctx, keeper, farmPool, totalStakedDollars)
 		status := getFarmPoolStatus(startAt, finishAt, farmPool)
 		responseList[i] = types.FarmPoolResponse{
 			PoolName:    farmPool.Name,
 			LockSymbol:  farmPool.MinLockAmount.Denom,
-			YieldSymbol: farmPool.YieldedTokenInfos[0].RemainingAmount.Denom,
+			YieldSymbol: getFarmPoolYieldSymbol(farmPool),
 			TotalStaked: totalStakedDollars,
 			StartAt:     startAt,
 			FinishAt:    finishAt,
@@ -204,10 +202,7 @@ func queryFarmDashboard(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (
 		startAt := calculateFarmPoolStartAt(ctx, farmPool)
 		finishAt := calculateFarmPoolFinishAt(ctx, keeper, farmPool, startAt)
 		// calculate pool rate and farm apy
-		yieldedInDay := farmPool.YieldedTokenInfos[0].AmountYieldedPerBlock.MulInt64(int64(types.BlocksPerDay))
-		poolRate := sdk.NewDecCoinsFromDec(farmPool.YieldedTokenInfos[0].RemainingAmount.Denom, yieldedInDay)
-		apy := calculateFarmApy(ctx, keeper, farmPool, totalStakedDollars)
-		farmApy := sdk.NewDecCoinsFromDec(farmPool.YieldedTokenInfos[0].RemainingAmount.Denom, apy)
+		poolRate, farmApy := calculateFarmPoolRateAndApy(ctx, keeper, farmPool, totalStakedDollars)
 
 		// calculate total farmed and claim infos
 		var unclaimed sdk.SysCoins
@@ -226,7 +221,7 @@ func queryFarmDashboard(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (
 		responseList = append(responseList, types.FarmPoolResponse{
 			PoolName:      farmPool.Name,
 			LockSymbol:    farmPool.MinLockAmount.Denom,
-			YieldSymbol:   farmPool.YieldedTokenInfos[0].RemainingAmount.Denom,
+			YieldSymbol:   getFarmPoolYieldSymbol(farmPool),
 			TotalStaked:   userStakedDollars,
 			UserStaked:    userStaked,
 			PoolRatio:     poolRatio,
@@ -459,26 +454,64 @@ func calculateAmountToDollars(ctx sdk.Context, keeper Keeper, amount sdk.SysCoin
 	return dollarAmount
 }
 
+// getFarmPoolYieldSymbol joins the symbols of all the tokens yielded by the farm pool
+func getFarmPoolYieldSymbol(farmPool farm.FarmPool) string {
+	symbols := make([]string, len(farmPool.YieldedTokenInfos))
+	for i, yieldedTokenInfo := range farmPool.YieldedTokenInfos {
+		symbols[i] = yieldedTokenInfo.RemainingAmount.Denom
+	}
+	return strings.Join(symbols, ",")
+}
+
+// getAmountYieldedPerBlock returns the amount yielded per block of the current emission phase, or of the first phase
+// if the token hasn't started to yield
+func getAmountYieldedPerBlock(ctx sdk.Context, yieldedTokenInfo farm.YieldedTokenInfo) sdk.Dec {
+	height := ctx.BlockHeight()
+	if height < yieldedTokenInfo.StartBlockHeightToYield {
+		height = yieldedTokenInfo.StartBlockHeightToYield
+	}
+	return yieldedTokenInfo.GetAmountYieldedPerBlock(height)
+}
+
+// calculates the earliest start time of the tokens yielded by the farm pool
 func calculateFarmPoolStartAt(ctx sdk.Context, farmPool farm.FarmPool) int64 {
-	if farmPool.YieldedTokenInfos[0].StartBlockHeightToYield == 0 {
+	var startHeight int64
+	for _, yieldedTokenInfo := range farmPool.YieldedTokenInfos {
+		if !yieldedTokenInfo.IsYielding() {
+			continue
+		}
+		if startHeight == 0 || yieldedTokenInfo.StartBlockHeightToYield < startHeight {
+			startHeight = yieldedTokenInfo.StartBlockHeightToYield
+		}
+	}
+	if startHeight == 0 {
 		return 0
 	}
 	blockTime := ctx.BlockTime().Unix()
-	return blockTime + (farmPool.YieldedTokenInfos[0].StartBlockHeightToYield-ctx.BlockHeight())*types.BlockInterval
+	return blockTime + (startHeight-ctx.BlockHeight())*types.BlockInterval
 }
 
+// calculates the latest finish time of the tokens yielded by the farm pool at their current rates
 func calculateFarmPoolFinishAt(ctx sdk.Context, keeper Keeper, farmPool farm.FarmPool, startAt int64) int64 {
 	var finishAt int64
 	updatedPool, _ := keeper.farmKeeper.CalculateAmountYieldedBetween(ctx, farmPool)
-	if updatedPool.YieldedTokenInfos[0].RemainingAmount.Amount.IsPositive() && updatedPool.YieldedTokenInfos[0].AmountYieldedPerBlock.IsPositive() {
-		blockTime := ctx.BlockTime().Unix()
-		if startAt > blockTime {
-			finishAt = startAt + updatedPool.YieldedTokenInfos[0].RemainingAmount.Amount.Quo(
-				updatedPool.YieldedTokenInfos[0].AmountYieldedPerBlock).TruncateInt64()*types.BlockInterval
-
-		} else {
-			finishAt = blockTime + updatedPool.YieldedTokenInfos[0].RemainingAmount.Amount.Quo(
-				updatedPool.YieldedTokenInfos[0].AmountYieldedPerBlock).TruncateInt64()*types.BlockInterval
+	blockTime := ctx.BlockTime().Unix()
+	for _, yieldedTokenInfo := range updatedPool.YieldedTokenInfos {
+		amountYieldedPerBlock := getAmountYieldedPerBlock(ctx, yieldedTokenInfo)
+		if !yieldedTokenInfo.RemainingAmount.Amount.IsPositive() || !amountYieldedPerBlock.IsPositive() {
+			continue
+		}
+		startHeight, tokenStartAt := ctx.BlockHeight(), blockTime
+		if yieldedTokenInfo.StartBlockHeightToYield > startHeight {
+			startHeight = yieldedTokenInfo.StartBlockHeightToYield
+			tokenStartAt = blockTime + (startHeight-ctx.BlockHeight())*types.BlockInterval
+		}
+		blocks := yieldedTokenInfo.RemainingAmount.Amount.Quo(amountYieldedPerBlock).TruncateInt64()
+		if yieldedTokenInfo.EndBlockHeightToYield != 0 && startHeight+blocks > yieldedTokenInfo.EndBlockHeightToYield {
+			blocks = yieldedTokenInfo.EndBlockHeightToYield - startHeight
+		}
+		if tokenFinishAt := tokenStartAt + blocks*types.BlockInterval; tokenFinishAt > finishAt {
+			finishAt = tokenFinishAt
 		}
 	}
 	return finishAt
@@ -512,8 +545,12 @@ func getFarmPoolStatus(startAt int64, finishAt int64, farmPool farm.FarmPool) ty
 	if startAt == 0 {
 		return types.FarmPoolCreated
 	}
-	if startAt > time.Now().Unix() && farmPool.YieldedTokenInfos[0].RemainingAmount.IsPositive() {
-		return types.FarmPoolProvided
+	if startAt > time.Now().Unix() {
+		for _, yieldedTokenInfo := range farmPool.YieldedTokenInfos {
+			if yieldedTokenInfo.RemainingAmount.IsPositive() {
+				return types.FarmPoolProvided
+			}
+		}
 	}
 	if time.Now().Unix() > startAt && time.Now().Unix() < finishAt {
 		return types.FarmPoolYielded
@@ -521,28 +558,50 @@ func getFarmPoolStatus(startAt int64, finishAt int64, farmPool farm.FarmPool) ty
 	return types.FarmPoolFinished
 }
 
+// calculates the pool rate per day and the apy of every token yielded by the farm pool at its current rate
+func calculateFarmPoolRateAndApy(ctx sdk.Context, keeper Keeper, farmPool farm.FarmPool, totalStakedDollars sdk.Dec) (
+	poolRate sdk.SysCoins, farmApy sdk.SysCoins) {
+	for _, yieldedTokenInfo := range farmPool.YieldedTokenInfos {
+		yieldedInDay := sdk.NewDecCoinFromDec(yieldedTokenInfo.RemainingAmount.Denom,
+			getAmountYieldedPerBlock(ctx, yieldedTokenInfo).MulInt64(int64(types.BlocksPerDay)))
+		apy := calculateYieldedTokenApy(ctx, keeper, farmPool, yieldedInDay, totalStakedDollars)
+		poolRate = poolRate.Add(yieldedInDay)
+		farmApy = farmApy.Add(sdk.NewDecCoinFromDec(yieldedInDay.Denom, apy))
+	}
+	return poolRate, farmApy
+}
+
+// calculates the sum of the apy of the tokens yielded by the farm pool
 func calculateFarmApy(ctx sdk.Context, keeper Keeper, farmPool farm.FarmPool, totalStakedDollars sdk.Dec) sdk.Dec {
-	if farmPool.YieldedTokenInfos[0].AmountYieldedPerBlock.IsZero() || farmPool.TotalValueLocked.Amount.IsZero() {
+	apy := sdk.ZeroDec()
+	for _, yieldedTokenInfo := range farmPool.YieldedTokenInfos {
+		yieldedInDay := sdk.NewDecCoinFromDec(yieldedTokenInfo.RemainingAmount.Denom,
+			getAmountYieldedPerBlock(ctx, yieldedTokenInfo).MulInt64(int64(types.BlocksPerDay)))
+		apy = apy.Add(calculateYieldedTokenApy(ctx, keeper, farmPool, yieldedInDay, totalStakedDollars))
+	}
+	return apy
+}
+
+func calculateYieldedTokenApy(ctx sdk.Context, keeper Keeper, farmPool farm.FarmPool, yieldedInDay sdk.SysCoin,
+	totalStakedDollars sdk.Dec) sdk.Dec {
+	if yieldedInDay.Amount.IsZero() || farmPool.TotalValueLocked.Amount.IsZero() {
 		return sdk.ZeroDec()
 	}
 
-	yieldedInDay := farmPool.YieldedTokenInfos[0].AmountYieldedPerBlock.MulInt64(int64(types.BlocksPerDay))
-	yieldedDollarsInDay := calculateAmountToDollars(ctx, keeper,
-		sdk.NewDecCoinFromDec(farmPool.YieldedTokenInfos[0].RemainingAmount.Denom, yieldedInDay))
+	yieldedDollarsInDay := calculateAmountToDollars(ctx, keeper, yieldedInDay)
 	if !totalStakedDollars.IsZero() && !yieldedDollarsInDay.IsZero() {
 		return yieldedDollarsInDay.Quo(totalStakedDollars).MulInt64(types.DaysInYear)
 	}
 
 	apy := sdk.ZeroDec()
-	tokenPairName := ammswap.GetSwapTokenPairName(farmPool.TotalValueLocked.Denom, farmPool.YieldedTokenInfos[0].RemainingAmount.Denom)
+	tokenPairName := ammswap.GetSwapTokenPairName(farmPool.TotalValueLocked.Denom, yieldedInDay.Denom)
 	swapTokenPair, err := keeper.swapKeeper.GetSwapTokenPair(ctx, tokenPairName)
 	if err == nil {
 		if swapTokenPair.QuotePooledCoin.Denom == farmPool.TotalValueLocked.Denom && swapTokenPair.BasePooledCoin.Amount.IsPositive() {
-			yieldedInDay.Mul(swapTokenPair.QuotePooledCoin.Amount.Quo(swapTokenPair.BasePooledCoin.Amount))
-			apy = common.MulAndQuo(yieldedInDay, swapTokenPair.QuotePooledCoin.Amount,
+			apy = common.MulAndQuo(yieldedInDay.Amount, swapTokenPair.QuotePooledCoin.Amount,
 				swapTokenPair.BasePooledCoin.Amount).Quo(farmPool.TotalValueLocked.Amount).MulInt64(types.DaysInYear)
 		} else if swapTokenPair.QuotePooledCoin.Amount.IsPositive() {
-			apy = common.MulAndQuo(yieldedInDay, swapTokenPair.BasePooledCoin.Amount,
+			apy = common.MulAndQuo(yieldedInDay.Amount, swapTokenPair.BasePooledCoin.Amount,
 				swapTokenPair.QuotePooledCoin.Amount).Quo(farmPool.TotalValueLocked.Amount).MulInt64(types.DaysInYear)
 		}
 	}
@@ -121,7 +121,7 @@ func querySwapWatchlist(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (
 		// calculate fee apy
 		feeApy := sdk.ZeroDec()
 		if liquidity.IsPositive() && liquidity.IsPositive() {
-			feeApy = volume24h.Mul(swapParams.FeeRate).Quo(liquidity).Mul(sdk.NewDec(365))
+			feeApy = volume24h.Mul(swapTokenPair.GetFeeRate(swapParams.FeeRate)).Quo(liquidity).Mul(sdk.NewDec(365))
 		}
 
 		// calculate price change
@@ -1148,12 +1148,12 @@ func (orm *ORM) GetOrderList(address, product, side string, open bool, offset, l
 		query = query.Where("product = ?", product)
 	}
 	if open {
-		query = query.Where("status = 0")
+		query = query.Where("status in (0, 6)")
 	} else {
 		if hideNoFill {
 			query = query.Where("status in (1, 4, 5)")
 		} else {
-			query = query.Where("status > 0")
+			query = query.Where("status in (1, 2, 3, 4, 5)")
 		}
 	}
 
@@ -1405,9 +1405,9 @@ func (orm *ORM) GetOrderListV2(instrumentID string, address string, side string,
 	}
 
 	if open {
-		query = query.Where("status = 0")
+		query = query.Where("status in (0, 6)")
 	} else {
-		query = query.Where("status > 0")
+		query = query.Where("status in (1, 2, 3, 4, 5)")
 	}
 
 	query.Order("timestamp desc").Limit(limit).Find(&orders)
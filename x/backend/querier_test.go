@@ -12,6 +12,7 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/backend/types"
 	"github.com/okex/exchain/x/common"
+	farm "github.com/okex/exchain/x/farm/types"
 	orderTypes "github.com/okex/exchain/x/order/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -203,3 +204,50 @@ func TestQuerier_QueryTxList(t *testing.T) {
 	}
 
 }
+
+func TestQuerier_QueryFarmPools(t *testing.T) {
+	mapp, _ := getMockApp(t, 1, true, "")
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	blockTime := int64(1600000000)
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockTime(time.Unix(blockTime, 0)).WithBlockHeight(20)
+	querier := NewQuerier(mapp.backendKeeper)
+
+	// both tokens start to yield at the height of 30, and the rate of aab changes at the height of 40
+	aabInfo := farm.NewYieldedTokenInfo(sdk.NewDecCoinFromDec("aab", sdk.NewDec(1000)), 30, sdk.NewDec(1))
+	aabInfo.EmissionPhases = []farm.EmissionPhase{farm.NewEmissionPhase(40, sdk.NewDec(3))}
+	bbbInfo := farm.NewYieldedTokenInfo(sdk.NewDecCoinFromDec("bbb", sdk.NewDec(1000)), 30, sdk.NewDec(2))
+	bbbInfo.EndBlockHeightToYield = 50
+	pool := farm.NewFarmPool(nil, "pool", sdk.NewDecCoinFromDec("xxb", sdk.ZeroDec()),
+		sdk.NewDecCoinFromDec(common.NativeToken, sdk.ZeroDec()), sdk.NewDecCoinFromDec("xxb", sdk.ZeroDec()),
+		farm.NewYieldedTokenInfos(aabInfo, bbbInfo), nil)
+	mapp.farmKeeper.SetFarmPool(ctx, pool)
+	mapp.farmKeeper.SetPoolCurrentRewards(ctx, pool.Name, farm.NewPoolCurrentRewards(20, 1, sdk.SysCoins{}))
+
+	queryFarmPools := func(ctx sdk.Context) types.FarmPoolResponse {
+		params := types.NewQueryFarmPoolsParams(types.NormalFarmPool, "", "", 1, 10)
+		bz, err := querier(ctx, []string{types.QueryFarmPools}, abci.RequestQuery{Data: mapp.Cdc.MustMarshalJSON(params)})
+		require.Nil(t, err)
+		var response struct {
+			Data struct {
+				Data []types.FarmPoolResponse `json:"data"`
+			} `json:"data"`
+		}
+		require.Nil(t, json.Unmarshal(bz, &response))
+		require.Equal(t, 1, len(response.Data.Data))
+		return response.Data.Data[0]
+	}
+
+	// the pool yields all the tokens at their first rates before it starts
+	response := queryFarmPools(ctx)
+	require.Equal(t, "aab,bbb", response.YieldSymbol)
+	require.Equal(t, blockTime+10*types.BlockInterval, response.StartAt)
+	require.Equal(t, sdk.NewDecCoinsFromDec("aab", sdk.NewDec(types.BlocksPerDay)).
+		Add(sdk.NewDecCoinFromDec("bbb", sdk.NewDec(2*types.BlocksPerDay))), response.PoolRate)
+	// aab yields 1000 blocks at the rate of 1, bbb stops at the height of 50
+	require.Equal(t, blockTime+(10+1000)*types.BlockInterval, response.FinishAt)
+
+	// the rate of aab changes by its emission phase
+	response = queryFarmPools(ctx.WithBlockHeight(45))
+	require.Equal(t, sdk.NewDecCoinsFromDec("aab", sdk.NewDec(3*types.BlocksPerDay)).
+		Add(sdk.NewDecCoinFromDec("bbb", sdk.NewDec(2*types.BlocksPerDay))), response.PoolRate)
+}
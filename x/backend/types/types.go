@@ -103,6 +103,9 @@ type Order struct {
 	FilledAvgPrice string `gorm:"type:varchar(40)" json:"filled_avg_price" v2:"filled_avg_price"`
 	RemainQuantity string `gorm:"type:varchar(40)" json:"remain_quantity" v2:"remain_quantity"`
 	Timestamp      int64  `gorm:"index;" json:"timestamp" v2:"timestamp"`
+	Type           string `gorm:"type:varchar(20)" json:"type" v2:"type"`
+	TimeInForce    string `gorm:"type:varchar(20)" json:"time_in_force" v2:"time_in_force"`
+	StopPrice      string `gorm:"type:varchar(40)" json:"stop_price" v2:"stop_price"`
 }
 
 type Transaction struct {
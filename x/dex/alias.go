@@ -38,13 +38,14 @@ type (
 	StreamKeeper        = keeper.StreamKeeper
 
 	// Messages
-	MsgList              = types.MsgList
-	MsgDeposit           = types.MsgDeposit
-	MsgWithdraw          = types.MsgWithdraw
-	MsgTransferOwnership = types.MsgTransferOwnership
-	MsgConfirmOwnership  = types.MsgConfirmOwnership
-	MsgUpdateOperator    = types.MsgUpdateOperator
-	MsgCreateOperator    = types.MsgCreateOperator
+	MsgList                = types.MsgList
+	MsgDeposit             = types.MsgDeposit
+	MsgWithdraw            = types.MsgWithdraw
+	MsgTransferOwnership   = types.MsgTransferOwnership
+	MsgConfirmOwnership    = types.MsgConfirmOwnership
+	MsgUpdateOperator      = types.MsgUpdateOperator
+	MsgCreateOperator      = types.MsgCreateOperator
+	MsgUpdateProductParams = types.MsgUpdateProductParams
 
 	TokenPair     = types.TokenPair
 	Params        = types.Params
@@ -52,6 +53,7 @@ type (
 	WithdrawInfos = types.WithdrawInfos
 	DEXOperator   = types.DEXOperator
 	DEXOperators  = types.DEXOperators
+	ProductParams = types.ProductParams
 )
 
 var (
@@ -33,6 +33,7 @@ const (
 	FlagTo                 = "to"
 	FlagWebsite            = "website"
 	FlagHandlingFeeAddress = "handling-fee-address"
+	FlagAuctionType        = "auction-type"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -50,6 +51,7 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		getCmdConfirmOwnership(cdc),
 		getCmdRegisterOperator(cdc),
 		getCmdEditOperator(cdc),
+		getCmdUpdateProductParams(cdc),
 	)...)
 
 	return txCmd
@@ -376,3 +378,43 @@ $ exchaincli tx dex edit-operator --website http://xxx/operator.json --handling-
 
 	return cmd
 }
+
+func getCmdUpdateProductParams(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-product-params",
+		Short: "update the params of a product",
+		Args:  cobra.ExactArgs(0),
+		Long: strings.TrimSpace(fmt.Sprintf(`Update the params of a product by its owner:
+
+$ exchaincli tx dex update-product-params --product mytoken_okt --auction-type %s --from mykey
+`, types.AuctionTypeContinuous)),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := auth.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			product, err := flags.GetString(FlagProduct)
+			if err != nil {
+				return err
+			}
+			auctionType, err := flags.GetString(FlagAuctionType)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgUpdateProductParams(cliCtx.GetFromAddress(), product, auctionType)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringP(FlagProduct, "p", "", "product whose params are updated")
+	cmd.Flags().String(FlagAuctionType, types.AuctionTypePeriodic,
+		fmt.Sprintf("how the orders of the product are matched, %s or %s", types.AuctionTypePeriodic, types.AuctionTypeContinuous))
+
+	return cmd
+}
@@ -16,6 +16,7 @@ type GenesisState struct {
 	ProductLocks   ordertypes.ProductLockMap `json:"product_locks"`
 	Operators      DEXOperators              `json:"operators"`
 	MaxTokenPairID uint64                    `json:"max_token_pair_id" yaml:"max_token_pair_id"`
+	ProductParams  map[string]ProductParams  `json:"product_params,omitempty"`
 }
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
@@ -38,6 +39,11 @@ func ValidateGenesis(data GenesisState) error {
 			return fmt.Errorf("invalid tx tokenPair ID: %d", pair.ID)
 		}
 	}
+	for product, productParams := range data.ProductParams {
+		if err := types.ValidateAuctionType(productParams.AuctionType); err != nil {
+			return fmt.Errorf("invalid params of product %s: %s", product, err)
+		}
+	}
 	return nil
 }
 
@@ -77,6 +83,10 @@ func InitGenesis(ctx sdk.Context, keeper IKeeper, data GenesisState) {
 	for k, v := range data.ProductLocks.Data {
 		keeper.LockTokenPair(ctx, k, v)
 	}
+
+	for product, productParams := range data.ProductParams {
+		keeper.SetProductParams(ctx, product, productParams)
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -98,6 +108,15 @@ func ExportGenesis(ctx sdk.Context, keeper IKeeper) (data GenesisState) {
 		withdrawInfos = append(withdrawInfos, withdrawInfo)
 		return false
 	})
+
+	var productParams map[string]ProductParams
+	keeper.IterateProductParams(ctx, func(product string, params ProductParams) bool {
+		if productParams == nil {
+			productParams = make(map[string]ProductParams)
+		}
+		productParams[product] = params
+		return false
+	})
 	return GenesisState{
 		Params:         params,
 		TokenPairs:     tokenPairs,
@@ -105,5 +124,6 @@ func ExportGenesis(ctx sdk.Context, keeper IKeeper) (data GenesisState) {
 		ProductLocks:   *keeper.LoadProductLocks(ctx),
 		Operators:      operators,
 		MaxTokenPairID: keeper.GetMaxTokenPairID(ctx),
+		ProductParams:  productParams,
 	}
 }
@@ -16,8 +16,10 @@ import (
 // NewHandler handles all "dex" type messages.
 func NewHandler(k IKeeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
-		// disable dex tx handler
-		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Dex messages are not allowd.")
+		// dex messages are enabled after the venus height
+		if !sdk.HigherThanVenus(ctx.BlockHeight()) {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Dex messages are not allowd.")
+		}
 
 		ctx = ctx.WithEventManager(sdk.NewEventManager())
 		logger := ctx.Logger().With("module", ModuleName)
@@ -60,6 +62,11 @@ func NewHandler(k IKeeper) sdk.Handler {
 			handlerFun = func() (*sdk.Result, error) {
 				return handleMsgUpdateOperator(ctx, k, msg, logger)
 			}
+		case MsgUpdateProductParams:
+			name = "handleMsgUpdateProductParams"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgUpdateProductParams(ctx, k, msg, logger)
+			}
 		default:
 			return types.ErrDexUnknownMsgType(msg.Type()).Result()
 		}
@@ -329,3 +336,30 @@ func handleMsgUpdateOperator(ctx sdk.Context, keeper IKeeper, msg MsgUpdateOpera
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+func handleMsgUpdateProductParams(ctx sdk.Context, keeper IKeeper, msg MsgUpdateProductParams,
+	logger log.Logger) (*sdk.Result, error) {
+	tokenPair := keeper.GetTokenPair(ctx, msg.Product)
+	if tokenPair == nil {
+		return types.ErrTokenPairNotFound(msg.Product).Result()
+	}
+	if !tokenPair.Owner.Equals(msg.Owner) {
+		return types.ErrUnauthorized(msg.Owner.String(), msg.Product).Result()
+	}
+
+	productParams := keeper.GetProductParams(ctx, msg.Product)
+	productParams.AuctionType = msg.AuctionType
+	keeper.SetProductParams(ctx, msg.Product, productParams)
+
+	logger.Debug(fmt.Sprintf("successfully handleMsgUpdateProductParams: "+
+		"BlockHeight: %d, Msg: %+v", ctx.BlockHeight(), msg))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, ModuleName),
+			sdk.NewAttribute("auction-type", productParams.AuctionType),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -2,6 +2,7 @@ package dex
 
 import (
 	"github.com/okex/exchain/x/common"
+	"os"
 	"testing"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
@@ -10,6 +11,12 @@ import (
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 )
 
+func TestMain(m *testing.M) {
+	// the dex messages are enabled after the venus height
+	sdk.UnittestOnlySetMilestoneVenusHeight(-1)
+	os.Exit(m.Run())
+}
+
 func getMockTestCaseEvn(t *testing.T) (mApp *mockApp,
 	tkKeeper *mockTokenKeeper, spKeeper *mockSupplyKeeper, dexKeeper *mockDexKeeper, testContext sdk.Context) {
 	fakeTokenKeeper := newMockTokenKeeper()
@@ -161,3 +168,31 @@ func TestHandler_handleMsgTransferOwnership(t *testing.T) {
 	spKeeper.behaveEvil = false
 	handlerFunctor(ctx, msgFailedConfirmOwnership)
 }
+
+func TestHandler_HandleMsgUpdateProductParams(t *testing.T) {
+	mApp, _, _, mDexKeeper, ctx := getMockTestCaseEvn(t)
+
+	tokenPair := GetBuiltInTokenPair()
+	err := mDexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	handlerFunctor := NewHandler(mApp.dexKeeper)
+
+	// fail case : the address is not the owner of product
+	msg := types.NewMsgUpdateProductParams(mApp.GenesisAccounts[0].GetAddress(), tokenPair.Name(), types.AuctionTypeContinuous)
+	_, err = handlerFunctor(ctx, msg)
+	require.NotNil(t, err)
+	require.False(t, mDexKeeper.GetProductParams(ctx, tokenPair.Name()).IsContinuousAuction())
+
+	// fail case : the dex messages are disabled before the venus height
+	sdk.UnittestOnlySetMilestoneVenusHeight(10)
+	msg = types.NewMsgUpdateProductParams(tokenPair.Owner, tokenPair.Name(), types.AuctionTypeContinuous)
+	_, err = handlerFunctor(ctx.WithBlockHeight(10), msg)
+	sdk.UnittestOnlySetMilestoneVenusHeight(-1)
+	require.NotNil(t, err)
+	require.False(t, mDexKeeper.GetProductParams(ctx, tokenPair.Name()).IsContinuousAuction())
+
+	// successful case
+	_, err = handlerFunctor(ctx, msg)
+	require.Nil(t, err)
+	require.True(t, mDexKeeper.GetProductParams(ctx, tokenPair.Name()).IsContinuousAuction())
+}
@@ -61,6 +61,9 @@ type IKeeper interface {
 	DeleteConfirmOwnership(ctx sdk.Context, product string)
 	UpdateUserTokenPair(ctx sdk.Context, product string, owner, to sdk.AccAddress)
 	UpdateTokenPair(ctx sdk.Context, product string, tokenPair *types.TokenPair)
+	GetProductParams(ctx sdk.Context, product string) types.ProductParams
+	SetProductParams(ctx sdk.Context, product string, productParams types.ProductParams)
+	IterateProductParams(ctx sdk.Context, cb func(product string, productParams types.ProductParams) (stop bool))
 }
 
 // StakingKeeper defines the expected staking Keeper (noalias)
@@ -178,6 +178,8 @@ func (k Keeper) DeleteTokenPairByName(ctx sdk.Context, owner sdk.AccAddress, pro
 	store.Delete(types.GetTokenPairAddress(product))
 	// remove the user-tokenpair relationship
 	k.deleteUserTokenPair(ctx, owner, product)
+	// remove the params of the token pair
+	ctx.KVStore(k.storeKey).Delete(types.GetProductParamsKey(product))
 
 	if k.observerKeeper != nil {
 		k.observerKeeper.OnTokenPairUpdated(ctx)
@@ -495,3 +497,37 @@ func (k Keeper) DeleteConfirmOwnership(ctx sdk.Context, product string) {
 	key := types.GetConfirmOwnershipKey(product)
 	store.Delete(key)
 }
+
+// GetProductParams returns the params of the product, the default ones if they have never been updated
+func (k Keeper) GetProductParams(ctx sdk.Context, product string) types.ProductParams {
+	store := ctx.KVStore(k.storeKey)
+	bytes := store.Get(types.GetProductParamsKey(product))
+	if bytes == nil {
+		return types.DefaultProductParams()
+	}
+
+	var productParams types.ProductParams
+	k.cdc.MustUnmarshalBinaryBare(bytes, &productParams)
+	return productParams
+}
+
+// SetProductParams sets the params of the product to db
+func (k Keeper) SetProductParams(ctx sdk.Context, product string, productParams types.ProductParams) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetProductParamsKey(product), k.cdc.MustMarshalBinaryBare(productParams))
+}
+
+// IterateProductParams iterates over the params of the products which have been updated
+func (k Keeper) IterateProductParams(ctx sdk.Context, cb func(product string, productParams types.ProductParams) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ProductParamsKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var productParams types.ProductParams
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &productParams)
+		if cb(types.GetKey(iterator), productParams) {
+			break
+		}
+	}
+}
@@ -127,6 +127,28 @@ func TestDeleteTokenPairByName(t *testing.T) {
 	require.EqualValues(t, 0, len(userTokenPairs))
 }
 
+func TestProductParams(t *testing.T) {
+	common.InitConfig()
+	testInput := createTestInputWithBalance(t, 1, 10000)
+	ctx := testInput.Ctx
+	keeper := testInput.DexKeeper
+	tokenPair := getTestTokenPair()
+	product := tokenPair.Name()
+
+	err := keeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	require.Equal(t, types.DefaultProductParams(), keeper.GetProductParams(ctx, product))
+
+	productParams := types.ProductParams{AuctionType: types.AuctionTypeContinuous}
+	keeper.SetProductParams(ctx, product, productParams)
+	require.Equal(t, productParams, keeper.GetProductParams(ctx, product))
+	require.True(t, keeper.GetProductParams(ctx, product).IsContinuousAuction())
+
+	// the params are dropped along with the token pair
+	keeper.DeleteTokenPairByName(ctx, tokenPair.Owner, product)
+	require.Equal(t, types.DefaultProductParams(), keeper.GetProductParams(ctx, product))
+}
+
 func TestUpdateTokenPair(t *testing.T) {
 	common.InitConfig()
 	testInput := createTestInputWithBalance(t, 1, 10000)
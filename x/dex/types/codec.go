@@ -12,6 +12,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(DelistProposal{}, "okexchain/dex/DelistProposal", nil)
 	cdc.RegisterConcrete(MsgCreateOperator{}, "okexchain/dex/CreateOperator", nil)
 	cdc.RegisterConcrete(MsgUpdateOperator{}, "okexchain/dex/UpdateOperator", nil)
+	cdc.RegisterConcrete(MsgUpdateProductParams{}, "okexchain/dex/UpdateProductParams", nil)
 }
 
 // ModuleCdc represents generic sealed codec to be used throughout this module
@@ -43,6 +43,7 @@ const (
 	CodeIsTransferringOwner         uint32 = 64031
 	CodeTransferOwnerExpired        uint32 = 64032
 	CodeUnauthorizedOperator        uint32 = 64033
+	CodeInvalidAuctionType          uint32 = 64034
 )
 
 // Addr and Product All Required
@@ -186,3 +187,7 @@ func ErrIsTransferOwnerExpired(time string) sdk.EnvelopedErr {
 func ErrUnauthorizedOperator(operator, owner string) sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeUnauthorizedOperator, fmt.Sprintf("%s is not the owner of operator(%s)", owner, operator))}
 }
+
+func ErrInvalidAuctionType(auctionType string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidAuctionType, fmt.Sprintf("invalid auction type (%s), expected %s or %s", auctionType, AuctionTypePeriodic, AuctionTypeContinuous))}
+}
@@ -63,6 +63,8 @@ var (
 	UserTokenPairKeyPrefix = []byte{0x06}
     //the prefix of the confirm ownership key
 	PrefixConfirmOwnershipKey = []byte{0x07}
+	// ProductParamsKeyPrefix is the store key prefix for the params of the products
+	ProductParamsKeyPrefix = []byte{0x08}
 )
 
 // GetUserTokenPairAddressPrefix returns token pair address prefix key
@@ -126,4 +128,9 @@ func GetOperatorAddressKey(addr sdk.AccAddress) []byte {
 
 func GetConfirmOwnershipKey(product string) []byte {
 	return append(PrefixConfirmOwnershipKey, []byte(product)...)
-}
\ No newline at end of file
+}
+
+// GetProductParamsKey returns key of the params of the product
+func GetProductParamsKey(product string) []byte {
+	return append(ProductParamsKeyPrefix, []byte(product)...)
+}
@@ -9,11 +9,12 @@ import (
 )
 
 const (
-	typeMsgDeposit           = "deposit"
-	typeMsgWithdraw          = "withdraw"
-	typeMsgTransferOwnership = "transferOwnership"
-	typeMsgUpdateOperator    = "updateOperator"
-	typeMsgCreateOperator    = "createOperator"
+	typeMsgDeposit             = "deposit"
+	typeMsgWithdraw            = "withdraw"
+	typeMsgTransferOwnership   = "transferOwnership"
+	typeMsgUpdateOperator      = "updateOperator"
+	typeMsgCreateOperator      = "createOperator"
+	typeMsgUpdateProductParams = "updateProductParams"
 )
 
 // MsgList - high level transaction of the dex module
@@ -340,3 +341,47 @@ func checkWebsite(website string) sdk.Error {
 	}
 	return nil
 }
+
+// MsgUpdateProductParams updates the params of a product by its owner
+type MsgUpdateProductParams struct {
+	Owner       sdk.AccAddress `json:"owner"`
+	Product     string         `json:"product"`
+	AuctionType string         `json:"auction_type"`
+}
+
+// NewMsgUpdateProductParams creates a new MsgUpdateProductParams
+func NewMsgUpdateProductParams(owner sdk.AccAddress, product, auctionType string) MsgUpdateProductParams {
+	return MsgUpdateProductParams{
+		Owner:       owner,
+		Product:     product,
+		AuctionType: auctionType,
+	}
+}
+
+// Route Implements Msg
+func (msg MsgUpdateProductParams) Route() string { return RouterKey }
+
+// Type Implements Msg
+func (msg MsgUpdateProductParams) Type() string { return typeMsgUpdateProductParams }
+
+// ValidateBasic Implements Msg
+func (msg MsgUpdateProductParams) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return ErrAddressIsRequired("owner")
+	}
+	if len(msg.Product) == 0 {
+		return ErrTokenPairIsRequired()
+	}
+	return ValidateAuctionType(msg.AuctionType)
+}
+
+// GetSignBytes Implements Msg
+func (msg MsgUpdateProductParams) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg
+func (msg MsgUpdateProductParams) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
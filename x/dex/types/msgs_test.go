@@ -88,6 +88,11 @@ func TestMsg(t *testing.T) {
 		{"transfer-no-from", NewMsgTransferOwnership(nil, toAddr, product), false},
 		{"transfer-no-to", NewMsgTransferOwnership(fromAddr, nil, product), false},
 		{"transfer-no-product", NewMsgTransferOwnership(fromAddr, toAddr, ""), false},
+
+		{"update-product-params", NewMsgUpdateProductParams(fromAddr, product, AuctionTypeContinuous), true},
+		{"update-product-params-no-owner", NewMsgUpdateProductParams(nil, product, AuctionTypeContinuous), false},
+		{"update-product-params-no-product", NewMsgUpdateProductParams(fromAddr, "", AuctionTypeContinuous), false},
+		{"update-product-params-invalid-auction-type", NewMsgUpdateProductParams(fromAddr, product, "auction"), false},
 	}
 	for _, tb := range testBasics {
 		t.Run(tb.name, func(t *testing.T) {
@@ -0,0 +1,34 @@
+package types
+
+// nolint
+const (
+	AuctionTypePeriodic   = "periodicauction"
+	AuctionTypeContinuous = "continuousauction"
+)
+
+// ProductParams are the params of a single product, the ones not set are the defaults
+type ProductParams struct {
+	AuctionType string `json:"auction_type"`
+}
+
+// DefaultProductParams returns the params of the products which have never been updated
+func DefaultProductParams() ProductParams {
+	return ProductParams{
+		AuctionType: AuctionTypePeriodic,
+	}
+}
+
+// IsContinuousAuction returns true if the orders of the product are matched at once
+func (p ProductParams) IsContinuousAuction() bool {
+	return p.AuctionType == AuctionTypeContinuous
+}
+
+// ValidateAuctionType checks if the auction type is known
+func ValidateAuctionType(auctionType string) error {
+	switch auctionType {
+	case AuctionTypePeriodic, AuctionTypeContinuous:
+		return nil
+	default:
+		return ErrInvalidAuctionType(auctionType)
+	}
+}
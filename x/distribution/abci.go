@@ -17,6 +17,11 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper)
 		previousTotalPower += voteInfo.Validator.Power
 	}
 
+	// the delegators of the validators created before the venus height start to get rewards from now on
+	if sdk.HigherThanVenus(ctx.BlockHeight()) && !sdk.HigherThanVenus(ctx.BlockHeight()-1) {
+		k.InitializeDelegatorRewards(ctx)
+	}
+
 	// TODO this is Tendermint-dependent
 	// ref https://github.com/cosmos/cosmos-sdk/issues/3095
 	if ctx.BlockHeight() > tmtypes.GetStartBlockHeight()+1 {
@@ -3,6 +3,7 @@ package distribution
 import (
 	"testing"
 
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/distribution/keeper"
 	"github.com/stretchr/testify/require"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
@@ -24,3 +25,17 @@ func TestBeginBlocker(t *testing.T) {
 		require.Equal(t, k.GetPreviousProposerConsAddr(ctx), valConsAddrs[index])
 	}
 }
+
+func TestBeginBlockerInitializeDelegatorRewards(t *testing.T) {
+	sdk.UnittestOnlySetMilestoneVenusHeight(5)
+	defer sdk.UnittestOnlySetMilestoneVenusHeight(0)
+
+	valOpAddrs, _, _ := keeper.GetTestAddrs()
+	ctx, _, k, _, _ := keeper.CreateTestInputDefault(t, false, 1000)
+	for i := int64(1); i <= 6; i++ {
+		ctx = ctx.WithBlockHeight(i)
+		BeginBlocker(ctx, abci.RequestBeginBlock{Header: abci.Header{Height: i}}, k)
+		// the rewards records of the validators created before are initialized at the venus height
+		require.Equal(t, i > 5, k.HasValidatorCurrentRewards(ctx, valOpAddrs[0]))
+	}
+}
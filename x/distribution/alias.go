@@ -20,6 +20,8 @@ const (
 	QueryParams                 = types.QueryParams
 	QueryValidatorCommission    = types.QueryValidatorCommission
 	QueryWithdrawAddr           = types.QueryWithdrawAddr
+	QueryDelegationRewards      = types.QueryDelegationRewards
+	QueryDelegatorTotalRewards  = types.QueryDelegatorTotalRewards
 	ParamWithdrawAddrEnabled    = types.ParamWithdrawAddrEnabled
 	DefaultParamspace           = types.DefaultParamspace
 )
@@ -43,6 +45,9 @@ var (
 	ValidateGenesis                          = types.ValidateGenesis
 	NewMsgSetWithdrawAddress                 = types.NewMsgSetWithdrawAddress
 	NewMsgWithdrawValidatorCommission        = types.NewMsgWithdrawValidatorCommission
+	NewMsgWithdrawDelegatorReward            = types.NewMsgWithdrawDelegatorReward
+	NewQueryDelegationRewardsParams          = types.NewQueryDelegationRewardsParams
+	NewQueryDelegatorParams                  = types.NewQueryDelegatorParams
 	NewQueryValidatorCommissionParams        = types.NewQueryValidatorCommissionParams
 	NewQueryDelegatorWithdrawAddrParams      = types.NewQueryDelegatorWithdrawAddrParams
 	InitialValidatorAccumulatedCommission    = types.InitialValidatorAccumulatedCommission
@@ -57,6 +62,8 @@ var (
 	EventTypeCommission                  = types.EventTypeCommission
 	EventTypeWithdrawCommission          = types.EventTypeWithdrawCommission
 	EventTypeProposerReward              = types.EventTypeProposerReward
+	EventTypeRewards                     = types.EventTypeRewards
+	EventTypeWithdrawRewards             = types.EventTypeWithdrawRewards
 	AttributeKeyWithdrawAddress          = types.AttributeKeyWithdrawAddress
 	AttributeKeyValidator                = types.AttributeKeyValidator
 	AttributeValueCategory               = types.AttributeValueCategory
@@ -71,6 +78,10 @@ type (
 	GenesisState                         = types.GenesisState
 	MsgSetWithdrawAddress                = types.MsgSetWithdrawAddress
 	MsgWithdrawValidatorCommission       = types.MsgWithdrawValidatorCommission
+	MsgWithdrawDelegatorReward           = types.MsgWithdrawDelegatorReward
+	QueryDelegationRewardsParams         = types.QueryDelegationRewardsParams
+	QueryDelegatorParams                 = types.QueryDelegatorParams
+	QueryDelegatorTotalRewardsResponse   = types.QueryDelegatorTotalRewardsResponse
 	QueryValidatorCommissionParams       = types.QueryValidatorCommissionParams
 	QueryDelegatorWithdrawAddrParams     = types.QueryDelegatorWithdrawAddrParams
 	ValidatorAccumulatedCommission       = types.ValidatorAccumulatedCommission
@@ -33,6 +33,7 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 
 	distTxCmd.AddCommand(flags.PostCommands(
 		GetCmdWithdrawRewards(cdc),
+		GetCmdWithdrawDelegatorReward(cdc),
 		GetCmdSetWithdrawAddr(cdc),
 	)...)
 
@@ -104,6 +105,38 @@ $ %s tx distr withdraw-rewards exvaloper1alq9na49n9yycysh889rl90g9nhe58lcqkfpfg
 	return cmd
 }
 
+// GetCmdWithdrawDelegatorReward command to withdraw the rewards of the shares added to a validator by a delegator
+func GetCmdWithdrawDelegatorReward(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdraw-delegator-reward [validator-addr]",
+		Short: "withdraw the delegator rewards from a validator",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Withdraw the rewards of the shares added to a validator by the delegator.
+
+Example:
+$ %s tx distr withdraw-delegator-reward exvaloper1alq9na49n9yycysh889rl90g9nhe58lcqkfpfg --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			delAddr := cliCtx.GetFromAddress()
+			valAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgWithdrawDelegatorReward(delAddr, valAddr)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
 // GetCmdSubmitProposal implements the command to submit a community-pool-spend proposal
 func GetCmdSubmitProposal(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
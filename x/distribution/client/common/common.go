@@ -40,6 +40,24 @@ func QueryValidatorCommission(cliCtx context.CLIContext, queryRoute string, vali
 	return res, err
 }
 
+// QueryDelegationRewards returns the pending rewards of the shares added to a validator by a delegator
+func QueryDelegationRewards(cliCtx context.CLIContext, queryRoute string, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) ([]byte, int64, error) {
+	return cliCtx.QueryWithData(
+		fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDelegationRewards),
+		cliCtx.Codec.MustMarshalJSON(types.NewQueryDelegationRewardsParams(delAddr, valAddr)),
+	)
+}
+
+// QueryDelegatorTotalRewards returns the pending rewards of all the shares added to validators by a delegator
+func QueryDelegatorTotalRewards(cliCtx context.CLIContext, queryRoute string, delAddr sdk.AccAddress) (
+	[]byte, int64, error) {
+	return cliCtx.QueryWithData(
+		fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDelegatorTotalRewards),
+		cliCtx.Codec.MustMarshalJSON(types.NewQueryDelegatorParams(delAddr)),
+	)
+}
+
 // WithdrawValidatorRewardsAndCommission builds a two-message message slice to be
 // used to withdraw both validation's commission and self-delegation reward.
 func WithdrawValidatorRewardsAndCommission(validatorAddr sdk.ValAddress) ([]sdk.Msg, error) {
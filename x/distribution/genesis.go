@@ -24,6 +24,19 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, supplyKeeper types.SupplyKeeper
 		keeper.SetValidatorAccumulatedCommission(ctx, acc.ValidatorAddress, acc.Accumulated)
 		moduleHoldings = moduleHoldings.Add(acc.Accumulated...)
 	}
+	for _, rec := range data.OutstandingRewards {
+		keeper.SetValidatorOutstandingRewards(ctx, rec.ValidatorAddress, rec.OutstandingRewards)
+		moduleHoldings = moduleHoldings.Add(rec.OutstandingRewards...)
+	}
+	for _, his := range data.ValidatorHistoricalRewards {
+		keeper.SetValidatorHistoricalRewards(ctx, his.ValidatorAddress, his.Period, his.Rewards)
+	}
+	for _, cur := range data.ValidatorCurrentRewards {
+		keeper.SetValidatorCurrentRewards(ctx, cur.ValidatorAddress, cur.Rewards)
+	}
+	for _, del := range data.DelegatorStartingInfos {
+		keeper.SetDelegatorStartingInfo(ctx, del.ValidatorAddress, del.DelegatorAddress, del.StartingInfo)
+	}
 	moduleHoldings = moduleHoldings.Add(data.FeePool.CommunityPool...)
 
 	// check if the module account exists
@@ -64,5 +77,53 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) types.GenesisState {
 		},
 	)
 
-	return types.NewGenesisState(params, feePool, dwi, pp, acc)
+	outstanding := make([]types.ValidatorOutstandingRewardsRecord, 0)
+	keeper.IterateValidatorOutstandingRewards(ctx,
+		func(addr sdk.ValAddress, rewards types.ValidatorOutstandingRewards) (stop bool) {
+			outstanding = append(outstanding, types.ValidatorOutstandingRewardsRecord{
+				ValidatorAddress:   addr,
+				OutstandingRewards: rewards,
+			})
+			return false
+		},
+	)
+	his := make([]types.ValidatorHistoricalRewardsRecord, 0)
+	keeper.IterateValidatorHistoricalRewards(ctx,
+		func(val sdk.ValAddress, period uint64, rewards types.ValidatorHistoricalRewards) (stop bool) {
+			his = append(his, types.ValidatorHistoricalRewardsRecord{
+				ValidatorAddress: val,
+				Period:           period,
+				Rewards:          rewards,
+			})
+			return false
+		},
+	)
+	cur := make([]types.ValidatorCurrentRewardsRecord, 0)
+	keeper.IterateValidatorCurrentRewards(ctx,
+		func(val sdk.ValAddress, rewards types.ValidatorCurrentRewards) (stop bool) {
+			cur = append(cur, types.ValidatorCurrentRewardsRecord{
+				ValidatorAddress: val,
+				Rewards:          rewards,
+			})
+			return false
+		},
+	)
+	dels := make([]types.DelegatorStartingInfoRecord, 0)
+	keeper.IterateDelegatorStartingInfos(ctx,
+		func(val sdk.ValAddress, del sdk.AccAddress, info types.DelegatorStartingInfo) (stop bool) {
+			dels = append(dels, types.DelegatorStartingInfoRecord{
+				ValidatorAddress: val,
+				DelegatorAddress: del,
+				StartingInfo:     info,
+			})
+			return false
+		},
+	)
+
+	genesisState := types.NewGenesisState(params, feePool, dwi, pp, acc)
+	genesisState.OutstandingRewards = outstanding
+	genesisState.ValidatorHistoricalRewards = his
+	genesisState.ValidatorCurrentRewards = cur
+	genesisState.DelegatorStartingInfos = dels
+	return genesisState
 }
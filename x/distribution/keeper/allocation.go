@@ -144,16 +144,47 @@ func (k Keeper) allocateByShares(ctx sdk.Context, rewards sdk.SysCoins) sdk.SysC
 
 // AllocateTokensToValidator allocate tokens to a particular validator, splitting according to commissions
 func (k Keeper) AllocateTokensToValidator(ctx sdk.Context, val exported.ValidatorI, tokens sdk.SysCoins) {
-	// split tokens between validator and delegators according to commissions
-	// commissions is always 1.0, so tokens.MulDec(val.GetCommission()) = tokens
-	// only update current commissions
-	commission := k.GetValidatorAccumulatedCommission(ctx, val.GetOperator())
-	commission = commission.Add(tokens...)
-	k.SetValidatorAccumulatedCommission(ctx, val.GetOperator(), commission)
+	// split tokens between validator and delegators according to commissions, the tokens are all the commission of
+	// the validator before the venus height
+	commission := tokens
+	if delegatorRewardsEnabled(ctx) && val.GetCommission().LT(sdk.OneDec()) {
+		// the rewards of the shares of the msd, which belong to no delegator, go to the commission too
+		delegated := k.delegatedShares(ctx, val)
+		if delegated.IsPositive() {
+			toDelegators := tokens.Sub(tokens.MulDecTruncate(val.GetCommission()))
+			commission = tokens.Sub(toDelegators.MulDecTruncate(delegated.QuoTruncate(val.GetDelegatorShares())))
+		}
+	}
+	shared := tokens.Sub(commission)
+
+	// update current commission
+	currentCommission := k.GetValidatorAccumulatedCommission(ctx, val.GetOperator())
+	currentCommission = currentCommission.Add(commission...)
+	k.SetValidatorAccumulatedCommission(ctx, val.GetOperator(), currentCommission)
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			types.EventTypeCommission,
-			sdk.NewAttribute(sdk.AttributeKeyAmount, tokens.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, commission.String()),
+			sdk.NewAttribute(types.AttributeKeyValidator, val.GetOperator().String()),
+		),
+	)
+
+	if shared.IsZero() {
+		return
+	}
+
+	// update current rewards and outstanding rewards of the delegators
+	k.checkValidatorRewardsInitialized(ctx, val)
+	currentRewards := k.GetValidatorCurrentRewards(ctx, val.GetOperator())
+	currentRewards.Rewards = currentRewards.Rewards.Add(shared...)
+	k.SetValidatorCurrentRewards(ctx, val.GetOperator(), currentRewards)
+	outstanding := k.GetValidatorOutstandingRewards(ctx, val.GetOperator())
+	outstanding = outstanding.Add(shared...)
+	k.SetValidatorOutstandingRewards(ctx, val.GetOperator(), outstanding)
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRewards,
+			sdk.NewAttribute(sdk.AttributeKeyAmount, shared.String()),
 			sdk.NewAttribute(types.AttributeKeyValidator, val.GetOperator().String()),
 		),
 	)
@@ -0,0 +1,141 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/distribution/types"
+	"github.com/okex/exchain/x/staking/exported"
+)
+
+// initialize starting info for a new delegation
+func (k Keeper) initializeDelegation(ctx sdk.Context, valAddr sdk.ValAddress, delAddr sdk.AccAddress) {
+	// period has already been incremented - we want to store the period ended by this delegation action
+	previousPeriod := k.GetValidatorCurrentRewards(ctx, valAddr).Period - 1
+
+	// increment reference count for the period we're going to track
+	k.incrementReferenceCount(ctx, valAddr, previousPeriod)
+
+	shares, _ := k.stakingKeeper.GetShares(ctx, delAddr, valAddr)
+	k.SetDelegatorStartingInfo(ctx, valAddr, delAddr,
+		types.NewDelegatorStartingInfo(previousPeriod, shares, uint64(ctx.BlockHeight())))
+}
+
+// calculate the rewards accrued by a delegation between two periods
+func (k Keeper) calculateDelegationRewardsBetween(ctx sdk.Context, val exported.ValidatorI,
+	startingPeriod, endingPeriod uint64, stake sdk.Dec) sdk.SysCoins {
+	// sanity check
+	if startingPeriod > endingPeriod {
+		panic("startingPeriod cannot be greater than endingPeriod")
+	}
+
+	// sanity check
+	if stake.IsNegative() {
+		panic("stake should not be negative")
+	}
+
+	// return staking * (ending - starting)
+	starting := k.GetValidatorHistoricalRewards(ctx, val.GetOperator(), startingPeriod)
+	ending := k.GetValidatorHistoricalRewards(ctx, val.GetOperator(), endingPeriod)
+	difference := ending.CumulativeRewardRatio.Sub(starting.CumulativeRewardRatio)
+	if difference.IsAnyNegative() {
+		panic("negative rewards should not be possible")
+	}
+
+	return difference.MulDecTruncate(stake)
+}
+
+// calculate the total rewards accrued by a delegation until the ending period
+func (k Keeper) calculateDelegationRewards(ctx sdk.Context, val exported.ValidatorI, delAddr sdk.AccAddress,
+	endingPeriod uint64) sdk.SysCoins {
+	startingInfo := k.GetDelegatorStartingInfo(ctx, val.GetOperator(), delAddr)
+	return k.calculateDelegationRewardsBetween(ctx, val, startingInfo.PreviousPeriod, endingPeriod, startingInfo.Stake)
+}
+
+// pendingDelegationRewards ends the current period of a validator and calculates the rewards of a delegation, which
+// should only be called with a cached context
+func (k Keeper) pendingDelegationRewards(ctx sdk.Context, val exported.ValidatorI, delAddr sdk.AccAddress) sdk.SysCoins {
+	k.checkValidatorRewardsInitialized(ctx, val)
+	if !k.HasDelegatorStartingInfo(ctx, val.GetOperator(), delAddr) {
+		return sdk.SysCoins{}
+	}
+
+	endingPeriod := k.incrementValidatorPeriod(ctx, val)
+	return k.calculateDelegationRewards(ctx, val, delAddr, endingPeriod)
+}
+
+// withdrawDelegationRewards withdraws the rewards of a delegation and removes its starting info
+func (k Keeper) withdrawDelegationRewards(ctx sdk.Context, val exported.ValidatorI, delAddr sdk.AccAddress) (
+	sdk.SysCoins, error) {
+	valAddr := val.GetOperator()
+	// check existence of delegator starting info
+	if !k.HasDelegatorStartingInfo(ctx, valAddr, delAddr) {
+		return nil, types.ErrEmptyDelegationDistInfo()
+	}
+
+	// end current period and calculate rewards
+	endingPeriod := k.incrementValidatorPeriod(ctx, val)
+	rewardsRaw := k.calculateDelegationRewards(ctx, val, delAddr, endingPeriod)
+	outstanding := k.GetValidatorOutstandingRewards(ctx, valAddr)
+
+	// defensive edge case may happen on the very final digits
+	// of the coins due to operation order of the distribution mechanism.
+	rewards := rewardsRaw.Intersect(outstanding)
+	if !rewards.IsEqual(rewardsRaw) {
+		k.Logger(ctx).Info(fmt.Sprintf("missing rewards rounding error, delegator %s validator %s, calculated %s, "+
+			"outstanding %s", delAddr, valAddr, rewardsRaw, outstanding))
+	}
+
+	// add coins to user account
+	if !rewards.IsZero() {
+		withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, delAddr)
+		err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, withdrawAddr, rewards)
+		if err != nil {
+			return nil, types.ErrSendCoinsFromModuleToAccountFailed()
+		}
+	}
+
+	// update the outstanding rewards
+	k.SetValidatorOutstandingRewards(ctx, valAddr, outstanding.Sub(rewards))
+
+	// decrement reference count of starting period
+	startingInfo := k.GetDelegatorStartingInfo(ctx, valAddr, delAddr)
+	k.decrementReferenceCount(ctx, valAddr, startingInfo.PreviousPeriod)
+
+	// remove delegator starting info
+	k.DeleteDelegatorStartingInfo(ctx, valAddr, delAddr)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeWithdrawRewards,
+			sdk.NewAttribute(sdk.AttributeKeyAmount, rewards.String()),
+			sdk.NewAttribute(types.AttributeKeyValidator, valAddr.String()),
+		),
+	)
+
+	return rewards, nil
+}
+
+// WithdrawDelegationRewards withdraws the rewards of the shares added to a validator by a delegator
+func (k Keeper) WithdrawDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (
+	sdk.SysCoins, error) {
+	val := k.stakingKeeper.Validator(ctx, valAddr)
+	if val == nil {
+		return nil, types.ErrEmptyValidatorDistInfo()
+	}
+
+	if _, found := k.stakingKeeper.GetShares(ctx, delAddr, valAddr); !found {
+		return nil, types.ErrEmptyDelegationDistInfo()
+	}
+
+	k.checkValidatorRewardsInitialized(ctx, val)
+	rewards, err := k.withdrawDelegationRewards(ctx, val, delAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// reinitialize the delegation
+	k.initializeDelegation(ctx, valAddr, delAddr)
+	return rewards, nil
+}
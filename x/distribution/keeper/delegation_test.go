@@ -0,0 +1,162 @@
+package keeper
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+
+	"github.com/okex/exchain/x/distribution/types"
+	"github.com/okex/exchain/x/staking"
+)
+
+func TestMain(m *testing.M) {
+	// the delegator rewards are distributed after the venus height
+	sdk.UnittestOnlySetMilestoneVenusHeight(-1)
+	os.Exit(m.Run())
+}
+
+func getQueriedDelegationRewards(t *testing.T, ctx sdk.Context, k Keeper, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) sdk.SysCoins {
+	querier := NewQuerier(k)
+	bz, err := k.cdc.MarshalJSON(types.NewQueryDelegationRewardsParams(delAddr, valAddr))
+	require.NoError(t, err)
+	res, err := querier(ctx, []string{types.QueryDelegationRewards}, abci.RequestQuery{Data: bz})
+	require.NoError(t, err)
+
+	var rewards sdk.SysCoins
+	require.NoError(t, k.cdc.UnmarshalJSON(res, &rewards))
+	return rewards
+}
+
+func TestWithdrawDelegationRewards(t *testing.T) {
+	ctx, ak, k, sk, supplyKeeper := CreateTestInputDefault(t, false, 1000)
+	h := staking.NewHandler(sk)
+
+	// lower the commission rate of the validator to share the rewards with the delegators
+	ctx = ctx.WithBlockTime(time.Now())
+	_, err := h(ctx, staking.NewMsgEditValidatorCommissionRate(valOpAddr1, sdk.NewDecWithPrec(5, 1)))
+	require.NoError(t, err)
+
+	// no delegation yet
+	_, err = k.WithdrawDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.Error(t, err)
+	_, err = k.WithdrawDelegationRewards(ctx, delAddr1, sdk.ValAddress(delAddr4))
+	require.Error(t, err)
+
+	// add shares to the validator
+	_, err = h(ctx, staking.NewMsgDeposit(delAddr1, NewTestSysCoin(100, 0)))
+	require.NoError(t, err)
+	_, err = h(ctx, staking.NewMsgAddShares(delAddr1, []sdk.ValAddress{valOpAddr1}))
+	require.NoError(t, err)
+	delShares, found := sk.GetShares(ctx, delAddr1, valOpAddr1)
+	require.True(t, found)
+	require.True(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr1))
+
+	// allocate tokens to the validator
+	tokens := NewTestSysCoins(10, 0)
+	require.NoError(t, supplyKeeper.SendCoinsFromAccountToModule(ctx, delAddr2, types.ModuleName, tokens))
+	val := sk.Validator(ctx, valOpAddr1)
+	k.AllocateTokensToValidator(ctx, val, tokens)
+	// the rewards of the shares of the msd go to the commission
+	shared := NewTestSysCoins(5, 0).MulDecTruncate(delShares.QuoTruncate(val.GetDelegatorShares()))
+	require.Equal(t, tokens.Sub(shared), k.GetValidatorAccumulatedCommission(ctx, valOpAddr1))
+	require.Equal(t, shared, k.GetValidatorOutstandingRewards(ctx, valOpAddr1))
+
+	// query the pending rewards
+	ratio := shared.QuoDecTruncate(delShares)
+	expected := ratio.MulDecTruncate(delShares)
+	require.Equal(t, expected, getQueriedDelegationRewards(t, ctx, k, delAddr1, valOpAddr1))
+
+	// withdraw the rewards
+	balanceBefore := ak.GetAccount(ctx, delAddr1).GetCoins()
+	rewards, err := k.WithdrawDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.NoError(t, err)
+	require.Equal(t, expected, rewards)
+	require.Equal(t, balanceBefore.Add(rewards...), ak.GetAccount(ctx, delAddr1).GetCoins())
+	require.Equal(t, shared.Sub(rewards), k.GetValidatorOutstandingRewards(ctx, valOpAddr1))
+	require.True(t, getQueriedDelegationRewards(t, ctx, k, delAddr1, valOpAddr1).IsZero())
+
+	// the rewards are withdrawn automatically once the shares change
+	require.NoError(t, supplyKeeper.SendCoinsFromAccountToModule(ctx, delAddr2, types.ModuleName, tokens))
+	k.AllocateTokensToValidator(ctx, sk.Validator(ctx, valOpAddr1), tokens)
+	balanceBefore = ak.GetAccount(ctx, delAddr1).GetCoins()
+	_, err = h(ctx, staking.NewMsgWithdraw(delAddr1, NewTestSysCoin(50, 0)))
+	require.NoError(t, err)
+	require.Equal(t, balanceBefore.Add(expected...), ak.GetAccount(ctx, delAddr1).GetCoins())
+
+	// the invariants hold
+	_, broken := ModuleAccountInvariant(k)(ctx)
+	require.False(t, broken)
+	_, broken = NonNegativeOutstandingInvariant(k)(ctx)
+	require.False(t, broken)
+}
+
+func TestInitializeLegacyValidatorRewards(t *testing.T) {
+	ctx, _, k, sk, _ := CreateTestInputDefault(t, false, 1000)
+	h := staking.NewHandler(sk)
+	_, err := h(ctx, staking.NewMsgDeposit(delAddr1, NewTestSysCoin(100, 0)))
+	require.NoError(t, err)
+	_, err = h(ctx, staking.NewMsgAddShares(delAddr1, []sdk.ValAddress{valOpAddr1}))
+	require.NoError(t, err)
+
+	// remove the rewards records as the validators created before the delegator rewards
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetDelegatorStartingInfoKey(valOpAddr1, delAddr1))
+	k.deleteValidatorOutstandingRewards(ctx, valOpAddr1)
+	k.deleteValidatorHistoricalRewards(ctx, valOpAddr1)
+	k.deleteValidatorCurrentRewards(ctx, valOpAddr1)
+
+	// the records are initialized lazily from the shares existing
+	rewards, err := k.WithdrawDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.NoError(t, err)
+	require.True(t, rewards.IsZero())
+	require.True(t, k.HasValidatorCurrentRewards(ctx, valOpAddr1))
+	require.True(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr1))
+}
+
+func TestDelegatorRewardsBeforeVenus(t *testing.T) {
+	sdk.UnittestOnlySetMilestoneVenusHeight(10)
+	defer sdk.UnittestOnlySetMilestoneVenusHeight(-1)
+
+	ctx, _, k, sk, supplyKeeper := CreateTestInputDefault(t, false, 1000)
+	ctx = ctx.WithBlockHeight(10).WithBlockTime(time.Now())
+	h := staking.NewHandler(sk)
+
+	// the commission rate can't be edited before the venus height
+	_, err := h(ctx, staking.NewMsgEditValidatorCommissionRate(valOpAddr1, sdk.NewDecWithPrec(5, 1)))
+	require.Error(t, err)
+
+	// no rewards records of the delegators are written before the venus height
+	_, err = h(ctx, staking.NewMsgDeposit(delAddr1, NewTestSysCoin(100, 0)))
+	require.NoError(t, err)
+	_, err = h(ctx, staking.NewMsgAddShares(delAddr1, []sdk.ValAddress{valOpAddr1}))
+	require.NoError(t, err)
+	tokens := NewTestSysCoins(10, 0)
+	require.NoError(t, supplyKeeper.SendCoinsFromAccountToModule(ctx, delAddr2, types.ModuleName, tokens))
+	k.AllocateTokensToValidator(ctx, sk.Validator(ctx, valOpAddr1), tokens)
+	require.Equal(t, tokens, k.GetValidatorAccumulatedCommission(ctx, valOpAddr1))
+	store := ctx.KVStore(k.storeKey)
+	for _, prefix := range [][]byte{types.ValidatorOutstandingRewardsPrefix, types.DelegatorStartingInfoPrefix,
+		types.ValidatorHistoricalRewardsPrefix, types.ValidatorCurrentRewardsPrefix} {
+		iter := sdk.KVStorePrefixIterator(store, prefix)
+		require.False(t, iter.Valid())
+		iter.Close()
+	}
+
+	// the delegations existing start to get rewards at the venus height
+	ctx = ctx.WithBlockHeight(11)
+	k.InitializeDelegatorRewards(ctx)
+	require.True(t, k.HasDelegatorStartingInfo(ctx, valOpAddr1, delAddr1))
+	_, err = h(ctx, staking.NewMsgEditValidatorCommissionRate(valOpAddr1, sdk.NewDecWithPrec(5, 1)))
+	require.NoError(t, err)
+	require.NoError(t, supplyKeeper.SendCoinsFromAccountToModule(ctx, delAddr2, types.ModuleName, tokens))
+	k.AllocateTokensToValidator(ctx, sk.Validator(ctx, valOpAddr1), tokens)
+	rewards, err := k.WithdrawDelegationRewards(ctx, delAddr1, valOpAddr1)
+	require.NoError(t, err)
+	require.False(t, rewards.IsZero())
+}
@@ -23,6 +23,11 @@ func (h Hooks) AfterValidatorCreated(ctx sdk.Context, valAddr sdk.ValAddress) {
 	h.k.initializeValidator(ctx, val)
 }
 
+// the rewards records of the delegators are written after the venus height
+func delegatorRewardsEnabled(ctx sdk.Context) bool {
+	return sdk.HigherThanVenus(ctx.BlockHeight())
+}
+
 // AfterValidatorRemoved cleans up for after validator is removed
 func (h Hooks) AfterValidatorRemoved(ctx sdk.Context, _ sdk.ConsAddress, valAddr sdk.ValAddress) {
 	// force-withdraw commission
@@ -47,8 +52,23 @@ func (h Hooks) AfterValidatorRemoved(ctx sdk.Context, _ sdk.ConsAddress, valAddr
 		}
 	}
 
+	// the outstanding rewards left can't be withdrawn by any delegator, which go to community pool
+	outstanding := h.k.GetValidatorOutstandingRewards(ctx, valAddr)
+	if !outstanding.IsZero() {
+		feePool := h.k.GetFeePool(ctx)
+		feePool.CommunityPool = feePool.CommunityPool.Add(outstanding...)
+		h.k.SetFeePool(ctx, feePool)
+	}
+
 	// remove commission record
 	h.k.deleteValidatorAccumulatedCommission(ctx, valAddr)
+	if !delegatorRewardsEnabled(ctx) {
+		return
+	}
+	// remove the rewards records of the delegators
+	h.k.deleteValidatorOutstandingRewards(ctx, valAddr)
+	h.k.deleteValidatorHistoricalRewards(ctx, valAddr)
+	h.k.deleteValidatorCurrentRewards(ctx, valAddr)
 }
 
 // AfterValidatorDestroyed nothing to do
@@ -56,7 +76,54 @@ func (h Hooks) AfterValidatorDestroyed(ctx sdk.Context, consAddr sdk.ConsAddress
 
 }
 
+// BeforeValidatorModified ends the current period of the validator before its shares change
+func (h Hooks) BeforeValidatorModified(ctx sdk.Context, valAddr sdk.ValAddress) {
+	if !delegatorRewardsEnabled(ctx) {
+		return
+	}
+	val := h.k.stakingKeeper.Validator(ctx, valAddr)
+	if val != nil && h.k.HasValidatorCurrentRewards(ctx, valAddr) {
+		h.k.incrementValidatorPeriod(ctx, val)
+	}
+}
+
+// BeforeDelegationCreated ends the current period of the validator before the delegation is created
+func (h Hooks) BeforeDelegationCreated(ctx sdk.Context, _ sdk.AccAddress, valAddr sdk.ValAddress) {
+	if !delegatorRewardsEnabled(ctx) {
+		return
+	}
+	val := h.k.stakingKeeper.Validator(ctx, valAddr)
+	h.k.checkValidatorRewardsInitialized(ctx, val)
+	h.k.incrementValidatorPeriod(ctx, val)
+}
+
+// BeforeDelegationSharesModified withdraws the delegation rewards before the shares change
+func (h Hooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if !delegatorRewardsEnabled(ctx) {
+		return
+	}
+	val := h.k.stakingKeeper.Validator(ctx, valAddr)
+	h.k.checkValidatorRewardsInitialized(ctx, val)
+	if !h.k.HasDelegatorStartingInfo(ctx, valAddr, delAddr) {
+		h.k.incrementValidatorPeriod(ctx, val)
+		return
+	}
+
+	if _, err := h.k.withdrawDelegationRewards(ctx, val, delAddr); err != nil {
+		panic(err)
+	}
+}
+
+// AfterDelegationModified initializes the starting info of the delegation with the new shares
+func (h Hooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if !delegatorRewardsEnabled(ctx) {
+		return
+	}
+	if _, found := h.k.stakingKeeper.GetShares(ctx, delAddr, valAddr); found {
+		h.k.initializeDelegation(ctx, valAddr, delAddr)
+	}
+}
+
 // nolint - unused hooks
-func (h Hooks) BeforeValidatorModified(_ sdk.Context, _ sdk.ValAddress)                         {}
 func (h Hooks) AfterValidatorBonded(_ sdk.Context, _ sdk.ConsAddress, _ sdk.ValAddress)         {}
 func (h Hooks) AfterValidatorBeginUnbonding(_ sdk.Context, _ sdk.ConsAddress, _ sdk.ValAddress) {}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"fmt"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
 	"github.com/okex/exchain/x/distribution/types"
@@ -11,4 +13,110 @@ import (
 func (k Keeper) initializeValidator(ctx sdk.Context, val exported.ValidatorI) {
 	// set accumulated commissions
 	k.SetValidatorAccumulatedCommission(ctx, val.GetOperator(), types.InitialValidatorAccumulatedCommission())
+	// set the rewards records of the delegators, the validators created before the venus height are initialized by
+	// InitializeDelegatorRewards
+	if delegatorRewardsEnabled(ctx) {
+		k.initializeValidatorRewards(ctx, val)
+	}
+}
+
+// InitializeDelegatorRewards initializes the rewards records of the delegators of all the validators, which is called
+// once at the venus height. The shares added before start from the period 0 of their validators
+func (k Keeper) InitializeDelegatorRewards(ctx sdk.Context) {
+	k.stakingKeeper.IterateValidators(ctx, func(_ int64, val exported.ValidatorI) (stop bool) {
+		k.checkValidatorRewardsInitialized(ctx, val)
+		return false
+	})
+}
+
+// delegatedShares returns the shares added by the delegators of a validator, which exclude the shares of the msd
+func (k Keeper) delegatedShares(ctx sdk.Context, val exported.ValidatorI) sdk.Dec {
+	shares := val.GetDelegatorShares().Sub(k.stakingKeeper.GetMinSelfDelegationShares(val))
+	if shares.IsNegative() {
+		return sdk.ZeroDec()
+	}
+	return shares
+}
+
+// initializeValidatorRewards initializes the rewards records of the delegators of a validator. The shares added to the
+// validator already start from the period 0, which is referred by them and the current period 1
+func (k Keeper) initializeValidatorRewards(ctx sdk.Context, val exported.ValidatorI) {
+	valAddr := val.GetOperator()
+	sharesResps := k.stakingKeeper.GetValidatorAllShares(ctx, valAddr)
+
+	k.SetValidatorHistoricalRewards(ctx, valAddr, 0,
+		types.NewValidatorHistoricalRewards(sdk.SysCoins{}, uint32(1+len(sharesResps))))
+	k.SetValidatorCurrentRewards(ctx, valAddr, types.NewValidatorCurrentRewards(sdk.SysCoins{}, 1))
+	k.SetValidatorOutstandingRewards(ctx, valAddr, types.ValidatorOutstandingRewards{})
+
+	height := uint64(ctx.BlockHeight())
+	for _, sharesResp := range sharesResps {
+		k.SetDelegatorStartingInfo(ctx, valAddr, sharesResp.DelAddr,
+			types.NewDelegatorStartingInfo(0, sharesResp.Shares, height))
+	}
+}
+
+// checkValidatorRewardsInitialized initializes the rewards records of the delegators of a validator lazily, which is
+// necessary for the validators created before the delegator rewards are distributed
+func (k Keeper) checkValidatorRewardsInitialized(ctx sdk.Context, val exported.ValidatorI) {
+	if !k.HasValidatorCurrentRewards(ctx, val.GetOperator()) {
+		k.initializeValidatorRewards(ctx, val)
+	}
+}
+
+// incrementValidatorPeriod ends the current period of a validator and returns it
+func (k Keeper) incrementValidatorPeriod(ctx sdk.Context, val exported.ValidatorI) uint64 {
+	valAddr := val.GetOperator()
+	rewards := k.GetValidatorCurrentRewards(ctx, valAddr)
+
+	// calculate the rewards ratio of the current period
+	var current sdk.SysCoins
+	shares := k.delegatedShares(ctx, val)
+	if shares.IsZero() {
+		// the ratio can't be calculated without shares, so the rewards go to the community pool
+		feePool := k.GetFeePool(ctx)
+		outstanding := k.GetValidatorOutstandingRewards(ctx, valAddr)
+		feePool.CommunityPool = feePool.CommunityPool.Add(rewards.Rewards...)
+		k.SetFeePool(ctx, feePool)
+		k.SetValidatorOutstandingRewards(ctx, valAddr, outstanding.Sub(rewards.Rewards))
+		current = sdk.SysCoins{}
+	} else {
+		current = rewards.Rewards.QuoDecTruncate(shares)
+	}
+
+	// fetch the historical rewards of the last period
+	historical := k.GetValidatorHistoricalRewards(ctx, valAddr, rewards.Period-1).CumulativeRewardRatio
+	// the current period doesn't refer to the last one any longer
+	k.decrementReferenceCount(ctx, valAddr, rewards.Period-1)
+
+	// set the historical rewards of the current period, referred by the next period
+	k.SetValidatorHistoricalRewards(ctx, valAddr, rewards.Period,
+		types.NewValidatorHistoricalRewards(historical.Add(current...), 1))
+	// set the next period
+	k.SetValidatorCurrentRewards(ctx, valAddr, types.NewValidatorCurrentRewards(sdk.SysCoins{}, rewards.Period+1))
+
+	return rewards.Period
+}
+
+// incrementReferenceCount increments the reference count of a historical rewards
+func (k Keeper) incrementReferenceCount(ctx sdk.Context, valAddr sdk.ValAddress, period uint64) {
+	historical := k.GetValidatorHistoricalRewards(ctx, valAddr, period)
+	historical.ReferenceCount++
+	k.SetValidatorHistoricalRewards(ctx, valAddr, period, historical)
+}
+
+// decrementReferenceCount decrements the reference count of a historical rewards and deletes it when it's referred
+// by nothing
+func (k Keeper) decrementReferenceCount(ctx sdk.Context, valAddr sdk.ValAddress, period uint64) {
+	historical := k.GetValidatorHistoricalRewards(ctx, valAddr, period)
+	if historical.ReferenceCount == 0 {
+		panic(fmt.Sprintf("cannot set negative reference count of validator %s at period %d", valAddr, period))
+	}
+
+	historical.ReferenceCount--
+	if historical.ReferenceCount == 0 {
+		k.deleteValidatorHistoricalReward(ctx, valAddr, period)
+	} else {
+		k.SetValidatorHistoricalRewards(ctx, valAddr, period, historical)
+	}
 }
@@ -0,0 +1,22 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// DelegatorStartingInfo is the starting info of the shares added to a validator by a delegator, whose rewards are
+// calculated by the cumulative rewards ratio of the validator between the previous period and the period ended
+type DelegatorStartingInfo struct {
+	PreviousPeriod uint64  `json:"previous_period" yaml:"previous_period"`
+	Stake          sdk.Dec `json:"stake" yaml:"stake"`
+	Height         uint64  `json:"creation_height" yaml:"creation_height"`
+}
+
+// NewDelegatorStartingInfo creates a new instance of DelegatorStartingInfo
+func NewDelegatorStartingInfo(previousPeriod uint64, stake sdk.Dec, height uint64) DelegatorStartingInfo {
+	return DelegatorStartingInfo{
+		PreviousPeriod: previousPeriod,
+		Stake:          stake,
+		Height:         height,
+	}
+}
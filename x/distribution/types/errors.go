@@ -28,6 +28,8 @@ const (
 	CodeBadDistribution                             uint32 = 67816
 	CodeInvalidProposalAmount                       uint32 = 67817
 	CodeEmptyProposalRecipient                      uint32 = 67818
+	CodeEmptyDelegationDistInfo                     uint32 = 67819
+	CodeEmptyValidatorDistInfo                      uint32 = 67820
 )
 
 func ErrNilDelegatorAddr() sdk.Error {
@@ -89,3 +91,11 @@ func ErrInvalidProposalAmount() sdk.Error {
 func ErrEmptyProposalRecipient() sdk.Error {
 	return sdkerrors.New(DefaultCodespace, CodeEmptyProposalRecipient, "invalid community pool spend proposal recipient")
 }
+
+func ErrEmptyDelegationDistInfo() sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeEmptyDelegationDistInfo, "no delegation distribution info")
+}
+
+func ErrEmptyValidatorDistInfo() sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeEmptyValidatorDistInfo, "no validator distribution info")
+}
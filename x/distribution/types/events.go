@@ -4,7 +4,9 @@ package types
 const (
 	EventTypeSetWithdrawAddress = "set_withdraw_address"
 	EventTypeCommission         = "commission"
+	EventTypeRewards            = "rewards"
 	EventTypeWithdrawCommission = "withdraw_commission"
+	EventTypeWithdrawRewards    = "withdraw_rewards"
 	EventTypeProposerReward     = "proposer_reward"
 
 	AttributeKeyWithdrawAddress = "withdraw_address"
@@ -5,6 +5,7 @@ import (
 	supplyexported "github.com/okex/exchain/libs/cosmos-sdk/x/supply/exported"
 
 	stakingexported "github.com/okex/exchain/x/staking/exported"
+	stakingtypes "github.com/okex/exchain/x/staking/types"
 )
 
 // StakingKeeper expected staking keeper (noalias)
@@ -35,6 +36,15 @@ type StakingKeeper interface {
 
 	GetLastTotalPower(ctx sdk.Context) sdk.Int
 	GetLastValidatorPower(ctx sdk.Context, valAddr sdk.ValAddress) int64
+
+	// get a particular delegator by address
+	Delegator(ctx sdk.Context, delAddr sdk.AccAddress) stakingexported.DelegatorI
+	// get the shares added to a validator by a delegator
+	GetShares(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (stakingtypes.Shares, bool)
+	// get the shares of the msd on a validator, which belong to no delegator
+	GetMinSelfDelegationShares(validator stakingexported.ValidatorI) sdk.Dec
+	// get all the shares added to a validator
+	GetValidatorAllShares(ctx sdk.Context, valAddr sdk.ValAddress) stakingtypes.SharesResponses
 }
 
 // StakingHooks event hooks for staking validator object (noalias)
@@ -17,6 +17,32 @@ type ValidatorAccumulatedCommissionRecord struct {
 	Accumulated      ValidatorAccumulatedCommission `json:"accumulated" yaml:"accumulated"`
 }
 
+// ValidatorOutstandingRewardsRecord is used for import/export via genesis json
+type ValidatorOutstandingRewardsRecord struct {
+	ValidatorAddress   sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+	OutstandingRewards sdk.SysCoins   `json:"outstanding_rewards" yaml:"outstanding_rewards"`
+}
+
+// ValidatorHistoricalRewardsRecord is used for import / export via genesis json
+type ValidatorHistoricalRewardsRecord struct {
+	ValidatorAddress sdk.ValAddress             `json:"validator_address" yaml:"validator_address"`
+	Period           uint64                     `json:"period" yaml:"period"`
+	Rewards          ValidatorHistoricalRewards `json:"rewards" yaml:"rewards"`
+}
+
+// ValidatorCurrentRewardsRecord is used for import / export via genesis json
+type ValidatorCurrentRewardsRecord struct {
+	ValidatorAddress sdk.ValAddress          `json:"validator_address" yaml:"validator_address"`
+	Rewards          ValidatorCurrentRewards `json:"rewards" yaml:"rewards"`
+}
+
+// DelegatorStartingInfoRecord is used for import / export via genesis json
+type DelegatorStartingInfoRecord struct {
+	DelegatorAddress sdk.AccAddress        `json:"delegator_address" yaml:"delegator_address"`
+	ValidatorAddress sdk.ValAddress        `json:"validator_address" yaml:"validator_address"`
+	StartingInfo     DelegatorStartingInfo `json:"starting_info" yaml:"starting_info"`
+}
+
 // GenesisState - all distribution state that must be provided at genesis
 type GenesisState struct {
 	Params                          Params                                 `json:"params" yaml:"params"`
@@ -24,6 +50,10 @@ type GenesisState struct {
 	DelegatorWithdrawInfos          []DelegatorWithdrawInfo                `json:"delegator_withdraw_infos" yaml:"delegator_withdraw_infos"`
 	PreviousProposer                sdk.ConsAddress                        `json:"previous_proposer" yaml:"previous_proposer"`
 	ValidatorAccumulatedCommissions []ValidatorAccumulatedCommissionRecord `json:"validator_accumulated_commissions" yaml:"validator_accumulated_commissions"`
+	OutstandingRewards              []ValidatorOutstandingRewardsRecord    `json:"outstanding_rewards" yaml:"outstanding_rewards"`
+	ValidatorHistoricalRewards      []ValidatorHistoricalRewardsRecord     `json:"validator_historical_rewards" yaml:"validator_historical_rewards"`
+	ValidatorCurrentRewards         []ValidatorCurrentRewardsRecord        `json:"validator_current_rewards" yaml:"validator_current_rewards"`
+	DelegatorStartingInfos          []DelegatorStartingInfoRecord          `json:"delegator_starting_infos" yaml:"delegator_starting_infos"`
 }
 
 // NewGenesisState creates a new object of GenesisState
@@ -47,6 +77,10 @@ func DefaultGenesisState() GenesisState {
 		DelegatorWithdrawInfos:          []DelegatorWithdrawInfo{},
 		PreviousProposer:                nil,
 		ValidatorAccumulatedCommissions: []ValidatorAccumulatedCommissionRecord{},
+		OutstandingRewards:              []ValidatorOutstandingRewardsRecord{},
+		ValidatorHistoricalRewards:      []ValidatorHistoricalRewardsRecord{},
+		ValidatorCurrentRewards:         []ValidatorCurrentRewardsRecord{},
+		DelegatorStartingInfos:          []DelegatorStartingInfoRecord{},
 	}
 }
 
@@ -1,6 +1,10 @@
 package types
 
-import sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+import (
+	"encoding/binary"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
 
 const (
 	// ModuleName is the module name constant used in many places
@@ -24,13 +28,25 @@ const (
 //
 // - 0x01: sdk.ConsAddress
 //
+// - 0x02<valAddr_Bytes>: ValidatorOutstandingRewards
+//
 // - 0x03<accAddr_Bytes>: sdk.AccAddress
 //
-// - 0x07<valAddr_Bytes>: ValidatorCurrentRewards
+// - 0x04<valAddr_Bytes><accAddr_Bytes>: DelegatorStartingInfo
+//
+// - 0x05<valAddr_Bytes><period_Bytes>: ValidatorHistoricalRewards
+//
+// - 0x06<valAddr_Bytes>: ValidatorCurrentRewards
+//
+// - 0x07<valAddr_Bytes>: ValidatorAccumulatedCommission
 var (
 	FeePoolKey                           = []byte{0x00} // key for global distribution state
 	ProposerKey                          = []byte{0x01} // key for the proposer operator address
+	ValidatorOutstandingRewardsPrefix    = []byte{0x02} // key for outstanding rewards of delegators
 	DelegatorWithdrawAddrPrefix          = []byte{0x03} // key for delegator withdraw address
+	DelegatorStartingInfoPrefix          = []byte{0x04} // key for delegator starting info
+	ValidatorHistoricalRewardsPrefix     = []byte{0x05} // key for historical validators rewards / stake
+	ValidatorCurrentRewardsPrefix        = []byte{0x06} // key for current validator rewards
 	ValidatorAccumulatedCommissionPrefix = []byte{0x07} // key for accumulated validator commission
 )
 
@@ -61,3 +77,78 @@ func GetDelegatorWithdrawAddrKey(delAddr sdk.AccAddress) []byte {
 func GetValidatorAccumulatedCommissionKey(v sdk.ValAddress) []byte {
 	return append(ValidatorAccumulatedCommissionPrefix, v.Bytes()...)
 }
+
+// GetValidatorOutstandingRewardsAddress returns the address from a validator's outstanding rewards key
+func GetValidatorOutstandingRewardsAddress(key []byte) (valAddr sdk.ValAddress) {
+	addr := key[1:]
+	if len(addr) != sdk.AddrLen {
+		panic("unexpected key length")
+	}
+	return sdk.ValAddress(addr)
+}
+
+// GetDelegatorStartingInfoAddresses returns the addresses from a delegator starting info key
+func GetDelegatorStartingInfoAddresses(key []byte) (valAddr sdk.ValAddress, delAddr sdk.AccAddress) {
+	addr := key[1 : 1+sdk.AddrLen]
+	if len(addr) != sdk.AddrLen {
+		panic("unexpected key length")
+	}
+	valAddr = sdk.ValAddress(addr)
+	addr = key[1+sdk.AddrLen:]
+	if len(addr) != sdk.AddrLen {
+		panic("unexpected key length")
+	}
+	delAddr = sdk.AccAddress(addr)
+	return
+}
+
+// GetValidatorHistoricalRewardsAddressPeriod returns the address & period from a validator's historical rewards key
+func GetValidatorHistoricalRewardsAddressPeriod(key []byte) (valAddr sdk.ValAddress, period uint64) {
+	addr := key[1 : 1+sdk.AddrLen]
+	if len(addr) != sdk.AddrLen {
+		panic("unexpected key length")
+	}
+	valAddr = sdk.ValAddress(addr)
+	b := key[1+sdk.AddrLen:]
+	if len(b) != 8 {
+		panic("unexpected key length")
+	}
+	period = binary.LittleEndian.Uint64(b)
+	return
+}
+
+// GetValidatorCurrentRewardsAddress returns the address from a validator's current rewards key
+func GetValidatorCurrentRewardsAddress(key []byte) (valAddr sdk.ValAddress) {
+	addr := key[1:]
+	if len(addr) != sdk.AddrLen {
+		panic("unexpected key length")
+	}
+	return sdk.ValAddress(addr)
+}
+
+// GetValidatorOutstandingRewardsKey returns the key for outstanding rewards of the delegators of a validator
+func GetValidatorOutstandingRewardsKey(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorOutstandingRewardsPrefix, valAddr.Bytes()...)
+}
+
+// GetDelegatorStartingInfoKey returns the key for a delegator's starting info on a validator
+func GetDelegatorStartingInfoKey(v sdk.ValAddress, d sdk.AccAddress) []byte {
+	return append(append(DelegatorStartingInfoPrefix, v.Bytes()...), d.Bytes()...)
+}
+
+// GetValidatorHistoricalRewardsPrefix returns the prefix key for a validator's historical rewards
+func GetValidatorHistoricalRewardsPrefix(v sdk.ValAddress) []byte {
+	return append(ValidatorHistoricalRewardsPrefix, v.Bytes()...)
+}
+
+// GetValidatorHistoricalRewardsKey returns the key for a validator's historical rewards of a period
+func GetValidatorHistoricalRewardsKey(v sdk.ValAddress, k uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, k)
+	return append(append(ValidatorHistoricalRewardsPrefix, v.Bytes()...), b...)
+}
+
+// GetValidatorCurrentRewardsKey returns the key for a validator's current rewards
+func GetValidatorCurrentRewardsKey(v sdk.ValAddress) []byte {
+	return append(ValidatorCurrentRewardsPrefix, v.Bytes()...)
+}
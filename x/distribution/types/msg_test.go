@@ -85,3 +85,25 @@ func TestMsgWithdrawValidatorCommission(t *testing.T) {
 		}
 	}
 }
+
+// TestMsgWithdrawDelegatorReward test ValidateBasic for MsgWithdrawDelegatorReward
+func TestMsgWithdrawDelegatorReward(t *testing.T) {
+	tests := []struct {
+		delegatorAddr sdk.AccAddress
+		validatorAddr sdk.ValAddress
+		expectPass    bool
+	}{
+		{delAddr1, valAddr1, true},
+		{emptyDelAddr, valAddr1, false},
+		{delAddr1, emptyValAddr, false},
+		{emptyDelAddr, emptyValAddr, false},
+	}
+	for i, tc := range tests {
+		msg := NewMsgWithdrawDelegatorReward(tc.delegatorAddr, tc.validatorAddr)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test index: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test index: %v", i)
+		}
+	}
+}
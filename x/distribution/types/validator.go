@@ -12,3 +12,37 @@ type ValidatorAccumulatedCommission = sdk.SysCoins
 func InitialValidatorAccumulatedCommission() ValidatorAccumulatedCommission {
 	return ValidatorAccumulatedCommission{}
 }
+
+// ValidatorHistoricalRewards is the cumulative rewards ratio of a validator for a period, i.e. the sum from the first
+// period to this one of the rewards per share to the delegators. The reference count is the number of the objects which
+// might need to read it, which are the delegations whose starting period is it and the next period of the validator
+type ValidatorHistoricalRewards struct {
+	CumulativeRewardRatio sdk.SysCoins `json:"cumulative_reward_ratio" yaml:"cumulative_reward_ratio"`
+	ReferenceCount        uint32       `json:"reference_count" yaml:"reference_count"`
+}
+
+// NewValidatorHistoricalRewards creates a new instance of ValidatorHistoricalRewards
+func NewValidatorHistoricalRewards(cumulativeRewardRatio sdk.SysCoins, referenceCount uint32) ValidatorHistoricalRewards {
+	return ValidatorHistoricalRewards{
+		CumulativeRewardRatio: cumulativeRewardRatio,
+		ReferenceCount:        referenceCount,
+	}
+}
+
+// ValidatorCurrentRewards is the rewards to the delegators of a validator accumulated in the current period, which
+// is ended once the shares added to the validator change
+type ValidatorCurrentRewards struct {
+	Rewards sdk.SysCoins `json:"rewards" yaml:"rewards"`
+	Period  uint64       `json:"period" yaml:"period"`
+}
+
+// NewValidatorCurrentRewards creates a new instance of ValidatorCurrentRewards
+func NewValidatorCurrentRewards(rewards sdk.SysCoins, period uint64) ValidatorCurrentRewards {
+	return ValidatorCurrentRewards{
+		Rewards: rewards,
+		Period:  period,
+	}
+}
+
+// ValidatorOutstandingRewards is the rewards allocated to the delegators of a validator which aren't withdrawn yet
+type ValidatorOutstandingRewards = sdk.SysCoins
@@ -138,3 +138,61 @@ Where proposal.json contains:
 		},
 	}
 }
+
+// GetCmdManageForkBlocksProposal implements a command handler for submitting a manage fork blocks proposal transaction
+func GetCmdManageForkBlocksProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "manage-fork-blocks [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to schedule the heights of the berlin and london forks",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to schedule the heights of the berlin and london forks along with an initial deposit.
+A negative height leaves the fork unscheduled. A fork that is already active can't be changed.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal manage-fork-blocks <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "activate london",
+  "description": "activate the berlin and london forks to support typed and dynamic fee transactions",
+  "berlin_block": "1000000",
+  "london_block": "1000000",
+  "deposit": [
+    {
+      "denom": "%s",
+      "amount": "100.000000000000000000"
+    }
+  ]
+}
+`, version.ClientName, sdk.DefaultBondDenom,
+			)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := evmutils.ParseManageForkBlocksProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			content := types.NewManageForkBlocksProposal(
+				proposal.Title,
+				proposal.Description,
+				proposal.BerlinBlock,
+				proposal.LondonBlock,
+			)
+
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -18,4 +18,10 @@ var (
 		cli.GetCmdManageContractBlockedListProposal,
 		rest.ManageContractBlockedListProposalRESTHandler,
 	)
+
+	// ManageForkBlocksProposalHandler alias gov NewProposalHandler
+	ManageForkBlocksProposalHandler = govcli.NewProposalHandler(
+		cli.GetCmdManageForkBlocksProposal,
+		rest.ManageForkBlocksProposalRESTHandler,
+	)
 )
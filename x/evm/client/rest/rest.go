@@ -192,6 +192,11 @@ func ManageContractBlockedListProposalRESTHandler(context.CLIContext) govRest.Pr
 	return govRest.ProposalRESTHandler{}
 }
 
+// ManageForkBlocksProposalRESTHandler defines evm proposal handler
+func ManageForkBlocksProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
+
 func QuerySectionFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		res, _, err := cliCtx.Query(fmt.Sprintf("custom/%s/%s", evmtypes.RouterKey, evmtypes.QuerySection))
@@ -27,6 +27,15 @@ type (
 		IsAdded       bool              `json:"is_added" yaml:"is_added"`
 		Deposit       sdk.SysCoins      `json:"deposit" yaml:"deposit"`
 	}
+	// ManageForkBlocksProposalJSON defines a ManageForkBlocksProposal with a deposit used to parse manage fork blocks
+	// proposals from a JSON file.
+	ManageForkBlocksProposalJSON struct {
+		Title       string       `json:"title" yaml:"title"`
+		Description string       `json:"description" yaml:"description"`
+		BerlinBlock sdk.Int      `json:"berlin_block" yaml:"berlin_block"`
+		LondonBlock sdk.Int      `json:"london_block" yaml:"london_block"`
+		Deposit     sdk.SysCoins `json:"deposit" yaml:"deposit"`
+	}
 )
 
 // ParseManageContractDeploymentWhitelistProposalJSON parses json from proposal file to ManageContractDeploymentWhitelistProposalJSON
@@ -53,3 +62,15 @@ func ParseManageContractBlockedListProposalJSON(cdc *codec.Codec, proposalFilePa
 	cdc.MustUnmarshalJSON(contents, &proposal)
 	return
 }
+
+// ParseManageForkBlocksProposalJSON parses json from proposal file to ManageForkBlocksProposalJSON struct
+func ParseManageForkBlocksProposalJSON(cdc *codec.Codec, proposalFilePath string) (
+	proposal ManageForkBlocksProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return
+	}
+
+	cdc.MustUnmarshalJSON(contents, &proposal)
+	return
+}
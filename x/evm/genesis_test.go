@@ -358,7 +358,7 @@ func (suite *EvmTestSuite) TestExport_db() {
 	os.Setenv("OKEXCHAIN_EVM_IMPORT_MODE", "default")
 	evm.InitGenesis(suite.ctx, *suite.app.EvmKeeper, &suite.app.AccountKeeper, initGenesis)
 
-	tmpPath := "./test_tmp_db"
+	tmpPath := suite.T().TempDir()
 	os.Setenv("OKEXCHAIN_EVM_EXPORT_MODE", "db")
 	os.Setenv("OKEXCHAIN_EVM_EXPORT_PATH", tmpPath)
 
@@ -455,7 +455,7 @@ func (suite *EvmTestSuite) TestExport_files() {
 	os.Setenv("OKEXCHAIN_EVM_IMPORT_MODE", "default")
 	evm.InitGenesis(suite.ctx, *suite.app.EvmKeeper, &suite.app.AccountKeeper, initGenesis)
 
-	tmpPath := "./test_tmp_db"
+	tmpPath := suite.T().TempDir()
 	os.Setenv("OKEXCHAIN_EVM_EXPORT_MODE", "files")
 	os.Setenv("OKEXCHAIN_EVM_EXPORT_PATH", tmpPath)
 
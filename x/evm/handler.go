@@ -139,13 +139,16 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 	StopTxLog(bam.Txhash)
 
 	StartTxLog(bam.SaveTx)
+	baseFee := k.GetBaseFee(ctx)
 	st := types.StateTransition{
 		AccountNonce: msg.Data.AccountNonce,
-		Price:        msg.Data.Price,
+		Price:        msg.EffectiveGasPrice(baseFee),
 		GasLimit:     msg.Data.GasLimit,
 		Recipient:    msg.Data.Recipient,
 		Amount:       msg.Data.Amount,
 		Payload:      msg.Data.Payload,
+		AccessList:   msg.Data.Accesses,
+		BaseFee:      baseFee,
 		Csdb:         types.CreateEmptyCommitStateDB(k.GenerateCSDBParams(), ctx),
 		ChainID:      chainIDEpoch,
 		TxHash:       &ethHash,
@@ -183,7 +186,7 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 			sendAcc := pm.AccountKeeper.GetAccount(infCtx, sender.Bytes())
 			//fix sender's balance in watcher with refund fees
 			gasConsumed := ctx.GasMeter().GasConsumed()
-			fixedFees := refund.CaculateRefundFees(ctx, gasConsumed, msg.GetFee(), msg.Data.Price)
+			fixedFees := refund.CaculateRefundFees(ctx, gasConsumed, msg.GetEffectiveFee(baseFee), st.Price)
 			coins := sendAcc.GetCoins().Add2(fixedFees)
 			_ = sendAcc.SetCoins(coins)
 			if sendAcc != nil {
@@ -214,6 +217,11 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 		})
 	}
 
+	// the inner txs of a failed tx are kept as well, they show where it failed
+	if !st.Simulate && innerTxs != nil {
+		k.AddInnerTx(*st.TxHash, innerTxs)
+	}
+
 	if err != nil {
 		if !st.Simulate {
 			k.Watcher.SaveTransactionReceipt(watcher.TransactionFailed, msg, common.BytesToHash(txHash), uint64(k.TxCount-1), &types.ResultData{}, ctx.GasMeter().GasConsumed())
@@ -222,13 +230,8 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 	}
 	StopTxLog(bam.TransitionDb)
 
-	if !st.Simulate {
-		if innerTxs != nil {
-			k.AddInnerTx(st.TxHash.Hex(), innerTxs)
-		}
-		if erc20s != nil {
-			k.AddContract(erc20s)
-		}
+	if !st.Simulate && erc20s != nil {
+		k.AddContract(erc20s)
 	}
 
 	StartTxLog(bam.Bloomfilter)
@@ -300,6 +303,7 @@ func handleMsgEthermint(ctx sdk.Context, k *Keeper, msg types.MsgEthermint) (*sd
 		GasLimit:     msg.GasLimit,
 		Amount:       msg.Amount.BigInt(),
 		Payload:      msg.Payload,
+		BaseFee:      k.GetBaseFee(ctx),
 		Csdb:         types.CreateEmptyCommitStateDB(k.GenerateCSDBParams(), ctx),
 		ChainID:      chainIDEpoch,
 		TxHash:       &ethHash,
@@ -319,23 +323,34 @@ func handleMsgEthermint(ctx sdk.Context, k *Keeper, msg types.MsgEthermint) (*sd
 		k.TxCount++
 	}
 
+	// the access list and the state overrides of a simulated call are only passed by the rpc simulator
+	// through the context
+	if call, ok := types.GetSimulatedCall(ctx); ok && st.Simulate {
+		st.AccessList = call.AccessList
+		// the overrides are applied before the gas of the tx is metered
+		if len(call.StateOverrides) > 0 {
+			overrideCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			if err := call.StateOverrides.Apply(st.Csdb.WithContext(overrideCtx)); err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+			}
+		}
+	}
+
 	config, found := k.GetChainConfig(ctx)
 	if !found {
 		return nil, types.ErrChainConfigNotFound
 	}
 
 	executionResult, _, err, innerTxs, erc20s := st.TransitionDb(ctx, config)
+	if !st.Simulate && innerTxs != nil {
+		k.AddInnerTx(*st.TxHash, innerTxs)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	if !st.Simulate {
-		if innerTxs != nil {
-			k.AddInnerTx(st.TxHash.Hex(), innerTxs)
-		}
-		if erc20s != nil {
-			k.AddContract(erc20s)
-		}
+	if !st.Simulate && erc20s != nil {
+		k.AddContract(erc20s)
 	}
 
 	// update block bloom filter
@@ -157,6 +157,7 @@ func (suite *EvmTestSuite) TestHandleMsgEthereumTx() {
 func (suite *EvmTestSuite) TestMsgEthermint() {
 	var (
 		tx   types.MsgEthermint
+		call types.SimulatedCall
 		from = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
 		to   = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
 	)
@@ -181,6 +182,29 @@ func (suite *EvmTestSuite) TestMsgEthermint() {
 			},
 			false,
 		},
+		{
+			"balance overridden",
+			func() {
+				tx = types.NewMsgEthermint(0, &to, sdk.NewInt(1), 100000, sdk.NewInt(2), []byte("test"), from)
+				balance := (*hexutil.Big)(big.NewInt(100))
+				call.StateOverrides = types.StateOverrides{
+					ethcmn.BytesToAddress(from.Bytes()): {Balance: &balance},
+				}
+			},
+			true,
+		},
+		{
+			"state and state diff overridden",
+			func() {
+				tx = types.NewMsgEthermint(0, &to, sdk.NewInt(1), 100000, sdk.NewInt(2), []byte("test"), from)
+				suite.app.EvmKeeper.SetBalance(suite.ctx, ethcmn.BytesToAddress(from.Bytes()), big.NewInt(100))
+				storage := map[ethcmn.Hash]ethcmn.Hash{}
+				call.StateOverrides = types.StateOverrides{
+					ethcmn.BytesToAddress(to.Bytes()): {State: &storage, StateDiff: &storage},
+				}
+			},
+			false,
+		},
 		{
 			"invalid chain ID",
 			func() {
@@ -193,11 +217,12 @@ func (suite *EvmTestSuite) TestMsgEthermint() {
 	for _, tc := range testCases {
 		suite.Run("", func() {
 			suite.SetupTest() // reset
+			call = types.SimulatedCall{}
 			//nolint
 			tc.malleate()
 			suite.ctx = suite.ctx.WithIsCheckTx(true)
 			suite.ctx = suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
-			res, err := suite.handler(suite.ctx, tx)
+			res, err := suite.handler(types.WithSimulatedCall(suite.ctx, call), tx)
 
 			//nolint
 			if tc.expPass {
@@ -654,7 +679,7 @@ func (suite *EvmTestSuite) TestSimulateConflict() {
 	result, err = suite.handler(suite.ctx, tx)
 	suite.Require().NotNil(result)
 	suite.Require().Nil(err)
-	var expectedGas uint64 = 22336
+	var expectedGas uint64 = 22363
 	suite.Require().EqualValues(expectedGas, suite.ctx.GasMeter().GasConsumed())
 }
 
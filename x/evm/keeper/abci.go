@@ -34,7 +34,6 @@ func (k *Keeper) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
 
 	k.SetHeightHash(ctx, uint64(height), common.BytesToHash(lastHash))
 	k.SetBlockHash(ctx, lastHash, height)
-	k.InitInnerBlock(common.BytesToHash(currentHash).Hex())
 
 	// reset counters that are used on CommitStateDB.Prepare
 	k.Bloom = big.NewInt(0)
@@ -99,11 +98,48 @@ func (k Keeper) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.Valid
 		params := k.GetParams(ctx)
 		k.Watcher.SaveParams(params)
 
-		k.Watcher.SaveBlock(bloom)
+		k.Watcher.SaveBlock(bloom, k.GetBaseFee(ctx), blockMaxGas(ctx))
 		k.Watcher.Commit()
 	}
 
-	k.UpdateInnerBlockData()
+	// set the base fee of the next block once the London fork is active for it, it
+	// replaces the base fee of the block, so it's set after the watcher saved the block
+	k.updateBaseFee(ctx, req.Height)
+
+	k.commitStateHistory(ctx, req.Height)
 
 	return []abci.ValidatorUpdate{}
 }
+
+// updateBaseFee sets the base fee of the block following the one at height. It is
+// adjusted according to EIP-1559 from the gas used in the block at height and the max
+// gas of the consensus params, the first block of the London fork uses the initial base
+// fee. The base fee is kept if the block max gas is unlimited, as there's no gas target.
+//
+// NOTE: fees are still collected by the fee collector, the base fee is not burnt.
+func (k Keeper) updateBaseFee(ctx sdk.Context, height int64) {
+	config, found := k.GetChainConfig(ctx)
+	if !found || !config.IsLondon(height+1) {
+		return
+	}
+
+	baseFee := types.InitialBaseFee()
+	if config.IsLondon(height) {
+		var gasUsed uint64
+		if blockGasMeter := ctx.BlockGasMeter(); blockGasMeter != nil {
+			gasUsed = blockGasMeter.GasConsumed()
+		}
+		_, parentBaseFee := k.getLatestBaseFee(ctx)
+		baseFee = types.CalcBaseFee(parentBaseFee, gasUsed, blockMaxGas(ctx))
+	}
+
+	k.SetBlockBaseFee(ctx, height+1, baseFee)
+}
+
+// blockMaxGas returns the max gas of a block from the consensus params, or 0 if it's unlimited
+func blockMaxGas(ctx sdk.Context) uint64 {
+	if params := ctx.ConsensusParams(); params != nil && params.Block != nil && params.Block.MaxGas > 0 {
+		return uint64(params.Block.MaxGas)
+	}
+	return 0
+}
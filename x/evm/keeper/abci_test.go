@@ -5,6 +5,7 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/evm/watcher"
 	"github.com/spf13/viper"
@@ -63,6 +64,52 @@ func (suite *KeeperTestSuite) TestEndBlock() {
 	suite.Require().Equal(int64(10), bloom.Big().Int64())
 }
 
+func (suite *KeeperTestSuite) TestEndBlockBaseFee() {
+	_ = suite.app.EvmKeeper.EndBlock(suite.ctx, abci.RequestEndBlock{Height: 100})
+	suite.Require().Nil(suite.app.EvmKeeper.GetBaseFee(suite.ctx.WithBlockHeight(101)))
+
+	// schedule london at the next block
+	config, _ := suite.app.EvmKeeper.GetChainConfig(suite.ctx)
+	config.BerlinBlock = sdk.NewInt(101)
+	config.LondonBlock = sdk.NewInt(101)
+	suite.app.EvmKeeper.SetChainConfig(suite.ctx, config)
+
+	_ = suite.app.EvmKeeper.EndBlock(suite.ctx, abci.RequestEndBlock{Height: 100})
+	suite.Require().Equal(types.InitialBaseFee(), suite.app.EvmKeeper.GetBaseFee(suite.ctx.WithBlockHeight(101)))
+
+	// the block gas meter is infinite, the gas target is taken from the consensus params
+	consensusParams := &abci.ConsensusParams{Block: &abci.BlockParams{MaxGas: 10000000}}
+	fullBlockGasMeter := sdk.NewInfiniteGasMeter()
+	fullBlockGasMeter.ConsumeGas(10000000, "test")
+	emptyBlockGasMeter := sdk.NewInfiniteGasMeter()
+
+	// a full block increases the base fee by 12.5%
+	ctx := suite.ctx.WithBlockHeight(101).WithBlockGasMeter(fullBlockGasMeter).WithConsensusParams(consensusParams)
+	_ = suite.app.EvmKeeper.EndBlock(ctx, abci.RequestEndBlock{Height: 101})
+	suite.Require().Equal(big.NewInt(1125000000), suite.app.EvmKeeper.GetBaseFee(suite.ctx.WithBlockHeight(102)))
+
+	ctx = suite.ctx.WithBlockHeight(102).WithBlockGasMeter(fullBlockGasMeter).WithConsensusParams(consensusParams)
+	_ = suite.app.EvmKeeper.EndBlock(ctx, abci.RequestEndBlock{Height: 102})
+	suite.Require().Equal(big.NewInt(1265625000), suite.app.EvmKeeper.GetBaseFee(suite.ctx.WithBlockHeight(103)))
+
+	// an empty block decreases it by 12.5%
+	ctx = suite.ctx.WithBlockHeight(103).WithBlockGasMeter(emptyBlockGasMeter).WithConsensusParams(consensusParams)
+	_ = suite.app.EvmKeeper.EndBlock(ctx, abci.RequestEndBlock{Height: 103})
+	suite.Require().Equal(big.NewInt(1107421875), suite.app.EvmKeeper.GetBaseFee(suite.ctx.WithBlockHeight(104)))
+
+	// without block max gas there's no gas target, the base fee is kept
+	ctx = suite.ctx.WithBlockHeight(104).WithBlockGasMeter(fullBlockGasMeter)
+	_ = suite.app.EvmKeeper.EndBlock(ctx, abci.RequestEndBlock{Height: 104})
+	suite.Require().Equal(big.NewInt(1107421875), suite.app.EvmKeeper.GetBaseFee(suite.ctx.WithBlockHeight(105)))
+
+	// only the latest base fee is kept
+	baseFee, found := suite.app.EvmKeeper.GetBlockBaseFee(suite.ctx, 105)
+	suite.Require().True(found)
+	suite.Require().Equal(big.NewInt(1107421875), baseFee)
+	_, found = suite.app.EvmKeeper.GetBlockBaseFee(suite.ctx, 104)
+	suite.Require().False(found)
+}
+
 func (suite *KeeperTestSuite) TestEndBlockWatcher() {
 	// update the counters
 	suite.app.EvmKeeper.Bloom.SetInt64(10)
@@ -82,6 +129,31 @@ func (suite *KeeperTestSuite) TestEndBlockWatcher() {
 	suite.Require().True(res2)
 }
 
+func (suite *KeeperTestSuite) TestEndBlockWatcherGasLimit() {
+	viper.Set(watcher.FlagFastQueryLru, 100)
+	defer os.RemoveAll(watcher.WatchDbDir)
+	querier := watcher.NewQuerier()
+
+	// the gas used ratio of the block is computed against the max gas of the consensus params
+	req := abci.RequestBeginBlock{Header: abci.Header{LastBlockId: abci.BlockID{Hash: []byte("hash")}, Height: 10}}
+	suite.app.EvmKeeper.BeginBlock(suite.ctx, req)
+	ctx := suite.ctx.WithConsensusParams(&abci.ConsensusParams{Block: &abci.BlockParams{MaxGas: 10000000}})
+	_ = suite.app.EvmKeeper.EndBlock(ctx, abci.RequestEndBlock{Height: 10})
+	time.Sleep(time.Millisecond)
+	gasPrice, err := querier.GetBlockGasPrice(10)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(10000000), uint64(gasPrice.GasLimit))
+
+	// the gas limit returned by the web3 api is used if the max gas is unlimited
+	req.Header.Height = 11
+	suite.app.EvmKeeper.BeginBlock(suite.ctx, req)
+	_ = suite.app.EvmKeeper.EndBlock(suite.ctx, abci.RequestEndBlock{Height: 11})
+	time.Sleep(time.Millisecond)
+	gasPrice, err = querier.GetBlockGasPrice(11)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(0xffffffff), uint64(gasPrice.GasLimit))
+}
+
 func (suite *KeeperTestSuite) TestResetCache() {
 	// fill journal
 	suite.stateDB.AddAddressToAccessList(suite.address)
@@ -49,8 +49,8 @@ type Keeper struct {
 
 	LogsManages *LogsManager
 
-	// add inner block data
-	innerBlockData BlockInnerData
+	// flat history of the evm state, nil unless it is enabled
+	stateHistory *types.StateHistory
 }
 
 // NewKeeper generates new evm module keeper
@@ -63,16 +63,17 @@ func NewKeeper(
 	}
 
 	types.InitTxTraces()
-	err := initInnerDB()
-	if err != nil {
-		panic(err)
-	}
 
 	if enable := types.GetEnableBloomFilter(); enable {
 		db := types.BloomDb()
 		types.InitIndexer(db)
 	}
 
+	if enable := types.GetEnableStateHistory(); enable {
+		db := types.StateHistoryDb()
+		types.InitStateHistory(db)
+	}
+
 	// NOTE: we pass in the parameter space to the CommitStateDB in order to use custom denominations for the EVM operations
 	k := &Keeper{
 		cdc:           cdc,
@@ -87,9 +88,9 @@ func NewKeeper(
 		Watcher:       watcher.NewWatcher(),
 		Ada:           types.DefaultPrefixDb{},
 
-		innerBlockData: defaultBlockInnerData(),
+		stateHistory: types.GetStateHistory(),
 	}
-	if k.Watcher.Enabled() {
+	if k.Watcher.Enabled() || k.stateHistory != nil {
 		ak.SetObserverKeeper(k)
 	}
 
@@ -118,6 +119,7 @@ func NewSimulateKeeper(
 
 func (k Keeper) OnAccountUpdated(acc auth.Account) {
 	k.Watcher.DeleteAccount(acc.GetAddress())
+	k.stateHistory.MarkAccount(ethcmn.BytesToAddress(acc.GetAddress()))
 }
 
 // Logger returns a module-specific logger.
@@ -130,6 +132,7 @@ func (k Keeper) GenerateCSDBParams() types.CommitStateDBParams {
 		BankKeeper:    k.bankKeeper,
 		Watcher:       k.Watcher,
 		Ada:           k.Ada,
+		StateHistory:  k.stateHistory,
 	}
 }
 
@@ -204,6 +207,65 @@ func (k Keeper) GetBlockBloom(ctx sdk.Context, height int64) ethtypes.Bloom {
 	return ethtypes.BytesToBloom(bz)
 }
 
+// ----------------------------------------------------------------------------
+// Block base fee mapping functions
+// Required by the London fork and the Web3 API.
+// ----------------------------------------------------------------------------
+
+// GetBaseFee returns the base fee per gas of the block of the context, or nil if the
+// London fork is not active at its height. Only the latest base fee is kept, which in
+// CheckTx is the base fee of the next block.
+func (k Keeper) GetBaseFee(ctx sdk.Context) *big.Int {
+	// the base fee is read by the ante handler and the msg handler, it mustn't change the gas used by the tx
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	config, found := k.GetChainConfig(ctx)
+	if !found || !config.IsLondon(ctx.BlockHeight()) {
+		return nil
+	}
+
+	_, baseFee := k.getLatestBaseFee(ctx)
+	return baseFee
+}
+
+// IsBerlin returns whether the Berlin fork is active at the height of the context
+func (k Keeper) IsBerlin(ctx sdk.Context) bool {
+	// read by the ante handler, see GetBaseFee
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	config, found := k.GetChainConfig(ctx)
+	return found && config.IsBerlin(ctx.BlockHeight())
+}
+
+// GetBlockBaseFee gets the base fee per gas of the block at the given height. The base fee
+// of a block is set at the end of its parent block and replaces the one of the parent, so
+// it's only found in the state of the parent block.
+func (k Keeper) GetBlockBaseFee(ctx sdk.Context, height int64) (*big.Int, bool) {
+	latestHeight, baseFee := k.getLatestBaseFee(ctx)
+	if latestHeight != height {
+		return nil, false
+	}
+	return baseFee, true
+}
+
+// SetBlockBaseFee sets the base fee per gas of the block at the given height, replacing the
+// one of the previous block
+func (k Keeper) SetBlockBaseFee(ctx sdk.Context, height int64, baseFee *big.Int) {
+	store := k.Ada.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixBaseFee)
+	// set to an empty key that's already prefixed by KeyPrefixBaseFee
+	store.Set([]byte{}, append(sdk.Uint64ToBigEndian(uint64(height)), baseFee.Bytes()...))
+}
+
+// getLatestBaseFee returns the latest base fee and the height of its block, or the initial
+// base fee if it isn't set
+func (k Keeper) getLatestBaseFee(ctx sdk.Context) (int64, *big.Int) {
+	store := k.Ada.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixBaseFee)
+	bz := store.Get([]byte{})
+	if len(bz) < 8 {
+		return 0, types.InitialBaseFee()
+	}
+
+	return int64(binary.BigEndian.Uint64(bz[:8])), new(big.Int).SetBytes(bz[8:])
+}
+
 func (k Keeper) GetStoreKey() store.StoreKey {
 	return k.storeKey
 }
@@ -240,11 +302,14 @@ func (k Keeper) GetChainConfig(ctx sdk.Context) (types.ChainConfig, bool) {
 
 	var config types.ChainConfig
 	k.cdc.MustUnmarshalBinaryBare(bz, &config)
+	config.SetUnscheduledForks()
 	return config, true
 }
 
 // SetChainConfig sets the mapping from block consensus hash to block height
 func (k Keeper) SetChainConfig(ctx sdk.Context, config types.ChainConfig) {
+	// an uninitialized fork block would be encoded as zero, activating the fork at genesis
+	config.SetUnscheduledForks()
 	store := k.Ada.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixChainConfig)
 	bz := k.cdc.MustMarshalBinaryBare(config)
 	// get to an empty key that's already prefixed by KeyPrefixChainConfig
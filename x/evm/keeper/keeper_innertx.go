@@ -1,22 +1,14 @@
 package keeper
 
-func initInnerDB() error {
-	return nil
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+// AddInnerTx saves the inner txs of the tx to the watcher db
+func (k *Keeper) AddInnerTx(txHash common.Hash, innerTxs []*types.InnerTx) {
+	k.Watcher.SaveInnerTxs(txHash, innerTxs)
 }
 
-type BlockInnerData = interface{}
-
-func defaultBlockInnerData() BlockInnerData {
-	return nil
-}
-
-// InitInnerBlock init inner block data
-func (k *Keeper) InitInnerBlock(...interface{}) {}
-
-func (k *Keeper) UpdateInnerBlockData(...interface{}) {}
-
-// AddInnerTx add inner tx
-func (k *Keeper) AddInnerTx(...interface{}) {}
-
 // AddContract add erc20 contract
 func (k *Keeper) AddContract(...interface{}) {}
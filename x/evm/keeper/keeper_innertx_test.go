@@ -0,0 +1,63 @@
+package keeper_test
+
+import (
+	"math/big"
+	"os"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/viper"
+
+	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/evm"
+	"github.com/okex/exchain/x/evm/types"
+	"github.com/okex/exchain/x/evm/watcher"
+)
+
+func (suite *KeeperTestSuite) TestInnerTxsOfFailedTx() {
+	viper.Set(watcher.FlagFastQueryLru, 100)
+	defer os.RemoveAll(watcher.WatchDbDir)
+	querier := watcher.NewQuerier()
+
+	params := types.DefaultParams()
+	params.EnableCall = true
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	priv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	suite.stateDB.SetBalance(ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey), big.NewInt(1000000))
+
+	// the contract calls the reverter and reverts itself
+	reverter := ethcmn.BytesToAddress([]byte("reverter"))
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	code := append([]byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x73}, reverter.Bytes()...)
+	code = append(code, 0x5a, 0xf1, 0x50, 0x60, 0x00, 0x60, 0x00, 0xfd)
+	suite.stateDB.SetCode(reverter, []byte{0x60, 0x00, 0x60, 0x00, 0xfd})
+	suite.stateDB.SetCode(contract, code)
+	_, err = suite.stateDB.Commit(false)
+	suite.Require().NoError(err)
+
+	tx := types.NewMsgEthereumTx(0, &contract, big.NewInt(0), 100000, big.NewInt(1), nil)
+	suite.Require().NoError(tx.Sign(big.NewInt(3), priv.ToECDSA()))
+	txBytes := []byte("failed tx")
+	txHash := ethcmn.BytesToHash(tmtypes.Tx(txBytes).Hash())
+
+	req := abci.RequestBeginBlock{Header: abci.Header{LastBlockId: abci.BlockID{Hash: []byte("hash")}, Height: 10}}
+	suite.app.EvmKeeper.BeginBlock(suite.ctx, req)
+	_, err = evm.NewHandler(suite.app.EvmKeeper)(suite.ctx.WithTxBytes(txBytes), tx)
+	suite.Require().Error(err)
+	_ = suite.app.EvmKeeper.EndBlock(suite.ctx, abci.RequestEndBlock{Height: 10})
+	time.Sleep(time.Millisecond)
+
+	// the inner txs of the failed tx are kept
+	innerTxs, err := querier.GetInnerTxs(txHash)
+	suite.Require().NoError(err)
+	suite.Require().Len(innerTxs, 2)
+	suite.Require().Equal(contract, innerTxs[0].To)
+	suite.Require().Equal("execution reverted", innerTxs[0].Error)
+	suite.Require().Equal(reverter, innerTxs[1].To)
+	suite.Require().Equal("execution reverted", innerTxs[1].Error)
+}
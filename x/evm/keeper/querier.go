@@ -53,6 +53,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryContractDeploymentWhitelist(ctx, keeper)
 		case types.QueryContractBlockedList:
 			return queryContractBlockedList(ctx, keeper)
+		case types.QueryBaseFee:
+			return queryBlockBaseFee(ctx, path, keeper)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
 		}
@@ -202,6 +204,30 @@ func queryHashToHeight(ctx sdk.Context, path []string, keeper Keeper) ([]byte, e
 	return bz, nil
 }
 
+func queryBlockBaseFee(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	num, err := strconv.ParseInt(path[1], 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrStrConvertFailed, fmt.Sprintf("could not unmarshal block height: %s", err))
+	}
+
+	var res types.QueryResBaseFee
+	if baseFee, found := keeper.GetBlockBaseFee(ctx, num); found {
+		res.BaseFee = baseFee.String()
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
 func queryBlockBloom(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
 	if len(path) < 2 {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	ethermint "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+// commitStateHistory writes the accounts and the storage slots changed in the block
+// at height to the state history, their values are read from the state at the end
+// of the block.
+func (k *Keeper) commitStateHistory(ctx sdk.Context, height int64) {
+	if k.stateHistory == nil {
+		return
+	}
+
+	getAccount := func(addr ethcmn.Address) *types.HistoricalAccount {
+		acc := k.accountKeeper.GetAccount(ctx, addr.Bytes())
+		if acc == nil {
+			return nil
+		}
+		historical := &types.HistoricalAccount{
+			Balance:  acc.GetCoins().AmountOf(sdk.DefaultBondDenom).BigInt(),
+			Nonce:    acc.GetSequence(),
+			CodeHash: ethcrypto.Keccak256(nil),
+		}
+		if ethAcc, ok := acc.(*ethermint.EthAccount); ok {
+			historical.CodeHash = ethAcc.CodeHash
+		}
+		return historical
+	}
+	getState := func(addr ethcmn.Address, key ethcmn.Hash) ethcmn.Hash {
+		return k.GetStateByKey(ctx, addr, key)
+	}
+
+	fromGenesis := height == tmtypes.GetStartBlockHeight()+1
+	if err := k.stateHistory.Commit(height, fromGenesis, getAccount, getState); err != nil {
+		k.Logger(ctx).Error("failed to commit the state history", "height", height, "error", err)
+	}
+}
@@ -0,0 +1,26 @@
+package keeper
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+// SetSystemContract deploys the code at the address unless a contract is already there, and sets the storage of the
+// contract. The other modules expose their state to the evm contracts through the system contracts
+func (k *Keeper) SetSystemContract(ctx sdk.Context, addr ethcmn.Address, code []byte, storage types.Storage) error {
+	csdb := types.CreateEmptyCommitStateDB(k.GenerateCSDBParams(), ctx)
+	if csdb.GetCodeSize(addr) == 0 {
+		csdb.SetCode(addr, code)
+	}
+	for _, state := range storage {
+		csdb.SetState(addr, state.Key, state.Value)
+	}
+
+	// the storage is committed by Finalise and the code by Commit
+	if err := csdb.Finalise(false); err != nil {
+		return err
+	}
+	_, err := csdb.Commit(false)
+	return err
+}
@@ -0,0 +1,22 @@
+package keeper_test
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+func (suite *KeeperTestSuite) TestSetSystemContract() {
+	addr := ethcmn.HexToAddress("0x000000000000000000000000000000000000a001")
+	code := []byte{0x60, 0x00, 0x54}
+
+	storage := types.Storage{types.NewState(ethcmn.HexToHash("0x1"), ethcmn.HexToHash("0x2"))}
+	suite.Require().NoError(suite.app.EvmKeeper.SetSystemContract(suite.ctx, addr, code, storage))
+	suite.Require().Equal(code, suite.app.EvmKeeper.GetCode(suite.ctx, addr))
+	suite.Require().Equal(ethcmn.HexToHash("0x2"), suite.app.EvmKeeper.GetState(suite.ctx, addr, ethcmn.HexToHash("0x1")))
+
+	// the code deployed is kept and the storage is updated
+	storage = types.Storage{types.NewState(ethcmn.HexToHash("0x1"), ethcmn.HexToHash("0x3"))}
+	suite.Require().NoError(suite.app.EvmKeeper.SetSystemContract(suite.ctx, addr, []byte{0x00}, storage))
+	suite.Require().Equal(code, suite.app.EvmKeeper.GetCode(suite.ctx, addr))
+	suite.Require().Equal(ethcmn.HexToHash("0x3"), suite.app.EvmKeeper.GetState(suite.ctx, addr, ethcmn.HexToHash("0x1")))
+}
@@ -15,6 +15,8 @@ func NewManageContractDeploymentWhitelistProposalHandler(k *Keeper) govTypes.Han
 			return handleManageContractDeploymentWhitelistProposal(ctx, k, proposal)
 		case types.ManageContractBlockedListProposal:
 			return handleManageContractBlockedlListProposal(ctx, k, proposal)
+		case types.ManageForkBlocksProposal:
+			return handleManageForkBlocksProposal(ctx, k, proposal)
 		default:
 			return common.ErrUnknownProposalType(types.DefaultCodespace, content.ProposalType())
 		}
@@ -58,3 +60,20 @@ func handleManageContractBlockedlListProposal(ctx sdk.Context, k *Keeper, propos
 	csdb.DeleteContractBlockedList(manageContractBlockedListProposal.ContractAddrs)
 	return nil
 }
+
+func handleManageForkBlocksProposal(ctx sdk.Context, k *Keeper, proposal *govTypes.Proposal) sdk.Error {
+	// check
+	manageForkBlocksProposal, ok := proposal.Content.(types.ManageForkBlocksProposal)
+	if !ok {
+		return types.ErrUnexpectedProposalType
+	}
+
+	config, _ := k.GetChainConfig(ctx)
+	config, err := manageForkBlocksProposal.Apply(config, ctx.BlockHeight())
+	if err != nil {
+		return err
+	}
+
+	k.SetChainConfig(ctx, config)
+	return nil
+}
@@ -2,6 +2,7 @@ package evm_test
 
 import (
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/evm"
 	"github.com/okex/exchain/x/evm/types"
 	govtypes "github.com/okex/exchain/x/gov/types"
@@ -170,3 +171,33 @@ func (suite *EvmTestSuite) TestProposalHandler_ManageContractBlockedListProposal
 		})
 	}
 }
+
+func (suite *EvmTestSuite) TestProposalHandler_ManageForkBlocksProposal() {
+	proposal := types.NewManageForkBlocksProposal(
+		"default title",
+		"default description",
+		sdk.NewInt(10),
+		sdk.NewInt(20),
+	)
+
+	suite.govHandler = evm.NewManageContractDeploymentWhitelistProposalHandler(suite.app.EvmKeeper)
+	govProposal := govtypes.Proposal{
+		Content: proposal,
+	}
+
+	// schedule the forks
+	ctx := suite.ctx.WithBlockHeight(5)
+	suite.Require().NoError(suite.govHandler(ctx, &govProposal))
+	config, found := suite.app.EvmKeeper.GetChainConfig(ctx)
+	suite.Require().True(found)
+	suite.Require().Equal(sdk.NewInt(10), config.BerlinBlock)
+	suite.Require().Equal(sdk.NewInt(20), config.LondonBlock)
+
+	// berlin can't be rescheduled after it has been activated
+	proposal.BerlinBlock = sdk.NewInt(15)
+	govProposal.Content = proposal
+	ctx = suite.ctx.WithBlockHeight(12)
+	suite.Require().Error(suite.govHandler(ctx, &govProposal))
+	config, _ = suite.app.EvmKeeper.GetChainConfig(ctx)
+	suite.Require().Equal(sdk.NewInt(10), config.BerlinBlock)
+}
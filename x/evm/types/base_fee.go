@@ -0,0 +1,41 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// InitialBaseFee returns the base fee of the first block of the London fork
+func InitialBaseFee() *big.Int {
+	return new(big.Int).SetUint64(params.InitialBaseFee)
+}
+
+// CalcBaseFee calculates the base fee of the next block from the base fee, gas used
+// and gas limit of the current one, as go-ethereum's misc.CalcBaseFee does. A block
+// without gas limit has no gas target, so its base fee is kept.
+func CalcBaseFee(baseFee *big.Int, gasUsed, gasLimit uint64) *big.Int {
+	gasTarget := gasLimit / params.ElasticityMultiplier
+	if gasTarget == 0 || gasUsed == gasTarget {
+		return new(big.Int).Set(baseFee)
+	}
+
+	target := new(big.Int).SetUint64(gasTarget)
+	denominator := new(big.Int).SetUint64(params.BaseFeeChangeDenominator)
+	if gasUsed > gasTarget {
+		// base fee increases by at least 1 when the block is over the target
+		delta := new(big.Int).SetUint64(gasUsed - gasTarget)
+		delta.Mul(delta, baseFee)
+		delta.Div(delta, target)
+		delta.Div(delta, denominator)
+		delta = math.BigMax(delta, big.NewInt(1))
+		return delta.Add(delta, baseFee)
+	}
+
+	delta := new(big.Int).SetUint64(gasTarget - gasUsed)
+	delta.Mul(delta, baseFee)
+	delta.Div(delta, target)
+	delta.Div(delta, denominator)
+	return math.BigMax(delta.Sub(baseFee, delta), big.NewInt(0))
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcBaseFee(t *testing.T) {
+	initial := InitialBaseFee()
+
+	testCases := []struct {
+		name     string
+		gasUsed  uint64
+		gasLimit uint64
+		expected *big.Int
+	}{
+		{"at target", 5000000, 10000000, initial},
+		{"full block", 10000000, 10000000, big.NewInt(1125000000)},
+		{"empty block", 0, 10000000, big.NewInt(875000000)},
+		{"above target", 7500000, 10000000, big.NewInt(1062500000)},
+		{"below target", 2500000, 10000000, big.NewInt(937500000)},
+		{"infinite gas limit", 2500000, 0, initial},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, CalcBaseFee(initial, tc.gasUsed, tc.gasLimit), tc.name)
+	}
+
+	// the base fee increases by at least 1 wei if the block is above the target
+	require.Equal(t, big.NewInt(2), CalcBaseFee(big.NewInt(1), 6, 10))
+}
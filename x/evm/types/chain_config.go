@@ -39,6 +39,8 @@ type ChainConfig struct {
 	PetersburgBlock     sdk.Int `json:"petersburg_block" yaml:"petersburg_block"`         // Petersburg switch block (< 0 same as Constantinople)
 	IstanbulBlock       sdk.Int `json:"istanbul_block" yaml:"istanbul_block"`             // Istanbul switch block (< 0 no fork, 0 = already on istanbul)
 	MuirGlacierBlock    sdk.Int `json:"muir_glacier_block" yaml:"muir_glacier_block"`     // Eip-2384 (bomb delay) switch block (< 0 no fork, 0 = already activated)
+	BerlinBlock         sdk.Int `json:"berlin_block" yaml:"berlin_block"`                 // Berlin switch block (< 0 or nil no fork, 0 = already on berlin)
+	LondonBlock         sdk.Int `json:"london_block" yaml:"london_block"`                 // London switch block (< 0 or nil no fork, 0 = already on london)
 
 	YoloV2Block sdk.Int `json:"yoloV2_block" yaml:"yoloV2_block"` // YOLO v1: https://github.com/ethereum/EIPs/pull/2657 (Ephemeral testnet)
 	EWASMBlock  sdk.Int `json:"ewasm_block" yaml:"ewasm_block"`   // EWASM switch block (< 0 no fork, 0 = already activated)
@@ -61,6 +63,8 @@ func (cc ChainConfig) EthereumConfig(chainID *big.Int) *params.ChainConfig {
 		PetersburgBlock:     getBlockValue(cc.PetersburgBlock),
 		IstanbulBlock:       getBlockValue(cc.IstanbulBlock),
 		MuirGlacierBlock:    getBlockValue(cc.MuirGlacierBlock),
+		BerlinBlock:         getBlockValue(cc.BerlinBlock),
+		LondonBlock:         getBlockValue(cc.LondonBlock),
 	}
 }
 
@@ -74,6 +78,29 @@ func (cc ChainConfig) IsHomestead() bool {
 	return getBlockValue(cc.HomesteadBlock) != nil
 }
 
+// IsBerlin returns whether the Berlin fork is active at the given height.
+func (cc ChainConfig) IsBerlin(height int64) bool {
+	return isForked(cc.BerlinBlock, height)
+}
+
+// IsLondon returns whether the London fork is active at the given height.
+func (cc ChainConfig) IsLondon(height int64) bool {
+	return isForked(cc.LondonBlock, height)
+}
+
+// SetUnscheduledForks sets the fork blocks that are uninitialized to -1. The Berlin and
+// London blocks were added after the chain launched, so the config stored in the chain
+// state or an existing genesis file doesn't contain them. They are not activated until
+// they are scheduled through a ManageForkBlocksProposal.
+func (cc *ChainConfig) SetUnscheduledForks() {
+	if cc.BerlinBlock.IsNil() {
+		cc.BerlinBlock = sdk.NewInt(-1)
+	}
+	if cc.LondonBlock.IsNil() {
+		cc.LondonBlock = sdk.NewInt(-1)
+	}
+}
+
 // String implements the fmt.Stringer interface
 func (cc ChainConfig) String() string {
 	out, _ := yaml.Marshal(cc)
@@ -95,19 +122,26 @@ func DefaultChainConfig() ChainConfig {
 		PetersburgBlock:     sdk.ZeroInt(),
 		IstanbulBlock:       sdk.ZeroInt(),
 		MuirGlacierBlock:    sdk.ZeroInt(),
+		BerlinBlock:         sdk.NewInt(-1),
+		LondonBlock:         sdk.NewInt(-1),
 		YoloV2Block:         sdk.NewInt(-1),
 		EWASMBlock:          sdk.NewInt(-1),
 	}
 }
 
 func getBlockValue(block sdk.Int) *big.Int {
-	if block.IsNegative() {
+	if block.IsNil() || block.IsNegative() {
 		return nil
 	}
 
 	return block.BigInt()
 }
 
+func isForked(block sdk.Int, height int64) bool {
+	value := getBlockValue(block)
+	return value != nil && value.Cmp(big.NewInt(height)) <= 0
+}
+
 // Validate performs a basic validation of the ChainConfig params. The function will return an error
 // if any of the block values is uninitialized (i.e nil) or if the EIP150Hash is an invalid hash.
 func (cc ChainConfig) Validate() error {
@@ -144,6 +178,10 @@ func (cc ChainConfig) Validate() error {
 	if err := validateBlock(cc.MuirGlacierBlock); err != nil {
 		return sdkerrors.Wrap(err, "muirGlacierBlock")
 	}
+	// the Berlin and London blocks are allowed to be uninitialized, see SetUnscheduledForks
+	if err := validateForkOrder(cc.BerlinBlock, cc.LondonBlock); err != nil {
+		return sdkerrors.Wrap(err, "londonBlock")
+	}
 	if err := validateBlock(cc.YoloV2Block); err != nil {
 		return sdkerrors.Wrap(err, "yoloV2Block")
 	}
@@ -178,3 +216,19 @@ func validateBlock(block sdk.Int) error {
 
 	return nil
 }
+
+// validateForkOrder checks that a fork is not activated before the fork it depends on.
+func validateForkOrder(prev, next sdk.Int) error {
+	prevValue, nextValue := getBlockValue(prev), getBlockValue(next)
+	if nextValue == nil {
+		return nil
+	}
+	if prevValue == nil {
+		return sdkerrors.Wrap(ErrInvalidChainConfig, "cannot be activated without the fork it depends on")
+	}
+	if prevValue.Cmp(nextValue) > 0 {
+		return sdkerrors.Wrapf(ErrInvalidChainConfig, "cannot be activated before the fork it depends on (%s > %s)", prevValue, nextValue)
+	}
+
+	return nil
+}
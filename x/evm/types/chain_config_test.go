@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -257,8 +258,39 @@ constantinople_block: "0"
 petersburg_block: "0"
 istanbul_block: "0"
 muir_glacier_block: "0"
+berlin_block: "-1"
+london_block: "-1"
 yoloV2_block: "-1"
 ewasm_block: "-1"
 `
 	require.Equal(t, configStr, DefaultChainConfig().String())
 }
+
+func TestChainConfigForkBlocks(t *testing.T) {
+	config := DefaultChainConfig()
+	require.False(t, config.IsBerlin(100))
+	require.False(t, config.IsLondon(100))
+	require.Nil(t, config.EthereumConfig(big.NewInt(1)).LondonBlock)
+
+	config.BerlinBlock = sdk.NewInt(10)
+	config.LondonBlock = sdk.NewInt(20)
+	require.NoError(t, config.Validate())
+	require.True(t, config.IsBerlin(10))
+	require.False(t, config.IsLondon(19))
+	require.True(t, config.IsLondon(20))
+	require.Equal(t, big.NewInt(20), config.EthereumConfig(big.NewInt(1)).LondonBlock)
+
+	// london can't be activated before berlin
+	config.BerlinBlock = sdk.NewInt(30)
+	require.Error(t, config.Validate())
+	config.BerlinBlock = sdk.NewInt(-1)
+	require.Error(t, config.Validate())
+
+	// configs stored before the forks were added don't contain them
+	config.BerlinBlock, config.LondonBlock = sdk.Int{}, sdk.Int{}
+	require.NoError(t, config.Validate())
+	require.False(t, config.IsLondon(100))
+	config.SetUnscheduledForks()
+	require.Equal(t, sdk.NewInt(-1), config.BerlinBlock)
+	require.Equal(t, sdk.NewInt(-1), config.LondonBlock)
+}
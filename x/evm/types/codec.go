@@ -16,6 +16,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(ChainConfig{}, "ethermint/ChainConfig", nil)
 	cdc.RegisterConcrete(ManageContractDeploymentWhitelistProposal{}, "okexchain/evm/ManageContractDeploymentWhitelistProposal", nil)
 	cdc.RegisterConcrete(ManageContractBlockedListProposal{}, "okexchain/evm/ManageContractBlockedListProposal", nil)
+	cdc.RegisterConcrete(ManageForkBlocksProposal{}, "okexchain/evm/ManageForkBlocksProposal", nil)
 }
 
 func init() {
@@ -44,6 +44,12 @@ var (
 	// ErrDuplicatedAddr returns an error if the address is duplicated in address list
 	ErrDuplicatedAddr = sdkerrors.Register(ModuleName, 12, "Duplicated address in address list")
 
+	// ErrTxTypeNotSupported returns an error if the typed tx is unknown or its fork is not active
+	ErrTxTypeNotSupported = sdkerrors.Register(ModuleName, 16, "transaction type not supported")
+
+	// ErrFeeCapTooLow returns an error if the max fee per gas of a tx is lower than the base fee of the block
+	ErrFeeCapTooLow = sdkerrors.Register(ModuleName, 17, "max fee per gas less than block base fee")
+
 	CodeSpaceEvmCallFailed = uint32(7)
 
 	ErrorHexData = "HexData"
@@ -0,0 +1,268 @@
+package types
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+const (
+	innerTxFailure       = "internal failure"
+	innerTxReverted      = "execution reverted"
+	innerTxParentFailure = "parent call reverted"
+)
+
+// InnerTx is a call, create or selfdestruct run by a tx, the tx itself is the inner
+// tx of depth 0. Error is set if the inner tx or one of its parents failed, in which
+// case its value transfer is reverted.
+type InnerTx struct {
+	Type    string         `json:"type"`
+	From    common.Address `json:"from"`
+	To      common.Address `json:"to"`
+	Value   *hexutil.Big   `json:"value"`
+	Gas     hexutil.Uint64 `json:"gas"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Depth   int            `json:"depth"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type innerTxFrame struct {
+	tx      *InnerTx
+	gasIn   uint64
+	gasCost uint64
+	entered bool
+	calls   []*innerTxFrame
+}
+
+// InnerTxRecorder is a vm.Tracer recording the inner txs of a tx. The calls are found
+// from the opcodes run by the evm, the same way as the call tracer of go-ethereum.
+type InnerTxRecorder struct {
+	callstack []*innerTxFrame
+	descended bool
+}
+
+var _ vm.Tracer = (*InnerTxRecorder)(nil)
+
+// NewInnerTxRecorder returns a recorder for a single tx
+func NewInnerTxRecorder() *InnerTxRecorder {
+	return &InnerTxRecorder{}
+}
+
+func (r *InnerTxRecorder) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	typ := vm.CALL.String()
+	if create {
+		typ = vm.CREATE.String()
+	}
+	r.callstack = []*innerTxFrame{{
+		tx: &InnerTx{
+			Type:  typ,
+			From:  from,
+			To:    to,
+			Value: (*hexutil.Big)(new(big.Int).Set(value)),
+			Gas:   hexutil.Uint64(gas),
+		},
+		entered: true,
+	}}
+}
+
+func (r *InnerTxRecorder) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if err != nil {
+		r.fault(err)
+		return
+	}
+	if len(r.callstack) == 0 {
+		return
+	}
+	stack := scope.Stack
+	caller := scope.Contract.Address()
+
+	switch op {
+	case vm.CREATE, vm.CREATE2:
+		r.push(&innerTxFrame{
+			tx: &InnerTx{
+				Type:  op.String(),
+				From:  caller,
+				Value: (*hexutil.Big)(stack.Back(0).ToBig()),
+			},
+			gasIn:   gas,
+			gasCost: cost,
+		})
+		return
+	case vm.SELFDESTRUCT:
+		top := r.callstack[len(r.callstack)-1]
+		top.calls = append(top.calls, &innerTxFrame{
+			tx: &InnerTx{
+				Type:  op.String(),
+				From:  caller,
+				To:    common.Address(stack.Back(0).Bytes20()),
+				Value: (*hexutil.Big)(env.StateDB.GetBalance(caller)),
+				Depth: len(r.callstack),
+			},
+		})
+		return
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		to := common.Address(stack.Back(1).Bytes20())
+		if _, ok := vm.PrecompiledContractsBerlin[to]; ok {
+			return
+		}
+		value := new(big.Int)
+		if op == vm.CALL || op == vm.CALLCODE {
+			value = stack.Back(2).ToBig()
+		}
+		r.push(&innerTxFrame{
+			tx: &InnerTx{
+				Type:  op.String(),
+				From:  caller,
+				To:    to,
+				Value: (*hexutil.Big)(value),
+			},
+			gasIn:   gas,
+			gasCost: cost,
+		})
+		return
+	}
+
+	// the first op after a call tells if the evm entered the callee
+	if r.descended {
+		if depth >= len(r.callstack) {
+			top := r.callstack[len(r.callstack)-1]
+			top.tx.Gas = hexutil.Uint64(gas)
+			top.entered = true
+		}
+		r.descended = false
+	}
+
+	if op == vm.REVERT {
+		r.callstack[len(r.callstack)-1].tx.Error = innerTxReverted
+		return
+	}
+
+	// back to the caller, the result of the call is on the top of the stack
+	if depth == len(r.callstack)-1 {
+		frame := r.callstack[len(r.callstack)-1]
+		r.callstack = r.callstack[:len(r.callstack)-1]
+
+		ret := stack.Back(0)
+		switch frame.tx.Type {
+		case vm.CREATE.String(), vm.CREATE2.String():
+			frame.tx.GasUsed = hexutil.Uint64(subGas(frame.gasIn, frame.gasCost+gas))
+			if !ret.IsZero() {
+				frame.tx.To = common.Address(ret.Bytes20())
+			}
+		default:
+			if frame.entered {
+				frame.tx.GasUsed = hexutil.Uint64(subGas(frame.gasIn+uint64(frame.tx.Gas), frame.gasCost+gas))
+			}
+		}
+		if ret.IsZero() && frame.tx.Error == "" {
+			frame.tx.Error = innerTxFailure
+		}
+
+		parent := r.callstack[len(r.callstack)-1]
+		parent.calls = append(parent.calls, frame)
+	}
+}
+
+func (r *InnerTxRecorder) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+	r.fault(err)
+}
+
+func (r *InnerTxRecorder) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) {
+	if len(r.callstack) == 0 {
+		return
+	}
+	root := r.callstack[0]
+	root.tx.GasUsed = hexutil.Uint64(gasUsed)
+	if err != nil {
+		root.tx.Error = err.Error()
+	}
+}
+
+// InnerTxs returns the recorded inner txs in the order they are run. failed tells if
+// the tx failed, then all its inner txs are reverted.
+func (r *InnerTxRecorder) InnerTxs(failed bool) []*InnerTx {
+	if len(r.callstack) == 0 {
+		return nil
+	}
+	root := r.callstack[0]
+	if failed && root.tx.Error == "" {
+		root.tx.Error = innerTxReverted
+	}
+
+	var txs []*InnerTx
+	var walk func(frame *innerTxFrame, parentFailed bool)
+	walk = func(frame *innerTxFrame, parentFailed bool) {
+		if parentFailed && frame.tx.Error == "" {
+			frame.tx.Error = innerTxParentFailure
+		}
+		txs = append(txs, frame.tx)
+		for _, call := range frame.calls {
+			walk(call, frame.tx.Error != "")
+		}
+	}
+	walk(root, false)
+	return txs
+}
+
+func (r *InnerTxRecorder) push(frame *innerTxFrame) {
+	frame.tx.Depth = len(r.callstack)
+	r.callstack = append(r.callstack, frame)
+	r.descended = true
+}
+
+// fault fails the current call, all the gas given to it is used
+func (r *InnerTxRecorder) fault(err error) {
+	if len(r.callstack) == 0 {
+		return
+	}
+	frame := r.callstack[len(r.callstack)-1]
+	if frame.tx.Error != "" {
+		return
+	}
+	frame.tx.Error = err.Error()
+	frame.tx.GasUsed = frame.tx.Gas
+	if len(r.callstack) == 1 {
+		return
+	}
+
+	r.callstack = r.callstack[:len(r.callstack)-1]
+	parent := r.callstack[len(r.callstack)-1]
+	parent.calls = append(parent.calls, frame)
+}
+
+func subGas(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// multiTracer forwards the evm events to all its tracers
+type multiTracer []vm.Tracer
+
+func (t multiTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	for _, tracer := range t {
+		tracer.CaptureStart(env, from, to, create, input, gas, value)
+	}
+}
+
+func (t multiTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	for _, tracer := range t {
+		tracer.CaptureState(env, pc, op, gas, cost, scope, rData, depth, err)
+	}
+}
+
+func (t multiTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+	for _, tracer := range t {
+		tracer.CaptureFault(env, pc, op, gas, cost, scope, depth, err)
+	}
+}
+
+func (t multiTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) {
+	for _, tracer := range t {
+		tracer.CaptureEnd(output, gasUsed, d, err)
+	}
+}
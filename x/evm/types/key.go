@@ -28,6 +28,7 @@ var (
 	KeyPrefixHeightHash                  = []byte{0x07}
 	KeyPrefixContractDeploymentWhitelist = []byte{0x08}
 	KeyPrefixContractBlockedList         = []byte{0x09}
+	KeyPrefixBaseFee                     = []byte{0x0A}
 )
 
 // HeightHashKey returns the key for the given chain epoch and height.
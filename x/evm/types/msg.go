@@ -220,6 +220,46 @@ func (msg MsgEthereumTx) Route() string { return RouterKey }
 // Type returns the type value of an MsgEthereumTx.
 func (msg MsgEthereumTx) Type() string { return TypeMsgEthereumTx }
 
+// NewMsgEthereumTxDynamicFee returns a reference to a new EIP-1559 dynamic fee
+// Ethereum transaction message. A nil recipient means contract creation.
+func NewMsgEthereumTxDynamicFee(
+	chainID *big.Int, nonce uint64, to *ethcmn.Address, amount *big.Int,
+	gasLimit uint64, gasTipCap, gasFeeCap *big.Int, payload []byte, accesses ethtypes.AccessList,
+) MsgEthereumTx {
+	msg := newMsgEthereumTx(nonce, to, amount, gasLimit, gasFeeCap, payload)
+	msg.Data.Type = ethtypes.DynamicFeeTxType
+	msg.Data.ChainID = new(big.Int)
+	msg.Data.GasTipCap = new(big.Int)
+	msg.Data.Accesses = accesses
+
+	if chainID != nil {
+		msg.Data.ChainID.Set(chainID)
+	}
+	if gasTipCap != nil {
+		msg.Data.GasTipCap.Set(gasTipCap)
+	}
+
+	return msg
+}
+
+// NewMsgEthereumTxAccessList returns a reference to a new EIP-2930 access list
+// transaction message, the recipient is nil for a contract creation.
+func NewMsgEthereumTxAccessList(
+	chainID *big.Int, nonce uint64, to *ethcmn.Address, amount *big.Int,
+	gasLimit uint64, gasPrice *big.Int, payload []byte, accesses ethtypes.AccessList,
+) MsgEthereumTx {
+	msg := newMsgEthereumTx(nonce, to, amount, gasLimit, gasPrice, payload)
+	msg.Data.Type = ethtypes.AccessListTxType
+	msg.Data.ChainID = new(big.Int)
+	msg.Data.Accesses = accesses
+
+	if chainID != nil {
+		msg.Data.ChainID.Set(chainID)
+	}
+
+	return msg
+}
+
 // ValidateBasic implements the sdk.Msg interface. It performs basic validation
 // checks of a Transaction. If returns an error if validation fails.
 func (msg MsgEthereumTx) ValidateBasic() error {
@@ -236,6 +276,35 @@ func (msg MsgEthereumTx) ValidateBasic() error {
 		return sdkerrors.Wrapf(types.ErrInvalidValue, "amount cannot be negative %s", msg.Data.Amount)
 	}
 
+	if msg.Data.IsTyped() {
+		return msg.validateTyped()
+	}
+
+	return nil
+}
+
+func (msg MsgEthereumTx) validateTyped() error {
+	if msg.Data.Type != ethtypes.AccessListTxType && !msg.Data.IsDynamicFee() {
+		return sdkerrors.Wrapf(ErrTxTypeNotSupported, "tx type %d", msg.Data.Type)
+	}
+
+	if msg.Data.ChainID == nil || msg.Data.ChainID.Sign() != 1 {
+		return sdkerrors.Wrapf(types.ErrInvalidValue, "invalid chain id %s", msg.Data.ChainID)
+	}
+
+	if !msg.Data.IsDynamicFee() {
+		return nil
+	}
+
+	if msg.Data.GasTipCap == nil || msg.Data.GasTipCap.Sign() == -1 {
+		return sdkerrors.Wrapf(types.ErrInvalidValue, "max priority fee per gas cannot be nil or negative %s", msg.Data.GasTipCap)
+	}
+
+	if msg.Data.GasTipCap.Cmp(msg.Data.Price) > 0 {
+		return sdkerrors.Wrapf(types.ErrInvalidValue,
+			"max priority fee per gas higher than max fee per gas (%s > %s)", msg.Data.GasTipCap, msg.Data.Price)
+	}
+
 	return nil
 }
 
@@ -274,6 +343,14 @@ func (msg MsgEthereumTx) GetSignBytes() []byte {
 // RLPSignBytes returns the RLP hash of an Ethereum transaction message with a
 // given chainID used for signing.
 func (msg MsgEthereumTx) RLPSignBytes(chainID *big.Int) ethcmn.Hash {
+	if msg.Data.IsTyped() {
+		ethTx, err := msg.Data.toEthTx()
+		if err != nil {
+			return ethcmn.Hash{}
+		}
+		return ethtypes.NewLondonSigner(chainID).Hash(ethTx)
+	}
+
 	return rlpHash([]interface{}{
 		msg.Data.AccountNonce,
 		msg.Data.Price,
@@ -298,24 +375,80 @@ func (msg MsgEthereumTx) HomesteadSignHash() ethcmn.Hash {
 	})
 }
 
-// EncodeRLP implements the rlp.Encoder interface.
+// EncodeRLP implements the rlp.Encoder interface. As in go-ethereum, typed txs
+// are encoded as an RLP string holding their binary encoding.
 func (msg *MsgEthereumTx) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &msg.Data)
+	if !msg.Data.IsTyped() {
+		return rlp.Encode(w, &msg.Data)
+	}
+
+	bz, err := msg.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, bz)
 }
 
 // DecodeRLP implements the rlp.Decoder interface.
 func (msg *MsgEthereumTx) DecodeRLP(s *rlp.Stream) error {
-	_, size, err := s.Kind()
+	kind, size, err := s.Kind()
 	if err != nil {
 		// return error if stream is too large
 		return err
 	}
 
-	if err := s.Decode(&msg.Data); err != nil {
+	switch kind {
+	case rlp.List:
+		if err := s.Decode(&msg.Data); err != nil {
+			return err
+		}
+		msg.size.Store(ethcmn.StorageSize(rlp.ListSize(size)))
+	case rlp.String:
+		bz, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		return msg.UnmarshalBinary(bz)
+	default:
+		return rlp.ErrExpectedList
+	}
+
+	return nil
+}
+
+// MarshalBinary returns the canonical encoding of the transaction, which is the
+// RLP encoding for legacy txs and the EIP-2718 envelope for typed txs.
+func (msg *MsgEthereumTx) MarshalBinary() ([]byte, error) {
+	if !msg.Data.IsTyped() {
+		return rlp.EncodeToBytes(&msg.Data)
+	}
+
+	ethTx, err := msg.Data.toEthTx()
+	if err != nil {
+		return nil, err
+	}
+	return ethTx.MarshalBinary()
+}
+
+// UnmarshalBinary decodes the canonical encoding of a transaction, such as the
+// raw tx of eth_sendRawTransaction.
+func (msg *MsgEthereumTx) UnmarshalBinary(bz []byte) error {
+	if len(bz) > 0 && bz[0] > 0x7f {
+		// legacy txs are RLP lists
+		return rlp.DecodeBytes(bz, msg)
+	}
+
+	ethTx := new(ethtypes.Transaction)
+	if err := ethTx.UnmarshalBinary(bz); err != nil {
 		return err
 	}
 
-	msg.size.Store(ethcmn.StorageSize(rlp.ListSize(size)))
+	var data TxData
+	if err := data.fromEthTx(ethTx); err != nil {
+		return err
+	}
+	msg.Data = data
+	msg.size.Store(ethcmn.StorageSize(len(bz)))
 	return nil
 }
 
@@ -324,6 +457,10 @@ func (msg *MsgEthereumTx) DecodeRLP(s *rlp.Stream) error {
 // EIP155 standard. It mutates the transaction as it populates the V, R, S
 // fields of the Transaction's Signature.
 func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) error {
+	if msg.Data.IsTyped() {
+		// typed txs commit to their chain ID
+		msg.Data.ChainID = new(big.Int).Set(chainID)
+	}
 	txHash := msg.RLPSignBytes(chainID)
 
 	sig, err := ethcrypto.Sign(txHash[:], priv)
@@ -340,7 +477,10 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) error {
 
 	var v *big.Int
 
-	if chainID.Sign() == 0 {
+	if msg.Data.IsTyped() {
+		// typed txs use the y parity of the signature as V
+		v = big.NewInt(int64(sig[64]))
+	} else if chainID.Sign() == 0 {
 		v = new(big.Int).SetBytes([]byte{sig[64] + 27})
 	} else {
 		v = big.NewInt(int64(sig[64] + 35))
@@ -359,7 +499,9 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) error {
 // A derived address is returned upon success or an error if recovery fails.
 func (msg *MsgEthereumTx) VerifySig(chainID *big.Int, height int64, sigCtx sdk.SigCache) (sdk.SigCache, error) {
 	var signer ethtypes.Signer
-	if isProtectedV(msg.Data.V) {
+	if msg.Data.IsTyped() {
+		signer = ethtypes.NewLondonSigner(chainID)
+	} else if isProtectedV(msg.Data.V) {
 		signer = ethtypes.NewEIP155Signer(chainID)
 	} else {
 		if sdk.HigherThanMercury(height) {
@@ -386,6 +528,10 @@ func (msg *MsgEthereumTx) VerifySig(chainID *big.Int, height int64, sigCtx sdk.S
 		}
 	}
 
+	if msg.Data.IsTyped() {
+		return msg.verifyTypedSig(signer)
+	}
+
 	V := new(big.Int)
 	var sigHash ethcmn.Hash
 	if isProtectedV(msg.Data.V) {
@@ -414,6 +560,23 @@ func (msg *MsgEthereumTx) VerifySig(chainID *big.Int, height int64, sigCtx sdk.S
 	return sigCache, nil
 }
 
+// verifyTypedSig recovers the sender of a typed tx with the go-ethereum signer,
+// which also checks the chain ID the tx was signed for.
+func (msg *MsgEthereumTx) verifyTypedSig(signer ethtypes.Signer) (sdk.SigCache, error) {
+	ethTx, err := msg.Data.toEthTx()
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := signer.Sender(ethTx)
+	if err != nil {
+		return nil, err
+	}
+	sigCache := &ethSigCache{signer: signer, from: sender}
+	msg.from.Store(sigCache)
+	return sigCache, nil
+}
+
 // codes from go-ethereum/core/types/transaction.go:122
 func isProtectedV(V *big.Int) bool {
 	if V.BitLen() <= 8 {
@@ -429,13 +592,39 @@ func (msg MsgEthereumTx) GetGas() uint64 {
 	return msg.Data.GasLimit
 }
 
-// Fee returns gasprice * gaslimit.
+// Fee returns gasprice * gaslimit. For dynamic fee txs, it is the max fee the
+// tx may pay.
 func (msg MsgEthereumTx) Fee() *big.Int {
 	return new(big.Int).Mul(msg.Data.Price, new(big.Int).SetUint64(msg.Data.GasLimit))
 }
 
+// EffectiveGasPrice returns the gas price the tx pays in a block with the given
+// base fee, which is nil before the London fork. Dynamic fee txs pay the base
+// fee plus their priority fee, capped to their max fee per gas.
+func (msg MsgEthereumTx) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+	if baseFee == nil || !msg.Data.IsDynamicFee() {
+		return new(big.Int).Set(msg.Data.Price)
+	}
+
+	price := new(big.Int).Add(msg.Data.GasTipCap, baseFee)
+	if price.Cmp(msg.Data.Price) > 0 {
+		price.Set(msg.Data.Price)
+	}
+	return price
+}
+
+// GetEffectiveFee returns the fee paid by the tx in a block with the given base
+// fee, that is the effective gas price * gas limit.
+func (msg MsgEthereumTx) GetEffectiveFee(baseFee *big.Int) sdk.Coins {
+	fee := new(big.Int).Mul(msg.EffectiveGasPrice(baseFee), new(big.Int).SetUint64(msg.Data.GasLimit))
+	return sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewDecFromBigIntWithPrec(fee, sdk.Precision)))
+}
+
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (msg *MsgEthereumTx) ChainID() *big.Int {
+	if msg.Data.IsTyped() {
+		return msg.Data.ChainID
+	}
 	return deriveChainID(msg.Data.V)
 }
 
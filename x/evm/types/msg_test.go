@@ -223,6 +223,118 @@ func TestMsgEthereumTxSig(t *testing.T) {
 	require.Nil(t, signerCache)
 }
 
+func TestMsgEthereumTxDynamicFee(t *testing.T) {
+	chainID := big.NewInt(3)
+	priv, _ := ethsecp256k1.GenerateKey()
+	addr := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+	accesses := ethtypes.AccessList{{Address: addr, StorageKeys: []ethcmn.Hash{{0x1}}}}
+
+	msg := NewMsgEthereumTxDynamicFee(chainID, 1, &addr, big.NewInt(10), 100000, big.NewInt(2), big.NewInt(5), []byte("test"), accesses)
+	require.NoError(t, msg.ValidateBasic())
+	require.NoError(t, msg.Sign(chainID, priv.ToECDSA()))
+	require.True(t, chainID.Cmp(msg.ChainID()) == 0)
+
+	// the signature is compatible with the london signer of go-ethereum
+	bz, err := msg.MarshalBinary()
+	require.NoError(t, err)
+	var ethTx ethtypes.Transaction
+	require.NoError(t, ethTx.UnmarshalBinary(bz))
+	require.Equal(t, uint8(ethtypes.DynamicFeeTxType), ethTx.Type())
+	sender, err := ethtypes.Sender(ethtypes.NewLondonSigner(chainID), &ethTx)
+	require.NoError(t, err)
+	require.Equal(t, addr, sender)
+
+	signerCache, err := msg.VerifySig(chainID, 0, sdk.EmptyContext().SigCache())
+	require.NoError(t, err)
+	require.Equal(t, addr, signerCache.GetFrom())
+
+	var decoded MsgEthereumTx
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	require.Equal(t, msg.Data, decoded.Data)
+
+	// typed txs are wrapped into an rlp string when they are rlp encoded
+	raw, err := rlp.EncodeToBytes(&msg)
+	require.NoError(t, err)
+	decoded = MsgEthereumTx{}
+	require.NoError(t, rlp.DecodeBytes(raw, &decoded))
+	require.Equal(t, msg.Data, decoded.Data)
+
+	// the effective gas price is capped by the max fee per gas
+	require.Equal(t, big.NewInt(5), msg.EffectiveGasPrice(nil))
+	require.Equal(t, big.NewInt(3), msg.EffectiveGasPrice(big.NewInt(1)))
+	require.Equal(t, big.NewInt(5), msg.EffectiveGasPrice(big.NewInt(4)))
+
+	// the signature doesn't match another chain id
+	_, err = msg.VerifySig(big.NewInt(4), 0, sdk.EmptyContext().SigCache())
+	require.Error(t, err)
+}
+
+func TestMsgEthereumTxAccessList(t *testing.T) {
+	chainID := big.NewInt(3)
+	priv, _ := ethsecp256k1.GenerateKey()
+	addr := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+	accesses := ethtypes.AccessList{{Address: addr, StorageKeys: []ethcmn.Hash{{0x1}}}}
+
+	msg := NewMsgEthereumTxAccessList(chainID, 1, &addr, big.NewInt(10), 100000, big.NewInt(5), []byte("test"), accesses)
+	require.NoError(t, msg.ValidateBasic())
+	require.NoError(t, msg.Sign(chainID, priv.ToECDSA()))
+
+	// the signature is compatible with the berlin signer of go-ethereum
+	bz, err := msg.MarshalBinary()
+	require.NoError(t, err)
+	var ethTx ethtypes.Transaction
+	require.NoError(t, ethTx.UnmarshalBinary(bz))
+	require.Equal(t, uint8(ethtypes.AccessListTxType), ethTx.Type())
+	require.Equal(t, accesses, ethTx.AccessList())
+	sender, err := ethtypes.Sender(ethtypes.NewEIP2930Signer(chainID), &ethTx)
+	require.NoError(t, err)
+	require.Equal(t, addr, sender)
+
+	signerCache, err := msg.VerifySig(chainID, 0, sdk.EmptyContext().SigCache())
+	require.NoError(t, err)
+	require.Equal(t, addr, signerCache.GetFrom())
+
+	var decoded MsgEthereumTx
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	require.Equal(t, msg.Data, decoded.Data)
+
+	// the gas price isn't affected by the base fee
+	require.Equal(t, big.NewInt(5), msg.EffectiveGasPrice(big.NewInt(1)))
+
+	// an access list tx requires a chain id
+	msg = NewMsgEthereumTxAccessList(big.NewInt(0), 1, &addr, big.NewInt(10), 100000, big.NewInt(5), []byte("test"), accesses)
+	require.Error(t, msg.ValidateBasic())
+}
+
+func TestMsgEthereumTxDynamicFeeValidation(t *testing.T) {
+	testCases := []struct {
+		msg        string
+		chainID    *big.Int
+		gasTipCap  *big.Int
+		gasFeeCap  *big.Int
+		txType     uint8
+		expectPass bool
+	}{
+		{msg: "pass", chainID: big.NewInt(3), gasTipCap: big.NewInt(1), gasFeeCap: big.NewInt(2), txType: ethtypes.DynamicFeeTxType, expectPass: true},
+		{msg: "zero tip", chainID: big.NewInt(3), gasTipCap: big.NewInt(0), gasFeeCap: big.NewInt(2), txType: ethtypes.DynamicFeeTxType, expectPass: true},
+		{msg: "invalid chain id", chainID: big.NewInt(0), gasTipCap: big.NewInt(1), gasFeeCap: big.NewInt(2), txType: ethtypes.DynamicFeeTxType, expectPass: false},
+		{msg: "negative tip", chainID: big.NewInt(3), gasTipCap: big.NewInt(-1), gasFeeCap: big.NewInt(2), txType: ethtypes.DynamicFeeTxType, expectPass: false},
+		{msg: "tip higher than fee cap", chainID: big.NewInt(3), gasTipCap: big.NewInt(3), gasFeeCap: big.NewInt(2), txType: ethtypes.DynamicFeeTxType, expectPass: false},
+		{msg: "unknown tx type", chainID: big.NewInt(3), gasTipCap: big.NewInt(1), gasFeeCap: big.NewInt(2), txType: 0x7f, expectPass: false},
+	}
+
+	for i, tc := range testCases {
+		msg := NewMsgEthereumTxDynamicFee(tc.chainID, 0, nil, nil, 0, tc.gasTipCap, tc.gasFeeCap, nil, nil)
+		msg.Data.Type = tc.txType
+
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "valid test %d failed: %s", i, tc.msg)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "invalid test %d passed: %s", i, tc.msg)
+		}
+	}
+}
+
 func TestMsgEthereumTx_ChainID(t *testing.T) {
 	chainID := big.NewInt(3)
 	priv, _ := ethsecp256k1.GenerateKey()
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 	govtypes "github.com/okex/exchain/x/gov/types"
 )
 
@@ -14,18 +15,23 @@ const (
 	proposalTypeManageContractDeploymentWhitelist = "ManageContractDeploymentWhitelist"
 	// proposalTypeManageContractBlockedList defines the type for a ManageContractBlockedListProposal
 	proposalTypeManageContractBlockedList = "ManageContractBlockedList"
+	// proposalTypeManageForkBlocks defines the type for a ManageForkBlocksProposal
+	proposalTypeManageForkBlocks = "ManageForkBlocks"
 )
 
 func init() {
 	govtypes.RegisterProposalType(proposalTypeManageContractDeploymentWhitelist)
 	govtypes.RegisterProposalType(proposalTypeManageContractBlockedList)
+	govtypes.RegisterProposalType(proposalTypeManageForkBlocks)
 	govtypes.RegisterProposalTypeCodec(ManageContractDeploymentWhitelistProposal{}, "okexchain/evm/ManageContractDeploymentWhitelistProposal")
 	govtypes.RegisterProposalTypeCodec(ManageContractBlockedListProposal{}, "okexchain/evm/ManageContractBlockedListProposal")
+	govtypes.RegisterProposalTypeCodec(ManageForkBlocksProposal{}, "okexchain/evm/ManageForkBlocksProposal")
 }
 
 var (
 	_ govtypes.Content = (*ManageContractDeploymentWhitelistProposal)(nil)
 	_ govtypes.Content = (*ManageContractBlockedListProposal)(nil)
+	_ govtypes.Content = (*ManageForkBlocksProposal)(nil)
 )
 
 // ManageContractDeploymentWhitelistProposal - structure for the proposal to add or delete deployer addresses from whitelist
@@ -239,3 +245,116 @@ func (mp ManageContractBlockedListProposal) String() string {
 
 	return strings.TrimSpace(builder.String())
 }
+
+// ManageForkBlocksProposal - structure for the proposal to schedule the heights of the Berlin and London forks. A
+// negative height leaves the fork unscheduled.
+type ManageForkBlocksProposal struct {
+	Title       string  `json:"title" yaml:"title"`
+	Description string  `json:"description" yaml:"description"`
+	BerlinBlock sdk.Int `json:"berlin_block" yaml:"berlin_block"`
+	LondonBlock sdk.Int `json:"london_block" yaml:"london_block"`
+}
+
+// NewManageForkBlocksProposal creates a new instance of ManageForkBlocksProposal
+func NewManageForkBlocksProposal(title, description string, berlinBlock, londonBlock sdk.Int) ManageForkBlocksProposal {
+	return ManageForkBlocksProposal{
+		Title:       title,
+		Description: description,
+		BerlinBlock: berlinBlock,
+		LondonBlock: londonBlock,
+	}
+}
+
+// GetTitle returns title of a manage fork blocks proposal object
+func (mp ManageForkBlocksProposal) GetTitle() string {
+	return mp.Title
+}
+
+// GetDescription returns description of a manage fork blocks proposal object
+func (mp ManageForkBlocksProposal) GetDescription() string {
+	return mp.Description
+}
+
+// ProposalRoute returns route key of a manage fork blocks proposal object
+func (mp ManageForkBlocksProposal) ProposalRoute() string {
+	return RouterKey
+}
+
+// ProposalType returns type of a manage fork blocks proposal object
+func (mp ManageForkBlocksProposal) ProposalType() string {
+	return proposalTypeManageForkBlocks
+}
+
+// ValidateBasic validates a manage fork blocks proposal
+func (mp ManageForkBlocksProposal) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(mp.Title)) == 0 {
+		return govtypes.ErrInvalidProposalContent("title is required")
+	}
+	if len(mp.Title) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent("title length is longer than the maximum title length")
+	}
+
+	if len(mp.Description) == 0 {
+		return govtypes.ErrInvalidProposalContent("description is required")
+	}
+
+	if len(mp.Description) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent("description length is longer than the maximum description length")
+	}
+
+	if mp.ProposalType() != proposalTypeManageForkBlocks {
+		return govtypes.ErrInvalidProposalType(mp.ProposalType())
+	}
+
+	if err := validateBlock(mp.BerlinBlock); err != nil {
+		return govtypes.ErrInvalidProposalContent("berlin block: " + err.Error())
+	}
+	if err := validateBlock(mp.LondonBlock); err != nil {
+		return govtypes.ErrInvalidProposalContent("london block: " + err.Error())
+	}
+	if err := validateForkOrder(mp.BerlinBlock, mp.LondonBlock); err != nil {
+		return govtypes.ErrInvalidProposalContent("london block: " + err.Error())
+	}
+
+	return nil
+}
+
+// Apply returns the chain config with the fork blocks of the proposal. Forks that are already active at the given
+// height cannot be changed, and forks can only be scheduled after it.
+func (mp ManageForkBlocksProposal) Apply(config ChainConfig, height int64) (ChainConfig, error) {
+	if err := scheduleFork(config.BerlinBlock, mp.BerlinBlock, height); err != nil {
+		return config, sdkerrors.Wrap(err, "berlin block")
+	}
+	if err := scheduleFork(config.LondonBlock, mp.LondonBlock, height); err != nil {
+		return config, sdkerrors.Wrap(err, "london block")
+	}
+
+	config.BerlinBlock = mp.BerlinBlock
+	config.LondonBlock = mp.LondonBlock
+	return config, config.Validate()
+}
+
+func scheduleFork(current, next sdk.Int, height int64) error {
+	if current.Equal(next) {
+		return nil
+	}
+	if isForked(current, height) {
+		return sdkerrors.Wrapf(ErrInvalidChainConfig, "fork is already active since block %s", current)
+	}
+	if value := getBlockValue(next); value != nil && value.Int64() <= height {
+		return sdkerrors.Wrapf(ErrInvalidChainConfig, "fork must be scheduled after the current block %d", height)
+	}
+
+	return nil
+}
+
+// String returns a human readable string representation of a ManageForkBlocksProposal
+func (mp ManageForkBlocksProposal) String() string {
+	return fmt.Sprintf(`ManageForkBlocksProposal:
+ Title:					%s
+ Description:        	%s
+ Type:                	%s
+ BerlinBlock:			%s
+ LondonBlock:			%s`,
+		mp.Title, mp.Description, mp.ProposalType(), mp.BerlinBlock, mp.LondonBlock)
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	govtypes "github.com/okex/exchain/x/gov/types"
 	"github.com/stretchr/testify/suite"
 )
@@ -29,6 +30,12 @@ const (
  ContractAddrs:
 						ex1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqm2k6w2
 						ex1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqpxuz0nc`
+	expectedManageForkBlocksProposalString = `ManageForkBlocksProposal:
+ Title:					default title
+ Description:        	default description
+ Type:                	ManageForkBlocks
+ BerlinBlock:			10
+ LondonBlock:			20`
 )
 
 type ProposalTestSuite struct {
@@ -252,3 +259,123 @@ func (suite *ProposalTestSuite) TestProposal_ManageContractBlockedListProposal()
 		})
 	}
 }
+
+func (suite *ProposalTestSuite) TestProposal_ManageForkBlocksProposal() {
+	proposal := NewManageForkBlocksProposal(
+		expectedTitle,
+		expectedDescription,
+		sdk.NewInt(10),
+		sdk.NewInt(20),
+	)
+
+	suite.Require().Equal(expectedTitle, proposal.GetTitle())
+	suite.Require().Equal(expectedDescription, proposal.GetDescription())
+	suite.Require().Equal(RouterKey, proposal.ProposalRoute())
+	suite.Require().Equal(proposalTypeManageForkBlocks, proposal.ProposalType())
+	suite.Require().Equal(expectedManageForkBlocksProposalString, proposal.String())
+
+	testCases := []struct {
+		msg           string
+		prepare       func()
+		expectedError bool
+	}{
+		{
+			"pass",
+			func() {},
+			false,
+		},
+		{
+			"empty title",
+			func() {
+				proposal.Title = ""
+			},
+			true,
+		},
+		{
+			"empty description",
+			func() {
+				proposal.Title = expectedTitle
+				proposal.Description = ""
+			},
+			true,
+		},
+		{
+			"nil london block",
+			func() {
+				proposal.Description = expectedDescription
+				proposal.LondonBlock = sdk.Int{}
+			},
+			true,
+		},
+		{
+			"london before berlin",
+			func() {
+				proposal.LondonBlock = sdk.NewInt(5)
+			},
+			true,
+		},
+		{
+			"london without berlin",
+			func() {
+				proposal.BerlinBlock = sdk.NewInt(-1)
+			},
+			true,
+		},
+		{
+			"unschedule both forks",
+			func() {
+				proposal.LondonBlock = sdk.NewInt(-1)
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			tc.prepare()
+
+			err := proposal.ValidateBasic()
+
+			if tc.expectedError {
+				suite.Require().Error(err)
+			} else {
+				suite.Require().NoError(err)
+			}
+		})
+	}
+}
+
+func (suite *ProposalTestSuite) TestProposal_ManageForkBlocksProposalApply() {
+	config := DefaultChainConfig()
+	config.BerlinBlock = sdk.NewInt(10)
+
+	testCases := []struct {
+		msg           string
+		berlinBlock   sdk.Int
+		londonBlock   sdk.Int
+		height        int64
+		expectedError bool
+	}{
+		{"schedule london", sdk.NewInt(10), sdk.NewInt(20), 15, false},
+		{"schedule london at the current height", sdk.NewInt(10), sdk.NewInt(15), 15, true},
+		{"reschedule active berlin", sdk.NewInt(12), sdk.NewInt(20), 15, true},
+		{"reschedule pending berlin", sdk.NewInt(20), sdk.NewInt(20), 5, false},
+		{"unschedule pending berlin", sdk.NewInt(-1), sdk.NewInt(-1), 5, false},
+		{"unschedule active berlin", sdk.NewInt(-1), sdk.NewInt(-1), 10, true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			proposal := NewManageForkBlocksProposal(expectedTitle, expectedDescription, tc.berlinBlock, tc.londonBlock)
+			newConfig, err := proposal.Apply(config, tc.height)
+
+			if tc.expectedError {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.berlinBlock, newConfig.BerlinBlock)
+			suite.Require().Equal(tc.londonBlock, newConfig.LondonBlock)
+		})
+	}
+}
@@ -25,6 +25,7 @@ const (
 	QuerySection                     = "section"
 	QueryContractDeploymentWhitelist = "contract-deployment-whitelist"
 	QueryContractBlockedList         = "contract-blocked-list"
+	QueryBaseFee                     = "baseFee"
 )
 
 // QueryResBalance is response type for balance query
@@ -96,6 +97,16 @@ func (q QueryBloomFilter) String() string {
 	return string(q.Bloom.Bytes())
 }
 
+// QueryResBaseFee is response type for block base fee query, the base fee is
+// empty if the London fork is not active at the block
+type QueryResBaseFee struct {
+	BaseFee string `json:"base_fee"`
+}
+
+func (q QueryResBaseFee) String() string {
+	return q.BaseFee
+}
+
 // QueryAccount is response type for querying Ethereum state objects
 type QueryResAccount struct {
 	Balance  string `json:"balance"`
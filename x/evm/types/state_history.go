@@ -0,0 +1,242 @@
+package types
+
+import (
+	"encoding/binary"
+	"math/big"
+	"path/filepath"
+	"sync"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/spf13/viper"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	FlagEnableStateHistory = "evm-state-history"
+
+	stateHistoryDir = "state_history"
+)
+
+var (
+	stateHistory            *StateHistory
+	enableStateHistory      bool
+	enableStateHistoryOnce  sync.Once
+	prefixHistoryAccount    = []byte{0x01}
+	prefixHistoryStorage    = []byte{0x02}
+	keyHistoryStartHeight   = []byte{0x03}
+	keyHistoryLatestHeight  = []byte{0x04}
+	keyHistoryFromGenesis   = []byte{0x05}
+	historyHeightLength     = 8
+	historyAccountKeyLength = 1 + ethcmn.AddressLength
+	historyStorageKeyLength = 1 + ethcmn.AddressLength + ethcmn.HashLength
+)
+
+// HistoricalAccount is the part of an account kept by the state history
+type HistoricalAccount struct {
+	Balance  *big.Int
+	Nonce    uint64
+	CodeHash []byte
+}
+
+// StateHistory is a flat store of the evm state indexed by height. Every account and
+// storage slot changed in a block gets an entry of its value keyed by the height of
+// the block, so the value at any height is a single reverse seek instead of a walk
+// of the IAVL versions.
+//
+// The keys changed in a block are marked during the block by the CommitStateDB and
+// the account keeper, their values are read and written once the block is ended.
+type StateHistory struct {
+	db dbm.DB
+
+	mtx      sync.Mutex
+	accounts map[ethcmn.Address]struct{}
+	storage  map[ethcmn.Address]map[ethcmn.Hash]struct{}
+}
+
+func GetEnableStateHistory() bool {
+	enableStateHistoryOnce.Do(func() {
+		enableStateHistory = viper.GetBool(FlagEnableStateHistory)
+	})
+	return enableStateHistory
+}
+
+func StateHistoryDb() dbm.DB {
+	dataDir := filepath.Join(viper.GetString("home"), "data")
+	db, err := sdk.NewLevelDB(stateHistoryDir, dataDir)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+func InitStateHistory(db dbm.DB) {
+	stateHistory = NewStateHistory(db)
+}
+
+func GetStateHistory() *StateHistory {
+	return stateHistory
+}
+
+func CloseStateHistory() {
+	if stateHistory != nil {
+		stateHistory.db.Close()
+	}
+}
+
+// NewStateHistory returns a state history stored in the given db
+func NewStateHistory(db dbm.DB) *StateHistory {
+	return &StateHistory{
+		db:       db,
+		accounts: make(map[ethcmn.Address]struct{}),
+		storage:  make(map[ethcmn.Address]map[ethcmn.Hash]struct{}),
+	}
+}
+
+// MarkAccount marks the account as changed in the current block
+func (h *StateHistory) MarkAccount(addr ethcmn.Address) {
+	if h == nil {
+		return
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.accounts[addr] = struct{}{}
+}
+
+// MarkState marks the storage slot as changed in the current block, the key is the
+// key of the slot in the KVStore.
+func (h *StateHistory) MarkState(addr ethcmn.Address, key ethcmn.Hash) {
+	if h == nil {
+		return
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	keys, ok := h.storage[addr]
+	if !ok {
+		keys = make(map[ethcmn.Hash]struct{})
+		h.storage[addr] = keys
+	}
+	keys[key] = struct{}{}
+}
+
+// Commit writes the values of the keys marked in the block at height. The account
+// getter returns nil for the accounts that don't exist anymore. fromGenesis tells if
+// it is the first block of the chain, only then the keys without any entry are known
+// to be empty.
+func (h *StateHistory) Commit(height int64, fromGenesis bool,
+	getAccount func(ethcmn.Address) *HistoricalAccount, getState func(ethcmn.Address, ethcmn.Hash) ethcmn.Hash) error {
+	h.mtx.Lock()
+	accounts, storage := h.accounts, h.storage
+	h.accounts = make(map[ethcmn.Address]struct{})
+	h.storage = make(map[ethcmn.Address]map[ethcmn.Hash]struct{})
+	h.mtx.Unlock()
+
+	batch := h.db.NewBatch()
+	defer batch.Close()
+
+	for addr := range accounts {
+		// the removed accounts get an empty value
+		value := []byte{}
+		if acc := getAccount(addr); acc != nil {
+			bz, err := rlp.EncodeToBytes(acc)
+			if err != nil {
+				return err
+			}
+			value = bz
+		}
+		batch.Set(historyAccountKey(addr, height), value)
+	}
+	for addr, keys := range storage {
+		for key := range keys {
+			value := getState(addr, key)
+			batch.Set(historyStorageKey(addr, key, height), value.Bytes())
+		}
+	}
+
+	if start, _ := h.heightOf(keyHistoryStartHeight); start == 0 {
+		batch.Set(keyHistoryStartHeight, sdk.Uint64ToBigEndian(uint64(height)))
+		if fromGenesis {
+			batch.Set(keyHistoryFromGenesis, []byte{1})
+		}
+	}
+	batch.Set(keyHistoryLatestHeight, sdk.Uint64ToBigEndian(uint64(height)))
+	return batch.WriteSync()
+}
+
+// GetAccount returns the account at height, it is nil if the account doesn't exist.
+// found is false if the history can't tell the account at height.
+func (h *StateHistory) GetAccount(addr ethcmn.Address, height int64) (acc *HistoricalAccount, found bool, err error) {
+	value, found, err := h.get(historyAccountKey(addr, height), historyAccountKeyLength, height)
+	if err != nil || !found || len(value) == 0 {
+		return nil, found, err
+	}
+
+	acc = new(HistoricalAccount)
+	if err := rlp.DecodeBytes(value, acc); err != nil {
+		return nil, false, err
+	}
+	return acc, true, nil
+}
+
+// GetState returns the value of the storage slot at height, the key is the key of the
+// slot in the KVStore. found is false if the history can't tell the value at height.
+func (h *StateHistory) GetState(addr ethcmn.Address, key ethcmn.Hash, height int64) (ethcmn.Hash, bool, error) {
+	value, found, err := h.get(historyStorageKey(addr, key, height), historyStorageKeyLength, height)
+	return ethcmn.BytesToHash(value), found, err
+}
+
+// LatestHeight returns the latest height written to the history
+func (h *StateHistory) LatestHeight() int64 {
+	height, _ := h.heightOf(keyHistoryLatestHeight)
+	return height
+}
+
+// get returns the value of the latest entry of the key at or below height. With no
+// such entry the value is empty if the history starts from the genesis, otherwise
+// it is unknown.
+func (h *StateHistory) get(key []byte, prefixLength int, height int64) ([]byte, bool, error) {
+	start, err := h.heightOf(keyHistoryStartHeight)
+	if err != nil {
+		return nil, false, err
+	}
+	latest, err := h.heightOf(keyHistoryLatestHeight)
+	if err != nil {
+		return nil, false, err
+	}
+	if start == 0 || height < start || height > latest {
+		return nil, false, nil
+	}
+
+	// the entries of the key sort by height, the first one in reverse is the latest
+	it, err := h.db.ReverseIterator(key[:prefixLength], sdk.PrefixEndBytes(key))
+	if err != nil {
+		return nil, false, err
+	}
+	defer it.Close()
+	if it.Valid() {
+		return it.Value(), true, nil
+	}
+
+	fromGenesis, err := h.db.Has(keyHistoryFromGenesis)
+	return nil, fromGenesis, err
+}
+
+func (h *StateHistory) heightOf(key []byte) (int64, error) {
+	bz, err := h.db.Get(key)
+	if err != nil || len(bz) != historyHeightLength {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(bz)), nil
+}
+
+func historyAccountKey(addr ethcmn.Address, height int64) []byte {
+	key := append(append([]byte{}, prefixHistoryAccount...), addr.Bytes()...)
+	return append(key, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+func historyStorageKey(addr ethcmn.Address, slot ethcmn.Hash, height int64) []byte {
+	key := append(append([]byte{}, prefixHistoryStorage...), addr.Bytes()...)
+	key = append(key, slot.Bytes()...)
+	return append(key, sdk.Uint64ToBigEndian(uint64(height))...)
+}
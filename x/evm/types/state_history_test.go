@@ -0,0 +1,110 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestStateHistory(t *testing.T) {
+	addr := ethcmn.BytesToAddress([]byte{0x1})
+	key := ethcmn.BytesToHash([]byte{0x2})
+
+	balances := map[ethcmn.Address]*big.Int{addr: big.NewInt(100)}
+	values := map[ethcmn.Hash]ethcmn.Hash{key: ethcmn.BytesToHash([]byte{0x3})}
+	getAccount := func(addr ethcmn.Address) *HistoricalAccount {
+		balance, ok := balances[addr]
+		if !ok {
+			return nil
+		}
+		return &HistoricalAccount{Balance: balance, Nonce: 1, CodeHash: []byte{0x4}}
+	}
+	getState := func(_ ethcmn.Address, key ethcmn.Hash) ethcmn.Hash {
+		return values[key]
+	}
+
+	h := NewStateHistory(dbm.NewMemDB())
+	_, found, err := h.GetAccount(addr, 1)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// block 1 changes the account and the slot
+	h.MarkAccount(addr)
+	h.MarkState(addr, key)
+	require.NoError(t, h.Commit(1, true, getAccount, getState))
+
+	// block 2 changes nothing, block 3 changes the balance and deletes the slot
+	require.NoError(t, h.Commit(2, true, getAccount, getState))
+	balances[addr] = big.NewInt(50)
+	values[key] = ethcmn.Hash{}
+	h.MarkAccount(addr)
+	h.MarkState(addr, key)
+	require.NoError(t, h.Commit(3, true, getAccount, getState))
+
+	// block 4 removes the account
+	delete(balances, addr)
+	h.MarkAccount(addr)
+	require.NoError(t, h.Commit(4, true, getAccount, getState))
+	require.Equal(t, int64(4), h.LatestHeight())
+
+	for height, balance := range map[int64]int64{1: 100, 2: 100, 3: 50} {
+		acc, found, err := h.GetAccount(addr, height)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, big.NewInt(balance), acc.Balance)
+		require.Equal(t, uint64(1), acc.Nonce)
+		require.Equal(t, []byte{0x4}, acc.CodeHash)
+	}
+	acc, found, err := h.GetAccount(addr, 4)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Nil(t, acc)
+
+	value, found, err := h.GetState(addr, key, 2)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, ethcmn.BytesToHash([]byte{0x3}), value)
+	value, found, err = h.GetState(addr, key, 3)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, ethcmn.Hash{}, value)
+
+	// the keys never changed are empty since the history starts from the genesis
+	value, found, err = h.GetState(ethcmn.BytesToAddress([]byte{0x5}), key, 2)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, ethcmn.Hash{}, value)
+
+	// the heights above the history are unknown
+	_, found, err = h.GetAccount(addr, 5)
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestStateHistoryNotFromGenesis(t *testing.T) {
+	addr := ethcmn.BytesToAddress([]byte{0x1})
+	key := ethcmn.BytesToHash([]byte{0x2})
+	getAccount := func(ethcmn.Address) *HistoricalAccount { return nil }
+	getState := func(ethcmn.Address, ethcmn.Hash) ethcmn.Hash { return ethcmn.BytesToHash([]byte{0x3}) }
+
+	h := NewStateHistory(dbm.NewMemDB())
+	require.NoError(t, h.Commit(10, false, getAccount, getState))
+	h.MarkState(addr, key)
+	require.NoError(t, h.Commit(11, false, getAccount, getState))
+
+	// the value before the first change since the history started is unknown
+	_, found, err := h.GetState(addr, key, 10)
+	require.NoError(t, err)
+	require.False(t, found)
+	_, found, err = h.GetState(addr, key, 9)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	value, found, err := h.GetState(addr, key, 11)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, ethcmn.BytesToHash([]byte{0x3}), value)
+}
@@ -88,6 +88,10 @@ type stateObject struct {
 	dirtyCode bool // true if the code was updated
 	suicided  bool
 	deleted   bool
+
+	// fakeStorage is set when the storage of the account is replaced by the origin
+	// storage, see CommitStateDB.SetStorage
+	fakeStorage bool
 }
 
 func newStateObject(db *CommitStateDB, accProto authexported.Account) *stateObject {
@@ -152,6 +156,22 @@ func (so *stateObject) setState(key, value ethcmn.Hash) {
 	so.keyToDirtyStorageIndex[key] = idx
 }
 
+// setStorage replaces the storage of the state object, the keys that aren't set
+// are empty.
+func (so *stateObject) setStorage(storage map[ethcmn.Hash]ethcmn.Hash) {
+	so.originStorage = Storage{}
+	so.dirtyStorage = Storage{}
+	so.keyToOriginStorageIndex = make(map[ethcmn.Hash]int)
+	so.keyToDirtyStorageIndex = make(map[ethcmn.Hash]int)
+
+	for key, value := range storage {
+		prefixKey := so.GetStorageByAddressKey(key.Bytes())
+		so.originStorage = append(so.originStorage, NewState(prefixKey, value))
+		so.keyToOriginStorageIndex[prefixKey] = len(so.originStorage) - 1
+	}
+	so.fakeStorage = true
+}
+
 // SetCode sets the state object's code.
 func (so *stateObject) SetCode(codeHash ethcmn.Hash, code []byte) {
 	prevCode := so.Code(nil)
@@ -253,6 +273,9 @@ func (so *stateObject) commitState() {
 
 	for _, state := range so.dirtyStorage {
 		// NOTE: key is already prefixed from GetStorageByAddressKey
+		if !ctx.IsCheckTx() {
+			so.stateDB.stateHistory.MarkState(so.Address(), state.Key)
+		}
 
 		// delete empty values from the store
 		if (state.Value == ethcmn.Hash{}) {
@@ -385,6 +408,11 @@ func (so *stateObject) GetCommittedState(_ ethstate.Database, key ethcmn.Hash) e
 		return so.originStorage[idx].Value
 	}
 
+	// the replaced storage doesn't fall back to the KVStore
+	if so.fakeStorage {
+		return ethcmn.Hash{}
+	}
+
 	// otherwise load the value from the KVStore
 	state := NewState(prefixKey, ethcmn.Hash{})
 
@@ -428,6 +456,7 @@ func (so *stateObject) deepCopy(db *CommitStateDB) *stateObject {
 	newStateObj.suicided = so.suicided
 	newStateObj.dirtyCode = so.dirtyCode
 	newStateObj.deleted = so.deleted
+	newStateObj.fakeStorage = so.fakeStorage
 
 	return newStateObj
 }
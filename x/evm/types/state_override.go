@@ -0,0 +1,94 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// AccountOverride defines the fields of an account that are overridden during a
+// simulated call, the fields that are nil are read from the chain state.
+// NOTE: State and StateDiff can't be set at the same time. State replaces the whole
+// storage of the account while StateDiff only replaces the given slots.
+type AccountOverride struct {
+	Nonce     *hexutil.Uint64              `json:"nonce"`
+	Code      *hexutil.Bytes               `json:"code"`
+	Balance   **hexutil.Big                `json:"balance"`
+	State     *map[ethcmn.Hash]ethcmn.Hash `json:"state"`
+	StateDiff *map[ethcmn.Hash]ethcmn.Hash `json:"stateDiff"`
+}
+
+// StateOverrides is the set of accounts overridden during a simulated call
+type StateOverrides map[ethcmn.Address]AccountOverride
+
+// Validate checks that the state and the state diff of an account aren't overridden
+// at the same time.
+func (so StateOverrides) Validate() error {
+	for addr, account := range so {
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+	}
+	return nil
+}
+
+// Apply overrides the accounts in the state db, it must be called before the
+// state transition.
+func (so StateOverrides) Apply(csdb *CommitStateDB) error {
+	if err := so.Validate(); err != nil {
+		return err
+	}
+
+	for addr, account := range so {
+		if account.Nonce != nil {
+			csdb.SetNonce(addr, uint64(*account.Nonce))
+		}
+		if account.Code != nil {
+			csdb.SetCode(addr, *account.Code)
+		}
+		if account.Balance != nil {
+			csdb.SetBalance(addr, (*account.Balance).ToInt())
+		}
+		if account.State != nil {
+			csdb.SetStorage(addr, *account.State)
+		}
+		if account.StateDiff != nil {
+			for key, value := range *account.StateDiff {
+				csdb.SetState(addr, key, value)
+			}
+		}
+	}
+	return nil
+}
+
+// SimulatedCall defines the options of a simulated call which aren't part of the message, they are only passed
+// by the rpc simulator through the context and never come from a tx
+type SimulatedCall struct {
+	AccessList     ethtypes.AccessList
+	StateOverrides StateOverrides
+}
+
+type simulatedCallKey struct{}
+
+// WithSimulatedCall keeps the options of a simulated call in the context
+func WithSimulatedCall(ctx sdk.Context, call SimulatedCall) sdk.Context {
+	parent := ctx.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	return ctx.WithContext(context.WithValue(parent, simulatedCallKey{}, call))
+}
+
+// GetSimulatedCall returns the options of a simulated call kept in the context
+func GetSimulatedCall(ctx sdk.Context) (SimulatedCall, bool) {
+	if ctx.Context() == nil {
+		return SimulatedCall{}, false
+	}
+	call, ok := ctx.Context().Value(simulatedCallKey{}).(SimulatedCall)
+	return call, ok
+}
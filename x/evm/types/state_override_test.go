@@ -0,0 +1,32 @@
+package types
+
+import (
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateOverridesValidate(t *testing.T) {
+	addr := ethcmn.BytesToAddress(newSdkAddress().Bytes())
+	storage := map[ethcmn.Hash]ethcmn.Hash{}
+
+	require.NoError(t, StateOverrides{addr: {State: &storage}}.Validate())
+	require.NoError(t, StateOverrides{addr: {StateDiff: &storage}}.Validate())
+	// the state and the state diff of an account can't be overridden together
+	require.Error(t, StateOverrides{addr: {State: &storage, StateDiff: &storage}}.Validate())
+}
+
+func TestSimulatedCallContext(t *testing.T) {
+	ctx := sdk.EmptyContext()
+	_, found := GetSimulatedCall(ctx)
+	require.False(t, found)
+
+	addr := ethcmn.BytesToAddress(newSdkAddress().Bytes())
+	call := SimulatedCall{AccessList: ethtypes.AccessList{{Address: addr}}}
+	got, found := GetSimulatedCall(WithSimulatedCall(ctx, call))
+	require.True(t, found)
+	require.Equal(t, call, got)
+}
@@ -26,7 +26,10 @@ type StateTransition struct {
 	Recipient    *common.Address
 	Amount       *big.Int
 	Payload      []byte
+	AccessList   ethtypes.AccessList
 
+	// BaseFee is the base fee per gas of the block, nil before the London fork
+	BaseFee  *big.Int
 	ChainID  *big.Int
 	Csdb     *CommitStateDB
 	TxHash   *common.Hash
@@ -92,6 +95,7 @@ func (st StateTransition) newEVM(
 		Time:        big.NewInt(ctx.BlockHeader().Time.Unix()),
 		Difficulty:  big.NewInt(0), // unused. Only required in PoW context
 		GasLimit:    gasLimit,
+		BaseFee:     st.BaseFee,
 	}
 
 	txCtx := vm.TxContext{
@@ -105,7 +109,7 @@ func (st StateTransition) newEVM(
 // TransitionDb will transition the state by applying the current transaction and
 // returning the evm execution result.
 // NOTE: State transition checks are run during AnteHandler execution.
-func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exeRes *ExecutionResult, resData *ResultData, err error, innerTxs, erc20Contracts interface{}) {
+func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exeRes *ExecutionResult, resData *ResultData, err error, innerTxs []*InnerTx, erc20Contracts interface{}) {
 	defer func() {
 		if e := recover(); e != nil {
 			// if the msg recovered can be asserted into type 'common.Address', it must be captured by the panics of blocked
@@ -121,7 +125,7 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 
 	contractCreation := st.Recipient == nil
 
-	cost, err := core.IntrinsicGas(st.Payload, []ethtypes.AccessTuple{}, contractCreation, config.IsHomestead(), config.IsIstanbul())
+	cost, err := core.IntrinsicGas(st.Payload, st.AccessList, contractCreation, config.IsHomestead(), config.IsIstanbul())
 	if err != nil {
 		return exeRes, resData, sdkerrors.Wrap(err, "invalid intrinsic gas for transaction"), innerTxs, erc20Contracts
 	}
@@ -164,11 +168,29 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 	}
 	enableDebug := checkTracesSegment(ctx.BlockHeight(), st.Sender.String(), to)
 
+	// the tx is traced on demand by the debug namespace
+	traceTx := ctx.TraceTx()
+	if ctx.IsTraceTx() && traceTx != nil {
+		if prebuilt, ok := traceTx.Tracer.(vm.Tracer); ok {
+			tracer = prebuilt
+		} else {
+			var stopTracer func()
+			tracer, stopTracer, err = newTracer(traceTx.Config, *st.TxHash)
+			if err != nil {
+				return exeRes, resData, sdkerrors.Wrap(err, "invalid trace config"), innerTxs, erc20Contracts
+			}
+			defer stopTracer()
+		}
+		enableDebug = true
+	}
+
 	vmConfig := vm.Config{
 		ExtraEips: params.ExtraEIPs,
 		Debug:     enableDebug,
 		Tracer:    tracer,
 	}
+	innerTxRecorder := newInnerTxRecorder(st, ctx.IsCheckTx(), ctx.IsTraceTx())
+	vmConfig = withInnerTxRecorder(vmConfig, innerTxRecorder)
 
 	evm := st.newEVM(ctx, csdb, gasLimit, st.Price, config, vmConfig)
 
@@ -180,14 +202,16 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		senderRef       = vm.AccountRef(st.Sender)
 	)
 
+	// warm up the sender, the recipient, the precompiles and the tx access list (EIP-2929 and EIP-2930)
+	if rules := evm.ChainConfig().Rules(evm.Context.BlockNumber); rules.IsBerlin {
+		csdb.PrepareAccessList(st.Sender, st.Recipient, vm.ActivePrecompiles(rules), st.AccessList)
+	}
+
 	// Get nonce of account outside of the EVM
 	currentNonce := csdb.GetNonce(st.Sender)
 	// Set nonce of sender account before evm state transition for usage in generating Create address
 	csdb.SetNonce(st.Sender, st.AccountNonce)
 
-	//add InnerTx
-	callTx := addDefaultInnerTx(evm, st.Sender.String())
-
 	// create contract or execute call
 	switch contractCreation {
 	case true:
@@ -205,8 +229,6 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		defer StopTxLog(analyzer.EVMCORE)
 		ret, contractAddress, leftOverGas, err = evm.Create(senderRef, st.Payload, gasLimit, st.Amount)
 		recipientLog = fmt.Sprintf("contract address %s", contractAddress.String())
-
-		updateDefaultInnerTxTo(callTx, contractAddress.String())
 	default:
 		if !params.EnableCall {
 			return exeRes, resData, ErrCallDisabled, innerTxs, erc20Contracts
@@ -219,13 +241,11 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		ret, leftOverGas, err = evm.Call(senderRef, *st.Recipient, st.Payload, gasLimit, st.Amount)
 
 		recipientLog = fmt.Sprintf("recipient address %s", st.Recipient.String())
-
-		updateDefaultInnerTxTo(callTx, st.Recipient.String())
 	}
 
 	gasConsumed := gasLimit - leftOverGas
 
-	innerTxs, erc20Contracts = parseInnerTxAndContract(evm, err != nil)
+	innerTxs, erc20Contracts = parseInnerTxAndContract(innerTxRecorder, err != nil)
 
 	defer func() {
 		// Consume gas from evm execution
@@ -242,6 +262,18 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 			}
 			saveTraceResult(ctx, tracer, result)
 		}
+		if ctx.IsTraceTx() && traceTx != nil && traceTx.Tracer == nil {
+			result := &core.ExecutionResult{
+				UsedGas:    gasConsumed,
+				Err:        err,
+				ReturnData: ret,
+			}
+			traceRes, traceErr := GetTraceResult(tracer, result)
+			if traceErr != nil {
+				traceRes = []byte(traceErr.Error())
+			}
+			traceTx.Output = []byte(traceRes)
+		}
 	}()
 
 	if err != nil {
@@ -270,7 +302,8 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		bloomFilter = ethtypes.BytesToBloom(bloomInt.Bytes())
 	}
 
-	if !st.Simulate {
+	// txs replayed for tracing keep their state changes for the txs following them
+	if !st.Simulate || ctx.IsTraceTx() {
 		// Finalise state if not a simulated transaction
 		// TODO: change to depend on config
 		if err = csdb.Finalise(true); err != nil {
@@ -342,3 +375,22 @@ func newRevertError(data []byte, e error) error {
 	}
 	return errors.New(string(ret))
 }
+
+// UnpackRevertError returns the revert reason and the return data carried by an error
+// of newRevertError, ok is false if err isn't a revert error.
+func UnpackRevertError(err error) (reason string, data []byte, ok bool) {
+	var resultError []string
+	if err == nil || json.Unmarshal([]byte(err.Error()), &resultError) != nil {
+		return "", nil, false
+	}
+	if len(resultError) != 4 || resultError[0] != vm.ErrExecutionReverted.Error() || resultError[2] != ErrorHexData {
+		return "", nil, false
+	}
+
+	data, e := hexutil.Decode(resultError[3])
+	if e != nil {
+		return "", nil, false
+	}
+	reason, _ = abi.UnpackRevert(data)
+	return reason, data, true
+}
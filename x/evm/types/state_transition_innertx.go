@@ -1,11 +1,55 @@
 package types
 
-func addDefaultInnerTx(...interface{}) interface{} {
-	return nil
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/spf13/viper"
+)
+
+const (
+	FlagEnableInnerTx = "evm-inner-tx"
+)
+
+var (
+	enableInnerTx     bool
+	enableInnerTxOnce sync.Once
+)
+
+func GetEnableInnerTx() bool {
+	enableInnerTxOnce.Do(func() {
+		enableInnerTx = viper.GetBool(FlagEnableInnerTx)
+	})
+	return enableInnerTx
 }
 
-func updateDefaultInnerTxTo(...interface{}) {}
+// newInnerTxRecorder returns the recorder of the inner txs of a delivered tx, it is
+// nil if the inner txs aren't recorded.
+func newInnerTxRecorder(st StateTransition, isCheckTx, isTraceTx bool) *InnerTxRecorder {
+	if !GetEnableInnerTx() || st.Simulate || isCheckTx || isTraceTx {
+		return nil
+	}
+	return NewInnerTxRecorder()
+}
+
+// withInnerTxRecorder adds the recorder to the tracer of the evm, the tracer only
+// runs along if the tx is debugged.
+func withInnerTxRecorder(vmConfig vm.Config, recorder *InnerTxRecorder) vm.Config {
+	if recorder == nil {
+		return vmConfig
+	}
+	if vmConfig.Debug {
+		vmConfig.Tracer = multiTracer{vmConfig.Tracer, recorder}
+	} else {
+		vmConfig.Tracer = recorder
+		vmConfig.Debug = true
+	}
+	return vmConfig
+}
 
-func parseInnerTxAndContract(...interface{}) (interface{}, interface{}) {
-	return nil, nil
+func parseInnerTxAndContract(recorder *InnerTxRecorder, isErr bool) ([]*InnerTx, interface{}) {
+	if recorder == nil {
+		return nil, nil
+	}
+	return recorder.InnerTxs(isErr), nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
 	ethermint "github.com/okex/exchain/app/types"
@@ -284,3 +285,155 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 	suite.Require().Equal(fromBalance, sdk.NewDec(4940).BigInt())
 	suite.Require().Equal(toBalance, sdk.NewDec(50).BigInt())
 }
+
+func (suite *StateDBTestSuite) TestTransitionDbTraceTx() {
+	addr := sdk.AccAddress(suite.address.Bytes())
+	balance := ethermint.NewPhotonCoin(sdk.NewInt(5000))
+	acc := suite.app.AccountKeeper.GetAccount(suite.ctx, addr)
+	_ = acc.SetCoins(sdk.NewCoins(balance))
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	priv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	recipient := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+
+	testCase := []struct {
+		name      string
+		config    []byte
+		expPass   bool
+		expOutput string
+	}{
+		{"struct logger", nil, true, "structLogs"},
+		{"call tracer", []byte(`{"tracer":"callTracer"}`), true, `"type":"CALL"`},
+		{"invalid tracer", []byte(`{"tracer":"invalid js"}`), false, ""},
+	}
+
+	for _, tc := range testCase {
+		suite.Run(tc.name, func() {
+			ctx := suite.ctx.WithIsCheckTx(true).WithIsTraceTx(true)
+			traceTx := &sdk.TraceTxConfig{Config: tc.config}
+			st := types.StateTransition{
+				AccountNonce: 0,
+				Price:        big.NewInt(10),
+				GasLimit:     100000,
+				Recipient:    &recipient,
+				Amount:       big.NewInt(50),
+				ChainID:      big.NewInt(1),
+				Csdb:         types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), ctx),
+				TxHash:       &ethcmn.Hash{},
+				Sender:       suite.address,
+				Simulate:     true,
+			}
+
+			_, _, err, _, _ := st.TransitionDb(ctx.WithTraceTx(traceTx), types.DefaultChainConfig())
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Contains(string(traceTx.Output), tc.expOutput)
+		})
+	}
+
+	// the state changes of a traced tx are kept although it is run as a simulation
+	suite.Require().Equal(big.NewInt(100), suite.app.EvmKeeper.GetBalance(suite.ctx, recipient))
+}
+
+func (suite *StateDBTestSuite) TestTransitionDbPrebuiltTracer() {
+	addr := sdk.AccAddress(suite.address.Bytes())
+	balance := ethermint.NewPhotonCoin(sdk.NewInt(5000))
+	acc := suite.app.AccountKeeper.GetAccount(suite.ctx, addr)
+	_ = acc.SetCoins(sdk.NewCoins(balance))
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	recipient := ethcmn.BytesToAddress([]byte("recipient"))
+	ctx := suite.ctx.WithIsCheckTx(true).WithIsTraceTx(true)
+	st := types.StateTransition{
+		AccountNonce: 0,
+		Price:        big.NewInt(10),
+		GasLimit:     100000,
+		Recipient:    &recipient,
+		Amount:       big.NewInt(50),
+		ChainID:      big.NewInt(1),
+		Csdb:         types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), ctx),
+		TxHash:       &ethcmn.Hash{},
+		Sender:       suite.address,
+		Simulate:     true,
+	}
+
+	// the tracer built by the caller is used and the output is left to it
+	tracer := vm.NewAccessListTracer(nil, suite.address, recipient, vm.PrecompiledAddressesBerlin)
+	traceTx := &sdk.TraceTxConfig{Tracer: tracer}
+	_, _, err, _, _ := st.TransitionDb(ctx.WithTraceTx(traceTx), types.DefaultChainConfig())
+	suite.Require().NoError(err)
+	suite.Require().Empty(traceTx.Output)
+	suite.Require().Empty(tracer.AccessList())
+}
+
+func (suite *StateDBTestSuite) TestTransitionDbInnerTxs() {
+	addr := sdk.AccAddress(suite.address.Bytes())
+	balance := ethermint.NewPhotonCoin(sdk.NewInt(5000))
+	acc := suite.app.AccountKeeper.GetAccount(suite.ctx, addr)
+	_ = acc.SetCoins(sdk.NewCoins(balance))
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	recipient := ethcmn.BytesToAddress([]byte("recipient"))
+	reverter := ethcmn.BytesToAddress([]byte("reverter"))
+
+	// the contract sends 1 to the recipient, then calls the reverter
+	code := append([]byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x01, 0x73}, recipient.Bytes()...)
+	code = append(code, 0x5a, 0xf1, 0x50, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x73)
+	code = append(code, reverter.Bytes()...)
+	code = append(code, 0x5a, 0xf1, 0x00)
+
+	ctx := suite.ctx.WithIsCheckTx(true).WithIsTraceTx(true)
+	csdb := types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), ctx)
+	csdb.SetCode(contract, code)
+	csdb.SetCode(reverter, []byte{0x60, 0x00, 0x60, 0x00, 0xfd})
+
+	st := types.StateTransition{
+		AccountNonce: 0,
+		Price:        big.NewInt(10),
+		GasLimit:     200000,
+		Recipient:    &contract,
+		Amount:       big.NewInt(50),
+		ChainID:      big.NewInt(1),
+		Csdb:         csdb,
+		TxHash:       &ethcmn.Hash{},
+		Sender:       suite.address,
+		Simulate:     true,
+	}
+
+	recorder := types.NewInnerTxRecorder()
+	traceTx := &sdk.TraceTxConfig{Tracer: recorder}
+	_, _, err, _, _ := st.TransitionDb(ctx.WithTraceTx(traceTx), types.DefaultChainConfig())
+	suite.Require().NoError(err)
+
+	innerTxs := recorder.InnerTxs(false)
+	suite.Require().Len(innerTxs, 3)
+
+	suite.Require().Equal("CALL", innerTxs[0].Type)
+	suite.Require().Equal(suite.address, innerTxs[0].From)
+	suite.Require().Equal(contract, innerTxs[0].To)
+	suite.Require().Equal(big.NewInt(50), innerTxs[0].Value.ToInt())
+	suite.Require().Equal(0, innerTxs[0].Depth)
+	suite.Require().Empty(innerTxs[0].Error)
+
+	suite.Require().Equal("CALL", innerTxs[1].Type)
+	suite.Require().Equal(contract, innerTxs[1].From)
+	suite.Require().Equal(recipient, innerTxs[1].To)
+	suite.Require().Equal(big.NewInt(1), innerTxs[1].Value.ToInt())
+	suite.Require().Equal(1, innerTxs[1].Depth)
+	suite.Require().Empty(innerTxs[1].Error)
+
+	suite.Require().Equal(reverter, innerTxs[2].To)
+	suite.Require().Equal(1, innerTxs[2].Depth)
+	suite.Require().Equal("execution reverted", innerTxs[2].Error)
+	suite.Require().NotZero(innerTxs[2].GasUsed)
+
+	// all the inner txs of a failed tx are reverted
+	for _, innerTx := range recorder.InnerTxs(true)[1:] {
+		suite.Require().NotEmpty(innerTx.Error)
+	}
+}
@@ -41,6 +41,7 @@ type CommitStateDBParams struct {
 	Watcher       Watcher
 	BankKeeper    BankKeeper
 	Ada           DbAdapter
+	StateHistory  *StateHistory
 }
 
 type Watcher interface {
@@ -76,6 +77,7 @@ type CommitStateDB struct {
 	supplyKeeper  SupplyKeeper
 	Watcher       Watcher
 	bankKeeper    BankKeeper
+	stateHistory  *StateHistory
 
 	// array that hold 'live' objects, which will get modified while processing a
 	// state transition
@@ -179,6 +181,7 @@ func CreateEmptyCommitStateDB(csdbParams CommitStateDBParams, ctx sdk.Context) *
 		supplyKeeper:  csdbParams.SupplyKeeper,
 		bankKeeper:    csdbParams.BankKeeper,
 		Watcher:       csdbParams.Watcher,
+		stateHistory:  csdbParams.StateHistory,
 
 		stateObjects:         make(map[ethcmn.Address]*stateEntry),
 		stateObjectsDirty:    make(map[ethcmn.Address]struct{}),
@@ -312,6 +315,15 @@ func (csdb *CommitStateDB) SetState(addr ethcmn.Address, key, value ethcmn.Hash)
 	}
 }
 
+// SetStorage replaces the whole storage of an account, the storage in the store is
+// ignored afterwards. It is only meant to override the state of a simulated call.
+func (csdb *CommitStateDB) SetStorage(addr ethcmn.Address, storage map[ethcmn.Hash]ethcmn.Hash) {
+	so := csdb.GetOrNewStateObject(addr)
+	if so != nil {
+		so.(*stateObject).setStorage(storage)
+	}
+}
+
 // SetCode sets the code for a given account.
 func (csdb *CommitStateDB) SetCode(addr ethcmn.Address, code []byte) {
 	if !csdb.ctx.IsCheckTx() {
@@ -460,7 +472,7 @@ func (csdb *CommitStateDB) PrepareAccessList(sender ethcmn.Address, dest *ethcmn
 	}
 
 	csdb.AddAddressToAccessList(sender)
-	if csdb != nil {
+	if dest != nil {
 		csdb.AddAddressToAccessList(*dest)
 		// If it's a create-tx, the destination will be added inside evm.create
 	}
@@ -857,6 +869,7 @@ func (csdb *CommitStateDB) updateStateObject(so *stateObject) error {
 		if csdb.Watcher.Enabled() {
 			csdb.Watcher.SaveAccount(so.account, false)
 		}
+		csdb.stateHistory.MarkAccount(so.address)
 	}
 	// return csdb.bankKeeper.SetBalance(csdb.ctx, so.account.Address, newBalance)
 	return nil
@@ -866,6 +879,9 @@ func (csdb *CommitStateDB) updateStateObject(so *stateObject) error {
 func (csdb *CommitStateDB) deleteStateObject(so *stateObject) {
 	so.deleted = true
 	csdb.accountKeeper.RemoveAccount(csdb.ctx, so.account)
+	if !csdb.ctx.IsCheckTx() {
+		csdb.stateHistory.MarkAccount(so.address)
+	}
 }
 
 // ----------------------------------------------------------------------------
@@ -1039,11 +1055,13 @@ func (csdb *CommitStateDB) clearJournalAndRefund() {
 }
 
 // Prepare sets the current transaction hash and index and block hash which is
-// used when the EVM emits new state logs.
+// used when the EVM emits new state logs. The access list is reset since it only
+// lives for the duration of a transaction.
 func (csdb *CommitStateDB) Prepare(thash, bhash ethcmn.Hash, txi int) {
 	csdb.thash = thash
 	csdb.bhash = bhash
 	csdb.txIndex = txi
+	csdb.accessList = newAccessList()
 }
 
 // CreateAccount explicitly creates a state object. If a state object with the
@@ -222,6 +222,24 @@ func (suite *StateDBTestSuite) TestStateDB_State() {
 	}
 }
 
+func (suite *StateDBTestSuite) TestStateDB_SetStorage() {
+	key := ethcmn.BytesToHash([]byte("foo"))
+	val := ethcmn.BytesToHash([]byte("bar"))
+	suite.stateDB.SetState(suite.address, key, val)
+	suite.stateDB.Commit(false)
+
+	// the stored slots are dropped once the storage is replaced
+	newKey := ethcmn.BytesToHash([]byte("key"))
+	suite.stateDB.SetStorage(suite.address, map[ethcmn.Hash]ethcmn.Hash{newKey: val})
+	suite.Require().Equal(ethcmn.Hash{}, suite.stateDB.GetState(suite.address, key))
+	suite.Require().Equal(val, suite.stateDB.GetState(suite.address, newKey))
+	suite.Require().Equal(val, suite.stateDB.GetCommittedState(suite.address, newKey))
+
+	suite.stateDB.SetState(suite.address, key, val)
+	suite.Require().Equal(val, suite.stateDB.GetState(suite.address, key))
+	suite.Require().Equal(ethcmn.Hash{}, suite.stateDB.GetCommittedState(suite.address, key))
+}
+
 func (suite *StateDBTestSuite) TestStateDB_Code() {
 	testCase := []struct {
 		name     string
@@ -694,6 +712,10 @@ func (suite *StateDBTestSuite) TestCommitStateDB_AccessList() {
 	addrIn, slotIn = suite.stateDB.SlotInAccessList(addr, hash)
 	suite.Require().True(addrIn)
 	suite.Require().True(slotIn)
+
+	// the access list of a tx doesn't leak into the next one
+	suite.stateDB.Prepare(ethcmn.Hash{1}, ethcmn.Hash{}, 1)
+	suite.Require().False(suite.stateDB.AddressInAccessList(addr))
 }
 
 func (suite *StateDBTestSuite) TestCommitStateDB_ContractDeploymentWhitelist() {
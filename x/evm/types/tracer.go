@@ -1,10 +1,12 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -20,6 +22,8 @@ import (
 const (
 	tracesDir = "traces"
 
+	defaultTraceTimeout = 5 * time.Second
+
 	FlagEnableTraces           = "evm-trace-enable"
 	FlagTraceSegment           = "evm-trace-segment"
 	FlagTraceFromAddrs         = "evm-trace-from-addrs"
@@ -117,11 +121,16 @@ func checkTracesSegment(height int64, from, to string) bool {
 }
 
 func saveTraceResult(ctx sdk.Context, tracer vm.Tracer, result *core.ExecutionResult) {
-	var (
-		res []byte
-		err error
-	)
-	// Depending on the tracer type, format and return the output
+	res, err := GetTraceResult(tracer, result)
+	if err != nil {
+		res = []byte(err.Error())
+	}
+
+	saveToDB(tmtypes.Tx(ctx.TxBytes()).Hash(), res)
+}
+
+// GetTraceResult formats the output of the tracer depending on its type
+func GetTraceResult(tracer vm.Tracer, result *core.ExecutionResult) (json.RawMessage, error) {
 	switch tracer := tracer.(type) {
 	case *vm.StructLogger:
 		// If the result contains a revert reason, return it.
@@ -130,23 +139,59 @@ func saveTraceResult(ctx sdk.Context, tracer vm.Tracer, result *core.ExecutionRe
 			returnVal = fmt.Sprintf("%x", result.Revert())
 		}
 
-		res, err = json.ConfigFastest.Marshal(&TraceExecutionResult{
+		return json.ConfigFastest.Marshal(&TraceExecutionResult{
 			Gas:         result.UsedGas,
 			Failed:      result.Failed(),
 			ReturnValue: returnVal,
 			StructLogs:  FormatLogs(tracer.StructLogs()),
 		})
 	case *tracers.Tracer:
-		res, err = tracer.GetResult()
+		res, err := tracer.GetResult()
+		return json.RawMessage(res), err
 	default:
-		res = []byte(fmt.Sprintf("bad tracer type %T", tracer))
+		return []byte(fmt.Sprintf("bad tracer type %T", tracer)), nil
 	}
+}
 
-	if err != nil {
-		res = []byte(err.Error())
+// TraceConfig holds the parameters of the debug trace functions, which are the
+// same as the ones of geth: a struct logger config or the name or the js code of
+// a tracer.
+type TraceConfig struct {
+	*vm.LogConfig
+	Tracer  *string `json:"tracer"`
+	Timeout *string `json:"timeout"`
+}
+
+// newTracer builds the tracer described by the json encoded TraceConfig. The
+// returned stop function must be called once the execution has finished.
+func newTracer(configBytes []byte, txHash common.Hash) (vm.Tracer, func(), error) {
+	var config TraceConfig
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &config); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	saveToDB(tmtypes.Tx(ctx.TxBytes()).Hash(), res)
+	if config.Tracer == nil {
+		return vm.NewStructLogger(config.LogConfig), func() {}, nil
+	}
+
+	timeout := defaultTraceTimeout
+	if config.Timeout != nil {
+		var err error
+		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	tracer, err := tracers.New(*config.Tracer, &tracers.Context{TxHash: txHash})
+	if err != nil {
+		return nil, nil, err
+	}
+	timer := time.AfterFunc(timeout, func() {
+		tracer.Stop(errors.New("execution timeout"))
+	})
+	return tracer, func() { timer.Stop() }, nil
 }
 
 func saveToDB(txHash []byte, value json.RawMessage) {
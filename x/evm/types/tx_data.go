@@ -7,10 +7,16 @@ import (
 	"github.com/okex/exchain/app/utils"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // TxData implements the Ethereum transaction data structure. It is used
 // solely as intended in Ethereum abiding by the protocol.
+//
+// Besides legacy txs, it holds the EIP-2718 typed txs. The typed fields are
+// not part of the legacy RLP encoding, typed txs are encoded as their
+// go-ethereum counterparts instead. For dynamic fee txs, Price holds the max
+// fee per gas.
 type TxData struct {
 	AccountNonce uint64          `json:"nonce"`
 	Price        *big.Int        `json:"gasPrice"`
@@ -26,6 +32,12 @@ type TxData struct {
 
 	// hash is only used when marshaling to JSON
 	Hash *ethcmn.Hash `json:"hash" rlp:"-"`
+
+	// typed tx values, they are empty for legacy txs
+	Type      uint8               `json:"type" rlp:"-"`
+	ChainID   *big.Int            `json:"chainId" rlp:"-"`
+	GasTipCap *big.Int            `json:"maxPriorityFeePerGas" rlp:"-"`
+	Accesses  ethtypes.AccessList `json:"accessList" rlp:"-"`
 }
 
 // encodableTxData implements the Ethereum transaction data structure. It is used
@@ -45,9 +57,25 @@ type encodableTxData struct {
 
 	// hash is only used when marshaling to JSON
 	Hash *ethcmn.Hash `json:"hash" rlp:"-"`
+
+	// typed tx values are appended so that legacy txs keep their encoding
+	Type      uint8               `json:"type"`
+	ChainID   string              `json:"chainId"`
+	GasTipCap string              `json:"maxPriorityFeePerGas"`
+	Accesses  ethtypes.AccessList `json:"accessList"`
 }
 
 func (td TxData) String() string {
+	if td.Type == ethtypes.AccessListTxType {
+		return fmt.Sprintf("type=%d chainId=%s nonce=%d price=%s gasLimit=%d recipient=%v amount=%s data=0x%x accessList=%d v=%s r=%s s=%s",
+			td.Type, td.ChainID, td.AccountNonce, td.Price, td.GasLimit, td.Recipient, td.Amount, td.Payload, len(td.Accesses), td.V, td.R, td.S)
+	}
+
+	if td.IsTyped() {
+		return fmt.Sprintf("type=%d chainId=%s nonce=%d maxFeePerGas=%s maxPriorityFeePerGas=%s gasLimit=%d recipient=%v amount=%s data=0x%x accessList=%d v=%s r=%s s=%s",
+			td.Type, td.ChainID, td.AccountNonce, td.Price, td.GasTipCap, td.GasLimit, td.Recipient, td.Amount, td.Payload, len(td.Accesses), td.V, td.R, td.S)
+	}
+
 	if td.Recipient != nil {
 		return fmt.Sprintf("nonce=%d price=%s gasLimit=%d recipient=%s amount=%s data=0x%x v=%s r=%s s=%s",
 			td.AccountNonce, td.Price, td.GasLimit, td.Recipient.Hex(), td.Amount, td.Payload, td.V, td.R, td.S)
@@ -97,6 +125,20 @@ func (td TxData) MarshalAmino() ([]byte, error) {
 		Hash:         td.Hash,
 	}
 
+	if td.IsTyped() {
+		e.Type = td.Type
+		e.Accesses = td.Accesses
+		if e.ChainID, err = utils.MarshalBigInt(td.ChainID); err != nil {
+			return nil, err
+		}
+		// access list txs have no tip cap
+		if td.GasTipCap != nil {
+			if e.GasTipCap, err = utils.MarshalBigInt(td.GasTipCap); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return ModuleCdc.MarshalBinaryBare(e)
 }
 
@@ -169,6 +211,92 @@ func (td *TxData) UnmarshalAmino(data []byte) error {
 		td.S = s
 	}
 
+	td.Type = e.Type
+	td.Accesses = e.Accesses
+	if e.ChainID != "" {
+		if td.ChainID, err = utils.UnmarshalBigInt(e.ChainID); err != nil {
+			return err
+		}
+	}
+	if e.GasTipCap != "" {
+		if td.GasTipCap, err = utils.UnmarshalBigInt(e.GasTipCap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsTyped returns whether the tx is an EIP-2718 typed tx.
+func (td TxData) IsTyped() bool {
+	return td.Type != ethtypes.LegacyTxType
+}
+
+// IsDynamicFee returns whether the tx is an EIP-1559 dynamic fee tx.
+func (td TxData) IsDynamicFee() bool {
+	return td.Type == ethtypes.DynamicFeeTxType
+}
+
+// toEthTx converts a typed tx to its go-ethereum counterpart, which defines its
+// encoding and signing hash.
+func (td TxData) toEthTx() (*ethtypes.Transaction, error) {
+	switch td.Type {
+	case ethtypes.AccessListTxType:
+		return ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID:    td.ChainID,
+			Nonce:      td.AccountNonce,
+			GasPrice:   td.Price,
+			Gas:        td.GasLimit,
+			To:         td.Recipient,
+			Value:      td.Amount,
+			Data:       td.Payload,
+			AccessList: td.Accesses,
+			V:          td.V,
+			R:          td.R,
+			S:          td.S,
+		}), nil
+	case ethtypes.DynamicFeeTxType:
+		return ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:    td.ChainID,
+			Nonce:      td.AccountNonce,
+			GasTipCap:  td.GasTipCap,
+			GasFeeCap:  td.Price,
+			Gas:        td.GasLimit,
+			To:         td.Recipient,
+			Value:      td.Amount,
+			Data:       td.Payload,
+			AccessList: td.Accesses,
+			V:          td.V,
+			R:          td.R,
+			S:          td.S,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported tx type %d", td.Type)
+	}
+}
+
+// fromEthTx fills the TxData with the values of a go-ethereum typed tx.
+func (td *TxData) fromEthTx(tx *ethtypes.Transaction) error {
+	switch tx.Type() {
+	case ethtypes.AccessListTxType:
+		td.GasTipCap = nil
+		td.Price = tx.GasPrice()
+	case ethtypes.DynamicFeeTxType:
+		td.GasTipCap = tx.GasTipCap()
+		td.Price = tx.GasFeeCap()
+	default:
+		return fmt.Errorf("unsupported tx type %d", tx.Type())
+	}
+
+	td.Type = tx.Type()
+	td.ChainID = tx.ChainId()
+	td.AccountNonce = tx.Nonce()
+	td.GasLimit = tx.Gas()
+	td.Recipient = tx.To()
+	td.Amount = tx.Value()
+	td.Payload = tx.Data()
+	td.Accesses = tx.AccessList()
+	td.V, td.R, td.S = tx.RawSignatureValues()
 	return nil
 }
 
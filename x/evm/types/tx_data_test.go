@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 func TestMarshalAndUnmarshalData(t *testing.T) {
@@ -61,6 +62,45 @@ func TestMsgEthereumTxAmino(t *testing.T) {
 	require.Equal(t, msg, msg2)
 }
 
+func TestMsgEthereumTxDynamicFeeAmino(t *testing.T) {
+	addr := GenerateEthAddress()
+	accesses := ethtypes.AccessList{{Address: addr, StorageKeys: []ethcmn.Hash{{0x1}}}}
+	msg := NewMsgEthereumTxDynamicFee(big.NewInt(3), 5, &addr, big.NewInt(1), 100000, big.NewInt(2), big.NewInt(3), []byte("test"), accesses)
+
+	msg.Data.V = big.NewInt(1)
+	msg.Data.R = big.NewInt(2)
+	msg.Data.S = big.NewInt(3)
+
+	raw, err := ModuleCdc.MarshalBinaryBare(msg)
+	require.NoError(t, err)
+
+	var msg2 MsgEthereumTx
+
+	err = ModuleCdc.UnmarshalBinaryBare(raw, &msg2)
+	require.NoError(t, err)
+	require.Equal(t, msg, msg2)
+}
+
+func TestMsgEthereumTxAccessListAmino(t *testing.T) {
+	addr := GenerateEthAddress()
+	accesses := ethtypes.AccessList{{Address: addr, StorageKeys: []ethcmn.Hash{{0x1}}}}
+	msg := NewMsgEthereumTxAccessList(big.NewInt(3), 5, nil, big.NewInt(1), 100000, big.NewInt(2), []byte("test"), accesses)
+
+	msg.Data.V = big.NewInt(1)
+	msg.Data.R = big.NewInt(2)
+	msg.Data.S = big.NewInt(3)
+
+	raw, err := ModuleCdc.MarshalBinaryBare(msg)
+	require.NoError(t, err)
+
+	var msg2 MsgEthereumTx
+
+	err = ModuleCdc.UnmarshalBinaryBare(raw, &msg2)
+	require.NoError(t, err)
+	require.Equal(t, msg, msg2)
+	require.Nil(t, msg2.Data.GasTipCap)
+}
+
 func TestTxData_String(t *testing.T) {
 	const expectedStrWithoutRecipient = "nonce=2 price=3 gasLimit=1 recipient=nil amount=4 data=0x1234567890abcdef v=5 r=6 s=7"
 	payload, err := hexutil.Decode("0x1234567890abcdef")
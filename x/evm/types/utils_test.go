@@ -5,6 +5,7 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 	"math/big"
@@ -129,3 +130,27 @@ func TestTxDecoder(t *testing.T) {
 	_, err = txDecoder(txbytes[1:])
 	require.Error(t, err)
 }
+
+func TestUnpackRevertError(t *testing.T) {
+	// abi encoding of Error("boom")
+	data := ethcmn.FromHex("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"626f6f6d00000000000000000000000000000000000000000000000000000000")
+
+	reason, ret, ok := UnpackRevertError(newRevertError(data, vm.ErrExecutionReverted))
+	require.True(t, ok)
+	require.Equal(t, "boom", reason)
+	require.Equal(t, data, ret)
+
+	// reverted without a reason
+	reason, ret, ok = UnpackRevertError(newRevertError([]byte{0x1}, vm.ErrExecutionReverted))
+	require.True(t, ok)
+	require.Empty(t, reason)
+	require.Equal(t, []byte{0x1}, ret)
+
+	_, _, ok = UnpackRevertError(newRevertError(nil, vm.ErrOutOfGas))
+	require.False(t, ok)
+	_, _, ok = UnpackRevertError(nil)
+	require.False(t, ok)
+}
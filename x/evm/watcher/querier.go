@@ -65,6 +65,25 @@ func (q Querier) GetTransactionReceipt(hash common.Hash) (*TransactionReceipt, e
 	return &receipt, nil
 }
 
+// GetInnerTxs returns the inner txs of the tx, in the order they are run
+func (q Querier) GetInnerTxs(hash common.Hash) ([]*evmtypes.InnerTx, error) {
+	if !q.enabled() {
+		return nil, errors.New(MsgFunctionDisable)
+	}
+	b, e := q.store.Get(append(prefixInnerTx, hash.Bytes()...))
+	if e != nil {
+		return nil, e
+	}
+	if b == nil {
+		return nil, errNotFound
+	}
+	var innerTxs []*evmtypes.InnerTx
+	if e = json.Unmarshal(b, &innerTxs); e != nil {
+		return nil, e
+	}
+	return innerTxs, nil
+}
+
 func (q Querier) GetBlockByHash(hash common.Hash, fullTx bool) (*EthBlock, error) {
 	if !q.enabled() {
 		return nil, errors.New(MsgFunctionDisable)
@@ -102,6 +121,25 @@ func (q Querier) GetBlockByHash(hash common.Hash, fullTx bool) (*EthBlock, error
 	return &block, nil
 }
 
+func (q Querier) GetBlockGasPrice(height uint64) (*BlockGasPrice, error) {
+	if !q.enabled() {
+		return nil, errors.New(MsgFunctionDisable)
+	}
+	var gasPrice BlockGasPrice
+	b, e := q.store.Get(append(prefixBlockGasPrice, []byte(strconv.Itoa(int(height)))...))
+	if e != nil {
+		return nil, e
+	}
+	if b == nil {
+		return nil, errNotFound
+	}
+	e = json.Unmarshal(b, &gasPrice)
+	if e != nil {
+		return nil, e
+	}
+	return &gasPrice, nil
+}
+
 func (q Querier) GetBlockHashByNumber(number uint64) (common.Hash, error) {
 	if !q.enabled() {
 		return common.Hash{}, errors.New(MsgFunctionDisable)
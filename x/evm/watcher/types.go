@@ -3,6 +3,7 @@ package watcher
 import (
 	"encoding/binary"
 	"encoding/json"
+	"sort"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
@@ -22,19 +23,21 @@ import (
 )
 
 var (
-	prefixTx           = []byte{0x01}
-	prefixBlock        = []byte{0x02}
-	prefixReceipt      = []byte{0x03}
-	prefixCode         = []byte{0x04}
-	prefixBlockInfo    = []byte{0x05}
-	prefixLatestHeight = []byte{0x06}
-	prefixAccount      = []byte{0x07}
-	PrefixState        = []byte{0x08}
-	prefixCodeHash     = []byte{0x09}
-	prefixParams       = []byte{0x10}
-	prefixWhiteList    = []byte{0x11}
-	prefixBlackList    = []byte{0x12}
-	prefixRpcDb        = []byte{0x13}
+	prefixTx            = []byte{0x01}
+	prefixBlock         = []byte{0x02}
+	prefixReceipt       = []byte{0x03}
+	prefixCode          = []byte{0x04}
+	prefixBlockInfo     = []byte{0x05}
+	prefixLatestHeight  = []byte{0x06}
+	prefixAccount       = []byte{0x07}
+	PrefixState         = []byte{0x08}
+	prefixCodeHash      = []byte{0x09}
+	prefixParams        = []byte{0x10}
+	prefixWhiteList     = []byte{0x11}
+	prefixBlackList     = []byte{0x12}
+	prefixRpcDb         = []byte{0x13}
+	prefixBlockGasPrice = []byte{0x14}
+	prefixInnerTx       = []byte{0x15}
 
 	KeyLatestHeight = "LatestHeight"
 
@@ -169,6 +172,7 @@ type TransactionReceipt struct {
 	TransactionIndex  hexutil.Uint64  `json:"transactionIndex"`
 	From              string          `json:"from"`
 	To                *common.Address `json:"to"`
+	Type              hexutil.Uint64  `json:"type"`
 }
 
 func NewMsgTransactionReceipt(status uint32, tx *types.MsgEthereumTx, txHash, blockHash common.Hash, txIndex, height uint64, data *types.ResultData, cumulativeGas, GasUsed uint64) *MsgTransactionReceipt {
@@ -186,6 +190,7 @@ func NewMsgTransactionReceipt(status uint32, tx *types.MsgEthereumTx, txHash, bl
 		TransactionIndex:  hexutil.Uint64(txIndex),
 		From:              common.BytesToAddress(tx.From().Bytes()).Hex(),
 		To:                tx.To(),
+		Type:              hexutil.Uint64(tx.Data.Type),
 	}
 
 	//contract address will be set to 0x0000000000000000000000000000000000000000 if contract deploy failed
@@ -265,9 +270,10 @@ type EthBlock struct {
 	Uncles           []common.Hash  `json:"uncles"`
 	ReceiptsRoot     common.Hash    `json:"receiptsRoot"`
 	Transactions     interface{}    `json:"transactions"`
+	BaseFee          *hexutil.Big   `json:"baseFeePerGas,omitempty"`
 }
 
-func NewMsgBlock(height uint64, blockBloom ethtypes.Bloom, blockHash common.Hash, header abci.Header, gasLimit uint64, gasUsed *big.Int, txs interface{}) *MsgBlock {
+func NewMsgBlock(height uint64, blockBloom ethtypes.Bloom, blockHash common.Hash, header abci.Header, gasLimit uint64, gasUsed, baseFee *big.Int, txs interface{}) *MsgBlock {
 	b := EthBlock{
 		Number:           hexutil.Uint64(height),
 		Hash:             blockHash,
@@ -289,6 +295,7 @@ func NewMsgBlock(height uint64, blockBloom ethtypes.Bloom, blockHash common.Hash
 		Uncles:           []common.Hash{},
 		ReceiptsRoot:     common.Hash{},
 		Transactions:     txs,
+		BaseFee:          (*hexutil.Big)(baseFee),
 	}
 	jsBlock, e := json.Marshal(b)
 	if e != nil {
@@ -488,3 +495,129 @@ func (msgItem *MsgContractDeploymentWhitelistItem) GetKey() []byte {
 func (msgItem *MsgContractDeploymentWhitelistItem) GetValue() string {
 	return ""
 }
+
+type MsgBlockGasPrice struct {
+	height   []byte
+	gasPrice string
+}
+
+func (b MsgBlockGasPrice) GetType() uint32 {
+	return TypeOthers
+}
+
+// TxGasPrice is the effective gas price and the gas used of a tx
+type TxGasPrice struct {
+	GasPrice *hexutil.Big   `json:"gasPrice"`
+	GasUsed  hexutil.Uint64 `json:"gasUsed"`
+}
+
+// BlockGasPrice is the gas price index of a block. Its txs are sorted by their effective gas price, so that the
+// priority fees paid by the given percentiles of the gas used in the block can be found without loading the block.
+type BlockGasPrice struct {
+	BaseFee  *hexutil.Big   `json:"baseFee,omitempty"`
+	GasUsed  hexutil.Uint64 `json:"gasUsed"`
+	GasLimit hexutil.Uint64 `json:"gasLimit"`
+	Txs      []TxGasPrice   `json:"txs"`
+}
+
+func NewMsgBlockGasPrice(height uint64, baseFee *big.Int, gasUsed, gasLimit uint64, txs []TxGasPrice) *MsgBlockGasPrice {
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].GasPrice.ToInt().Cmp(txs[j].GasPrice.ToInt()) < 0
+	})
+	gp := BlockGasPrice{
+		BaseFee:  (*hexutil.Big)(baseFee),
+		GasUsed:  hexutil.Uint64(gasUsed),
+		GasLimit: hexutil.Uint64(gasLimit),
+		Txs:      txs,
+	}
+	jsGp, e := json.Marshal(gp)
+	if e != nil {
+		return nil
+	}
+	return &MsgBlockGasPrice{
+		height:   []byte(strconv.Itoa(int(height))),
+		gasPrice: string(jsGp),
+	}
+}
+
+func (b MsgBlockGasPrice) GetKey() []byte {
+	return append(prefixBlockGasPrice, b.height...)
+}
+
+func (b MsgBlockGasPrice) GetValue() string {
+	return b.gasPrice
+}
+
+// GasUsedRatio returns the ratio of the gas used to the gas limit of the block
+func (gp BlockGasPrice) GasUsedRatio() float64 {
+	if gp.GasLimit == 0 {
+		return 0
+	}
+	return float64(gp.GasUsed) / float64(gp.GasLimit)
+}
+
+// Rewards returns the priority fees paid by the txs at the given percentiles of the gas used in the block, as the
+// rewards of eth_feeHistory. The percentiles must be sorted in ascending order, the rewards are zero if the block
+// has no txs.
+func (gp BlockGasPrice) Rewards(percentiles []float64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	if len(gp.Txs) == 0 {
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return rewards
+	}
+
+	var gasUsed uint64
+	for _, tx := range gp.Txs {
+		gasUsed += uint64(tx.GasUsed)
+	}
+
+	txIndex := 0
+	sumGasUsed := uint64(gp.Txs[0].GasUsed)
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(gasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(gp.Txs)-1 {
+			txIndex++
+			sumGasUsed += uint64(gp.Txs[txIndex].GasUsed)
+		}
+		rewards[i] = gp.tip(gp.Txs[txIndex].GasPrice.ToInt())
+	}
+	return rewards
+}
+
+func (gp BlockGasPrice) tip(gasPrice *big.Int) *big.Int {
+	if gp.BaseFee == nil {
+		return new(big.Int).Set(gasPrice)
+	}
+	tip := new(big.Int).Sub(gasPrice, gp.BaseFee.ToInt())
+	if tip.Sign() < 0 {
+		return new(big.Int)
+	}
+	return tip
+}
+
+type MsgInnerTxs struct {
+	txHash   []byte
+	innerTxs string
+}
+
+func (m MsgInnerTxs) GetType() uint32 {
+	return TypeOthers
+}
+
+func NewMsgInnerTxs(txHash common.Hash, innerTxs []*types.InnerTx) *MsgInnerTxs {
+	jsTxs, e := json.Marshal(innerTxs)
+	if e != nil {
+		return nil
+	}
+	return &MsgInnerTxs{txHash: txHash.Bytes(), innerTxs: string(jsTxs)}
+}
+
+func (m MsgInnerTxs) GetKey() []byte {
+	return append(prefixInnerTx, m.txHash...)
+}
+
+func (m MsgInnerTxs) GetValue() string {
+	return m.innerTxs
+}
@@ -0,0 +1,52 @@
+package watcher
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newTxGasPrice(gasPrice int64, gasUsed uint64) TxGasPrice {
+	return TxGasPrice{GasPrice: (*hexutil.Big)(big.NewInt(gasPrice)), GasUsed: hexutil.Uint64(gasUsed)}
+}
+
+func TestBlockGasPrice(t *testing.T) {
+	txs := []TxGasPrice{
+		newTxGasPrice(30, 50000),
+		newTxGasPrice(10, 21000),
+		newTxGasPrice(20, 29000),
+	}
+	msg := NewMsgBlockGasPrice(10, big.NewInt(5), 100000, 200000, txs)
+	require.NotNil(t, msg)
+	require.Equal(t, append(prefixBlockGasPrice, []byte("10")...), msg.GetKey())
+
+	var gasPrice BlockGasPrice
+	require.NoError(t, json.Unmarshal([]byte(msg.GetValue()), &gasPrice))
+	require.Equal(t, 0.5, gasPrice.GasUsedRatio())
+
+	// txs are sorted by gas price, the rewards are the gas price minus the base fee
+	rewards := gasPrice.Rewards([]float64{0, 10, 21, 25, 50, 51, 100})
+	expected := []int64{5, 5, 5, 15, 15, 25, 25}
+	require.Equal(t, len(expected), len(rewards))
+	for i, reward := range rewards {
+		require.Equal(t, expected[i], reward.Int64(), "percentile %d", i)
+	}
+
+	// the reward is the gas price before the London fork
+	gasPrice.BaseFee = nil
+	require.Equal(t, int64(10), gasPrice.Rewards([]float64{0})[0].Int64())
+
+	// the rewards of an empty block are zero
+	gasPrice.Txs = nil
+	rewards = gasPrice.Rewards([]float64{50, 100})
+	require.Equal(t, 2, len(rewards))
+	for _, reward := range rewards {
+		require.Zero(t, reward.Sign())
+	}
+
+	gasPrice.GasLimit = 0
+	require.Zero(t, gasPrice.GasUsedRatio())
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/spf13/viper"
@@ -26,11 +27,20 @@ type Watcher struct {
 	cumulativeGas map[uint64]uint64
 	gasUsed       uint64
 	blockTxs      []common.Hash
+	txGasPrices   []txGasPrice
 	sw            bool
 	firstUse      bool
 	delayEraseKey [][]byte
 }
 
+// blockGasLimit is the gas limit of the blocks returned by the web3 api
+const blockGasLimit = uint64(0xffffffff)
+
+type txGasPrice struct {
+	msg     evmtypes.MsgEthereumTx
+	gasUsed uint64
+}
+
 var (
 	watcherEnable  = false
 	watcherLruSize = 1000
@@ -83,6 +93,7 @@ func (w *Watcher) NewHeight(height uint64, blockHash common.Hash, header types.H
 	w.cumulativeGas = make(map[uint64]uint64)
 	w.gasUsed = 0
 	w.blockTxs = []common.Hash{}
+	w.txGasPrices = []txGasPrice{}
 }
 
 func (w *Watcher) SaveEthereumTx(msg evmtypes.MsgEthereumTx, txHash common.Hash, index uint64) {
@@ -121,12 +132,24 @@ func (w *Watcher) SaveTransactionReceipt(status uint32, msg evmtypes.MsgEthereum
 		return
 	}
 	w.UpdateCumulativeGas(txIndex, gasUsed)
+	w.txGasPrices = append(w.txGasPrices, txGasPrice{msg: msg, gasUsed: gasUsed})
 	wMsg := NewMsgTransactionReceipt(status, &msg, txHash, w.blockHash, txIndex, w.height, data, w.cumulativeGas[txIndex], gasUsed)
 	if wMsg != nil {
 		w.batch = append(w.batch, wMsg)
 	}
 }
 
+// SaveInnerTxs saves the calls, creates and selfdestructs run by the tx
+func (w *Watcher) SaveInnerTxs(txHash common.Hash, innerTxs []*evmtypes.InnerTx) {
+	if !w.Enabled() {
+		return
+	}
+	wMsg := NewMsgInnerTxs(txHash, innerTxs)
+	if wMsg != nil {
+		w.batch = append(w.batch, wMsg)
+	}
+}
+
 func (w *Watcher) UpdateCumulativeGas(txIndex, gasUsed uint64) {
 	if !w.Enabled() {
 		return
@@ -193,15 +216,32 @@ func (w *Watcher) SaveState(addr common.Address, key, value []byte) {
 	}
 }
 
-func (w *Watcher) SaveBlock(bloom ethtypes.Bloom) {
+// SaveBlock saves the block and its gas prices, the gas used ratio of the block is computed
+// against the given max gas, or the gas limit returned by the web3 api if it's unlimited.
+func (w *Watcher) SaveBlock(bloom ethtypes.Bloom, baseFee *big.Int, maxGas uint64) {
 	if !w.Enabled() {
 		return
 	}
-	wMsg := NewMsgBlock(w.height, bloom, w.blockHash, w.header, uint64(0xffffffff), big.NewInt(int64(w.gasUsed)), w.blockTxs)
+	wMsg := NewMsgBlock(w.height, bloom, w.blockHash, w.header, blockGasLimit, big.NewInt(int64(w.gasUsed)), baseFee, w.blockTxs)
 	if wMsg != nil {
 		w.batch = append(w.batch, wMsg)
 	}
 
+	txs := make([]TxGasPrice, 0, len(w.txGasPrices))
+	for _, tx := range w.txGasPrices {
+		txs = append(txs, TxGasPrice{
+			GasPrice: (*hexutil.Big)(tx.msg.EffectiveGasPrice(baseFee)),
+			GasUsed:  hexutil.Uint64(tx.gasUsed),
+		})
+	}
+	if maxGas == 0 {
+		maxGas = blockGasLimit
+	}
+	wGasPrice := NewMsgBlockGasPrice(w.height, baseFee, w.gasUsed, maxGas, txs)
+	if wGasPrice != nil {
+		w.batch = append(w.batch, wGasPrice)
+	}
+
 	wInfo := NewMsgBlockInfo(w.height, w.blockHash)
 	if wInfo != nil {
 		w.batch = append(w.batch, wInfo)
@@ -9,10 +9,11 @@ import (
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 )
 
-// BeginBlocker allocates the native token to the pools in PoolsYieldNativeToken
+// BeginBlocker removes the expired lock tiers, and allocates the native token to the pools in PoolsYieldNativeToken
 // according to the value of locked token in pool
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
 	logger := k.Logger(ctx)
+	rebaseExpiredLocks(ctx, k)
 
 	moduleAcc := k.SupplyKeeper().GetModuleAccount(ctx, MintFarmingAccount)
 	yieldedNativeTokenAmt := moduleAcc.GetCoins().AmountOf(sdk.DefaultBondDenom)
@@ -27,6 +27,7 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	farmQueryCmd.AddCommand(
 		client.GetCommands(
 			GetCmdQueryPool(queryRoute, cdc),
+			GetCmdQueryPoolAPR(queryRoute, cdc),
 			GetCmdQueryPools(queryRoute, cdc),
 			GetCmdQueryPoolNum(queryRoute, cdc),
 			GetCmdQueryLockInfo(queryRoute, cdc),
@@ -77,6 +78,43 @@ $ %s query farm pool pool-eth-xxb
 	}
 }
 
+// GetCmdQueryPoolAPR gets the pool apr query command.
+func GetCmdQueryPoolAPR(storeName string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pool-apr [pool-name]",
+		Short: "query the projected apr of the tokens yielded by a pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the APR of every token yielded by a pool, projected by its emission schedule over the next year
+and priced in the quote symbol of farm params.
+
+Example:
+$ %s query farm pool-apr pool-eth-xxb
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bytes, err := cdc.MarshalJSON(types.NewQueryPoolParams(args[0]))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", storeName, types.QueryPoolAPR)
+			resp, _, err := cliCtx.QueryWithData(route, bytes)
+			if err != nil {
+				return err
+			}
+
+			var poolAPR types.PoolAPR
+			cdc.MustUnmarshalJSON(resp, &poolAPR)
+			return cliCtx.PrintOutput(poolAPR)
+		},
+	}
+}
+
 // GetCmdQueryPools gets the pools query command.
 func GetCmdQueryPools(storeName string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
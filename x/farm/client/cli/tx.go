@@ -20,6 +20,12 @@ import (
 	"github.com/okex/exchain/x/farm/types"
 )
 
+const (
+	flagEndHeightToYield = "end-height"
+	flagEmissionPhases   = "emission-phases"
+	flagLockDuration     = "lock-duration"
+)
+
 // GetTxCmd returns the transaction commands for this module
 func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	farmTxCmd := &cobra.Command{
@@ -35,6 +41,7 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		GetCmdLock(cdc),
 		GetCmdUnlock(cdc),
 		GetCmdClaim(cdc),
+		GetCmdSetLockTiers(cdc),
 	)...)
 	return farmTxCmd
 }
@@ -100,11 +107,14 @@ func GetCmdProvide(cdc *codec.Codec) *cobra.Command {
 		Use:   "provide [pool-name] [amount] [yield-per-block] [start-height-to-yield]",
 		Short: "provide a number of yield tokens into a pool",
 		Long: strings.TrimSpace(
-			fmt.Sprintf(`Provide a number of yield tokens into a pool.
+			fmt.Sprintf(`Provide a number of yield tokens into a pool. A pool yields several tokens at the same time, each of
+them stops yielding at the end height if it's set, and changes the amount yielded per block by the emission phases
+in the format of [start-height]:[yield-per-block].
 
 Example:
 $ %s tx farm provide pool-eth-xxb 1000xxb 5 10000 --from mykey
-`, version.ClientName),
+$ %s tx farm provide pool-eth-xxb 1000xxb 5 10000 --end-height 50000 --emission-phases 20000:3,30000:1 --from mykey
+`, version.ClientName, version.ClientName),
 		),
 		Args: cobra.ExactArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -127,24 +137,69 @@ $ %s tx farm provide pool-eth-xxb 1000xxb 5 10000 --from mykey
 				return err
 			}
 
+			endHeightToYield, err := cmd.Flags().GetInt64(flagEndHeightToYield)
+			if err != nil {
+				return err
+			}
+
+			emissionPhasesStr, err := cmd.Flags().GetString(flagEmissionPhases)
+			if err != nil {
+				return err
+			}
+			emissionPhases, err := parseEmissionPhases(emissionPhasesStr)
+			if err != nil {
+				return err
+			}
+
 			poolName := args[0]
-			msg := types.NewMsgProvide(poolName, cliCtx.GetFromAddress(), amount, yieldPerBlock, startHeightToYield)
+			msg := types.NewMsgProvideWithEmissionSchedule(poolName, cliCtx.GetFromAddress(), amount, yieldPerBlock,
+				startHeightToYield, endHeightToYield, emissionPhases)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().Int64(flagEndHeightToYield, 0, "the height to stop yielding, 0 means no end")
+	cmd.Flags().String(flagEmissionPhases, "",
+		"the emission phases in the format of [start-height]:[yield-per-block] separated by comma")
 	return cmd
 }
 
+// parseEmissionPhases parses the emission phases like "20000:3,30000:1"
+func parseEmissionPhases(str string) ([]types.EmissionPhase, error) {
+	str = strings.TrimSpace(str)
+	if len(str) == 0 {
+		return nil, nil
+	}
+	var emissionPhases []types.EmissionPhase
+	for _, phaseStr := range strings.Split(str, ",") {
+		fields := strings.Split(strings.TrimSpace(phaseStr), ":")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid emission phase %s, it should be [start-height]:[yield-per-block]", phaseStr)
+		}
+		startHeight, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		yieldPerBlock, err := sdk.NewDecFromStr(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		emissionPhases = append(emissionPhases, types.NewEmissionPhase(startHeight, yieldPerBlock))
+	}
+	return emissionPhases, nil
+}
+
 func GetCmdLock(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "lock [pool-name] [amount]",
 		Short: "lock a number of tokens for yield farming",
 		Long: strings.TrimSpace(
-			fmt.Sprintf(`Lock a number of tokens for yield farming.
+			fmt.Sprintf(`Lock a number of tokens for yield farming. With a lock duration of the lock tiers of the pool, all the
+tokens locked in the pool are weighted by the multiplier of the tier in the rewards until the unlock time.
 
 Example:
 $ %s tx farm lock pool-eth-xxb 5eth --from mykey
-`, version.ClientName),
+$ %s tx farm lock pool-eth-xxb 5eth --lock-duration 2592000 --from mykey
+`, version.ClientName, version.ClientName),
 		),
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -157,14 +212,82 @@ $ %s tx farm lock pool-eth-xxb 5eth --from mykey
 				return err
 			}
 
+			lockDuration, err := cmd.Flags().GetInt64(flagLockDuration)
+			if err != nil {
+				return err
+			}
+
 			poolName := args[0]
-			msg := types.NewMsgLock(poolName, cliCtx.GetFromAddress(), amount)
+			msg := types.NewMsgLockWithDuration(poolName, cliCtx.GetFromAddress(), amount, lockDuration)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().Int64(flagLockDuration, 0, "the lock duration in seconds of a lock tier of the pool, 0 means no lock")
 	return cmd
 }
 
+func GetCmdSetLockTiers(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-lock-tiers [pool-name] [lock-tiers]",
+		Short: "set the lock tiers of a pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Set the lock tiers of a pool in the format of [duration]:[multiplier]:[early-unlock-penalty]
+separated by comma. The tokens locked for the duration in seconds are weighted by the multiplier in the rewards, and
+unlocking them early forfeits the penalty rate of them, or is disallowed if the penalty is 0. An empty string removes
+all the lock tiers, the tokens locked before keep their lock tiers until the unlock time.
+
+Example:
+$ %s tx farm set-lock-tiers pool-eth-xxb 2592000:1.5:0.1,31536000:3:0 --from mykey
+`, version.ClientName),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			lockTiers, err := parseLockTiers(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetLockTiers(args[0], cliCtx.GetFromAddress(), lockTiers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+// parseLockTiers parses the lock tiers like "2592000:1.5:0.1,31536000:3:0"
+func parseLockTiers(str string) ([]types.LockTier, error) {
+	str = strings.TrimSpace(str)
+	if len(str) == 0 {
+		return nil, nil
+	}
+	var lockTiers []types.LockTier
+	for _, tierStr := range strings.Split(str, ",") {
+		fields := strings.Split(strings.TrimSpace(tierStr), ":")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid lock tier %s, it should be [duration]:[multiplier]:[early-unlock-penalty]",
+				tierStr)
+		}
+		duration, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		multiplier, err := sdk.NewDecFromStr(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		penalty, err := sdk.NewDecFromStr(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		lockTiers = append(lockTiers, types.NewLockTier(duration, multiplier, penalty))
+	}
+	return lockTiers, nil
+}
+
 func GetCmdUnlock(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "unlock [pool-name] [amount]",
@@ -27,6 +27,12 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		queryPoolHandlerFn(cliCtx),
 	).Methods("GET")
 
+	// get the projected apr of the tokens yielded by a farm pool
+	r.HandleFunc(
+		"/farm/pool/{poolName}/apr",
+		queryPoolAPRHandlerFn(cliCtx),
+	).Methods("GET")
+
 	// get the current earnings of an account in a farm pool
 	r.HandleFunc(
 		"/farm/earnings/{poolName}/{accAddr}",
@@ -141,6 +147,34 @@ func queryPoolHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func queryPoolAPRHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		poolName := mux.Vars(r)["poolName"]
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		params := types.NewQueryPoolParams(poolName)
+
+		jsonBytes, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorCodecFails)
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryPoolAPR)
+		res, height, err := cliCtx.QueryWithData(route, jsonBytes)
+		if err != nil {
+			common.HandleErrorResponseV2(w, http.StatusInternalServerError, common.ErrorABCIQueryFails)
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryPoolsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		_, page, limit, err := rest.ParseHTTPArgsWithLimit(r, 0)
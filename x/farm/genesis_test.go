@@ -25,13 +25,9 @@ func TestInitAndExportGenesis(t *testing.T) {
 		},
 	}
 	defaultGenesisState.LockInfos = []types.LockInfo{
-		{
-			Owner:            poolMsg.Owner,
-			PoolName:         poolMsg.PoolName,
-			Amount:           sdk.NewDecCoinFromDec(poolMsg.MinLockAmount.Denom, sdk.NewDec(1)),
-			StartBlockHeight: 10,
-			ReferencePeriod:  1,
-		},
+		types.NewLockInfo(
+			poolMsg.Owner, poolMsg.PoolName, sdk.NewDecCoinFromDec(poolMsg.MinLockAmount.Denom, sdk.NewDec(1)), 10, 1,
+		),
 	}
 	defaultGenesisState.PoolCurrentRewards = []types.PoolCurrentRewardsRecord{
 		{
@@ -50,6 +50,11 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			handlerFun = func() (*sdk.Result, error) {
 				return handleMsgClaim(ctx, k, msg)
 			}
+		case types.MsgSetLockTiers:
+			name = "handleMsgSetLockTiers"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgSetLockTiers(ctx, k, msg)
+			}
 		default:
 			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
 			return types.ErrUnknownFarmMsgType(errMsg).Result()
@@ -81,26 +86,32 @@ func handleMsgProvide(ctx sdk.Context, k keeper.Keeper, msg types.MsgProvide) (*
 		return types.ErrInvalidPoolOwner(msg.Address.String(), msg.PoolName).Result()
 	}
 
-	// 1.3 Check if the provided coin denom is the same as the locked coin name
-	if len(pool.YieldedTokenInfos) != 1 {
-		panic(fmt.Sprintf("The YieldedTokenInfos length is %d, which should be 1 in current code version",
-			len(pool.YieldedTokenInfos)))
-	}
-	if pool.YieldedTokenInfos[0].RemainingAmount.Denom != msg.Amount.Denom {
-		return types.ErrInvalidDenom(pool.YieldedTokenInfos[0].RemainingAmount.Denom, msg.Amount.Denom).Result()
+	// 1.3 Check if the provided coin is yielded by the pool, otherwise the pool yields it as a new token
+	index := pool.IndexOfYieldedToken(msg.Amount.Denom)
+	if index == -1 {
+		if ok := k.TokenKeeper().TokenExist(ctx, msg.Amount.Denom); !ok {
+			return types.ErrTokenNotExist(msg.Amount.Denom).Result()
+		}
+		if len(pool.YieldedTokenInfos) >= types.MaxYieldedTokensNum {
+			return types.ErrTooManyYieldedTokens(msg.PoolName, types.MaxYieldedTokensNum).Result()
+		}
 	}
 
 	// 2.1 Calculate how many provided token & native token could be yielded in current period
 	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
 
-	// 2.2 Check if remaining amount is already zero
-	remainingAmount := updatedPool.YieldedTokenInfos[0].RemainingAmount
-	if !remainingAmount.IsZero() {
-		return types.ErrRemainingAmountNotZero(remainingAmount.String()).Result()
+	// 2.2 Check if the provided token has stopped yielding, the idle remaining amount is provided again
+	amount := msg.Amount
+	if index != -1 {
+		yieldedTokenInfo := updatedPool.YieldedTokenInfos[index]
+		if yieldedTokenInfo.IsYielding() && !yieldedTokenInfo.RemainingAmount.IsZero() {
+			return types.ErrRemainingAmountNotZero(yieldedTokenInfo.RemainingAmount.String()).Result()
+		}
+		amount = amount.Add(yieldedTokenInfo.RemainingAmount)
 	}
 
 	// 3. Terminate pool current period
-	k.IncrementPoolPeriod(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens)
+	k.IncrementPoolPeriod(ctx, pool.Name, pool.GetWeightedValueLocked(), yieldedTokens)
 
 	// 4. Transfer coin to farm module account
 	if err := k.SupplyKeeper().SendCoinsFromAccountToModule(
@@ -110,9 +121,14 @@ func handleMsgProvide(ctx sdk.Context, k keeper.Keeper, msg types.MsgProvide) (*
 	}
 
 	// 5. init a new yielded_token_info struct, then set it into store
-	updatedPool.YieldedTokenInfos[0] = types.NewYieldedTokenInfo(
-		msg.Amount, msg.StartHeightToYield, msg.AmountYieldedPerBlock,
-	)
+	yieldedTokenInfo := types.NewYieldedTokenInfo(amount, msg.StartHeightToYield, msg.AmountYieldedPerBlock)
+	yieldedTokenInfo.EndBlockHeightToYield = msg.EndHeightToYield
+	yieldedTokenInfo.EmissionPhases = msg.EmissionPhases
+	if index == -1 {
+		updatedPool.YieldedTokenInfos = append(updatedPool.YieldedTokenInfos, yieldedTokenInfo)
+	} else {
+		updatedPool.YieldedTokenInfos[index] = yieldedTokenInfo
+	}
 	k.SetFarmPool(ctx, updatedPool)
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -122,6 +138,7 @@ func handleMsgProvide(ctx sdk.Context, k keeper.Keeper, msg types.MsgProvide) (*
 		sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
 		sdk.NewAttribute(types.AttributeKeyStartHeightToYield, strconv.FormatInt(msg.StartHeightToYield, 10)),
 		sdk.NewAttribute(types.AttributeKeyAmountYieldPerBlock, msg.AmountYieldedPerBlock.String()),
+		sdk.NewAttribute(types.AttributeKeyEndHeightToYield, strconv.FormatInt(msg.EndHeightToYield, 10)),
 	))
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
@@ -137,13 +154,14 @@ func handleMsgClaim(ctx sdk.Context, k keeper.Keeper, msg types.MsgClaim) (*sdk.
 	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
 
 	// 3. Withdraw rewards
-	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens, msg.Address)
+	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.GetWeightedValueLocked(), yieldedTokens, msg.Address)
 	if err != nil {
 		return nil, err
 	}
 
-	// 4. Update the lock_info data
-	k.UpdateLockInfo(ctx, msg.Address, pool.Name, sdk.ZeroDec())
+	// 4. Update the lock_info data, the expired lock tier is removed
+	changedWeightedAmount := k.UpdateLockInfo(ctx, msg.Address, pool.Name, sdk.ZeroDec())
+	updatedPool.UpdateValueLocked(sdk.ZeroDec(), changedWeightedAmount)
 
 	// 5. Update farm pool
 	if updatedPool.TotalAccumulatedRewards.IsAllLT(rewards) {
@@ -1,6 +1,8 @@
 package farm
 
 import (
+	"strconv"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/farm/keeper"
 	"github.com/okex/exchain/x/farm/types"
@@ -17,11 +19,26 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 	}
 
 	// 1.2. check min lock amount
-	hasLocked := k.HasLockInfo(ctx, msg.Address, msg.PoolName)
+	lockInfo, hasLocked := k.GetLockInfo(ctx, msg.Address, msg.PoolName)
 	if !hasLocked && msg.Amount.Amount.LT(pool.MinLockAmount.Amount) {
 		return types.ErrLockAmountBelowMinimum(pool.MinLockAmount.Amount, msg.Amount.Amount).Result()
 	}
 
+	// 1.3 check the lock tier, which mustn't unlock the tokens locked before their unlock time
+	var lockTier types.LockTier
+	if msg.LockDuration != 0 {
+		var found bool
+		if lockTier, found = pool.GetLockTier(msg.LockDuration); !found {
+			return types.ErrInvalidLockDuration(msg.PoolName, msg.LockDuration).Result()
+		}
+		if hasLocked && lockInfo.UnlockTime > ctx.BlockTime().Unix()+msg.LockDuration {
+			return types.ErrLockDurationTooShort(lockInfo.UnlockTime).Result()
+		}
+	} else if hasLocked && lockInfo.IsLocked(ctx.BlockTime().Unix()) {
+		// the tokens added to the boosted tokens would be boosted by the lock tier without being locked
+		return types.ErrLockDurationRequired(lockInfo.UnlockTime).Result()
+	}
+
 	// 2. Calculate how many provided token & native token could be yielded in current period
 	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
 
@@ -30,7 +47,7 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 	if hasLocked {
 		// If it exists, withdraw money
 		var err error
-		rewards, err = k.WithdrawRewards(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens, msg.Address)
+		rewards, err = k.WithdrawRewards(ctx, pool.Name, pool.GetWeightedValueLocked(), yieldedTokens, msg.Address)
 		if err != nil {
 			return nil, err
 		}
@@ -41,10 +58,10 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 
 	} else {
 		// If it doesn't exist, only increase period
-		k.IncrementPoolPeriod(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens)
+		k.IncrementPoolPeriod(ctx, pool.Name, pool.GetWeightedValueLocked(), yieldedTokens)
 
 		// Create new lock info
-		lockInfo := types.NewLockInfo(
+		lockInfo = types.NewLockInfo(
 			msg.Address, pool.Name, sdk.NewDecCoinFromDec(pool.MinLockAmount.Denom, sdk.ZeroDec()),
 			ctx.BlockHeight(), 0,
 		)
@@ -52,8 +69,15 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 		k.SetAddressInFarmPool(ctx, msg.PoolName, msg.Address)
 	}
 
-	// 4. Update lock info
-	k.UpdateLockInfo(ctx, msg.Address, msg.PoolName, msg.Amount.Amount)
+	// 4. Update lock info, the whole locked amount is weighted by the new lock tier
+	if msg.LockDuration != 0 {
+		lockInfo, _ = k.GetLockInfo(ctx, msg.Address, msg.PoolName)
+		k.DeleteLockExpiry(ctx, lockInfo)
+		lockInfo.SetLockTier(lockTier, ctx.BlockTime().Unix())
+		k.SetLockInfo(ctx, lockInfo)
+		k.SetLockExpiry(ctx, lockInfo)
+	}
+	changedWeightedAmount := k.UpdateLockInfo(ctx, msg.Address, msg.PoolName, msg.Amount.Amount)
 
 	// 5. Send the locked-tokens from its own account to farm module account
 	if err := k.SupplyKeeper().SendCoinsFromAccountToModule(
@@ -63,7 +87,7 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 	}
 
 	// 6. Update farm pool
-	updatedPool.TotalValueLocked = updatedPool.TotalValueLocked.Add(msg.Amount)
+	updatedPool.UpdateValueLocked(msg.Amount.Amount, changedWeightedAmount)
 	k.SetFarmPool(ctx, updatedPool)
 
 	// 7. notify backend
@@ -76,6 +100,7 @@ func handleMsgLock(ctx sdk.Context, k keeper.Keeper, msg types.MsgLock) (*sdk.Re
 		sdk.NewAttribute(types.AttributeKeyAddress, msg.Address.String()),
 		sdk.NewAttribute(types.AttributeKeyPool, msg.PoolName),
 		sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyLockDuration, strconv.FormatInt(msg.LockDuration, 10)),
 	))
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
@@ -108,25 +133,43 @@ func handleMsgUnlock(ctx sdk.Context, k keeper.Keeper, msg types.MsgUnlock) (*sd
 		return types.ErrLockAmountBelowMinimum(pool.MinLockAmount.Amount, remainAmount).Result()
 	}
 
+	// 1.3 Check the unlock time, the tokens unlocked early forfeit the penalty if it's allowed
+	penalty := sdk.NewDecCoinFromDec(msg.Amount.Denom, sdk.ZeroDec())
+	if lockInfo.IsLocked(ctx.BlockTime().Unix()) {
+		if lockInfo.EarlyUnlockPenalty.IsNil() || !lockInfo.EarlyUnlockPenalty.IsPositive() {
+			return types.ErrLockNotExpired(lockInfo.UnlockTime).Result()
+		}
+		penalty.Amount = msg.Amount.Amount.MulTruncate(lockInfo.EarlyUnlockPenalty)
+	}
+
 	// 2. Calculate how many provided token & native token could be yielded in current period
 	updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
 
 	// 3. Withdraw money
-	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.TotalValueLocked, yieldedTokens, msg.Address)
+	rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.GetWeightedValueLocked(), yieldedTokens, msg.Address)
 	if err != nil {
 		return nil, err
 	}
 
 	// 4. Update the lock info
-	k.UpdateLockInfo(ctx, msg.Address, msg.PoolName, msg.Amount.Amount.Neg())
+	changedWeightedAmount := k.UpdateLockInfo(ctx, msg.Address, msg.PoolName, msg.Amount.Amount.Neg())
 
-	// 5. Send the locked-tokens from farm module account to its own account
-	if err = k.SupplyKeeper().SendCoinsFromModuleToAccount(ctx, ModuleName, msg.Address, msg.Amount.ToCoins()); err != nil {
+	// 5. Send the locked-tokens from farm module account to its own account, and the penalty to the fee collector
+	if err = k.SupplyKeeper().SendCoinsFromModuleToAccount(
+		ctx, ModuleName, msg.Address, msg.Amount.Sub(penalty).ToCoins(),
+	); err != nil {
 		return nil, types.ErrSendCoinsFromModuleToAccountFailed(err.Error())
 	}
+	if penalty.IsPositive() {
+		if err = k.SupplyKeeper().SendCoinsFromModuleToModule(
+			ctx, ModuleName, k.GetFeeCollector(), penalty.ToCoins(),
+		); err != nil {
+			return nil, types.ErrSendCoinsFromModuleToAccountFailed(err.Error())
+		}
+	}
 
 	// 6. Update farm pool
-	updatedPool.TotalValueLocked = updatedPool.TotalValueLocked.Sub(msg.Amount)
+	updatedPool.UpdateValueLocked(msg.Amount.Amount.Neg(), changedWeightedAmount)
 	if updatedPool.TotalAccumulatedRewards.IsAllLT(rewards) {
 		panic("should not happen")
 	}
@@ -141,6 +184,32 @@ func handleMsgUnlock(ctx sdk.Context, k keeper.Keeper, msg types.MsgUnlock) (*sd
 		sdk.NewAttribute(types.AttributeKeyAddress, msg.Address.String()),
 		sdk.NewAttribute(types.AttributeKeyPool, msg.PoolName),
 		sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyPenalty, penalty.String()),
 	))
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+// rebaseExpiredLocks removes the expired lock tiers, so the boost stops at the unlock time instead of the next action
+// of the locker. The rewards earned with the boost are withdrawn to the locker like a claim
+func rebaseExpiredLocks(ctx sdk.Context, k keeper.Keeper) {
+	for _, lockInfo := range k.GetExpiredLockInfos(ctx, ctx.BlockTime().Unix()) {
+		pool, found := k.GetFarmPool(ctx, lockInfo.PoolName)
+		if !found {
+			panic("should not happen")
+		}
+		updatedPool, yieldedTokens := k.CalculateAmountYieldedBetween(ctx, pool)
+		rewards, err := k.WithdrawRewards(ctx, pool.Name, pool.GetWeightedValueLocked(), yieldedTokens, lockInfo.Owner)
+		if err != nil {
+			panic(err)
+		}
+		changedWeightedAmount := k.UpdateLockInfo(ctx, lockInfo.Owner, lockInfo.PoolName, sdk.ZeroDec())
+
+		updatedPool.UpdateValueLocked(sdk.ZeroDec(), changedWeightedAmount)
+		if updatedPool.TotalAccumulatedRewards.IsAllLT(rewards) {
+			panic("should not happen")
+		}
+		updatedPool.TotalAccumulatedRewards = updatedPool.TotalAccumulatedRewards.Sub(rewards)
+		k.SetFarmPool(ctx, updatedPool)
+		k.OnClaim(ctx, lockInfo.Owner, pool.Name, rewards)
+	}
+}
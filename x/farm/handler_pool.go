@@ -1,6 +1,8 @@
 package farm
 
 import (
+	"fmt"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/farm/keeper"
@@ -83,10 +85,11 @@ func handleMsgDestroyPool(ctx sdk.Context, k keeper.Keeper, msg types.MsgDestroy
 		return types.ErrPoolNotFinished(msg.PoolName).Result()
 	}
 
-	// 3. give remaining rewards to the owner of pool
-	if !updatedPool.TotalAccumulatedRewards.IsZero() {
+	// 3. give remaining rewards and the idle yielded tokens to the owner of pool
+	refund := updatedPool.TotalAccumulatedRewards.Add2(updatedPool.IdleYieldedTokens())
+	if !refund.IsZero() {
 		err := k.SupplyKeeper().SendCoinsFromModuleToAccount(
-			ctx, YieldFarmingAccount, msg.Owner, updatedPool.TotalAccumulatedRewards,
+			ctx, YieldFarmingAccount, msg.Owner, refund,
 		)
 		if err != nil {
 			return nil, common.ErrInsufficientCoins(DefaultParamspace, err.Error())
@@ -122,3 +125,26 @@ func handleMsgDestroyPool(ctx sdk.Context, k keeper.Keeper, msg types.MsgDestroy
 	))
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+func handleMsgSetLockTiers(ctx sdk.Context, k keeper.Keeper, msg types.MsgSetLockTiers) (*sdk.Result, error) {
+	pool, found := k.GetFarmPool(ctx, msg.PoolName)
+	if !found {
+		return types.ErrNoFarmPoolFound(msg.PoolName).Result()
+	}
+
+	if !pool.Owner.Equals(msg.Owner) {
+		return types.ErrInvalidPoolOwner(msg.Owner.String(), msg.PoolName).Result()
+	}
+
+	// the tokens locked before keep their lock tiers until the unlock time
+	pool.LockTiers = msg.LockTiers
+	k.SetFarmPool(ctx, pool)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetLockTiers,
+		sdk.NewAttribute(types.AttributeKeyAddress, msg.Owner.String()),
+		sdk.NewAttribute(types.AttributeKeyPool, msg.PoolName),
+		sdk.NewAttribute(types.AttributeKeyLockTiers, fmt.Sprintf("%v", msg.LockTiers)),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/okex/exchain/x/common"
 
@@ -518,7 +519,7 @@ func TestHandlerMsgProvide(t *testing.T) {
 			expectedErr:  types.ErrNoFarmPoolFound("abc"),
 		},
 		{
-			caseName: "failed. The coin name should be %s, not %s",
+			caseName: "failed. Token %s does not exist",
 			preExec:  preExec,
 			getMsg: func(tCtx *testContext, preData interface{}) sdk.Msg {
 				provideMsg := normalGetProvideMsg(tCtx, preData).(types.MsgProvide)
@@ -526,7 +527,7 @@ func TestHandlerMsgProvide(t *testing.T) {
 				return provideMsg
 			},
 			verification: verification,
-			expectedErr:  types.ErrInvalidDenom("aab", "fff"),
+			expectedErr:  types.ErrTokenNotExist("fff"),
 		},
 		{
 			caseName: "failed. The remaining amount is %s, so it's not enable to provide token repeatedly util amount become zero",
@@ -560,6 +561,203 @@ func TestHandlerMsgProvide(t *testing.T) {
 	testCaseTest(t, tests)
 }
 
+func TestHandlerMultipleYieldedTokens(t *testing.T) {
+	tCtx := initEnvironment(t)
+	createPoolMsg := createPool(t, tCtx)
+	owner := createPoolMsg.Owner
+	poolName := createPoolMsg.PoolName
+	yieldedSymbol := createPoolMsg.YieldedSymbol
+	otherSymbol := tCtx.swapTokenPairs[0].QuotePooledCoin.Denom
+	height := tCtx.ctx.BlockHeight()
+
+	// 10 blocks of 5aab per block, then 2aab per block till the end height
+	provideMsg := types.NewMsgProvideWithEmissionSchedule(poolName, owner,
+		sdk.NewDecCoinFromDec(yieldedSymbol, sdk.NewDec(100)), sdk.NewDec(5), height+1, height+21,
+		[]types.EmissionPhase{types.NewEmissionPhase(height+11, sdk.NewDec(2))})
+	_, err := tCtx.handler(tCtx.ctx, provideMsg)
+	require.Nil(t, err)
+	// another token yielded at the same time
+	provideMsg = types.NewMsgProvideWithEmissionSchedule(poolName, owner,
+		sdk.NewDecCoinFromDec(otherSymbol, sdk.NewDec(100)), sdk.NewDec(2), height+1, height+41, nil)
+	_, err = tCtx.handler(tCtx.ctx, provideMsg)
+	require.Nil(t, err)
+	// the provided token can't be provided again while it's yielding
+	_, err = tCtx.handler(tCtx.ctx, provideMsg)
+	require.NotNil(t, err)
+
+	tCtx.ctx = tCtx.ctx.WithBlockHeight(height + 1)
+	lock(t, tCtx, createPoolMsg)
+
+	// the only locker gets all the yielded tokens by the schedules
+	tCtx.ctx = tCtx.ctx.WithBlockHeight(height + 31)
+	preCoins := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, owner)
+	unlock(t, tCtx, createPoolMsg)
+	afterCoins := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, owner)
+	require.Equal(t, sdk.NewDec(70), afterCoins.AmountOf(yieldedSymbol).Sub(preCoins.AmountOf(yieldedSymbol)))
+	require.Equal(t, sdk.NewDec(60), afterCoins.AmountOf(otherSymbol).Sub(preCoins.AmountOf(otherSymbol)))
+
+	// the remaining amount of the ended token is idle and can be provided again
+	pool, found := tCtx.k.GetFarmPool(tCtx.ctx, poolName)
+	require.True(t, found)
+	require.Equal(t, 2, len(pool.YieldedTokenInfos))
+	require.False(t, pool.YieldedTokenInfos[0].IsYielding())
+	require.Equal(t, sdk.NewDec(30), pool.YieldedTokenInfos[0].RemainingAmount.Amount)
+	provideMsg = types.NewMsgProvideWithEmissionSchedule(poolName, owner,
+		sdk.NewDecCoinFromDec(yieldedSymbol, sdk.NewDec(10)), sdk.NewDec(1), height+32, height+34, nil)
+	_, err = tCtx.handler(tCtx.ctx, provideMsg)
+	require.Nil(t, err)
+	pool, _ = tCtx.k.GetFarmPool(tCtx.ctx, poolName)
+	require.Equal(t, sdk.NewDec(40), pool.YieldedTokenInfos[0].RemainingAmount.Amount)
+
+	// the pool with the idle tokens is finished, which are returned to the owner with the rewards accumulated
+	// without locked tokens
+	tCtx.ctx = tCtx.ctx.WithBlockHeight(height + 50)
+	preCoins = tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, owner)
+	destroyPool(t, tCtx, createPoolMsg)
+	afterCoins = tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, owner)
+	require.Equal(t, sdk.NewDec(40), afterCoins.AmountOf(yieldedSymbol).Sub(preCoins.AmountOf(yieldedSymbol)))
+	require.Equal(t, sdk.NewDec(40), afterCoins.AmountOf(otherSymbol).Sub(preCoins.AmountOf(otherSymbol)))
+}
+
+func TestHandlerLockTiers(t *testing.T) {
+	tCtx := initEnvironment(t)
+	createPoolMsg := createPool(t, tCtx)
+	owner := createPoolMsg.Owner
+	poolName := createPoolMsg.PoolName
+	lpDenom := createPoolMsg.MinLockAmount.Denom
+	yieldedSymbol := createPoolMsg.YieldedSymbol
+	var now int64 = 1000
+	tCtx.ctx = tCtx.ctx.WithBlockTime(time.Unix(now, 0))
+
+	// only the owner of the pool can set the lock tiers
+	lockTiers := []types.LockTier{
+		types.NewLockTier(100, sdk.NewDec(2), sdk.ZeroDec()),
+		types.NewLockTier(1000, sdk.NewDec(3), sdk.NewDecWithPrec(5, 1)),
+	}
+	_, err := tCtx.handler(tCtx.ctx, types.NewMsgSetLockTiers(poolName, tCtx.addrList[0], lockTiers))
+	require.Equal(t, types.ErrInvalidPoolOwner(tCtx.addrList[0].String(), poolName).Error(), err.Error())
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgSetLockTiers(poolName, owner, lockTiers))
+	require.Nil(t, err)
+	provide(t, tCtx, createPoolMsg)
+
+	// the duration must be one of the lock tiers
+	amount := sdk.NewDecCoinFromDec(lpDenom, sdk.OneDec())
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgLockWithDuration(poolName, owner, amount, 50))
+	require.Equal(t, types.ErrInvalidLockDuration(poolName, 50).Error(), err.Error())
+
+	// the owner locks with the multiplier 2, and the other address locks without a lock tier
+	tCtx.ctx = tCtx.ctx.WithBlockHeight(tCtx.ctx.BlockHeight() + 1)
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgLockWithDuration(poolName, owner, amount, 100))
+	require.Nil(t, err)
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgLock(poolName, tCtx.addrList[0], amount))
+	require.Nil(t, err)
+	pool, found := tCtx.k.GetFarmPool(tCtx.ctx, poolName)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(2), pool.TotalValueLocked.Amount)
+	require.Equal(t, sdk.NewDec(3), pool.GetWeightedValueLocked().Amount)
+
+	// the boosted locker earns twice as much as the other one
+	tCtx.ctx = tCtx.ctx.WithBlockHeight(tCtx.ctx.BlockHeight() + 3).WithBlockTime(time.Unix(now+50, 0))
+	ownerRewards := claimRewards(t, tCtx, poolName, owner, yieldedSymbol)
+	otherRewards := claimRewards(t, tCtx, poolName, tCtx.addrList[0], yieldedSymbol)
+	require.True(t, ownerRewards.IsPositive())
+	require.Equal(t, otherRewards.MulInt64(2), ownerRewards)
+
+	// the tokens can't be unlocked early without the penalty of the lock tier
+	lockInfo, found := tCtx.k.GetLockInfo(tCtx.ctx, owner, poolName)
+	require.True(t, found)
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgUnlock(poolName, owner, amount))
+	require.Equal(t, types.ErrLockNotExpired(lockInfo.UnlockTime).Error(), err.Error())
+
+	// the boost is reset once the lock expires
+	tCtx.ctx = tCtx.ctx.WithBlockTime(time.Unix(now+101, 0))
+	claimRewards(t, tCtx, poolName, owner, yieldedSymbol)
+	lockInfo, _ = tCtx.k.GetLockInfo(tCtx.ctx, owner, poolName)
+	require.False(t, lockInfo.IsLocked(tCtx.ctx.BlockTime().Unix()))
+	require.Equal(t, sdk.OneDec(), lockInfo.GetMultiplier())
+	pool, _ = tCtx.k.GetFarmPool(tCtx.ctx, poolName)
+	require.Equal(t, sdk.NewDec(2), pool.GetWeightedValueLocked().Amount)
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgUnlock(poolName, owner, amount))
+	require.Nil(t, err)
+
+	// the tokens unlocked early forfeit the penalty to the fee collector
+	locker := tCtx.addrList[1]
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgLockWithDuration(poolName, locker,
+		sdk.NewDecCoinFromDec(lpDenom, sdk.NewDec(2)), 1000))
+	require.Nil(t, err)
+	// the lock duration can't be shortened before the unlock time
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgLockWithDuration(poolName, locker, amount, 100))
+	require.Equal(t, types.ErrLockDurationTooShort(now+101+1000).Error(), err.Error())
+	feeCollector := tCtx.mockKeeper.SupplyKeeper.GetModuleAddress(tCtx.k.GetFeeCollector())
+	preFees := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, feeCollector).AmountOf(lpDenom)
+	preCoins := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, locker).AmountOf(lpDenom)
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgUnlock(poolName, locker, amount))
+	require.Nil(t, err)
+	afterFees := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, feeCollector).AmountOf(lpDenom)
+	afterCoins := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, locker).AmountOf(lpDenom)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), afterCoins.Sub(preCoins))
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), afterFees.Sub(preFees))
+	pool, _ = tCtx.k.GetFarmPool(tCtx.ctx, poolName)
+	require.Equal(t, sdk.NewDec(2), pool.TotalValueLocked.Amount)
+	require.Equal(t, sdk.NewDec(4), pool.GetWeightedValueLocked().Amount)
+}
+
+func TestHandlerLockExpiry(t *testing.T) {
+	tCtx := initEnvironment(t)
+	createPoolMsg := createPool(t, tCtx)
+	owner := createPoolMsg.Owner
+	poolName := createPoolMsg.PoolName
+	lpDenom := createPoolMsg.MinLockAmount.Denom
+	yieldedSymbol := createPoolMsg.YieldedSymbol
+	var now int64 = 1000
+	tCtx.ctx = tCtx.ctx.WithBlockTime(time.Unix(now, 0))
+	_, err := tCtx.handler(tCtx.ctx, types.NewMsgSetLockTiers(poolName, owner,
+		[]types.LockTier{types.NewLockTier(100, sdk.NewDec(2), sdk.ZeroDec())}))
+	require.Nil(t, err)
+	height := tCtx.ctx.BlockHeight()
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgProvide(poolName, owner,
+		sdk.NewDecCoinFromDec(yieldedSymbol, sdk.NewDec(100)), sdk.OneDec(), height+1))
+	require.Nil(t, err)
+
+	// the owner locks with the multiplier 2, and the other address locks without a lock tier
+	tCtx.ctx = tCtx.ctx.WithBlockHeight(height + 1)
+	amount := sdk.NewDecCoinFromDec(lpDenom, sdk.OneDec())
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgLockWithDuration(poolName, owner, amount, 100))
+	require.Nil(t, err)
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgLock(poolName, tCtx.addrList[0], amount))
+	require.Nil(t, err)
+
+	// the tokens added to the boosted tokens must be locked with a lock duration
+	_, err = tCtx.handler(tCtx.ctx, types.NewMsgLock(poolName, owner, amount))
+	require.Equal(t, types.ErrLockDurationRequired(now+100).Error(), err.Error())
+
+	// the lock expires without any action of the owner, the boosted rewards of 3 blocks are withdrawn to the owner
+	tCtx.ctx = tCtx.ctx.WithBlockHeight(height + 4).WithBlockTime(time.Unix(now+100, 0))
+	preCoins := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, owner)
+	BeginBlocker(tCtx.ctx, abci.RequestBeginBlock{Header: abci.Header{Height: height + 4}}, tCtx.k)
+	afterCoins := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, owner)
+	require.Equal(t, sdk.NewDec(2), afterCoins.AmountOf(yieldedSymbol).Sub(preCoins.AmountOf(yieldedSymbol)))
+	require.Empty(t, tCtx.k.GetExpiredLockInfos(tCtx.ctx, now+100))
+	lockInfo, found := tCtx.k.GetLockInfo(tCtx.ctx, owner, poolName)
+	require.True(t, found)
+	require.Equal(t, sdk.OneDec(), lockInfo.GetMultiplier())
+	pool, _ := tCtx.k.GetFarmPool(tCtx.ctx, poolName)
+	require.Equal(t, sdk.NewDec(2), pool.GetWeightedValueLocked().Amount)
+
+	// the rewards of the next 6 blocks are unboosted
+	tCtx.ctx = tCtx.ctx.WithBlockHeight(height + 10).WithBlockTime(time.Unix(now+130, 0))
+	require.Equal(t, sdk.NewDec(3), claimRewards(t, tCtx, poolName, owner, yieldedSymbol))
+	require.Equal(t, sdk.NewDec(4), claimRewards(t, tCtx, poolName, tCtx.addrList[0], yieldedSymbol))
+}
+
+func claimRewards(t *testing.T, tCtx *testContext, poolName string, addr sdk.AccAddress, denom string) sdk.Dec {
+	preCoins := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, addr)
+	_, err := tCtx.handler(tCtx.ctx, types.NewMsgClaim(poolName, addr))
+	require.Nil(t, err)
+	afterCoins := tCtx.k.TokenKeeper().GetCoins(tCtx.ctx, addr)
+	return afterCoins.AmountOf(denom).Sub(preCoins.AmountOf(denom))
+}
+
 func TestHandlerMsgLock(t *testing.T) {
 	var preExec preExecFunc = func(t *testing.T, tCtx *testContext) interface{} {
 		// create pool
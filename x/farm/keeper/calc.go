@@ -6,14 +6,15 @@ import (
 )
 
 // CalculateAmountYieldedBetween is used for calculating how many tokens haven been yielded from
-// startBlockHeight to endBlockHeight. And return the amount.
+// startBlockHeight to endBlockHeight by the emission schedules. And return the amount.
 func (k Keeper) CalculateAmountYieldedBetween(ctx sdk.Context, pool types.FarmPool) (types.FarmPool, sdk.SysCoins) {
 	currentPeriod := k.GetPoolCurrentRewards(ctx, pool.Name)
 	endBlockHeight := ctx.BlockHeight()
 
 	totalYieldedTokens := sdk.SysCoins{}
 	for i := 0; i < len(pool.YieldedTokenInfos); i++ {
-		startBlockHeightToYield := pool.YieldedTokenInfos[i].StartBlockHeightToYield
+		yieldedTokenInfo := pool.YieldedTokenInfos[i]
+		startBlockHeightToYield := yieldedTokenInfo.StartBlockHeightToYield
 		var startBlockHeight int64
 		if currentPeriod.StartBlockHeight <= startBlockHeightToYield {
 			startBlockHeight = startBlockHeightToYield
@@ -28,12 +29,19 @@ func (k Keeper) CalculateAmountYieldedBetween(ctx sdk.Context, pool types.FarmPo
 
 		yieldedTokens := sdk.SysCoins{}
 		// calculate how many tokens to be yielded between startBlockHeight and endBlockHeight
-		blockInterval := sdk.NewDec(endBlockHeight - startBlockHeight)
-		amount := blockInterval.MulTruncate(pool.YieldedTokenInfos[i].AmountYieldedPerBlock)
-		remaining := pool.YieldedTokenInfos[i].RemainingAmount
+		amount := yieldedTokenInfo.CalculateAmountYielded(startBlockHeight, endBlockHeight)
+		remaining := yieldedTokenInfo.RemainingAmount
 		if amount.LT(remaining.Amount) {
 			pool.YieldedTokenInfos[i].RemainingAmount.Amount = remaining.Amount.Sub(amount)
-			yieldedTokens = sdk.NewDecCoinsFromDec(remaining.Denom, amount)
+			if amount.IsPositive() {
+				yieldedTokens = sdk.NewDecCoinsFromDec(remaining.Denom, amount)
+			}
+			// the remaining amount left after the end of the schedule is idle until it's provided again
+			if yieldedTokenInfo.IsEnded(endBlockHeight) {
+				pool.YieldedTokenInfos[i] = types.NewYieldedTokenInfo(
+					pool.YieldedTokenInfos[i].RemainingAmount, 0, sdk.ZeroDec(),
+				)
+			}
 		} else {
 			pool.YieldedTokenInfos[i] = types.NewYieldedTokenInfo(
 				sdk.NewDecCoin(remaining.Denom, sdk.ZeroInt()), 0, sdk.ZeroDec(),
@@ -73,7 +81,8 @@ func (k Keeper) WithdrawRewards(
 	return rewards, nil
 }
 
-// IncrementPoolPeriod increments pool period, returning the period just ended
+// IncrementPoolPeriod increments pool period, returning the period just ended. The rewards are shared by the locked
+// amounts weighted by their lock tiers
 func (k Keeper) IncrementPoolPeriod(
 	ctx sdk.Context, poolName string, totalValueLocked sdk.SysCoin, yieldedTokens sdk.SysCoins,
 ) uint64 {
@@ -137,11 +146,12 @@ func (k Keeper) calculateRewards(
 	}
 
 	startingPeriod := lockInfo.ReferencePeriod
-	// calculate rewards for final period
-	return k.calculateLockRewardsBetween(ctx, poolName, startingPeriod, endingPeriod, lockInfo.Amount)
+	// calculate rewards for final period by the weighted amount
+	return k.calculateLockRewardsBetween(ctx, poolName, startingPeriod, endingPeriod, lockInfo.GetWeightedAmount())
 }
 
-// calculateLockRewardsBetween calculate the rewards accrued by a pool between two periods
+// calculateLockRewardsBetween calculate the rewards accrued by a pool between two periods, the amount is weighted by
+// the multiplier of its lock tier
 func (k Keeper) calculateLockRewardsBetween(ctx sdk.Context, poolName string, startingPeriod, endingPeriod uint64,
 	amount sdk.SysCoin) (rewards sdk.SysCoins) {
 
@@ -162,8 +172,9 @@ func (k Keeper) calculateLockRewardsBetween(ctx sdk.Context, poolName string, st
 	return
 }
 
-// UpdateLockInfo updates lock info for the modified lock info
-func (k Keeper) UpdateLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName string, changedAmount sdk.Dec) {
+// UpdateLockInfo updates lock info for the modified lock info, and returns the change of its weighted amount. The lock
+// tier expired is removed
+func (k Keeper) UpdateLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName string, changedAmount sdk.Dec) sdk.Dec {
 	// period has already been incremented - we want to store the period ended by this lock action
 	previousPeriod := k.GetPoolCurrentRewards(ctx, poolName).Period - 1
 
@@ -172,9 +183,16 @@ func (k Keeper) UpdateLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName st
 	if !found {
 		panic("the lock info can't be found")
 	}
+	previousWeightedAmount := lockInfo.GetWeightedAmount().Amount
 	lockInfo.StartBlockHeight = ctx.BlockHeight()
 	lockInfo.ReferencePeriod = previousPeriod
+	// the lock info leaves the expiry queue once its lock tier is removed
+	if !lockInfo.IsLocked(ctx.BlockTime().Unix()) || lockInfo.Amount.Amount.Add(changedAmount).IsZero() {
+		k.DeleteLockExpiry(ctx, lockInfo)
+	}
+	lockInfo.ResetExpiredLock(ctx.BlockTime().Unix())
 	lockInfo.Amount.Amount = lockInfo.Amount.Amount.Add(changedAmount)
+	lockInfo.WeightedAmount = lockInfo.Amount.Amount.MulTruncate(lockInfo.GetMultiplier())
 	if lockInfo.Amount.IsZero() {
 		k.DeleteLockInfo(ctx, lockInfo.Owner, lockInfo.PoolName)
 		k.DeleteAddressInFarmPool(ctx, lockInfo.PoolName, lockInfo.Owner)
@@ -186,4 +204,5 @@ func (k Keeper) UpdateLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName st
 		k.SetLockInfo(ctx, lockInfo)
 		k.SetAddressInFarmPool(ctx, lockInfo.PoolName, lockInfo.Owner)
 	}
+	return lockInfo.WeightedAmount.Sub(previousWeightedAmount)
 }
@@ -131,6 +131,38 @@ func (k Keeper) DeleteLockInfo(ctx sdk.Context, addr sdk.AccAddress, poolName st
 	store.Delete(types.GetLockInfoKey(addr, poolName))
 }
 
+// SetLockExpiry queues the lock info by its unlock time, the lock info without a lock tier isn't queued
+func (k Keeper) SetLockExpiry(ctx sdk.Context, lockInfo types.LockInfo) {
+	if lockInfo.UnlockTime == 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetLockExpiryQueueKey(lockInfo.UnlockTime, lockInfo.Owner, lockInfo.PoolName), []byte(""))
+}
+
+// DeleteLockExpiry removes the lock info from the queue of its unlock time
+func (k Keeper) DeleteLockExpiry(ctx sdk.Context, lockInfo types.LockInfo) {
+	if lockInfo.UnlockTime == 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetLockExpiryQueueKey(lockInfo.UnlockTime, lockInfo.Owner, lockInfo.PoolName))
+}
+
+// GetExpiredLockInfos gets the lock infos whose lock tiers expire at or before the block time
+func (k Keeper) GetExpiredLockInfos(ctx sdk.Context, blockTime int64) (lockInfos []types.LockInfo) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.LockExpiryQueuePrefix, types.GetLockExpiryQueueTimeKey(blockTime+1))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		addr, poolName := types.SplitLockExpiryQueueKey(iter.Key())
+		if lockInfo, found := k.GetLockInfo(ctx, addr, poolName); found {
+			lockInfos = append(lockInfos, lockInfo)
+		}
+	}
+	return lockInfos
+}
+
 // GetPoolLockedValue gets the value of locked tokens in pool priced in quote symbol
 func (k Keeper) GetPoolLockedValue(ctx sdk.Context, pool types.FarmPool) sdk.Dec {
 	if pool.TotalValueLocked.Amount.LTE(sdk.ZeroDec()) {
@@ -168,6 +200,45 @@ func (k Keeper) calculateLockedLPTValue(
 	return quote0TokenAmt.Add(quote1TokenAmt)
 }
 
+// GetPoolAPR projects the APR of every token yielded by the pool by its emission schedule over the next year from the
+// current height, capped by its remaining amount. The yielded tokens and the locked tokens are priced in quote symbol
+func (k Keeper) GetPoolAPR(ctx sdk.Context, pool types.FarmPool) types.PoolAPR {
+	updatedPool, _ := k.CalculateAmountYieldedBetween(ctx, pool)
+	quoteSymbol := k.GetParams(ctx).QuoteSymbol
+	swapParams := k.swapKeeper.GetParams(ctx)
+	poolAPR := types.PoolAPR{
+		PoolName:    pool.Name,
+		QuoteSymbol: quoteSymbol,
+		LockedValue: k.GetPoolLockedValue(ctx, updatedPool),
+		TokenAPRs:   []types.YieldedTokenAPR{},
+		TotalAPR:    sdk.ZeroDec(),
+	}
+
+	height := ctx.BlockHeight()
+	for _, yieldedTokenInfo := range updatedPool.YieldedTokenInfos {
+		if !yieldedTokenInfo.IsYielding() {
+			continue
+		}
+		amount := yieldedTokenInfo.CalculateAmountYielded(height, height+types.BlocksPerYear)
+		amount = sdk.MinDec(amount, yieldedTokenInfo.RemainingAmount.Amount)
+		value := k.calculateBaseValueInQuote(ctx,
+			sdk.NewDecCoinFromDec(yieldedTokenInfo.RemainingAmount.Denom, amount), quoteSymbol, swapParams)
+		apr := sdk.ZeroDec()
+		if poolAPR.LockedValue.IsPositive() {
+			apr = value.Quo(poolAPR.LockedValue)
+		}
+		poolAPR.TokenAPRs = append(poolAPR.TokenAPRs, types.YieldedTokenAPR{
+			Denom:                 yieldedTokenInfo.RemainingAmount.Denom,
+			AmountYieldedPerBlock: yieldedTokenInfo.GetAmountYieldedPerBlock(height),
+			AmountYieldedPerYear:  amount,
+			ValuePerYear:          value,
+			APR:                   apr,
+		})
+		poolAPR.TotalAPR = poolAPR.TotalAPR.Add(apr)
+	}
+	return poolAPR
+}
+
 // calculate base token value denominated in quote token
 func (k Keeper) calculateBaseValueInQuote(
 	ctx sdk.Context, base sdk.SysCoin, quoteSymbol string, params swaptypes.Params,
@@ -132,3 +132,47 @@ func TestReadWriteFarmPool(t *testing.T) {
 	_, found = keeper.Keeper.GetFarmPool(ctx, poolName)
 	require.False(t, found)
 }
+
+func TestGetPoolAPR(t *testing.T) {
+	ctx, keeper := GetKeeper(t)
+	keeper.swapKeeper.SetParams(ctx, swaptypes.DefaultParams())
+	ctx = ctx.WithBlockHeight(100)
+	quoteSymbol := keeper.Keeper.GetParams(ctx).QuoteSymbol
+	keeper.Keeper.SetPoolCurrentRewards(ctx, "pool", types.NewPoolCurrentRewards(100, 1, sdk.SysCoins{}))
+
+	quoteInfo := types.NewYieldedTokenInfo(sdk.NewDecCoinFromDec(quoteSymbol, sdk.NewDec(100000000)), 101,
+		sdk.NewDec(1))
+	quoteInfo.EmissionPhases = []types.EmissionPhase{types.NewEmissionPhase(201, sdk.NewDec(2))}
+	// the yielded amount is capped by the remaining amount
+	cappedInfo := types.NewYieldedTokenInfo(sdk.NewDecCoinFromDec(quoteSymbol, sdk.NewDec(100)), 101, sdk.NewDec(1))
+	// the token without a swap token pair with the quote symbol is worthless
+	unpricedInfo := types.NewYieldedTokenInfo(sdk.NewDecCoinFromDec("xxb", sdk.NewDec(100)), 101, sdk.NewDec(1))
+	// the idle token isn't projected
+	idleInfo := types.NewYieldedTokenInfo(sdk.NewDecCoinFromDec("yyb", sdk.NewDec(100)), 0, sdk.ZeroDec())
+	pool := types.FarmPool{
+		Name:              "pool",
+		MinLockAmount:     sdk.NewDecCoinFromDec(quoteSymbol, sdk.ZeroDec()),
+		TotalValueLocked:  sdk.NewDecCoinFromDec(quoteSymbol, sdk.NewDec(1000)),
+		YieldedTokenInfos: types.YieldedTokenInfos{quoteInfo, cappedInfo, unpricedInfo, idleInfo},
+	}
+
+	poolAPR := keeper.Keeper.GetPoolAPR(ctx, pool)
+	require.Equal(t, quoteSymbol, poolAPR.QuoteSymbol)
+	require.Equal(t, sdk.NewDec(1000), poolAPR.LockedValue)
+	require.Equal(t, 3, len(poolAPR.TokenAPRs))
+
+	// it starts yielding at the next block, 100 blocks of 1 per block, then 2 per block for the rest of the year
+	amountPerYear := sdk.NewDec(100 + 2*(types.BlocksPerYear-101))
+	require.Equal(t, amountPerYear, poolAPR.TokenAPRs[0].AmountYieldedPerYear)
+	require.Equal(t, amountPerYear.QuoInt64(1000), poolAPR.TokenAPRs[0].APR)
+	require.Equal(t, sdk.ZeroDec(), poolAPR.TokenAPRs[0].AmountYieldedPerBlock)
+	require.Equal(t, sdk.MustNewDecFromStr("0.1"), poolAPR.TokenAPRs[1].APR)
+	require.Equal(t, "xxb", poolAPR.TokenAPRs[2].Denom)
+	require.Equal(t, sdk.ZeroDec(), poolAPR.TokenAPRs[2].APR)
+	require.Equal(t, poolAPR.TokenAPRs[0].APR.Add(poolAPR.TokenAPRs[1].APR), poolAPR.TotalAPR)
+
+	// no locked value
+	pool.TotalValueLocked = sdk.NewDecCoinFromDec(quoteSymbol, sdk.ZeroDec())
+	poolAPR = keeper.Keeper.GetPoolAPR(ctx, pool)
+	require.Equal(t, sdk.ZeroDec(), poolAPR.TotalAPR)
+}
@@ -11,6 +11,7 @@ func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
 	ir.RegisterRoute(types.ModuleName, "module-account", moduleAccountInvariant(k))
 	ir.RegisterRoute(types.ModuleName, "yield-farming-account", yieldFarmingAccountInvariant(k))
 	ir.RegisterRoute(types.ModuleName, "mint-farming-account", mintFarmingAccountInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "weighted-value-locked", weightedValueLockedInvariant(k))
 }
 
 // moduleAccountInvariant checks if farm ModuleAccount is consistent with the sum of deposit amount
@@ -94,3 +95,36 @@ func mintFarmingAccountInvariant(k Keeper) sdk.Invariant {
 				moduleAcc.GetCoins(), whiteLists)), broken
 	}
 }
+
+// weightedValueLockedInvariant checks if the total weighted value locked of every pool is consistent with the sum of
+// the weighted amounts of its lock infos
+func weightedValueLockedInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		// iterate all lock infos, then calculate the weighted amount locked in every pool
+		weightedAmounts := make(map[string]sdk.Dec)
+		k.IterateAllLockInfos(ctx, func(lockInfo types.LockInfo) (stop bool) {
+			weightedAmount, ok := weightedAmounts[lockInfo.PoolName]
+			if !ok {
+				weightedAmount = sdk.ZeroDec()
+			}
+			weightedAmounts[lockInfo.PoolName] = weightedAmount.Add(lockInfo.GetWeightedAmount().Amount)
+			return false
+		})
+
+		var msg string
+		broken := false
+		for _, pool := range k.GetFarmPools(ctx) {
+			weightedAmount, ok := weightedAmounts[pool.Name]
+			if !ok {
+				weightedAmount = sdk.ZeroDec()
+			}
+			if !pool.GetWeightedValueLocked().Amount.Equal(weightedAmount) {
+				broken = true
+				msg += fmt.Sprintf("	pool %s: expected total weighted value locked: %s, actual: %s\n",
+					pool.Name, weightedAmount, pool.GetWeightedValueLocked().Amount)
+			}
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, "total weighted value locked", msg), broken
+	}
+}
@@ -17,4 +17,6 @@ func TestInvariants(t *testing.T) {
 	require.False(t, broken)
 	_, broken = mintFarmingAccountInvariant(keeper.Keeper)(ctx)
 	require.False(t, broken)
+	_, broken = weightedValueLockedInvariant(keeper.Keeper)(ctx)
+	require.False(t, broken)
 }
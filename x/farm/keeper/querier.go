@@ -36,6 +36,8 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return queryAccountsLockedTo(ctx, req, k)
 		case types.QueryPoolNum:
 			return queryPoolNum(ctx, k)
+		case types.QueryPoolAPR:
+			return queryPoolAPR(ctx, req, k)
 		default:
 			return nil, types.ErrUnknownFarmQueryType("failed. unknown farm query endpoint")
 		}
@@ -64,6 +66,26 @@ func queryPool(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Er
 	return res, nil
 }
 
+func queryPoolAPR(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryPoolParams
+
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, defaultQueryErrParseParams(err)
+	}
+
+	pool, found := k.GetFarmPool(ctx, params.PoolName)
+	if !found {
+		return nil, types.ErrNoFarmPoolFound(params.PoolName)
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, k.GetPoolAPR(ctx, pool))
+	if err != nil {
+		return nil, defaultQueryErrJSONMarshal(err)
+	}
+
+	return res, nil
+}
+
 // support query by page && limit
 func queryPools(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
 	var params types.QueryPoolsParams
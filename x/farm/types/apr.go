@@ -0,0 +1,53 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// BlocksPerYear is the number of blocks in a year by the block interval of 3 seconds, which projects the APR
+const BlocksPerYear = 365 * 24 * 60 * 60 / 3
+
+// YieldedTokenAPR is the projected APR of a token yielded by a pool
+type YieldedTokenAPR struct {
+	Denom                 string  `json:"denom"`
+	AmountYieldedPerBlock sdk.Dec `json:"amount_yielded_per_block"`
+	AmountYieldedPerYear  sdk.Dec `json:"amount_yielded_per_year"`
+	ValuePerYear          sdk.Dec `json:"value_per_year"`
+	APR                   sdk.Dec `json:"apr"`
+}
+
+// String returns a human readable string representation of a YieldedTokenAPR
+func (ta YieldedTokenAPR) String() string {
+	return fmt.Sprintf(`YieldedTokenAPR:
+  Denom:							%s
+  Amount Yielded Per Block:			%s
+  Amount Yielded Per Year:			%s
+  Value Per Year:					%s
+  APR:								%s`,
+		ta.Denom, ta.AmountYieldedPerBlock, ta.AmountYieldedPerYear, ta.ValuePerYear, ta.APR)
+}
+
+// PoolAPR is the projected APRs of the tokens yielded by a pool, the values are priced in the quote symbol
+type PoolAPR struct {
+	PoolName    string            `json:"pool_name"`
+	QuoteSymbol string            `json:"quote_symbol"`
+	LockedValue sdk.Dec           `json:"locked_value"`
+	TokenAPRs   []YieldedTokenAPR `json:"token_aprs"`
+	TotalAPR    sdk.Dec           `json:"total_apr"`
+}
+
+// String returns a human readable string representation of a PoolAPR
+func (pa PoolAPR) String() string {
+	out := fmt.Sprintf(`PoolAPR:
+  Pool Name:						%s
+  Quote Symbol:						%s
+  Locked Value:						%s
+  Total APR:						%s`,
+		pa.PoolName, pa.QuoteSymbol, pa.LockedValue, pa.TotalAPR)
+	for _, tokenAPR := range pa.TokenAPRs {
+		out += "\n" + tokenAPR.String()
+	}
+	return out
+}
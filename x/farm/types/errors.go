@@ -35,6 +35,9 @@ const (
 	CodeLockAmountBelowMinimum             uint32 = 66019
 	CodeSendCoinsFromModuleToAccountFailed uint32 = 66020
 	CodeSwapTokenPairNotExist              uint32 = 66021
+	CodeTooManyYieldedTokens               uint32 = 66022
+	CodeInvalidLockDuration                uint32 = 66023
+	CodeLockNotExpired                     uint32 = 66024
 )
 
 // ErrInvalidInput returns an error when an input parameter is invalid
@@ -148,4 +151,34 @@ func ErrSendCoinsFromModuleToAccountFailed(content string) sdk.EnvelopedErr {
 // ErrSwapTokenPairNotExist returns an error when a swap token pair not exists
 func ErrSwapTokenPairNotExist(tokenName string) sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeSwapTokenPairNotExist, fmt.Sprintf("failed. swap token pair %s does not exist", tokenName))}
-}
\ No newline at end of file
+}
+
+// ErrTooManyYieldedTokens returns an error when a pool yields more tokens than the max number
+func ErrTooManyYieldedTokens(poolName string, max int) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeTooManyYieldedTokens,
+		fmt.Sprintf("failed. farm pool %s can't yield more than %d tokens", poolName, max))}
+}
+
+// ErrInvalidLockDuration returns an error when the lock duration isn't a lock tier of the pool
+func ErrInvalidLockDuration(poolName string, duration int64) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeInvalidLockDuration,
+		fmt.Sprintf("failed. lock duration %d isn't a lock tier of farm pool %s", duration, poolName))}
+}
+
+// ErrLockDurationTooShort returns an error when the new lock duration would unlock before the current unlock time
+func ErrLockDurationTooShort(unlockTime int64) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeInvalidLockDuration,
+		fmt.Sprintf("failed. the lock duration must not unlock the tokens before %d", unlockTime))}
+}
+
+// ErrLockNotExpired returns an error when the tokens are unlocked before the unlock time without the penalty
+func ErrLockNotExpired(unlockTime int64) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeLockNotExpired,
+		fmt.Sprintf("failed. the locked tokens can't be unlocked until %d", unlockTime))}
+}
+
+// ErrLockDurationRequired returns an error when the tokens are added to the locked tokens without a lock duration
+func ErrLockDurationRequired(unlockTime int64) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultParamspace, CodeInvalidLockDuration,
+		fmt.Sprintf("failed. the tokens added to the tokens locked until %d must be locked with a lock duration", unlockTime))}
+}
@@ -2,22 +2,27 @@ package types
 
 // farm module event types
 const (
-	EventTypeCreatePool  = "create-pool"
-	EventTypeDestroyPool = "destroy-pool"
-	EventTypeProvide     = "provide"
-	EventTypeLock        = "lock"
-	EventTypeUnlock      = "unlock"
-	EventTypeClaim       = "claim"
+	EventTypeCreatePool   = "create-pool"
+	EventTypeDestroyPool  = "destroy-pool"
+	EventTypeProvide      = "provide"
+	EventTypeLock         = "lock"
+	EventTypeUnlock       = "unlock"
+	EventTypeClaim        = "claim"
+	EventTypeSetLockTiers = "set-lock-tiers"
 
 	AttributeKeyAddress             = "address"
 	AttributeKeyPool                = "pool"
 	AttributeKeyStartHeightToYield  = "start_height_to_yield"
+	AttributeKeyEndHeightToYield    = "end_height_to_yield"
 	AttributeKeyAmountYieldPerBlock = "amount_yield_per_block"
 	AttributeKeyMinLockAmount       = "min_lock_amount"
 	AttributeKeyYieldToken          = "yield_token"
 	AttributeKeyDeposit             = "deposit"
 	AttributeKeyWithdraw            = "withdraw"
 	AttributeKeyClaimed             = "claimed"
+	AttributeKeyLockDuration        = "lock_duration"
+	AttributeKeyPenalty             = "penalty"
+	AttributeKeyLockTiers           = "lock_tiers"
 
 	AttributeValueCategory = ModuleName
 )
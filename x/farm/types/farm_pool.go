@@ -17,6 +17,9 @@ type FarmPool struct {
 	TotalValueLocked        sdk.SysCoin       `json:"total_value_locked"`
 	YieldedTokenInfos       YieldedTokenInfos `json:"yielded_token_infos"`
 	TotalAccumulatedRewards sdk.SysCoins      `json:"total_accumulated_rewards"`
+	LockTiers               []LockTier        `json:"lock_tiers,omitempty"`
+	// sum of LockInfo.WeightedAmount
+	TotalWeightedValueLocked sdk.Dec `json:"total_weighted_value_locked,omitempty"`
 }
 
 // NewFarmPool creates a new instance of FarmPool
@@ -32,18 +35,67 @@ func NewFarmPool(
 		TotalValueLocked:        totalValueLocked,
 		YieldedTokenInfos:       yieldedTokenInfos,
 		TotalAccumulatedRewards: accumulatedRewards,
+		// nothing is weighted by the lock tiers yet
+		TotalWeightedValueLocked: totalValueLocked.Amount,
 	}
 }
 
+// GetWeightedValueLocked returns the sum of the locked amounts weighted by the multipliers of their lock tiers, which
+// shares the rewards. The pools created before the lock tiers are weighted the same as the total value locked
+func (fp FarmPool) GetWeightedValueLocked() sdk.SysCoin {
+	if fp.TotalWeightedValueLocked.IsNil() || fp.TotalWeightedValueLocked.IsZero() {
+		return fp.TotalValueLocked
+	}
+	return sdk.NewDecCoinFromDec(fp.TotalValueLocked.Denom, fp.TotalWeightedValueLocked)
+}
+
+// UpdateValueLocked changes the total value locked and the total weighted value locked of the pool
+func (fp *FarmPool) UpdateValueLocked(changedAmount, changedWeightedAmount sdk.Dec) {
+	fp.TotalWeightedValueLocked = fp.GetWeightedValueLocked().Amount.Add(changedWeightedAmount)
+	fp.TotalValueLocked.Amount = fp.TotalValueLocked.Amount.Add(changedAmount)
+}
+
+// GetLockTier returns the lock tier of the duration
+func (fp FarmPool) GetLockTier(duration int64) (LockTier, bool) {
+	for _, lockTier := range fp.LockTiers {
+		if lockTier.Duration == duration {
+			return lockTier, true
+		}
+	}
+	return LockTier{}, false
+}
+
 func (fp FarmPool) Finished() bool {
 	for _, yieldedTokenInfo := range fp.YieldedTokenInfos {
-		if yieldedTokenInfo.RemainingAmount.IsPositive() {
+		if yieldedTokenInfo.IsYielding() && yieldedTokenInfo.RemainingAmount.IsPositive() {
 			return false
 		}
 	}
 	return fp.TotalValueLocked.IsZero()
 }
 
+// IndexOfYieldedToken returns the index of the yielded token info of the denom, or -1 if the pool doesn't yield it
+func (fp FarmPool) IndexOfYieldedToken(denom string) int {
+	for i, yieldedTokenInfo := range fp.YieldedTokenInfos {
+		if yieldedTokenInfo.RemainingAmount.Denom == denom {
+			return i
+		}
+	}
+	return -1
+}
+
+// IdleYieldedTokens returns the remaining amounts of the yielded tokens which have stopped yielding
+func (fp FarmPool) IdleYieldedTokens() sdk.SysCoins {
+	idleTokens := sdk.SysCoins{}
+	for _, yieldedTokenInfo := range fp.YieldedTokenInfos {
+		if !yieldedTokenInfo.IsYielding() && yieldedTokenInfo.RemainingAmount.IsPositive() {
+			idleTokens = idleTokens.Add2(sdk.NewDecCoinsFromDec(yieldedTokenInfo.RemainingAmount.Denom,
+				yieldedTokenInfo.RemainingAmount.Amount))
+		}
+	}
+	return idleTokens
+}
+
 // String returns a human readable string representation of FarmPool
 func (fp FarmPool) String() string {
 	return fmt.Sprintf(`FarmPool:
@@ -52,9 +104,12 @@ func (fp FarmPool) String() string {
   Min Lock Amount:      			    %s
   Deposit Amount:                   %s
   Total Value Locked:               %s
+  Total Weighted Value Locked:      %s
+  Lock Tiers:                       %v
   Yielded Token Infos:			    %s
   Total Accumulated Rewards:        %s`,
-		fp.Name, fp.Owner, fp.MinLockAmount.String(), fp.DepositAmount, fp.TotalValueLocked, fp.YieldedTokenInfos, fp.TotalAccumulatedRewards)
+		fp.Name, fp.Owner, fp.MinLockAmount.String(), fp.DepositAmount, fp.TotalValueLocked,
+		fp.GetWeightedValueLocked(), fp.LockTiers, fp.YieldedTokenInfos, fp.TotalAccumulatedRewards)
 }
 
 // FarmPools is a collection of FarmPool
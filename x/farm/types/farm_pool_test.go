@@ -28,7 +28,8 @@ func TestFarmPools(t *testing.T) {
 			totalValueLocked: sdk.NewDecCoinFromDec("wwb", sdk.NewDec(100)),
 			yieldedTokenInfos: YieldedTokenInfos{
 				{
-					RemainingAmount: sdk.NewDecCoinFromDec("wwb", sdk.NewDec(100)),
+					RemainingAmount:         sdk.NewDecCoinFromDec("wwb", sdk.NewDec(100)),
+					StartBlockHeightToYield: 100,
 				},
 			},
 			totalAccumulatedRewards: sdk.SysCoins{},
@@ -42,7 +43,8 @@ func TestFarmPools(t *testing.T) {
 			totalValueLocked: sdk.NewDecCoinFromDec("wwb", sdk.NewDec(0)),
 			yieldedTokenInfos: YieldedTokenInfos{
 				{
-					RemainingAmount: sdk.NewDecCoinFromDec("wwb", sdk.NewDec(100)),
+					RemainingAmount:         sdk.NewDecCoinFromDec("wwb", sdk.NewDec(100)),
+					StartBlockHeightToYield: 100,
 				},
 			},
 			totalAccumulatedRewards: sdk.SysCoins{},
@@ -62,6 +64,21 @@ func TestFarmPools(t *testing.T) {
 			totalAccumulatedRewards: sdk.SysCoins{},
 			isFinished:              true,
 		},
+		{
+			owner:            sdk.AccAddress{0x1},
+			name:             "pool",
+			lockedSymbol:     "xxb",
+			depositAmount:    sdk.NewDecCoin(common.NativeToken, sdk.ZeroInt()),
+			totalValueLocked: sdk.NewDecCoinFromDec("wwb", sdk.NewDec(0)),
+			yieldedTokenInfos: YieldedTokenInfos{
+				{
+					// the idle remaining amount after the end height to yield
+					RemainingAmount: sdk.NewDecCoinFromDec("wwb", sdk.NewDec(100)),
+				},
+			},
+			totalAccumulatedRewards: sdk.SysCoins{},
+			isFinished:              true,
+		},
 	}
 
 	for _, test := range tests {
@@ -39,10 +39,13 @@ var (
 	PoolsYieldNativeTokenPrefix = []byte{0x04}
 	PoolHistoricalRewardsPrefix = []byte{0x05}
 	PoolCurrentRewardsPrefix    = []byte{0x06}
+	LockExpiryQueuePrefix       = []byte{0x07}
 )
 
 const (
 	poolNameFromLockInfoKeyIndex = sdk.AddrLen + 1
+	// prefix, unlock time and address
+	poolNameFromLockExpiryQueueKeyIndex = 1 + 8 + sdk.AddrLen
 )
 
 func GetFarmPoolKey(poolName string) []byte {
@@ -87,3 +90,21 @@ func GetPoolHistoricalRewardsPrefix(poolName string) []byte {
 func GetPoolCurrentRewardsKey(poolName string) []byte {
 	return append(PoolCurrentRewardsPrefix, []byte(poolName)...)
 }
+
+// GetLockExpiryQueueTimeKey gets the prefix key of the lock infos expiring at the unlock time
+func GetLockExpiryQueueTimeKey(unlockTime int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(unlockTime))
+	return append(LockExpiryQueuePrefix, b...)
+}
+
+// GetLockExpiryQueueKey gets the key for a lock info in the queue of the lock infos ordered by their unlock time
+func GetLockExpiryQueueKey(unlockTime int64, addr sdk.AccAddress, poolName string) []byte {
+	return append(GetLockExpiryQueueTimeKey(unlockTime), append(addr.Bytes(), []byte(poolName)...)...)
+}
+
+// SplitLockExpiryQueueKey splits the address and the pool name out from a LockExpiryQueueKey
+func SplitLockExpiryQueueKey(key []byte) (sdk.AccAddress, string) {
+	return sdk.AccAddress(key[poolNameFromLockExpiryQueueKeyIndex-sdk.AddrLen : poolNameFromLockExpiryQueueKeyIndex]),
+		string(key[poolNameFromLockExpiryQueueKeyIndex:])
+}
@@ -5,23 +5,69 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
-// LockInfo is locked info of an address
+// LockInfo is locked info of an address. The locked amount weighted by the multiplier of its lock tier earns the
+// rewards, and it can't be unlocked without the early unlock penalty until the unlock time
 type LockInfo struct {
-	Owner            sdk.AccAddress `json:"owner"`
-	PoolName         string         `json:"pool_name"`
-	Amount           sdk.SysCoin    `json:"amount"`
-	StartBlockHeight int64          `json:"start_block_height"`
-	ReferencePeriod  uint64         `json:"reference_period"`
+	Owner              sdk.AccAddress `json:"owner"`
+	PoolName           string         `json:"pool_name"`
+	Amount             sdk.SysCoin    `json:"amount"`
+	StartBlockHeight   int64          `json:"start_block_height"`
+	ReferencePeriod    uint64         `json:"reference_period"`
+	UnlockTime         int64          `json:"unlock_time,omitempty"`
+	Multiplier         sdk.Dec        `json:"multiplier,omitempty"`
+	WeightedAmount     sdk.Dec        `json:"weighted_amount,omitempty"`
+	EarlyUnlockPenalty sdk.Dec        `json:"early_unlock_penalty,omitempty"`
 }
 
 // NewLockInfo creates a new instance of LockInfo
 func NewLockInfo(owner sdk.AccAddress, poolName string, amount sdk.SysCoin, startBlockHeight int64, referencePeriod uint64) LockInfo {
 	return LockInfo{
-		Owner:            owner,
-		PoolName:         poolName,
-		Amount:           amount,
-		StartBlockHeight: startBlockHeight,
-		ReferencePeriod:  referencePeriod,
+		Owner:              owner,
+		PoolName:           poolName,
+		Amount:             amount,
+		StartBlockHeight:   startBlockHeight,
+		ReferencePeriod:    referencePeriod,
+		Multiplier:         sdk.OneDec(),
+		WeightedAmount:     amount.Amount,
+		EarlyUnlockPenalty: sdk.ZeroDec(),
+	}
+}
+
+// GetMultiplier returns the reward weight multiplier of the lock info, the lock infos created before the lock tiers
+// are weighted by 1
+func (li LockInfo) GetMultiplier() sdk.Dec {
+	if li.Multiplier.IsNil() || li.Multiplier.IsZero() {
+		return sdk.OneDec()
+	}
+	return li.Multiplier
+}
+
+// GetWeightedAmount returns the locked amount weighted by the multiplier, which earns the rewards
+func (li LockInfo) GetWeightedAmount() sdk.SysCoin {
+	if li.WeightedAmount.IsNil() || li.WeightedAmount.IsZero() {
+		return li.Amount
+	}
+	return sdk.NewDecCoinFromDec(li.Amount.Denom, li.WeightedAmount)
+}
+
+// IsLocked returns true if the locked amount can't be unlocked without the early unlock penalty at the time
+func (li LockInfo) IsLocked(blockTime int64) bool {
+	return li.UnlockTime != 0 && li.UnlockTime > blockTime
+}
+
+// SetLockTier locks the amount for the duration of the lock tier from the time
+func (li *LockInfo) SetLockTier(lockTier LockTier, blockTime int64) {
+	li.UnlockTime = blockTime + lockTier.Duration
+	li.Multiplier = lockTier.Multiplier
+	li.EarlyUnlockPenalty = lockTier.EarlyUnlockPenalty
+}
+
+// ResetExpiredLock removes the lock tier after the unlock time, then the amount is weighted by 1
+func (li *LockInfo) ResetExpiredLock(blockTime int64) {
+	if li.UnlockTime != 0 && li.UnlockTime <= blockTime {
+		li.UnlockTime = 0
+		li.Multiplier = sdk.OneDec()
+		li.EarlyUnlockPenalty = sdk.ZeroDec()
 	}
 }
 
@@ -32,6 +78,10 @@ func (li LockInfo) String() string {
   Pool Name:					%s
   Locked Amount:      			%s
   Start Block Height:           %d
-  Reference Period:             %d`,
-		li.Owner, li.PoolName, li.Amount, li.StartBlockHeight, li.ReferencePeriod)
+  Reference Period:             %d
+  Unlock Time:                  %d
+  Multiplier:                   %s
+  Weighted Amount:              %s`,
+		li.Owner, li.PoolName, li.Amount, li.StartBlockHeight, li.ReferencePeriod, li.UnlockTime, li.GetMultiplier(),
+		li.GetWeightedAmount())
 }
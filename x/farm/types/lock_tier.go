@@ -0,0 +1,66 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// MaxLockTiersNum is the max number of lock tiers of a pool
+	MaxLockTiersNum = 8
+	// MaxLockDuration is the max lock duration in seconds
+	MaxLockDuration = 4 * 365 * 24 * 60 * 60
+)
+
+// MaxLockMultiplier is the max reward weight multiplier of a lock tier
+var MaxLockMultiplier = sdk.NewDec(10)
+
+// LockTier is a lock duration in seconds configured by the pool owner. The tokens locked for the duration are
+// weighted by the multiplier in the rewards. Unlocking them before the unlock time forfeits the early unlock penalty
+// rate of the unlocked amount, or is disallowed if the penalty is zero
+type LockTier struct {
+	Duration           int64   `json:"duration"`
+	Multiplier         sdk.Dec `json:"multiplier"`
+	EarlyUnlockPenalty sdk.Dec `json:"early_unlock_penalty"`
+}
+
+// NewLockTier creates a new instance of LockTier
+func NewLockTier(duration int64, multiplier, earlyUnlockPenalty sdk.Dec) LockTier {
+	return LockTier{
+		Duration:           duration,
+		Multiplier:         multiplier,
+		EarlyUnlockPenalty: earlyUnlockPenalty,
+	}
+}
+
+// String returns a human readable string representation of a LockTier
+func (lt LockTier) String() string {
+	return fmt.Sprintf("%ds with multiplier %s and early unlock penalty %s", lt.Duration, lt.Multiplier,
+		lt.EarlyUnlockPenalty)
+}
+
+// ValidateLockTiers checks the lock tiers are in the ascending order of duration with valid multipliers and penalties
+func ValidateLockTiers(lockTiers []LockTier) sdk.Error {
+	if len(lockTiers) > MaxLockTiersNum {
+		return ErrInvalidInput(fmt.Sprintf("lock tiers are more than %d", MaxLockTiersNum))
+	}
+	var previous int64
+	for _, tier := range lockTiers {
+		if tier.Duration <= previous {
+			return ErrInvalidInput("durations of lock tiers must be > 0 and in ascending order")
+		}
+		if tier.Duration > MaxLockDuration {
+			return ErrInvalidInput(fmt.Sprintf("duration of lock tier must be <= %d", MaxLockDuration))
+		}
+		if tier.Multiplier.IsNil() || tier.Multiplier.LT(sdk.OneDec()) || tier.Multiplier.GT(MaxLockMultiplier) {
+			return ErrInvalidInput(fmt.Sprintf("multiplier of lock tier must be between 1 and %s", MaxLockMultiplier))
+		}
+		if tier.EarlyUnlockPenalty.IsNil() || tier.EarlyUnlockPenalty.IsNegative() ||
+			tier.EarlyUnlockPenalty.GT(sdk.OneDec()) {
+			return ErrInvalidInput("early unlock penalty of lock tier must be between 0 and 1")
+		}
+		previous = tier.Duration
+	}
+	return nil
+}
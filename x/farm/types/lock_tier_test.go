@@ -0,0 +1,75 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLockTiers(t *testing.T) {
+	tests := []struct {
+		lockTiers []LockTier
+		valid     bool
+	}{
+		{nil, true},
+		{[]LockTier{NewLockTier(100, sdk.OneDec(), sdk.ZeroDec())}, true},
+		{[]LockTier{
+			NewLockTier(100, sdk.NewDec(2), sdk.NewDecWithPrec(1, 1)),
+			NewLockTier(MaxLockDuration, MaxLockMultiplier, sdk.OneDec()),
+		}, true},
+		{[]LockTier{NewLockTier(0, sdk.NewDec(2), sdk.ZeroDec())}, false},
+		{[]LockTier{NewLockTier(MaxLockDuration+1, sdk.NewDec(2), sdk.ZeroDec())}, false},
+		{[]LockTier{NewLockTier(100, sdk.NewDecWithPrec(5, 1), sdk.ZeroDec())}, false},
+		{[]LockTier{NewLockTier(100, MaxLockMultiplier.Add(sdk.OneDec()), sdk.ZeroDec())}, false},
+		{[]LockTier{NewLockTier(100, sdk.NewDec(2), sdk.NewDec(-1))}, false},
+		{[]LockTier{NewLockTier(100, sdk.NewDec(2), sdk.NewDec(2))}, false},
+		{[]LockTier{
+			NewLockTier(200, sdk.NewDec(2), sdk.ZeroDec()),
+			NewLockTier(100, sdk.NewDec(3), sdk.ZeroDec()),
+		}, false},
+	}
+
+	for i, test := range tests {
+		err := ValidateLockTiers(test.lockTiers)
+		if test.valid {
+			require.Nil(t, err, "case %d", i)
+		} else {
+			require.NotNil(t, err, "case %d", i)
+		}
+	}
+
+	var lockTiers []LockTier
+	for i := int64(1); i <= MaxLockTiersNum+1; i++ {
+		lockTiers = append(lockTiers, NewLockTier(i, sdk.OneDec(), sdk.ZeroDec()))
+	}
+	require.NotNil(t, ValidateLockTiers(lockTiers))
+}
+
+func TestLockInfoLockTier(t *testing.T) {
+	lockInfo := NewLockInfo(sdk.AccAddress{0x1}, "pool", sdk.NewDecCoinFromDec("xxb", sdk.NewDec(10)), 10, 1)
+	require.False(t, lockInfo.IsLocked(0))
+	require.Equal(t, sdk.OneDec(), lockInfo.GetMultiplier())
+	require.Equal(t, sdk.NewDec(10), lockInfo.GetWeightedAmount().Amount)
+
+	// the lock info stored before the lock tiers is unweighted
+	legacy := lockInfo
+	legacy.Multiplier, legacy.WeightedAmount, legacy.EarlyUnlockPenalty = sdk.Dec{}, sdk.Dec{}, sdk.Dec{}
+	require.Equal(t, sdk.OneDec(), legacy.GetMultiplier())
+	require.Equal(t, sdk.NewDec(10), legacy.GetWeightedAmount().Amount)
+
+	lockInfo.SetLockTier(NewLockTier(100, sdk.NewDec(3), sdk.NewDecWithPrec(2, 1)), 1000)
+	require.Equal(t, int64(1100), lockInfo.UnlockTime)
+	require.True(t, lockInfo.IsLocked(1099))
+	require.False(t, lockInfo.IsLocked(1100))
+	require.Equal(t, sdk.NewDec(3), lockInfo.GetMultiplier())
+	require.Equal(t, sdk.NewDecWithPrec(2, 1), lockInfo.EarlyUnlockPenalty)
+
+	// the lock info keeps the lock tier until it expires
+	lockInfo.ResetExpiredLock(1099)
+	require.Equal(t, sdk.NewDec(3), lockInfo.GetMultiplier())
+	lockInfo.ResetExpiredLock(1100)
+	require.Equal(t, int64(0), lockInfo.UnlockTime)
+	require.Equal(t, sdk.OneDec(), lockInfo.GetMultiplier())
+	require.Equal(t, sdk.ZeroDec(), lockInfo.EarlyUnlockPenalty)
+}
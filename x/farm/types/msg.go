@@ -1,18 +1,25 @@
 package types
 
 import (
+	"fmt"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
 const (
 	MaxPoolNameLength = 128
-
-	createPoolMsgType  = "create_pool"
-	destroyPoolMsgType = "destroy_pool"
-	provideMsgType     = "provide"
-	lockMsgType        = "lock"
-	unlockMsgType      = "unlock"
-	claimMsgType       = "claim"
+	// MaxYieldedTokensNum is the max number of tokens yielded by a pool at the same time
+	MaxYieldedTokensNum = 5
+	// MaxEmissionPhasesNum is the max number of emission phases of a yielded token
+	MaxEmissionPhasesNum = 16
+
+	createPoolMsgType   = "create_pool"
+	destroyPoolMsgType  = "destroy_pool"
+	provideMsgType      = "provide"
+	setLockTiersMsgType = "set_lock_tiers"
+	lockMsgType         = "lock"
+	unlockMsgType       = "unlock"
+	claimMsgType        = "claim"
 )
 
 type MsgCreatePool struct {
@@ -108,11 +115,13 @@ func (m MsgDestroyPool) GetSigners() []sdk.AccAddress {
 }
 
 type MsgProvide struct {
-	PoolName              string         `json:"pool_name" yaml:"pool_name"`
-	Address               sdk.AccAddress `json:"address" yaml:"address"`
-	Amount                sdk.SysCoin    `json:"amount" yaml:"amount"`
-	AmountYieldedPerBlock sdk.Dec        `json:"amount_yielded_per_block" yaml:"amount_yielded_per_block"`
-	StartHeightToYield    int64          `json:"start_height_to_yield" yaml:"start_height_to_yield"`
+	PoolName              string          `json:"pool_name" yaml:"pool_name"`
+	Address               sdk.AccAddress  `json:"address" yaml:"address"`
+	Amount                sdk.SysCoin     `json:"amount" yaml:"amount"`
+	AmountYieldedPerBlock sdk.Dec         `json:"amount_yielded_per_block" yaml:"amount_yielded_per_block"`
+	StartHeightToYield    int64           `json:"start_height_to_yield" yaml:"start_height_to_yield"`
+	EndHeightToYield      int64           `json:"end_height_to_yield,omitempty" yaml:"end_height_to_yield"`
+	EmissionPhases        []EmissionPhase `json:"emission_phases,omitempty" yaml:"emission_phases"`
 }
 
 func NewMsgProvide(poolName string, address sdk.AccAddress, amount sdk.SysCoin,
@@ -126,6 +135,17 @@ func NewMsgProvide(poolName string, address sdk.AccAddress, amount sdk.SysCoin,
 	}
 }
 
+// NewMsgProvideWithEmissionSchedule creates a MsgProvide which stops yielding at endHeightToYield if it's not 0, and
+// changes the amount yielded per block by the emission phases
+func NewMsgProvideWithEmissionSchedule(poolName string, address sdk.AccAddress, amount sdk.SysCoin,
+	amountYieldedPerBlock sdk.Dec, startHeightToYield, endHeightToYield int64,
+	emissionPhases []EmissionPhase) MsgProvide {
+	msg := NewMsgProvide(poolName, address, amount, amountYieldedPerBlock, startHeightToYield)
+	msg.EndHeightToYield = endHeightToYield
+	msg.EmissionPhases = emissionPhases
+	return msg
+}
+
 var _ sdk.Msg = MsgProvide{}
 
 func (m MsgProvide) Route() string {
@@ -155,6 +175,9 @@ func (m MsgProvide) ValidateBasic() sdk.Error {
 	if m.StartHeightToYield <= 0 {
 		return ErrInvalidInput("start height to yield must be > 0")
 	}
+	if err := ValidateEmissionSchedule(m.StartHeightToYield, m.EndHeightToYield, m.EmissionPhases); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -168,9 +191,10 @@ func (m MsgProvide) GetSigners() []sdk.AccAddress {
 }
 
 type MsgLock struct {
-	PoolName string         `json:"pool_name" yaml:"pool_name"`
-	Address  sdk.AccAddress `json:"address" yaml:"address"`
-	Amount   sdk.SysCoin    `json:"amount" yaml:"amount"`
+	PoolName     string         `json:"pool_name" yaml:"pool_name"`
+	Address      sdk.AccAddress `json:"address" yaml:"address"`
+	Amount       sdk.SysCoin    `json:"amount" yaml:"amount"`
+	LockDuration int64          `json:"lock_duration,omitempty" yaml:"lock_duration"`
 }
 
 func NewMsgLock(poolName string, address sdk.AccAddress, amount sdk.SysCoin) MsgLock {
@@ -181,6 +205,13 @@ func NewMsgLock(poolName string, address sdk.AccAddress, amount sdk.SysCoin) Msg
 	}
 }
 
+// NewMsgLockWithDuration creates a MsgLock which locks the whole locked amount for the duration of a lock tier
+func NewMsgLockWithDuration(poolName string, address sdk.AccAddress, amount sdk.SysCoin, lockDuration int64) MsgLock {
+	msg := NewMsgLock(poolName, address, amount)
+	msg.LockDuration = lockDuration
+	return msg
+}
+
 var _ sdk.Msg = MsgLock{}
 
 func (m MsgLock) Route() string {
@@ -201,6 +232,9 @@ func (m MsgLock) ValidateBasic() sdk.Error {
 	if m.Amount.Amount.LTE(sdk.ZeroDec()) || !m.Amount.IsValid() {
 		return ErrInvalidInputAmount(m.Amount.Amount.String())
 	}
+	if m.LockDuration < 0 || m.LockDuration > MaxLockDuration {
+		return ErrInvalidInput(fmt.Sprintf("lock duration must be between 0 and %d", MaxLockDuration))
+	}
 	return nil
 }
 
@@ -299,3 +333,47 @@ func (m MsgClaim) GetSignBytes() []byte {
 func (m MsgClaim) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{m.Address}
 }
+
+// MsgSetLockTiers sets the lock tiers of a pool, which apply to the tokens locked after it
+type MsgSetLockTiers struct {
+	PoolName  string         `json:"pool_name" yaml:"pool_name"`
+	Owner     sdk.AccAddress `json:"owner" yaml:"owner"`
+	LockTiers []LockTier     `json:"lock_tiers" yaml:"lock_tiers"`
+}
+
+func NewMsgSetLockTiers(poolName string, owner sdk.AccAddress, lockTiers []LockTier) MsgSetLockTiers {
+	return MsgSetLockTiers{
+		PoolName:  poolName,
+		Owner:     owner,
+		LockTiers: lockTiers,
+	}
+}
+
+var _ sdk.Msg = MsgSetLockTiers{}
+
+func (m MsgSetLockTiers) Route() string {
+	return RouterKey
+}
+
+func (m MsgSetLockTiers) Type() string {
+	return setLockTiersMsgType
+}
+
+func (m MsgSetLockTiers) ValidateBasic() sdk.Error {
+	if m.PoolName == "" || len(m.PoolName) > MaxPoolNameLength {
+		return ErrInvalidInput(m.PoolName)
+	}
+	if m.Owner.Empty() {
+		return ErrNilAddress()
+	}
+	return ValidateLockTiers(m.LockTiers)
+}
+
+func (m MsgSetLockTiers) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(m)
+	return sdk.MustSortJSON(bz)
+}
+
+func (m MsgSetLockTiers) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Owner}
+}
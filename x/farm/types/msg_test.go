@@ -160,6 +160,57 @@ func TestMsgProvide(t *testing.T) {
 	}
 }
 
+func TestMsgProvideEmissionSchedule(t *testing.T) {
+	amount := sdk.NewDecCoinFromDec("xxb", sdk.NewDec(100))
+	msg := NewMsgProvideWithEmissionSchedule("pool", sdk.AccAddress{0x1}, amount, sdk.NewDec(10), 10, 100,
+		[]EmissionPhase{NewEmissionPhase(50, sdk.NewDec(5)), NewEmissionPhase(80, sdk.ZeroDec())})
+	require.Nil(t, msg.ValidateBasic())
+
+	// the msg without a schedule signs the same bytes as before
+	msg = NewMsgProvideWithEmissionSchedule("pool", sdk.AccAddress{0x1}, amount, sdk.NewDec(10), 10, 0, nil)
+	require.Nil(t, msg.ValidateBasic())
+	require.NotContains(t, string(msg.GetSignBytes()), "end_height_to_yield")
+	require.NotContains(t, string(msg.GetSignBytes()), "emission_phases")
+
+	msg = NewMsgProvideWithEmissionSchedule("pool", sdk.AccAddress{0x1}, amount, sdk.NewDec(10), 10, 10, nil)
+	testCode(t, msg.ValidateBasic(), CodeInvalidInput)
+	msg = NewMsgProvideWithEmissionSchedule("pool", sdk.AccAddress{0x1}, amount, sdk.NewDec(10), 10, 100,
+		[]EmissionPhase{NewEmissionPhase(100, sdk.NewDec(5))})
+	testCode(t, msg.ValidateBasic(), CodeInvalidInput)
+}
+
+func TestMsgLockWithDuration(t *testing.T) {
+	amount := sdk.NewDecCoinFromDec("xxb", sdk.NewDec(100))
+	msg := NewMsgLockWithDuration("pool", sdk.AccAddress{0x1}, amount, 100)
+	require.Nil(t, msg.ValidateBasic())
+
+	// the msg without a lock duration signs the same bytes as before
+	msg = NewMsgLockWithDuration("pool", sdk.AccAddress{0x1}, amount, 0)
+	require.Nil(t, msg.ValidateBasic())
+	require.NotContains(t, string(msg.GetSignBytes()), "lock_duration")
+
+	msg = NewMsgLockWithDuration("pool", sdk.AccAddress{0x1}, amount, -1)
+	testCode(t, msg.ValidateBasic(), CodeInvalidInput)
+	msg = NewMsgLockWithDuration("pool", sdk.AccAddress{0x1}, amount, MaxLockDuration+1)
+	testCode(t, msg.ValidateBasic(), CodeInvalidInput)
+}
+
+func TestMsgSetLockTiers(t *testing.T) {
+	lockTiers := []LockTier{NewLockTier(100, sdk.NewDec(2), sdk.NewDecWithPrec(1, 1))}
+	msg := NewMsgSetLockTiers("pool", sdk.AccAddress{0x1}, lockTiers)
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, "set_lock_tiers", msg.Type())
+	require.Equal(t, []sdk.AccAddress{{0x1}}, msg.GetSigners())
+	require.Nil(t, msg.ValidateBasic())
+	// an empty list removes all the lock tiers
+	require.Nil(t, NewMsgSetLockTiers("pool", sdk.AccAddress{0x1}, nil).ValidateBasic())
+
+	testCode(t, NewMsgSetLockTiers("", sdk.AccAddress{0x1}, lockTiers).ValidateBasic(), CodeInvalidInput)
+	testCode(t, NewMsgSetLockTiers("pool", nil, lockTiers).ValidateBasic(), CodeInvalidAddress)
+	lockTiers = append(lockTiers, NewLockTier(100, sdk.NewDec(3), sdk.ZeroDec()))
+	testCode(t, NewMsgSetLockTiers("pool", sdk.AccAddress{0x1}, lockTiers).ValidateBasic(), CodeInvalidInput)
+}
+
 func TestMsgLock(t *testing.T) {
 	tests := []struct {
 		poolName string
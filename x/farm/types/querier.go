@@ -12,10 +12,12 @@ const (
 	QueryAccount          = "account"
 	QueryAccountsLockedTo = "accounts-locked-to"
 	QueryPoolNum          = "pool-num"
+	QueryPoolAPR          = "pool-apr"
 )
 
 // QueryPoolParams defines the params for the following queries:
 // - 'custom/farm/pool'
+// - 'custom/farm/pool-apr'
 type QueryPoolParams struct {
 	PoolName string
 }
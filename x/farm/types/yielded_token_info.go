@@ -7,11 +7,58 @@ import (
 )
 
 // YieldedTokenInfo is the token excluding native token which can be yielded by locking other tokens including LPT and
-// token issued
+// token issued. The amount yielded per block changes by the emission phases, and the token stops yielding at the end
+// block height if it's not 0. The remaining amount left after that is idle and can be provided again
 type YieldedTokenInfo struct {
-	RemainingAmount         sdk.SysCoin `json:"remaining_amount"`
-	StartBlockHeightToYield int64       `json:"start_block_height_to_yield"`
-	AmountYieldedPerBlock   sdk.Dec     `json:"amount_yielded_per_block"`
+	RemainingAmount         sdk.SysCoin     `json:"remaining_amount"`
+	StartBlockHeightToYield int64           `json:"start_block_height_to_yield"`
+	AmountYieldedPerBlock   sdk.Dec         `json:"amount_yielded_per_block"`
+	EndBlockHeightToYield   int64           `json:"end_block_height_to_yield,omitempty"`
+	EmissionPhases          []EmissionPhase `json:"emission_phases,omitempty"`
+}
+
+// EmissionPhase changes the amount yielded per block from its start block height on
+type EmissionPhase struct {
+	StartBlockHeight      int64   `json:"start_block_height"`
+	AmountYieldedPerBlock sdk.Dec `json:"amount_yielded_per_block"`
+}
+
+// NewEmissionPhase creates a new instance of EmissionPhase
+func NewEmissionPhase(startBlockHeight int64, amountYieldedPerBlock sdk.Dec) EmissionPhase {
+	return EmissionPhase{
+		StartBlockHeight:      startBlockHeight,
+		AmountYieldedPerBlock: amountYieldedPerBlock,
+	}
+}
+
+// String returns a human readable string representation of an EmissionPhase
+func (ep EmissionPhase) String() string {
+	return fmt.Sprintf("%s per block from %d", ep.AmountYieldedPerBlock, ep.StartBlockHeight)
+}
+
+// ValidateEmissionSchedule checks the emission phases are in the order of height between the start and the end block
+// heights to yield, the end block height is 0 if there's no end
+func ValidateEmissionSchedule(startBlockHeight, endBlockHeight int64, emissionPhases []EmissionPhase) sdk.Error {
+	if endBlockHeight != 0 && endBlockHeight <= startBlockHeight {
+		return ErrInvalidInput("end height to yield must be > start height to yield")
+	}
+	if len(emissionPhases) > MaxEmissionPhasesNum {
+		return ErrInvalidInput(fmt.Sprintf("emission phases are more than %d", MaxEmissionPhasesNum))
+	}
+	previous := startBlockHeight
+	for _, phase := range emissionPhases {
+		if phase.StartBlockHeight <= previous {
+			return ErrInvalidInput("start heights of emission phases must be in ascending order after start height to yield")
+		}
+		if endBlockHeight != 0 && phase.StartBlockHeight >= endBlockHeight {
+			return ErrInvalidInput("start heights of emission phases must be < end height to yield")
+		}
+		if phase.AmountYieldedPerBlock.IsNil() || phase.AmountYieldedPerBlock.IsNegative() {
+			return ErrInvalidInput("amount yielded per block of emission phases must be >= 0")
+		}
+		previous = phase.StartBlockHeight
+	}
+	return nil
 }
 
 // NewYieldedTokenInfo creates a new instance of YieldedTokenInfo
@@ -27,11 +74,74 @@ func NewYieldedTokenInfo(
 
 // String returns a human readable string representation of a YieldedTokenInfo
 func (yti YieldedTokenInfo) String() string {
-	return fmt.Sprintf(`YieldedTokenInfo：
+	out := fmt.Sprintf(`YieldedTokenInfo：
   RemainingAmount:					%s
   Start Block Height To Yield:		%d
   AmountYieldedPerBlock:			%s`,
 		yti.RemainingAmount, yti.StartBlockHeightToYield, yti.AmountYieldedPerBlock)
+	if yti.EndBlockHeightToYield != 0 {
+		out += fmt.Sprintf("\n  End Block Height To Yield:		%d", yti.EndBlockHeightToYield)
+	}
+	for _, phase := range yti.EmissionPhases {
+		out += fmt.Sprintf("\n  Emission Phase:					%s", phase)
+	}
+	return out
+}
+
+// IsYielding returns true if the token is scheduled to yield, otherwise the remaining amount is idle
+func (yti YieldedTokenInfo) IsYielding() bool {
+	return yti.StartBlockHeightToYield != 0
+}
+
+// IsEnded returns true if the token stops yielding at or before the block height
+func (yti YieldedTokenInfo) IsEnded(blockHeight int64) bool {
+	return yti.EndBlockHeightToYield != 0 && blockHeight >= yti.EndBlockHeightToYield
+}
+
+// GetAmountYieldedPerBlock returns the amount yielded at the block height by the emission schedule
+func (yti YieldedTokenInfo) GetAmountYieldedPerBlock(blockHeight int64) sdk.Dec {
+	if !yti.IsYielding() || blockHeight < yti.StartBlockHeightToYield || yti.IsEnded(blockHeight) {
+		return sdk.ZeroDec()
+	}
+	amount := yti.AmountYieldedPerBlock
+	for _, phase := range yti.EmissionPhases {
+		if phase.StartBlockHeight > blockHeight {
+			break
+		}
+		amount = phase.AmountYieldedPerBlock
+	}
+	return amount
+}
+
+// CalculateAmountYielded returns the amount yielded in the blocks from startBlockHeight to endBlockHeight by the
+// emission schedule, it isn't capped by the remaining amount
+func (yti YieldedTokenInfo) CalculateAmountYielded(startBlockHeight, endBlockHeight int64) sdk.Dec {
+	amount := sdk.ZeroDec()
+	if !yti.IsYielding() {
+		return amount
+	}
+	if yti.EndBlockHeightToYield != 0 && endBlockHeight > yti.EndBlockHeightToYield {
+		endBlockHeight = yti.EndBlockHeightToYield
+	}
+	if startBlockHeight < yti.StartBlockHeightToYield {
+		startBlockHeight = yti.StartBlockHeightToYield
+	}
+
+	amountYieldedPerBlock := yti.AmountYieldedPerBlock
+	for _, phase := range yti.EmissionPhases {
+		if phase.StartBlockHeight >= endBlockHeight {
+			break
+		}
+		if phase.StartBlockHeight > startBlockHeight {
+			amount = amount.Add(sdk.NewDec(phase.StartBlockHeight - startBlockHeight).MulTruncate(amountYieldedPerBlock))
+			startBlockHeight = phase.StartBlockHeight
+		}
+		amountYieldedPerBlock = phase.AmountYieldedPerBlock
+	}
+	if endBlockHeight > startBlockHeight {
+		amount = amount.Add(sdk.NewDec(endBlockHeight - startBlockHeight).MulTruncate(amountYieldedPerBlock))
+	}
+	return amount
 }
 
 // YieldedTokenInfos is a collection of YieldedTokenInfo
@@ -17,3 +17,67 @@ func TestYieldedTokenInfo(t *testing.T) {
 
 	require.Equal(t, yieldInfos.String(), yieldInfo1.String()+"\n"+yieldInfo2.String())
 }
+
+func TestYieldedTokenInfoEmissionSchedule(t *testing.T) {
+	yieldInfo := NewYieldedTokenInfo(sdk.NewDecCoinFromDec("xxb", sdk.NewDec(10000)), 100, sdk.NewDec(10))
+	// without a schedule it yields the same amount per block forever
+	require.Equal(t, sdk.NewDec(500), yieldInfo.CalculateAmountYielded(50, 150))
+	require.Equal(t, sdk.ZeroDec(), yieldInfo.CalculateAmountYielded(50, 100))
+	require.False(t, yieldInfo.IsEnded(1000000))
+
+	yieldInfo.EndBlockHeightToYield = 300
+	yieldInfo.EmissionPhases = []EmissionPhase{
+		NewEmissionPhase(150, sdk.NewDec(5)),
+		NewEmissionPhase(200, sdk.ZeroDec()),
+		NewEmissionPhase(250, sdk.NewDec(2)),
+	}
+	require.Nil(t, ValidateEmissionSchedule(yieldInfo.StartBlockHeightToYield, yieldInfo.EndBlockHeightToYield,
+		yieldInfo.EmissionPhases))
+
+	require.Equal(t, sdk.NewDec(10), yieldInfo.GetAmountYieldedPerBlock(100))
+	require.Equal(t, sdk.NewDec(5), yieldInfo.GetAmountYieldedPerBlock(150))
+	require.Equal(t, sdk.ZeroDec(), yieldInfo.GetAmountYieldedPerBlock(220))
+	require.Equal(t, sdk.NewDec(2), yieldInfo.GetAmountYieldedPerBlock(299))
+	require.Equal(t, sdk.ZeroDec(), yieldInfo.GetAmountYieldedPerBlock(300))
+
+	// 50 blocks * 10 + 50 blocks * 5 + 50 blocks * 0 + 50 blocks * 2
+	require.Equal(t, sdk.NewDec(850), yieldInfo.CalculateAmountYielded(0, 1000))
+	// the periods split by the phases sum up to the whole one
+	require.Equal(t, yieldInfo.CalculateAmountYielded(0, 1000),
+		yieldInfo.CalculateAmountYielded(0, 170).Add(yieldInfo.CalculateAmountYielded(170, 1000)))
+	require.Equal(t, sdk.NewDec(20), yieldInfo.CalculateAmountYielded(260, 270))
+	require.Equal(t, sdk.ZeroDec(), yieldInfo.CalculateAmountYielded(300, 400))
+	require.True(t, yieldInfo.IsEnded(300))
+
+	// idle token
+	idleInfo := NewYieldedTokenInfo(sdk.NewDecCoinFromDec("xxb", sdk.NewDec(100)), 0, sdk.ZeroDec())
+	require.Equal(t, sdk.ZeroDec(), idleInfo.CalculateAmountYielded(0, 1000))
+}
+
+func TestValidateEmissionSchedule(t *testing.T) {
+	tests := []struct {
+		start, end int64
+		phases     []EmissionPhase
+		valid      bool
+	}{
+		{100, 0, nil, true},
+		{100, 200, []EmissionPhase{NewEmissionPhase(150, sdk.NewDec(1))}, true},
+		{100, 100, nil, false},
+		{100, 200, []EmissionPhase{NewEmissionPhase(100, sdk.NewDec(1))}, false},
+		{100, 200, []EmissionPhase{NewEmissionPhase(200, sdk.NewDec(1))}, false},
+		{100, 0, []EmissionPhase{NewEmissionPhase(150, sdk.NewDec(1)), NewEmissionPhase(150, sdk.NewDec(2))}, false},
+		{100, 0, []EmissionPhase{NewEmissionPhase(150, sdk.NewDec(-1))}, false},
+		{100, 0, []EmissionPhase{{StartBlockHeight: 150}}, false},
+	}
+	for i, test := range tests {
+		err := ValidateEmissionSchedule(test.start, test.end, test.phases)
+		require.Equal(t, test.valid, err == nil, "case %d", i)
+	}
+
+	phases := make([]EmissionPhase, MaxEmissionPhasesNum+1)
+	for i := range phases {
+		phases[i] = NewEmissionPhase(int64(101+i), sdk.NewDec(1))
+	}
+	require.NotNil(t, ValidateEmissionSchedule(100, 0, phases))
+	require.Nil(t, ValidateEmissionSchedule(100, 0, phases[:MaxEmissionPhasesNum]))
+}
@@ -29,6 +29,7 @@ type (
 	MsgCancelOrder   = types.MsgCancelOrder
 	MsgNewOrders     = types.MsgNewOrders
 	MsgCancelOrders  = types.MsgCancelOrders
+	MsgAmendOrder    = types.MsgAmendOrder
 	BlockMatchResult = types.BlockMatchResult
 )
 
@@ -39,6 +40,7 @@ var (
 	DefaultParams     = types.DefaultParams
 	NewMsgNewOrder    = types.NewMsgNewOrder
 	NewMsgCancelOrder = types.NewMsgCancelOrder
+	NewMsgAmendOrder  = types.NewMsgAmendOrder
 	NewKeeper         = keeper.NewKeeper
 	NewQuerier        = keeper.NewQuerier
 	FormatOrderIDsKey = types.FormatOrderIDsKey
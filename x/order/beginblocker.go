@@ -5,15 +5,17 @@ import (
 
 	"github.com/okex/exchain/x/common/perf"
 	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match"
 	"github.com/okex/exchain/x/order/types"
 	//"github.com/okex/exchain/x/common/version"
 )
 
 // BeginBlocker runs the logic of BeginBlocker with version 0.
-// BeginBlocker resets keeper cache.
+// BeginBlocker resets keeper cache, and triggers the stop orders by the last prices.
 func BeginBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 	seq := perf.GetPerf().OnBeginBlockEnter(ctx, types.ModuleName)
 	defer perf.GetPerf().OnBeginBlockExit(ctx, types.ModuleName, seq)
 
 	keeper.ResetCache(ctx)
+	match.TriggerStopOrders(ctx, keeper)
 }
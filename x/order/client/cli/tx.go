@@ -26,6 +26,7 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	txCmd.AddCommand(client.PostCommands(
 		getCmdNewOrder(cdc),
 		getCmdCancelOrder(cdc),
+		getCmdAmendOrder(cdc),
 	)...)
 
 	return txCmd
@@ -37,10 +38,19 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 	var side string
 	var price string
 	var quantity string
+	var orderType string
+	var timeInForce string
+	var stopPrice string
+	var slippage string
 	cmd := &cobra.Command{
 		Use:   "new",
 		Short: "place a new order",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			isMarket := orderType == types.OrderTypeMarket || orderType == types.OrderTypeStop
+			if isMarket && len(price) == 0 {
+				// market orders are priced by the slippage
+				price = strings.TrimSuffix(strings.Repeat("0,", len(strings.Split(product, ","))), ",")
+			}
 			if len(product) == 0 || len(side) == 0 || len(price) == 0 || len(quantity) == 0 {
 				return errors.New("invalid param format")
 			}
@@ -53,7 +63,23 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 				return errors.New("invalid param counts")
 			}
 
-			err := handleNewOrder(cmd, cdc, product, side, price, quantity)
+			options := types.OrderItem{Type: orderType, TimeInForce: timeInForce}
+			if len(stopPrice) > 0 {
+				dec, err := sdk.NewDecFromStr(stopPrice)
+				if err != nil {
+					return err
+				}
+				options.StopPrice = dec
+			}
+			if len(slippage) > 0 {
+				dec, err := sdk.NewDecFromStr(slippage)
+				if err != nil {
+					return err
+				}
+				options.Slippage = dec
+			}
+
+			err := handleNewOrder(cmd, cdc, product, side, price, quantity, options)
 			return err
 
 		},
@@ -63,10 +89,15 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().StringVarP(&side, "side", "s", "", "BUY or SELL (default \"SELL\")")
 	cmd.Flags().StringVarP(&price, "price", "p", "", "The price of the order")
 	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The quantity of the order")
+	cmd.Flags().StringVarP(&orderType, "type", "", "", "LIMIT, MARKET, STOP or STOP_LIMIT (default \"LIMIT\")")
+	cmd.Flags().StringVarP(&timeInForce, "time-in-force", "", "", "GTC, IOC, FOK or POST_ONLY (default \"GTC\")")
+	cmd.Flags().StringVarP(&stopPrice, "stop-price", "", "", "The last price which triggers the STOP and STOP_LIMIT orders")
+	cmd.Flags().StringVarP(&slippage, "slippage", "", "", "The max slippage of the MARKET and STOP orders from the reference price, for example 0.05")
 	return cmd
 }
 
-func handleNewOrder(cmd *cobra.Command, cdc *codec.Codec, product string, side string, price string, quantity string) error {
+func handleNewOrder(cmd *cobra.Command, cdc *codec.Codec, product string, side string, price string, quantity string,
+	options types.OrderItem) error {
 	var items []types.OrderItem
 	productArr := strings.Split(product, ",")
 	sideArr := strings.Split(side, ",")
@@ -96,10 +127,14 @@ func handleNewOrder(cmd *cobra.Command, cdc *codec.Codec, product string, side s
 			return errors.New(err.Error())
 		}
 		items = append(items, types.OrderItem{
-			Product:  product,
-			Side:     side,
-			Price:    price,
-			Quantity: quantity,
+			Product:     product,
+			Side:        side,
+			Price:       price,
+			Quantity:    quantity,
+			Type:        options.Type,
+			TimeInForce: options.TimeInForce,
+			StopPrice:   options.StopPrice,
+			Slippage:    options.Slippage,
 		})
 	}
 	inBuf := bufio.NewReader(cmd.InOrStdin())
@@ -131,3 +166,38 @@ func getCmdCancelOrder(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func getCmdAmendOrder(cdc *codec.Codec) *cobra.Command {
+	var price string
+	var quantity string
+	cmd := &cobra.Command{
+		Use:   "amend [order-id]",
+		Short: "amend the price or the remaining quantity of an open order",
+		Long: strings.TrimSpace(`Amend the price or the remaining quantity of an open order. A smaller quantity at the
+same price is reduced in place and keeps its priority, otherwise the order is cancelled and replaced:
+
+$ exchaincli tx order amend ID0000000010-1 --price 10.1 --quantity 2.5 --from mykey
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			priceDec, err := sdk.NewDecFromStr(price)
+			if err != nil {
+				return err
+			}
+			quantityDec, err := sdk.NewDecFromStr(quantity)
+			if err != nil {
+				return err
+			}
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := authtxb.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgAmendOrder(cliCtx.GetFromAddress(), args[0], priceDec, quantityDec)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&price, "price", "p", "", "The new price of the order")
+	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The new remaining quantity of the order")
+	return cmd
+}
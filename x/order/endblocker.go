@@ -18,7 +18,7 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 	seq := perf.GetPerf().OnEndBlockEnter(ctx, types.ModuleName)
 	defer perf.GetPerf().OnEndBlockExit(ctx, types.ModuleName, seq)
 
-	match.GetEngine().Run(ctx, keeper)
+	match.Run(ctx, keeper)
 
 	// flush cache at the end
 	keeper.Cache2Disk(ctx)
@@ -48,6 +48,12 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data GenesisState) {
 		keeper.SetBlockOrderNum(ctx, height, orderNum+1)
 		keeper.SetOrder(ctx, order.OrderID, order)
 
+		if order.Status == types.OrderStatusUntriggered {
+			// untriggered stop orders wait in the trigger index
+			keeper.SetStopOrderTrigger(ctx, types.NewStopOrderTrigger(order))
+			keeper.GetDiskCache().CountNewOrder()
+			continue
+		}
 		// update depth book and orderIDsMap in cache
 		keeper.InsertOrderIntoDepthBook(order)
 	}
@@ -109,6 +115,12 @@ func ExportGenesis(ctx sdk.Context, keeper keeper.Keeper) (data GenesisState) {
 		}
 	}
 
+	// get untriggered stop orders
+	keeper.IterateStopOrderTriggers(ctx, func(trigger types.StopOrderTrigger) bool {
+		openOrders = append(openOrders, keeper.GetOrder(ctx, trigger.OrderID))
+		return false
+	})
+
 	return GenesisState{
 		Params:     *params,
 		OpenOrders: openOrders,
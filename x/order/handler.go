@@ -11,6 +11,7 @@ import (
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/common/perf"
 	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match"
 	"github.com/okex/exchain/x/order/types"
 	"github.com/okex/exchain/libs/tendermint/crypto/tmhash"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
@@ -23,6 +24,8 @@ func CalculateGas(msg sdk.Msg, params *types.Params) (gas uint64) {
 		gas = msg.CalculateGas(params.NewOrderMsgGasUnit)
 	case types.MsgCancelOrders:
 		gas = msg.CalculateGas(params.CancelOrderMsgGasUnit)
+	case types.MsgAmendOrder:
+		gas = msg.CalculateGas(params.NewOrderMsgGasUnit)
 	default:
 		gas = math.MaxUint64
 	}
@@ -33,8 +36,10 @@ func CalculateGas(msg sdk.Msg, params *types.Params) (gas uint64) {
 // NewOrderHandler returns the handler with version 0.
 func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
-		// disable order tx handler
-		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Order messages are not allowd.")
+		// order messages are enabled after the venus height
+		if !sdk.HigherThanVenus(ctx.BlockHeight()) {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Order messages are not allowd.")
+		}
 
 		gas := CalculateGas(msg, keeper.GetParams(ctx))
 
@@ -44,9 +49,10 @@ func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
 		if ctx.IsCheckTx() {
 			return &sdk.Result{}, nil
 		} else {
-			// set an infinite gas meter and recovery it when return
+			// set an infinite gas meter and recovery it when return, the fills are still charged on the gas meter of
+			// the tx
 			gasMeter := ctx.GasMeter()
-			ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			ctx = types.WithTxGasMeter(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), gasMeter)
 			defer func() { ctx = ctx.WithGasMeter(gasMeter) }()
 		}
 
@@ -65,6 +71,11 @@ func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
 			handlerFun = func() (*sdk.Result, error) {
 				return handleMsgCancelOrders(ctx, keeper, msg, logger)
 			}
+		case types.MsgAmendOrder:
+			name = "handleMsgAmendOrder"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgAmendOrder(ctx, keeper, msg, logger)
+			}
 		default:
 			errMsg := fmt.Sprintf("Invalid msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -96,11 +107,25 @@ func checkOrderNewMsg(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgNewOrd
 
 	priceDigit := tokenPair.MaxPriceDigit
 	quantityDigit := tokenPair.MaxQuantityDigit
-	roundedPrice := msg.Price.RoundDecimal(priceDigit)
-	roundedQuantity := msg.Quantity.RoundDecimal(quantityDigit)
-	if !roundedPrice.Equal(msg.Price) {
-		return types.ErrPriceOverAccuracy(msg.Price, priceDigit)
+	switch msg.Type {
+	case types.OrderTypeMarket, types.OrderTypeStop:
+		// market orders are priced by the slippage
+		if !getOrderPrice(ctx, keeper, msg).IsPositive() {
+			return types.ErrNoMarketPrice(msg.Product)
+		}
+	default:
+		roundedPrice := msg.Price.RoundDecimal(priceDigit)
+		if !roundedPrice.Equal(msg.Price) {
+			return types.ErrPriceOverAccuracy(msg.Price, priceDigit)
+		}
+	}
+	if msg.Type == types.OrderTypeStop || msg.Type == types.OrderTypeStopLimit {
+		roundedStopPrice := msg.StopPrice.RoundDecimal(priceDigit)
+		if !roundedStopPrice.Equal(msg.StopPrice) {
+			return types.ErrPriceOverAccuracy(msg.StopPrice, priceDigit)
+		}
 	}
+	roundedQuantity := msg.Quantity.RoundDecimal(quantityDigit)
 	if !roundedQuantity.Equal(msg.Quantity) {
 		return types.ErrQuantityOverAccuracy(msg.Quantity, quantityDigit)
 	}
@@ -115,17 +140,58 @@ func getOrderFromMsg(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrder, ra
 	feeParams := k.GetParams(ctx)
 	feePerBlockAmount := feeParams.FeePerBlock.Amount.Mul(sdk.MustNewDecFromStr(ratio))
 	feePerBlock := sdk.NewDecCoinFromDec(feeParams.FeePerBlock.Denom, feePerBlockAmount)
-	return types.NewOrder(
+	order := types.NewOrder(
 		fmt.Sprintf("%X", tmhash.Sum(ctx.TxBytes())),
 		msg.Sender,
 		msg.Product,
 		msg.Side,
-		msg.Price,
+		getOrderPrice(ctx, k, msg),
 		msg.Quantity,
 		ctx.BlockHeader().Time.Unix(),
 		feeParams.OrderExpireBlocks,
 		feePerBlock,
 	)
+	order.Type = msg.Type
+	order.TimeInForce = msg.TimeInForce
+	if order.IsStopOrder() {
+		order.RecordStopPrice(msg.StopPrice)
+	}
+	return order
+}
+
+// getOrderPrice returns the price of the order. Market orders are priced by the slippage around the
+// last price, and stop market orders around the stop price, zero if the product doesn't exist
+func getOrderPrice(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrder) sdk.Dec {
+	var refPrice sdk.Dec
+	switch msg.Type {
+	case types.OrderTypeMarket:
+		refPrice = k.GetLastPrice(ctx, msg.Product)
+	case types.OrderTypeStop:
+		refPrice = msg.StopPrice
+	default:
+		return msg.Price
+	}
+
+	tokenPair := k.GetDexKeeper().GetTokenPair(ctx, msg.Product)
+	if tokenPair == nil {
+		return sdk.ZeroDec()
+	}
+	return types.GetSlippagePrice(msg.Side, refPrice, msg.Slippage, tokenPair.MaxPriceDigit)
+}
+
+// newMsgNewOrder converts the order item of MsgNewOrders to MsgNewOrder
+func newMsgNewOrder(sender sdk.AccAddress, item types.OrderItem) MsgNewOrder {
+	return MsgNewOrder{
+		Sender:      sender,
+		Product:     item.Product,
+		Side:        item.Side,
+		Price:       item.Price,
+		Quantity:    item.Quantity,
+		Type:        item.Type,
+		TimeInForce: item.TimeInForce,
+		StopPrice:   item.StopPrice,
+		Slippage:    item.Slippage,
+	}
 }
 
 func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
@@ -133,13 +199,7 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 
 	cacheItem := ctx.MultiStore().CacheMultiStore()
 	ctxItem := ctx.WithMultiStore(cacheItem)
-	msg := MsgNewOrder{
-		Sender:   sender,
-		Product:  item.Product,
-		Side:     item.Side,
-		Price:    item.Price,
-		Quantity: item.Quantity,
-	}
+	msg := newMsgNewOrder(sender, item)
 	order := getOrderFromMsg(ctxItem, k, msg, ratio)
 	err := checkOrderNewMsg(ctxItem, k, msg)
 
@@ -147,7 +207,8 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 		if k.IsProductLocked(ctx, msg.Product) {
 			err = types.ErrIsProductLocked(order.Product)
 		} else {
-			err = k.PlaceOrder(ctxItem, order)
+			// continuous auction products fill the new order at once
+			err = match.PlaceOrder(ctxItem, k, order)
 		}
 	}
 
@@ -162,7 +223,7 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 			"    TxHash<%s>, Status<%s>\n"+
 			"    result<The User have created an order {ID:%s,RemainQuantity:%s,Status:%s} >\n",
 			ctx.BlockHeight(), "handleMsgNewOrder",
-			msg.Product, msg.Sender, msg.Price.String(), msg.Quantity.String(), msg.Side,
+			msg.Product, msg.Sender, order.Price.String(), msg.Quantity.String(), msg.Side,
 			order.TxHash, types.OrderStatus(types.OrderStatusOpen),
 			order.OrderID, order.RemainQuantity.String(), types.OrderStatus(order.Status)))
 	} else {
@@ -216,13 +277,7 @@ func ValidateMsgNewOrders(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrde
 	}
 
 	for _, item := range msg.OrderItems {
-		msg := MsgNewOrder{
-			Sender:   msg.Sender,
-			Product:  item.Product,
-			Side:     item.Side,
-			Price:    item.Price,
-			Quantity: item.Quantity,
-		}
+		msg := newMsgNewOrder(msg.Sender, item)
 		err := checkOrderNewMsg(ctx, k, msg)
 		if err != nil {
 			return nil, err
@@ -317,7 +372,7 @@ func validateCancelOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCan
 	if order == nil {
 		return types.ErrOrderIsNotExistOrClosed(msg.OrderID)
 	}
-	if order.Status != types.OrderStatusOpen {
+	if order.Status != types.OrderStatusOpen && order.Status != types.OrderStatusUntriggered {
 		return types.ErrOrderStatusIsNotOpen()
 	}
 	if !order.Sender.Equals(msg.Sender) {
@@ -344,3 +399,112 @@ func ValidateMsgCancelOrders(ctx sdk.Context, keeper keeper.Keeper, msg types.Ms
 
 	return nil
 }
+
+func handleMsgAmendOrder(ctx sdk.Context, k Keeper, msg types.MsgAmendOrder, logger log.Logger) (*sdk.Result, error) {
+	if err := ValidateMsgAmendOrder(ctx, k, msg); err != nil {
+		return nil, err
+	}
+
+	order := k.GetOrder(ctx, msg.OrderID)
+	if msg.Price.Equal(order.Price) && msg.Quantity.LT(order.RemainQuantity) {
+		// reduce in place, the order keeps its priority
+		k.ReduceOrder(ctx, order, msg.Quantity)
+	} else {
+		// cancel and replace in one tx, the new order is placed at the end of the queue
+		replaced := getReplacedOrder(ctx, k, order, msg)
+		if err := match.ReplaceOrder(ctx, k, order, replaced); err != nil {
+			return nil, err
+		}
+		order = replaced
+	}
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+		"    msg<Sender:%s,ID:%s,Price:%s,Quantity:%s>\n"+
+		"    result<The User have amended an order {ID:%s,RemainQuantity:%s,Status:%s} >\n",
+		ctx.BlockHeight(), "handleMsgAmendOrder",
+		msg.Sender, msg.OrderID, msg.Price.String(), msg.Quantity.String(),
+		order.OrderID, order.RemainQuantity.String(), types.OrderStatus(order.Status)))
+
+	rss, err := json.Marshal([]types.OrderResult{{OrderID: order.OrderID}})
+	if err != nil {
+		rss = []byte(fmt.Sprintf("failed to marshal result to JSON: %s", err))
+	}
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	event = event.AppendAttributes(sdk.NewAttribute("orders", string(rss)))
+	ctx.EventManager().EmitEvent(event)
+
+	var handlerResult bitset.BitSet
+	handlerResult.Set(0)
+	k.AddTxHandlerMsgResult(handlerResult)
+	return &sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}, nil
+}
+
+// getReplacedOrder returns the new order which replaces the amended one, triggered stop orders are
+// replaced by limit orders
+func getReplacedOrder(ctx sdk.Context, k keeper.Keeper, order *types.Order, msg types.MsgAmendOrder) *types.Order {
+	replaced := types.NewOrder(
+		fmt.Sprintf("%X", tmhash.Sum(ctx.TxBytes())),
+		order.Sender,
+		order.Product,
+		order.Side,
+		msg.Price,
+		msg.Quantity,
+		ctx.BlockHeader().Time.Unix(),
+		k.GetParams(ctx).OrderExpireBlocks,
+		order.FeePerBlock,
+	)
+	if !order.IsStopOrder() {
+		replaced.Type = order.Type
+	}
+	replaced.TimeInForce = order.TimeInForce
+	return replaced
+}
+
+// ValidateMsgAmendOrder validates whether the msg of amendOrder is valid, it checks everything before
+// the order is changed so that the amendment is done as a whole
+func ValidateMsgAmendOrder(ctx sdk.Context, k keeper.Keeper, msg types.MsgAmendOrder) error {
+	order := k.GetOrder(ctx, msg.OrderID)
+	if order == nil {
+		return types.ErrOrderIsNotExistOrClosed(msg.OrderID)
+	}
+	if order.Status != types.OrderStatusOpen {
+		return types.ErrOrderStatusIsNotOpen()
+	}
+	if !order.Sender.Equals(msg.Sender) {
+		return types.ErrNotOrderOwner(msg.OrderID)
+	}
+	if k.IsProductLocked(ctx, order.Product) {
+		return types.ErrIsProductLocked(order.Product)
+	}
+	if msg.Price.Equal(order.Price) && msg.Quantity.Equal(order.RemainQuantity) {
+		return types.ErrOrderIsNotAmended(msg.OrderID)
+	}
+
+	newMsg := MsgNewOrder{
+		Sender:   msg.Sender,
+		Product:  order.Product,
+		Side:     order.Side,
+		Price:    msg.Price,
+		Quantity: msg.Quantity,
+	}
+	if err := checkOrderNewMsg(ctx, k, newMsg); err != nil {
+		return err
+	}
+	if msg.Price.Equal(order.Price) && msg.Quantity.LT(order.RemainQuantity) {
+		return nil
+	}
+
+	// the coins & fee unlocked from the amended order are available to the new one
+	replaced := getReplacedOrder(ctx, k, order, msg)
+	available := k.GetCoins(ctx, order.Sender).Add2(order.NeedUnlockCoins()).Add2(keeper.GetOrderNewFee(order))
+	if _, hasNeg := available.SafeSub(replaced.NeedLockCoins().Add2(keeper.GetOrderNewFee(replaced))); hasNeg {
+		return common.ErrInsufficientCoins(DefaultParamspace, fmt.Sprintf("insufficient coins to replace order(%s)", msg.OrderID))
+	}
+	if replaced.TimeInForce == types.TimeInForcePostOnly &&
+		k.GetDepthBookCopy(order.Product).CrossingQuantity(order.Side, msg.Price).IsPositive() {
+		return types.ErrPostOnlyOrderWouldMatch(order.Product)
+	}
+	return nil
+}
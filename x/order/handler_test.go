@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"os"
 	"testing"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/x/supply"
@@ -15,11 +16,18 @@ import (
 
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/dex"
+	dextypes "github.com/okex/exchain/x/dex/types"
 	"github.com/okex/exchain/x/order/types"
 	"github.com/okex/exchain/x/token"
 	tokentypes "github.com/okex/exchain/x/token/types"
 )
 
+func TestMain(m *testing.M) {
+	// the order messages are enabled after the venus height
+	sdk.UnittestOnlySetMilestoneVenusHeight(-1)
+	os.Exit(m.Run())
+}
+
 func TestEventNewOrders(t *testing.T) {
 	common.InitConfig()
 	mapp, addrKeysSlice := getMockApp(t, 1)
@@ -1028,3 +1036,255 @@ func getHash(t *testing.T, orderIdList []string, order *Order) {
 	fmt.Println(orderIdList)
 	fmt.Println(res)
 }
+
+func TestHandleMsgAmendOrder(t *testing.T) {
+	common.InitConfig()
+	mapp, addrKeysSlice := getMockApp(t, 1)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	keeper := mapp.orderKeeper
+	feeParams := types.DefaultTestParams()
+	keeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+	sender := addrKeysSlice[0].Address
+	var orderIDs []string
+	for i := 0; i < 2; i++ {
+		msg := types.NewMsgNewOrders(sender, []types.OrderItem{
+			types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		})
+		res, err := handler(ctx.WithTxBytes([]byte(fmt.Sprintf("order%d", i))), msg)
+		require.Nil(t, err)
+		orderIDs = append(orderIDs, getOrderID(res))
+	}
+	orderNewFee := keeper.GetOrder(ctx, orderIDs[0]).GetExtraInfoWithKey(types.OrderExtraInfoKeyNewFee)
+	coins := keeper.GetCoins(ctx, sender).AmountOf(common.NativeToken)
+	requireLocked := func(expected string) {
+		locked := mapp.tokenKeeper.GetLockedCoins(ctx, sender).AmountOf(common.NativeToken)
+		require.Equal(t, sdk.MustNewDecFromStr(expected), locked)
+	}
+	requireLocked("20.0")
+
+	// nothing to amend
+	msg := types.NewMsgAmendOrder(sender, orderIDs[0], sdk.MustNewDecFromStr("10.0"), sdk.MustNewDecFromStr("1.0"))
+	_, err = handler(ctx, msg)
+	require.NotNil(t, err)
+	// not the owner
+	msg = types.NewMsgAmendOrder(addrKeysSlice[0].Address[1:], orderIDs[0],
+		sdk.MustNewDecFromStr("10.0"), sdk.MustNewDecFromStr("0.5"))
+	_, err = handler(ctx, msg)
+	require.NotNil(t, err)
+
+	// reduce in place, the order keeps its priority
+	msg = types.NewMsgAmendOrder(sender, orderIDs[0], sdk.MustNewDecFromStr("10.0"), sdk.MustNewDecFromStr("0.5"))
+	_, err = handler(ctx, msg)
+	require.Nil(t, err)
+	order0 := keeper.GetOrder(ctx, orderIDs[0])
+	require.EqualValues(t, types.OrderStatusOpen, order0.Status)
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), order0.RemainQuantity)
+	require.Equal(t, sdk.MustNewDecFromStr("5.0"), order0.RemainLocked)
+	key := types.FormatOrderIDsKey(types.TestTokenPair, sdk.MustNewDecFromStr("10.0"), types.BuyOrder)
+	require.Equal(t, orderIDs, keeper.GetProductPriceOrderIDs(key))
+	require.Equal(t, sdk.MustNewDecFromStr("1.5"), keeper.GetDepthBookCopy(types.TestTokenPair).Items[0].BuyQuantity)
+	coins = coins.Add(sdk.MustNewDecFromStr("5.0"))
+	require.Equal(t, coins, keeper.GetCoins(ctx, sender).AmountOf(common.NativeToken))
+	requireLocked("15.0")
+
+	// a replacement which can't be paid leaves the order untouched
+	ctx = ctx.WithBlockHeight(12)
+	msg = types.NewMsgAmendOrder(sender, orderIDs[0], sdk.MustNewDecFromStr("9.9"), sdk.MustNewDecFromStr("10.0"))
+	_, err = handler(ctx, msg)
+	require.NotNil(t, err)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orderIDs[0]).Status)
+	require.Equal(t, 1, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	requireLocked("15.0")
+
+	// a new price cancels and replaces the order, which pays the fee of the 2 blocks it has been open
+	feeCollected := func() sdk.Dec {
+		return mapp.supplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName).GetCoins().AmountOf(common.NativeToken)
+	}
+	feeCollectedBefore := feeCollected()
+	costFee := feeParams.FeePerBlock.Amount.MulInt64(2)
+	lockedFee, err := sdk.ParseDecCoins(orderNewFee)
+	require.Nil(t, err)
+	receiveFee := lockedFee.AmountOf(common.NativeToken).Sub(costFee)
+	msg = types.NewMsgAmendOrder(sender, orderIDs[0], sdk.MustNewDecFromStr("9.9"), sdk.MustNewDecFromStr("0.5"))
+	res, err := handler(ctx.WithTxBytes([]byte("amend")), msg)
+	require.Nil(t, err)
+	require.Equal(t, feeCollectedBefore.Add(costFee), feeCollected())
+	amended := keeper.GetOrder(ctx, orderIDs[0])
+	require.EqualValues(t, types.OrderStatusCancelled, amended.Status)
+	require.Equal(t, sdk.SysCoins{sdk.NewDecCoinFromDec(common.NativeToken, receiveFee)}.String(),
+		amended.GetExtraInfoWithKey(types.OrderExtraInfoKeyReceiveFee))
+	replacedID := getOrderID(res)
+	require.NotEqual(t, orderIDs[0], replacedID)
+	replaced := keeper.GetOrder(ctx, replacedID)
+	require.EqualValues(t, types.OrderStatusOpen, replaced.Status)
+	require.Equal(t, sdk.MustNewDecFromStr("9.9"), replaced.Price)
+	require.Equal(t, orderNewFee, replaced.GetExtraInfoWithKey(types.OrderExtraInfoKeyNewFee))
+	require.Equal(t, []string{orderIDs[1]}, keeper.GetProductPriceOrderIDs(key))
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 2, len(book.Items))
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), book.Items[1].BuyQuantity)
+	// the locked 5.0 & fee of the amended order pay for the 4.95 & fee of the new one, except the fee charged
+	coins = coins.Add(sdk.MustNewDecFromStr("0.05")).Sub(costFee)
+	require.Equal(t, coins, keeper.GetCoins(ctx, sender).AmountOf(common.NativeToken))
+	requireLocked("14.95")
+}
+
+func TestOrderHandlerBeforeVenus(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 1)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+	err := mapp.dexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Nil(t, err)
+
+	sdk.UnittestOnlySetMilestoneVenusHeight(10)
+	defer sdk.UnittestOnlySetMilestoneVenusHeight(-1)
+
+	handler := NewOrderHandler(mapp.orderKeeper)
+	msg := types.NewMsgNewOrders(addrKeysSlice[0].Address,
+		[]types.OrderItem{types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "1.0")})
+	_, err = handler(ctx, msg)
+	require.NotNil(t, err)
+
+	_, err = handler(ctx.WithBlockHeight(11), msg)
+	require.Nil(t, err)
+}
+
+func TestHandleMsgNewOrdersContinuousAuction(t *testing.T) {
+	common.InitConfig()
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	keeper.SetParams(ctx, &feeParams)
+	err := mapp.dexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Nil(t, err)
+	mapp.dexKeeper.SetProductParams(ctx, types.TestTokenPair, dex.ProductParams{AuctionType: dextypes.AuctionTypeContinuous})
+
+	handler := NewOrderHandler(keeper)
+	sellMsg := types.NewMsgNewOrders(addrKeysSlice[0].Address,
+		[]types.OrderItem{types.NewOrderItem(types.TestTokenPair, types.SellOrder, "10.0", "1.0")})
+	res, err := handler(ctx.WithTxBytes([]byte("sell")), sellMsg)
+	require.Nil(t, err)
+	sellOrderID := getOrderID(res)
+
+	// the buy order is filled in the tx that places it
+	buyMsg := types.NewMsgNewOrders(addrKeysSlice[1].Address,
+		[]types.OrderItem{types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.1", "1.0")})
+	res, err = handler(ctx.WithTxBytes([]byte("buy")), buyMsg)
+	require.Nil(t, err)
+	buyOrderID := getOrderID(res)
+
+	sellOrder := keeper.GetOrder(ctx, sellOrderID)
+	buyOrder := keeper.GetOrder(ctx, buyOrderID)
+	require.EqualValues(t, types.OrderStatusFilled, sellOrder.Status)
+	require.EqualValues(t, types.OrderStatusFilled, buyOrder.Status)
+	require.Equal(t, sdk.MustNewDecFromStr("10.0"), buyOrder.FilledAvgPrice)
+	require.Equal(t, sdk.MustNewDecFromStr("10.0"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+	require.Equal(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+}
+
+func TestHandleMsgNewOrdersFillGas(t *testing.T) {
+	common.InitConfig()
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	keeper.SetParams(ctx, &feeParams)
+	err := mapp.dexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Nil(t, err)
+	mapp.dexKeeper.SetProductParams(ctx, types.TestTokenPair, dex.ProductParams{AuctionType: dextypes.AuctionTypeContinuous})
+
+	handler := NewOrderHandler(keeper)
+	for i := 0; i < 3; i++ {
+		sellMsg := types.NewMsgNewOrders(addrKeysSlice[0].Address,
+			[]types.OrderItem{types.NewOrderItem(types.TestTokenPair, types.SellOrder, "10.0", "1.0")})
+		_, err := handler(ctx.WithTxBytes([]byte(fmt.Sprintf("sell%d", i))), sellMsg)
+		require.Nil(t, err)
+	}
+
+	// the buy order fills the 3 sell orders, each fill is charged on the tx
+	buyMsg := types.NewMsgNewOrders(addrKeysSlice[1].Address,
+		[]types.OrderItem{types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.1", "2.5")})
+	msgGas := CalculateGas(buyMsg, &feeParams)
+	gasMeter := sdk.NewGasMeter(msgGas + 2*types.FillGasUnit)
+	_, err = handler(ctx.WithTxBytes([]byte("buy")).WithGasMeter(gasMeter), buyMsg)
+	require.NotNil(t, err)
+	require.Equal(t, 1, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.Equal(t, sdk.MustNewDecFromStr("3.0"), keeper.GetDepthBookCopy(types.TestTokenPair).Items[0].SellQuantity)
+
+	// the params are read on the gas meter of the tx too
+	gasMeter = sdk.NewGasMeter(msgGas + 4*types.FillGasUnit)
+	_, err = handler(ctx.WithTxBytes([]byte("buy")).WithGasMeter(gasMeter), buyMsg)
+	require.Nil(t, err)
+	require.True(t, gasMeter.GasConsumed() >= msgGas+3*types.FillGasUnit)
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), keeper.GetDepthBookCopy(types.TestTokenPair).Items[0].SellQuantity)
+}
+
+func TestHandleMsgNewOrdersOrderTypes(t *testing.T) {
+	common.InitConfig()
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	keeper := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	keeper.SetParams(ctx, &feeParams)
+	err := mapp.dexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+	seller, buyer := addrKeysSlice[0].Address, addrKeysSlice[1].Address
+	newOrder := func(txBytes string, sender sdk.AccAddress, item types.OrderItem) (string, error) {
+		res, err := handler(ctx.WithTxBytes([]byte(txBytes)), types.NewMsgNewOrders(sender, []types.OrderItem{item}))
+		if err != nil {
+			return "", err
+		}
+		return getOrderID(res), nil
+	}
+
+	sellOrderID, err := newOrder("sell", seller, types.NewOrderItem(types.TestTokenPair, types.SellOrder, "10.0", "1.0"))
+	require.Nil(t, err)
+
+	// a post only order which would match is rejected
+	postOnly := types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	postOnly.TimeInForce = types.TimeInForcePostOnly
+	_, err = newOrder("post only", buyer, postOnly)
+	require.NotNil(t, err)
+	postOnly.Price = sdk.MustNewDecFromStr("9.9")
+	postOnlyOrderID, err := newOrder("post only", buyer, postOnly)
+	require.Nil(t, err)
+
+	// a stop limit order waits for its stop price out of the depth book
+	stopLimit := types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "12.1", "1.0")
+	stopLimit.Type, stopLimit.StopPrice = types.OrderTypeStopLimit, sdk.MustNewDecFromStr("12.0")
+	stopLimitOrderID, err := newOrder("stop limit", buyer, stopLimit)
+	require.Nil(t, err)
+	require.EqualValues(t, types.OrderStatusUntriggered, keeper.GetOrder(ctx, stopLimitOrderID).Status)
+	require.Equal(t, 2, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+
+	// a market order is priced by the slippage around the last price, its rest is cancelled after the match
+	market := types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "0", "2.0")
+	market.Type, market.TimeInForce, market.Slippage = types.OrderTypeMarket, types.TimeInForceIOC, sdk.MustNewDecFromStr("0.1")
+	marketOrderID, err := newOrder("market", buyer, market)
+	require.Nil(t, err)
+	lastPrice := keeper.GetLastPrice(ctx, types.TestTokenPair)
+	require.Equal(t, lastPrice.Mul(sdk.MustNewDecFromStr("1.1")), keeper.GetOrder(ctx, marketOrderID).Price)
+	EndBlocker(ctx, keeper)
+
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, sellOrderID).Status)
+	marketOrder := keeper.GetOrder(ctx, marketOrderID)
+	require.EqualValues(t, types.OrderStatusPartialFilledCancelled, marketOrder.Status)
+	require.Equal(t, sdk.MustNewDecFromStr("1.0"), marketOrder.RemainQuantity)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, postOnlyOrderID).Status)
+	require.EqualValues(t, types.OrderStatusUntriggered, keeper.GetOrder(ctx, stopLimitOrderID).Status)
+}
@@ -179,6 +179,18 @@ func (c *DiskCache) GetNewDepthbookKeys() []string {
 
 // insertOrder inserts a new order into orderIDsMap
 func (c *DiskCache) insertOrder(order *types.Order) {
+	c.addOrderToDepthBook(order)
+	c.CountNewOrder()
+}
+
+// CountNewOrder counts a new open order, stop orders are counted before they enter the depth book
+func (c *DiskCache) CountNewOrder() {
+	c.openNum++
+	c.storeOrderNum++
+}
+
+// addOrderToDepthBook inserts an order into depthBookMap and orderIDsMap
+func (c *DiskCache) addOrderToDepthBook(order *types.Order) {
 	// 1. update depthBookMap
 	depthBook, ok := c.depthBookMap.data[order.Product]
 	if !ok {
@@ -199,9 +211,6 @@ func (c *DiskCache) insertOrder(order *types.Order) {
 	orderIDs = append(orderIDs, order.OrderID)
 	orderIDsMap.Data[key] = orderIDs
 	c.orderIDsMap.updatedItems[key] = struct{}{}
-
-	c.openNum++
-	c.storeOrderNum++
 }
 
 func (c *DiskCache) closeOrder(orderID string) {
@@ -209,6 +218,15 @@ func (c *DiskCache) closeOrder(orderID string) {
 	c.openNum--
 }
 
+// reduceOrder reduces the quantity of an order in depthBookMap, it keeps its place in orderIDsMap
+func (c *DiskCache) reduceOrder(order *types.Order, quantity sdk.Dec) {
+	depthBook := c.getDepthBook(order.Product)
+	if depthBook != nil {
+		depthBook.ReduceOrder(order, quantity)
+		c.setDepthBook(order.Product, depthBook)
+	}
+}
+
 // remove an order from orderIDsMap when order cancelled/expired
 func (c *DiskCache) removeOrder(order *types.Order) {
 
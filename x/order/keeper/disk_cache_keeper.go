@@ -140,3 +140,96 @@ func (k Keeper) SetOrderIDs(key string, orderIDs []string) {
 func (k Keeper) GetProductsFromDepthBookMap() []string {
 	return k.diskCache.getProductsFromDepthBookMap()
 }
+
+// ===============================================
+// StopOrderTrigger is the trigger index entry of an untriggered stop order
+// nolint
+func (k Keeper) SetStopOrderTrigger(ctx sdk.Context, trigger types.StopOrderTrigger) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetStopOrderKey(trigger), k.cdc.MustMarshalBinaryBare(trigger))
+}
+
+// nolint
+func (k Keeper) DropStopOrderTrigger(ctx sdk.Context, trigger types.StopOrderTrigger) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetStopOrderKey(trigger))
+}
+
+// IterateStopOrderTriggers iterates over the untriggered stop orders sorted by the product, the side and the stop price
+func (k Keeper) IterateStopOrderTriggers(ctx sdk.Context, cb func(trigger types.StopOrderTrigger) (stop bool)) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.StopOrderKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var trigger types.StopOrderTrigger
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &trigger)
+		if cb(trigger) {
+			break
+		}
+	}
+}
+
+// GetTriggeredStopOrders returns the untriggered stop orders whose stop prices are reached by the last prices.
+// Only the range of the trigger index crossed by the last price of each product is scanned: its buy stop orders
+// from the lowest stop price up to the last price, then its sell stop orders from the highest stop price down to it
+func (k Keeper) GetTriggeredStopOrders(ctx sdk.Context) []types.StopOrderTrigger {
+	store := ctx.KVStore(k.orderStoreKey)
+	var triggers []types.StopOrderTrigger
+	collect := func(iter sdk.Iterator) {
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			var trigger types.StopOrderTrigger
+			k.cdc.MustUnmarshalBinaryBare(iter.Value(), &trigger)
+			triggers = append(triggers, trigger)
+		}
+	}
+
+	start, end := types.StopOrderKey, sdk.PrefixEndBytes(types.StopOrderKey)
+	for {
+		// the next product with untriggered stop orders
+		iter := store.Iterator(start, end)
+		if !iter.Valid() {
+			iter.Close()
+			break
+		}
+		product := types.SplitStopOrderProduct(iter.Key())
+		iter.Close()
+
+		lastPrice := k.GetLastPrice(ctx, product)
+		buyKey := types.GetStopOrderSideKey(product, types.BuyOrder)
+		collect(store.Iterator(buyKey,
+			sdk.PrefixEndBytes(types.GetStopOrderPriceKey(product, types.BuyOrder, lastPrice))))
+		sellKey := types.GetStopOrderSideKey(product, types.SellOrder)
+		collect(store.ReverseIterator(types.GetStopOrderPriceKey(product, types.SellOrder, lastPrice),
+			sdk.PrefixEndBytes(sellKey)))
+
+		start = sdk.PrefixEndBytes(types.GetStopOrderProductKey(product))
+	}
+	return triggers
+}
+
+// ===============================================
+// ImmediateOrder is an IOC or FOK order, the rest of which is cancelled once it is matched
+// nolint
+func (k Keeper) SetImmediateOrder(ctx sdk.Context, orderID string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetImmediateOrderKey(orderID), []byte(orderID))
+}
+
+// nolint
+func (k Keeper) DropImmediateOrder(ctx sdk.Context, orderID string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetImmediateOrderKey(orderID))
+}
+
+// IterateImmediateOrders iterates over the ids of the IOC and FOK orders in the order they are placed
+func (k Keeper) IterateImmediateOrders(ctx sdk.Context, cb func(orderID string) (stop bool)) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.ImmediateOrderKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if cb(string(iter.Value())) {
+			break
+		}
+	}
+}
@@ -44,6 +44,12 @@ func ModuleAccountInvariant(keeper Keeper) sdk.Invariant {
 				}
 			}
 		}
+		// untriggered stop orders lock fee before entering depth book
+		keeper.IterateStopOrderTriggers(ctx, func(trigger types.StopOrderTrigger) bool {
+			order := keeper.GetOrder(ctx, trigger.OrderID)
+			orderLockedFees = orderLockedFees.Add2(GetOrderNewFee(order))
+			return false
+		})
 
 		if !lockedFees.IsEqual(orderLockedFees) {
 			return sdk.FormatInvariant(types.ModuleName, "locks",
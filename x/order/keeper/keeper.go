@@ -363,6 +363,28 @@ func (k Keeper) SetBlockMatchResult(result *types.BlockMatchResult) {
 	}
 }
 
+// AddMatchResult merges the match result of the product into the block match result, the deals
+// of a product matched more than once in the block are appended to the ones matched before
+func (k Keeper) AddMatchResult(ctx sdk.Context, product string, result types.MatchResult) {
+	if !k.enableBackend {
+		return
+	}
+	blockMatchResult := k.cache.getBlockMatchResult()
+	if blockMatchResult == nil || blockMatchResult.ResultMap == nil {
+		blockMatchResult = &types.BlockMatchResult{
+			BlockHeight: ctx.BlockHeight(),
+			ResultMap:   make(map[string]types.MatchResult),
+			TimeStamp:   ctx.BlockHeader().Time.Unix(),
+		}
+		k.cache.setBlockMatchResult(blockMatchResult)
+	}
+	if last, ok := blockMatchResult.ResultMap[product]; ok {
+		result.Quantity = last.Quantity.Add(result.Quantity)
+		result.Deals = append(last.Deals, result.Deals...)
+	}
+	blockMatchResult.ResultMap[product] = result
+}
+
 // LockCoins locks coins from the specified address,
 func (k Keeper) LockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.SysCoins, lockCoinsType int) error {
 	if coins.IsZero() {
@@ -508,6 +530,19 @@ func (k Keeper) RemoveOrderFromDepthBook(order *types.Order, feeType string) {
 	k.diskCache.removeOrder(order)
 }
 
+// RemoveOrderFromTriggerIndex removes the untriggered stop order from the trigger index when it is cancelled/expired
+func (k Keeper) RemoveOrderFromTriggerIndex(ctx sdk.Context, order *types.Order, feeType string) {
+	k.addUpdatedOrderID(order.OrderID)
+	if feeType == types.FeeTypeOrderCancel {
+		k.cache.IncreaseCancelNum()
+	} else if feeType == types.FeeTypeOrderExpire {
+		k.cache.IncreaseExpireNum()
+	}
+
+	k.DropStopOrderTrigger(ctx, types.NewStopOrderTrigger(order))
+	k.diskCache.closeOrder(order.OrderID)
+}
+
 // nolint
 func (k Keeper) UpdateOrder(order *types.Order, ctx sdk.Context) {
 	// update order to keeper
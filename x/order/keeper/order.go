@@ -39,6 +39,12 @@ func (k Keeper) PlaceOrder(ctx sdk.Context, order *types.Order) error {
 	if err != nil {
 		return err
 	}
+	k.insertLockedOrder(ctx, order, fee)
+	return nil
+}
+
+// insertLockedOrder sets the order whose coins & fee are locked, and inserts it into the depth book
+func (k Keeper) insertLockedOrder(ctx sdk.Context, order *types.Order, fee sdk.SysCoins) {
 	order.RecordOrderNewFee(fee)
 	k.AddFeeDetail(ctx, order.Sender, fee, types.FeeTypeOrderNew)
 
@@ -51,9 +57,115 @@ func (k Keeper) PlaceOrder(ctx sdk.Context, order *types.Order) error {
 
 	// update depth book and orderIDsMap in cache
 	k.InsertOrderIntoDepthBook(order)
+	if order.IsImmediateOrder() {
+		k.SetImmediateOrder(ctx, order.OrderID)
+	}
+}
+
+// ReplaceOrder cancels the open order and places the new order instead in one step. The cancelled order pays the
+// fee of the blocks it has been open like CancelOrder does. The coins & fee of the new order are locked before the
+// depth book is changed, so the order is untouched if it fails
+func (k Keeper) ReplaceOrder(ctx sdk.Context, order, replaced *types.Order) error {
+	// the coins & the fee left by the cancelled order are available to the new one
+	lockedFee := GetOrderNewFee(order)
+	fee := GetOrderCostFee(order, ctx)
+	receiveFee := lockedFee.Sub(fee)
+	k.UnlockCoins(ctx, order.Sender, order.NeedUnlockCoins(), token.LockCoinsTypeQuantity)
+	k.UnlockCoins(ctx, order.Sender, lockedFee, token.LockCoinsTypeFee)
+	if err := k.AddCollectedFees(ctx, fee, order.Sender, types.FeeTypeOrderCancel, false); err != nil {
+		return err
+	}
+	newFee, err := k.TryPlaceOrder(ctx, replaced)
+	if err != nil {
+		return err
+	}
+
+	order.Cancel()
+	k.AddFeeDetail(ctx, order.Sender, receiveFee, types.FeeTypeOrderReceive)
+	order.RecordOrderReceiveFee(receiveFee)
+	order.Unlock()
+	k.SetOrder(ctx, order.OrderID, order)
+	k.RemoveOrderFromDepthBook(order, types.FeeTypeOrderCancel)
+
+	k.insertLockedOrder(ctx, replaced, newFee)
+	return nil
+}
+
+// PlaceStopOrder charges fee & locks coins like PlaceOrder, but keeps the order in the trigger index
+// instead of the depth book until the last price reaches its stop price
+func (k Keeper) PlaceStopOrder(ctx sdk.Context, order *types.Order) error {
+	fee, err := k.TryPlaceOrder(ctx, order)
+	if err != nil {
+		return err
+	}
+	order.RecordOrderNewFee(fee)
+	k.AddFeeDetail(ctx, order.Sender, fee, types.FeeTypeOrderNew)
+
+	blockHeight := ctx.BlockHeight()
+	orderNum := k.GetBlockOrderNum(ctx, blockHeight)
+	order.OrderID = types.FormatOrderID(blockHeight, orderNum+1)
+	order.Status = types.OrderStatusUntriggered
+
+	k.SetBlockOrderNum(ctx, blockHeight, orderNum+1)
+	k.SetOrder(ctx, order.OrderID, order)
+	k.SetStopOrderTrigger(ctx, types.NewStopOrderTrigger(order))
+	k.diskCache.CountNewOrder()
 	return nil
 }
 
+// TriggerStopOrder moves the untriggered stop order from the trigger index into the depth book
+func (k Keeper) TriggerStopOrder(ctx sdk.Context, order *types.Order) {
+	order.Trigger()
+	k.SetOrder(ctx, order.OrderID, order)
+	k.addUpdatedOrderID(order.OrderID)
+	k.DropStopOrderTrigger(ctx, types.NewStopOrderTrigger(order))
+
+	// the order was counted when it was placed
+	k.diskCache.addOrderToDepthBook(order)
+	if order.IsImmediateOrder() {
+		k.SetImmediateOrder(ctx, order.OrderID)
+	}
+}
+
+// CancelImmediateOrders cancels the rest of the IOC and FOK orders after the match of the block,
+// the orders of the locked products wait until their products are unlocked
+func (k Keeper) CancelImmediateOrders(ctx sdk.Context) {
+	logger := ctx.Logger().With("module", "order")
+	var orderIDs []string
+	k.IterateImmediateOrders(ctx, func(orderID string) bool {
+		orderIDs = append(orderIDs, orderID)
+		return false
+	})
+
+	for _, orderID := range orderIDs {
+		k.CancelImmediateOrder(ctx, orderID, logger)
+	}
+}
+
+// CancelImmediateOrder cancels the rest of the IOC or FOK order unless its product is locked
+func (k Keeper) CancelImmediateOrder(ctx sdk.Context, orderID string, logger log.Logger) {
+	order := k.GetOrder(ctx, orderID)
+	if order != nil && order.Status == types.OrderStatusOpen {
+		if k.IsProductLocked(ctx, order.Product) {
+			return
+		}
+		k.CancelOrder(ctx, order, logger)
+	}
+	k.DropImmediateOrder(ctx, orderID)
+}
+
+// ReduceOrder reduces the remaining quantity of the open order in place so that it keeps its priority,
+// and unlocks the coins of the reduced quantity
+func (k Keeper) ReduceOrder(ctx sdk.Context, order *types.Order, remainQuantity sdk.Dec) {
+	reduced := order.RemainQuantity.Sub(remainQuantity)
+	unlockCoins := order.Reduce(reduced)
+	k.UnlockCoins(ctx, order.Sender, unlockCoins, token.LockCoinsTypeQuantity)
+
+	k.SetOrder(ctx, order.OrderID, order)
+	k.addUpdatedOrderID(order.OrderID)
+	k.diskCache.reduceOrder(order, reduced)
+}
+
 // ExpireOrder quits the specified order with the expired state
 func (k Keeper) ExpireOrder(ctx sdk.Context, order *types.Order, logger log.Logger) {
 	k.quitOrder(ctx, order, types.FeeTypeOrderExpire, logger)
@@ -66,6 +178,7 @@ func (k Keeper) CancelOrder(ctx sdk.Context, order *types.Order, logger log.Logg
 
 // quitOrder unlocks & charges fee, unlocks coins, updates order, and updates DepthBook
 func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, logger log.Logger) (fee sdk.SysCoins) {
+	untriggered := order.Status == types.OrderStatusUntriggered
 	switch feeType {
 	case types.FeeTypeOrderCancel:
 		order.Cancel()
@@ -96,6 +209,11 @@ func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, l
 	order.Unlock()
 	k.SetOrder(ctx, order.OrderID, order)
 
+	if untriggered {
+		// untriggered stop orders are only in the trigger index
+		k.RemoveOrderFromTriggerIndex(ctx, order, feeType)
+		return fee
+	}
 	// remove order from depth book cache
 	k.RemoveOrderFromDepthBook(order, feeType)
 	return fee
@@ -109,7 +227,8 @@ func (k Keeper) DropExpiredOrdersByBlockHeight(ctx sdk.Context, expiredBlockHeig
 	for ; iter.Valid(); iter.Next() {
 		var order types.Order
 		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &order)
-		if order.Status == types.OrderStatusOpen && !k.IsProductLocked(ctx, order.Product) {
+		isOpen := order.Status == types.OrderStatusOpen || order.Status == types.OrderStatusUntriggered
+		if isOpen && !k.IsProductLocked(ctx, order.Product) {
 			k.ExpireOrder(ctx, &order, logger)
 			logger.Info(fmt.Sprintf("order (%s) expired", order.OrderID))
 		}
@@ -206,3 +206,132 @@ func TestPlaceOrderAndExpireOrder(t *testing.T) {
 	require.EqualValues(t, 0, keeper.diskCache.openNum)
 	require.EqualValues(t, 1, keeper.cache.expireNum)
 }
+
+func TestPlaceStopOrderAndTrigger(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	orders := []*types.Order{
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "9.0", "1.0"),
+	}
+	for _, order := range orders {
+		order.Sender = testInput.TestAddrs[0]
+		order.Type = types.OrderTypeStopLimit
+		order.RecordStopPrice(order.Price)
+		err = keeper.PlaceStopOrder(ctx, order)
+		require.Nil(t, err)
+	}
+
+	// stop orders lock coins but wait in the trigger index
+	require.EqualValues(t, types.OrderStatusUntriggered, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	var triggers []types.StopOrderTrigger
+	keeper.IterateStopOrderTriggers(ctx, func(trigger types.StopOrderTrigger) bool {
+		triggers = append(triggers, trigger)
+		return false
+	})
+	require.Equal(t, 2, len(triggers))
+	require.Equal(t, orders[0].OrderID, triggers[0].OrderID)
+	require.Equal(t, sdk.MustNewDecFromStr("10.0"), triggers[0].StopPrice)
+	require.EqualValues(t, 2, keeper.diskCache.openNum)
+
+	// trigger the first one
+	keeper.TriggerStopOrder(ctx, orders[0])
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	depthBook := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 1, len(depthBook.Items))
+	require.Equal(t, sdk.MustNewDecFromStr("1.0"), depthBook.Items[0].BuyQuantity)
+	require.EqualValues(t, 2, keeper.diskCache.openNum)
+
+	// cancel the untriggered one
+	keeper.CancelOrder(ctx, orders[1], ctx.Logger())
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	triggers = nil
+	keeper.IterateStopOrderTriggers(ctx, func(trigger types.StopOrderTrigger) bool {
+		triggers = append(triggers, trigger)
+		return false
+	})
+	require.Equal(t, 0, len(triggers))
+	require.Equal(t, []string{orders[1].OrderID}, keeper.GetDiskCache().GetClosedOrderIDs())
+	require.EqualValues(t, 1, keeper.diskCache.openNum)
+}
+
+func TestGetTriggeredStopOrders(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	stopPrices := map[string][]string{
+		types.BuyOrder:  {"10.5", "9.0", "20.0", "10.0"},
+		types.SellOrder: {"9.5", "10.0", "0.5", "11.0"},
+	}
+	for side, prices := range stopPrices {
+		for _, price := range prices {
+			order := mockOrder("", types.TestTokenPair, side, price, "1.0")
+			order.Sender = testInput.TestAddrs[0]
+			order.Type = types.OrderTypeStopLimit
+			order.RecordStopPrice(order.Price)
+			require.Nil(t, keeper.PlaceStopOrder(ctx, order))
+		}
+	}
+
+	// the triggered ones are sorted in the order the price reaches them
+	getStopPrices := func(lastPrice string) []string {
+		keeper.SetLastPrice(ctx, types.TestTokenPair, sdk.MustNewDecFromStr(lastPrice))
+		var prices []string
+		for _, trigger := range keeper.GetTriggeredStopOrders(ctx) {
+			require.True(t, trigger.IsTriggered(sdk.MustNewDecFromStr(lastPrice)))
+			prices = append(prices, trigger.Side+":"+trigger.StopPrice.String())
+		}
+		return prices
+	}
+	require.Equal(t, []string{
+		"BUY:9.000000000000000000", "BUY:10.000000000000000000",
+		"SELL:11.000000000000000000", "SELL:10.000000000000000000",
+	}, getStopPrices("10.0"))
+	require.Equal(t, []string{
+		"BUY:9.000000000000000000", "BUY:10.000000000000000000", "BUY:10.500000000000000000",
+		"SELL:11.000000000000000000",
+	}, getStopPrices("10.8"))
+	require.Equal(t, []string{
+		"SELL:11.000000000000000000", "SELL:10.000000000000000000", "SELL:9.500000000000000000",
+	}, getStopPrices("8.0"))
+}
+
+func TestCancelImmediateOrders(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	ioc := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	ioc.Sender = testInput.TestAddrs[0]
+	ioc.TimeInForce = types.TimeInForceIOC
+	gtc := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	gtc.Sender = testInput.TestAddrs[0]
+	require.Nil(t, keeper.PlaceOrder(ctx, ioc))
+	require.Nil(t, keeper.PlaceOrder(ctx, gtc))
+
+	keeper.CancelImmediateOrders(ctx)
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, ioc.OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, gtc.OrderID).Status)
+	var orderIDs []string
+	keeper.IterateImmediateOrders(ctx, func(orderID string) bool {
+		orderIDs = append(orderIDs, orderID)
+		return false
+	})
+	require.Equal(t, 0, len(orderIDs))
+}
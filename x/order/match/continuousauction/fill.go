@@ -0,0 +1,113 @@
+package continuousauction
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	orderkeeper "github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match/periodicauction"
+	"github.com/okex/exchain/x/order/types"
+)
+
+// fillDepthBook fills the best buy order against the best sell order while they cross. The orders
+// at the same price are filled in the order they are placed, and each fill is at the price of the
+// order placed earlier. It returns the deals, the price of the last fill and the filled quantity.
+func fillDepthBook(ctx sdk.Context, keeper orderkeeper.Keeper, product string,
+	feeParams *types.Params) (deals []types.Deal, lastPrice, quantity sdk.Dec) {
+
+	quantity = sdk.ZeroDec()
+	book := keeper.GetDepthBookCopy(product)
+	for {
+		buyIndex, sellIndex := bestBuyIndex(book), bestSellIndex(book)
+		if buyIndex < 0 || sellIndex < 0 || book.Items[buyIndex].Price.LT(book.Items[sellIndex].Price) {
+			break
+		}
+
+		buyKey := types.FormatOrderIDsKey(product, book.Items[buyIndex].Price, types.BuyOrder)
+		sellKey := types.FormatOrderIDsKey(product, book.Items[sellIndex].Price, types.SellOrder)
+		buyOrderIDs := keeper.GetProductPriceOrderIDs(buyKey)
+		sellOrderIDs := keeper.GetProductPriceOrderIDs(sellKey)
+		if len(buyOrderIDs) == 0 || len(sellOrderIDs) == 0 {
+			ctx.Logger().Error(fmt.Sprintf("[Order] depth book of %s is out of sync with the order ids", product))
+			break
+		}
+		buyOrder := keeper.GetOrder(ctx, buyOrderIDs[0])
+		sellOrder := keeper.GetOrder(ctx, sellOrderIDs[0])
+
+		// the order placed earlier is the maker, fill at its price
+		fillPrice := sellOrder.Price
+		if isPlacedBefore(buyOrder.OrderID, sellOrder.OrderID) {
+			fillPrice = buyOrder.Price
+		}
+		fillQuantity := sdk.MinDec(buyOrder.RemainQuantity, sellOrder.RemainQuantity)
+
+		for _, order := range []*types.Order{buyOrder, sellOrder} {
+			if deal := periodicauction.FillOrder(order, ctx, keeper, fillPrice, fillQuantity, feeParams); deal != nil {
+				deals = append(deals, *deal)
+			}
+		}
+		if buyOrder.Status == types.OrderStatusFilled {
+			keeper.SetOrderIDs(buyKey, remainOrderIDs(buyOrderIDs))
+		}
+		if sellOrder.Status == types.OrderStatusFilled {
+			keeper.SetOrderIDs(sellKey, remainOrderIDs(sellOrderIDs))
+		}
+
+		book.Sub(buyIndex, fillQuantity, types.BuyOrder)
+		book.Sub(sellIndex, fillQuantity, types.SellOrder)
+		// items are sorted by price desc, so sellIndex >= buyIndex
+		book.RemoveIfEmpty(sellIndex)
+		if sellIndex != buyIndex {
+			book.RemoveIfEmpty(buyIndex)
+		}
+
+		lastPrice = fillPrice
+		quantity = quantity.Add(fillQuantity)
+	}
+	keeper.SetDepthBook(product, book)
+
+	return deals, lastPrice, quantity
+}
+
+// bestBuyIndex returns the index of the highest buy price in the depth book, -1 if there is no buy order
+func bestBuyIndex(book *types.DepthBook) int {
+	for i := 0; i < len(book.Items); i++ {
+		if book.Items[i].BuyQuantity.IsPositive() {
+			return i
+		}
+	}
+	return -1
+}
+
+// bestSellIndex returns the index of the lowest sell price in the depth book, -1 if there is no sell order
+func bestSellIndex(book *types.DepthBook) int {
+	for i := len(book.Items) - 1; i >= 0; i-- {
+		if book.Items[i].SellQuantity.IsPositive() {
+			return i
+		}
+	}
+	return -1
+}
+
+// remainOrderIDs drops the first order id, which is filled
+func remainOrderIDs(orderIDs []string) []string {
+	// Note: orderIDs cannot be nil, we will use empty slice to remove Data on keeper
+	remain := []string{}
+	return append(remain, orderIDs[1:]...)
+}
+
+// isPlacedBefore returns true if the order of orderID is placed before the order of otherID
+func isPlacedBefore(orderID, otherID string) bool {
+	var height, num, otherHeight, otherNum int64
+	if _, err := fmt.Sscanf(orderID, "ID%d-%d", &height, &num); err != nil {
+		return orderID < otherID
+	}
+	if _, err := fmt.Sscanf(otherID, "ID%d-%d", &otherHeight, &otherNum); err != nil {
+		return orderID < otherID
+	}
+	if height != otherHeight {
+		return height < otherHeight
+	}
+	return num < otherNum
+}
@@ -4,13 +4,108 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
 	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
 )
 
-// nolint
+// CaEngine is the continuous auction match engine, the new orders are matched at once
+// against the depth book in price-time priority
 type CaEngine struct {
 }
 
-// nolint
+// Run matches the products which turned to continuous auction in the block, their orders
+// placed before may still cross
 func (e *CaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
-	// TODO
+	products := keeper.GetDiskCache().GetNewDepthbookKeys()
+	products = keeper.FilterDelistedProducts(ctx, products)
+	keeper.GetDexKeeper().SortProducts(ctx, products)
+
+	for _, product := range products {
+		if !keeper.GetDexKeeper().GetProductParams(ctx, product).IsContinuousAuction() {
+			continue
+		}
+		matchProduct(ctx, keeper, product)
+	}
+}
+
+// MatchOrder matches the new order against the orders in the depth book, the rest of it
+// stays in the depth book unless it's an IOC or FOK order
+func (e *CaEngine) MatchOrder(ctx sdk.Context, keeper keeper.Keeper, order *types.Order) {
+	// a FOK order is only matched if the depth book fills all of it
+	if order.TimeInForce != types.TimeInForceFOK || isFillable(keeper, order) {
+		matchProduct(ctx, keeper, order.Product)
+	}
+	if order.IsImmediateOrder() {
+		keeper.CancelImmediateOrder(ctx, order.OrderID, ctx.Logger().With("module", "order"))
+	}
+}
+
+// CountFills returns the number of the fills of the new order, which fills the opposite orders crossing it in
+// price-time priority until it's filled
+func (e *CaEngine) CountFills(ctx sdk.Context, keeper keeper.Keeper, order *types.Order) int {
+	if order.TimeInForce == types.TimeInForceFOK && !isFillable(keeper, order) {
+		return 0
+	}
+
+	book := keeper.GetDepthBookCopy(order.Product)
+	remain := order.RemainQuantity
+	fills := 0
+	for i := range book.Items {
+		// the best prices come first, the items are sorted by price desc
+		item := book.Items[i]
+		side, quantity := types.BuyOrder, item.BuyQuantity
+		if order.Side == types.BuyOrder {
+			item = book.Items[len(book.Items)-1-i]
+			side, quantity = types.SellOrder, item.SellQuantity
+		}
+		if !remain.IsPositive() || (order.Side == types.BuyOrder && item.Price.GT(order.Price)) ||
+			(order.Side == types.SellOrder && item.Price.LT(order.Price)) {
+			break
+		}
+		if !quantity.IsPositive() {
+			continue
+		}
+
+		orderIDs := keeper.GetProductPriceOrderIDs(types.FormatOrderIDsKey(order.Product, item.Price, side))
+		if remain.GTE(quantity) {
+			// all the orders at the price are filled
+			fills += len(orderIDs)
+			remain = remain.Sub(quantity)
+			continue
+		}
+		for _, orderID := range orderIDs {
+			if !remain.IsPositive() {
+				break
+			}
+			if opposite := keeper.GetOrder(ctx, orderID); opposite != nil {
+				fills++
+				remain = remain.Sub(opposite.RemainQuantity)
+			}
+		}
+	}
+	return fills
+}
+
+// isFillable returns true if the opposite orders in the depth book are enough to fill the order
+func isFillable(keeper keeper.Keeper, order *types.Order) bool {
+	book := keeper.GetDepthBookCopy(order.Product)
+	return book.CrossingQuantity(order.Side, order.Price).GTE(order.RemainQuantity)
+}
+
+func matchProduct(ctx sdk.Context, keeper keeper.Keeper, product string) {
+	if keeper.IsProductLocked(ctx, product) {
+		return
+	}
+
+	deals, price, quantity := fillDepthBook(ctx, keeper, product, keeper.GetParams(ctx))
+	if len(deals) == 0 {
+		return
+	}
+
+	keeper.SetLastPrice(ctx, product, price)
+	keeper.AddMatchResult(ctx, product, types.MatchResult{
+		BlockHeight: ctx.BlockHeight(),
+		Price:       price,
+		Quantity:    quantity,
+		Deals:       deals,
+	})
 }
@@ -0,0 +1,156 @@
+package continuousauction
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/dex"
+	dextypes "github.com/okex/exchain/x/dex/types"
+	orderkeeper "github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaEngine_MatchOrder(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	testInput.DexKeeper.SetProductParams(ctx, types.TestTokenPair,
+		dextypes.ProductParams{AuctionType: dextypes.AuctionTypeContinuous})
+
+	var startHeight int64 = 10
+
+	// mock orders, the last one is filled at once against the others
+	orders := []*types.Order{
+		types.MockOrder(types.FormatOrderID(startHeight, 1), types.TestTokenPair, types.SellOrder, "10.1", "1.0"),
+		types.MockOrder(types.FormatOrderID(startHeight, 2), types.TestTokenPair, types.SellOrder, "10.0", "0.5"),
+		types.MockOrder(types.FormatOrderID(startHeight, 3), types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.MockOrder(types.FormatOrderID(startHeight, 4), types.TestTokenPair, types.BuyOrder, "10.2", "2.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[1]
+	orders[1].Sender = testInput.TestAddrs[1]
+	orders[2].Sender = testInput.TestAddrs[1]
+	orders[3].Sender = testInput.TestAddrs[0]
+
+	engine := &CaEngine{}
+	for i := 0; i < 4; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.NoError(t, err)
+		engine.MatchOrder(ctx, keeper, orders[i])
+	}
+
+	// the sell orders at 10.0 are filled first, in the order they are placed, at their price
+	order0 := keeper.GetOrder(ctx, orders[0].OrderID)
+	order1 := keeper.GetOrder(ctx, orders[1].OrderID)
+	order2 := keeper.GetOrder(ctx, orders[2].OrderID)
+	order3 := keeper.GetOrder(ctx, orders[3].OrderID)
+	require.EqualValues(t, types.OrderStatusOpen, order0.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), order0.RemainQuantity)
+	require.EqualValues(t, types.OrderStatusFilled, order1.Status)
+	require.EqualValues(t, types.OrderStatusFilled, order2.Status)
+	require.EqualValues(t, types.OrderStatusFilled, order3.Status)
+	// (0.5 * 10.0 + 1.0 * 10.0 + 0.5 * 10.1) / 2
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.025"), order3.FilledAvgPrice)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.1"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+
+	// only the rest of the first sell order stays in the depth book
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.1"), book.Items[0].Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), book.Items[0].SellQuantity)
+	require.True(t, book.Items[0].BuyQuantity.IsZero())
+	key := types.FormatOrderIDsKey(types.TestTokenPair, sdk.MustNewDecFromStr("10.0"), types.SellOrder)
+	require.EqualValues(t, 0, len(keeper.GetProductPriceOrderIDs(key)))
+	key = types.FormatOrderIDsKey(types.TestTokenPair, sdk.MustNewDecFromStr("10.2"), types.BuyOrder)
+	require.EqualValues(t, 0, len(keeper.GetProductPriceOrderIDs(key)))
+}
+
+func TestCaEngine_Run(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	var startHeight int64 = 10
+
+	// the orders are placed while the product is in periodic auction, they cross
+	orders := []*types.Order{
+		types.MockOrder(types.FormatOrderID(startHeight, 1), types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		types.MockOrder(types.FormatOrderID(startHeight, 2), types.TestTokenPair, types.SellOrder, "9.0", "2.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[1]
+	for i := 0; i < 2; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.NoError(t, err)
+	}
+
+	engine := &CaEngine{}
+	engine.Run(ctx, keeper)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+
+	testInput.DexKeeper.SetProductParams(ctx, types.TestTokenPair,
+		dextypes.ProductParams{AuctionType: dextypes.AuctionTypeContinuous})
+	engine.Run(ctx, keeper)
+
+	// filled at the price of the buy order placed earlier
+	order0 := keeper.GetOrder(ctx, orders[0].OrderID)
+	order1 := keeper.GetOrder(ctx, orders[1].OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, order0.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), order0.FilledAvgPrice)
+	require.EqualValues(t, types.OrderStatusOpen, order1.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), order1.RemainQuantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+}
+
+func TestIsPlacedBefore(t *testing.T) {
+	require.True(t, isPlacedBefore(types.FormatOrderID(10, 2), types.FormatOrderID(10, 10)))
+	require.False(t, isPlacedBefore(types.FormatOrderID(10, 10), types.FormatOrderID(10, 2)))
+	require.True(t, isPlacedBefore(types.FormatOrderID(9, 10), types.FormatOrderID(10, 1)))
+}
+
+func TestCaEngine_MatchImmediateOrder(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	testInput.DexKeeper.SetProductParams(ctx, types.TestTokenPair,
+		dextypes.ProductParams{AuctionType: dextypes.AuctionTypeContinuous})
+
+	var startHeight int64 = 10
+	orders := []*types.Order{
+		types.MockOrder(types.FormatOrderID(startHeight, 1), types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.MockOrder(types.FormatOrderID(startHeight, 2), types.TestTokenPair, types.BuyOrder, "10.0", "2.0"),
+		types.MockOrder(types.FormatOrderID(startHeight, 3), types.TestTokenPair, types.BuyOrder, "10.0", "2.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[1]
+	orders[1].Sender = testInput.TestAddrs[0]
+	orders[1].TimeInForce = types.TimeInForceFOK
+	orders[2].Sender = testInput.TestAddrs[0]
+	orders[2].TimeInForce = types.TimeInForceIOC
+
+	engine := &CaEngine{}
+	for i := 0; i < 3; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.NoError(t, err)
+		engine.MatchOrder(ctx, keeper, orders[i])
+	}
+
+	// the FOK order can't be filled in full, it's cancelled without any fill
+	order1 := keeper.GetOrder(ctx, orders[1].OrderID)
+	require.EqualValues(t, types.OrderStatusCancelled, order1.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2.0"), order1.RemainQuantity)
+	// the IOC order is partially filled, and the rest is cancelled
+	order2 := keeper.GetOrder(ctx, orders[2].OrderID)
+	require.EqualValues(t, types.OrderStatusPartialFilledCancelled, order2.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), order2.RemainQuantity)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+}
@@ -1,38 +1,143 @@
 package match
 
 import (
-	"sync"
+	"fmt"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
+	dextypes "github.com/okex/exchain/x/dex/types"
 	"github.com/okex/exchain/x/order/keeper"
 	"github.com/okex/exchain/x/order/match/continuousauction"
 	"github.com/okex/exchain/x/order/match/periodicauction"
+	"github.com/okex/exchain/x/order/types"
 )
 
 // nolint
-const DefaultAuctionType = "periodicauction"
+const DefaultAuctionType = dextypes.AuctionTypePeriodic
 
 // nolint
 var (
-	once        sync.Once
-	engine      Engine
-	auctionType = DefaultAuctionType
+	engines = map[string]Engine{
+		dextypes.AuctionTypePeriodic:   &periodicauction.PaEngine{},
+		dextypes.AuctionTypeContinuous: &continuousauction.CaEngine{},
+	}
+	// engines run at the end of the block in this order
+	auctionTypes = []string{dextypes.AuctionTypePeriodic, dextypes.AuctionTypeContinuous}
 )
 
-// GetEngine : periodic auction only today
-func GetEngine() Engine {
-	once.Do(func() {
-		if auctionType == DefaultAuctionType {
-			engine = &periodicauction.PaEngine{}
-		} else {
-			engine = &continuousauction.CaEngine{}
+// GetEngine returns the engine of the auction type, the one of the default auction type if it's unknown
+func GetEngine(auctionType string) Engine {
+	if engine, ok := engines[auctionType]; ok {
+		return engine
+	}
+	return engines[DefaultAuctionType]
+}
+
+// GetProductEngine returns the engine selected by the params of the product in dex
+func GetProductEngine(ctx sdk.Context, keeper keeper.Keeper, product string) Engine {
+	return GetEngine(keeper.GetDexKeeper().GetProductParams(ctx, product).AuctionType)
+}
+
+// Run runs the engines of all the auction types at the end of the block, then cancels
+// the rest of the IOC and FOK orders
+func Run(ctx sdk.Context, keeper keeper.Keeper) {
+	for _, auctionType := range auctionTypes {
+		engines[auctionType].Run(ctx, keeper)
+	}
+	keeper.CancelImmediateOrders(ctx)
+}
+
+// PlaceOrder places the new order into the depth book and matches it by the engine of its product,
+// stop orders wait in the trigger index until the last price reaches their stop prices
+func PlaceOrder(ctx sdk.Context, keeper keeper.Keeper, order *types.Order) error {
+	if order.IsStopOrder() {
+		return keeper.PlaceStopOrder(ctx, order)
+	}
+	if order.TimeInForce == types.TimeInForcePostOnly && isCrossing(keeper, order) {
+		return types.ErrPostOnlyOrderWouldMatch(order.Product)
+	}
+	engine := GetProductEngine(ctx, keeper, order.Product)
+	if err := chargeFills(ctx, keeper, engine, order); err != nil {
+		return err
+	}
+	if err := keeper.PlaceOrder(ctx, order); err != nil {
+		return err
+	}
+	engine.MatchOrder(ctx, keeper, order)
+	return nil
+}
+
+// ReplaceOrder cancels the open order, then places the new order and matches it
+// by the engine of its product. The order is untouched if the new one can't be placed
+func ReplaceOrder(ctx sdk.Context, keeper keeper.Keeper, order, replaced *types.Order) error {
+	if replaced.TimeInForce == types.TimeInForcePostOnly && isCrossing(keeper, replaced) {
+		return types.ErrPostOnlyOrderWouldMatch(replaced.Product)
+	}
+	engine := GetProductEngine(ctx, keeper, replaced.Product)
+	if err := chargeFills(ctx, keeper, engine, replaced); err != nil {
+		return err
+	}
+	if err := keeper.ReplaceOrder(ctx, order, replaced); err != nil {
+		return err
+	}
+	engine.MatchOrder(ctx, keeper, replaced)
+	return nil
+}
+
+// chargeFills charges the fills of the new order matched at once on the tx placing it, before anything is changed
+func chargeFills(ctx sdk.Context, keeper keeper.Keeper, engine Engine, order *types.Order) error {
+	fills := engine.CountFills(ctx, keeper, order)
+	if fills == 0 {
+		return nil
+	}
+	return types.ConsumeTxGas(ctx, uint64(fills)*types.FillGasUnit, "order fills")
+}
+
+// TriggerStopOrders moves the stop orders whose stop prices are reached by the last prices into
+// the depth book. The stop orders of a product are triggered in the order the price reaches them:
+// the buy ones from the lowest stop price, then the sell ones from the highest stop price
+func TriggerStopOrders(ctx sdk.Context, keeper keeper.Keeper) {
+	logger := ctx.Logger().With("module", "order")
+	triggers := keeper.GetTriggeredStopOrders(ctx)
+
+	for _, trigger := range triggers {
+		if keeper.IsProductLocked(ctx, trigger.Product) {
+			continue
+		}
+		order := keeper.GetOrder(ctx, trigger.OrderID)
+		if order == nil || order.Status != types.OrderStatusUntriggered {
+			keeper.DropStopOrderTrigger(ctx, trigger)
+			continue
+		}
+		if keeper.GetDexKeeper().GetTokenPair(ctx, order.Product) == nil {
+			keeper.CancelOrder(ctx, order, logger)
+			continue
 		}
-	})
-	return engine
+
+		// a post only order which would match is cancelled when it is triggered
+		crossing := order.TimeInForce == types.TimeInForcePostOnly && isCrossing(keeper, order)
+		keeper.TriggerStopOrder(ctx, order)
+		logger.Info(fmt.Sprintf("stop order(%s) is triggered", order.OrderID))
+		if crossing {
+			keeper.CancelOrder(ctx, order, logger)
+			continue
+		}
+		GetProductEngine(ctx, keeper, order.Product).MatchOrder(ctx, keeper, order)
+	}
+}
+
+// isCrossing returns true if the order would match the opposite orders in the depth book
+func isCrossing(keeper keeper.Keeper, order *types.Order) bool {
+	book := keeper.GetDepthBookCopy(order.Product)
+	return book.CrossingQuantity(order.Side, order.Price).IsPositive()
 }
 
 // nolint
 type Engine interface {
+	// Run is called at the end of every block
 	Run(ctx sdk.Context, keeper keeper.Keeper)
+	// MatchOrder is called once a new order is placed into the depth book
+	MatchOrder(ctx sdk.Context, keeper keeper.Keeper, order *types.Order)
+	// CountFills returns the number of the fills of a new order matched by MatchOrder
+	CountFills(ctx sdk.Context, keeper keeper.Keeper, order *types.Order) int
 }
@@ -0,0 +1,71 @@
+package match
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/dex"
+	orderkeeper "github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerStopOrders(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	keeper.SetLastPrice(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("10.0"))
+
+	// a buy stop order above the last price and a sell stop order below it
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.6", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.4", "1.0"),
+	}
+	for i, stopPrice := range []string{"10.5", "9.5"} {
+		orders[i].Sender = testInput.TestAddrs[0]
+		orders[i].Type = types.OrderTypeStopLimit
+		orders[i].RecordStopPrice(sdk.MustNewDecFromStr(stopPrice))
+		require.Nil(t, PlaceOrder(ctx, keeper, orders[i]))
+	}
+
+	TriggerStopOrders(ctx, keeper)
+	require.EqualValues(t, types.OrderStatusUntriggered, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusUntriggered, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+
+	// the price rises to the stop price of the buy order
+	keeper.SetLastPrice(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("10.5"))
+	TriggerStopOrders(ctx, keeper)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusUntriggered, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 1, len(book.Items))
+	require.Equal(t, sdk.MustNewDecFromStr("10.6"), book.Items[0].Price)
+}
+
+func TestPlaceOrderPostOnly(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	sell := types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0")
+	sell.Sender = testInput.TestAddrs[1]
+	require.Nil(t, PlaceOrder(ctx, keeper, sell))
+
+	// a post only order which would match is rejected
+	buy := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	buy.Sender = testInput.TestAddrs[0]
+	buy.TimeInForce = types.TimeInForcePostOnly
+	require.NotNil(t, PlaceOrder(ctx, keeper, buy))
+
+	buy = types.MockOrder("", types.TestTokenPair, types.BuyOrder, "9.9", "1.0")
+	buy.Sender = testInput.TestAddrs[0]
+	buy.TimeInForce = types.TimeInForcePostOnly
+	require.Nil(t, PlaceOrder(ctx, keeper, buy))
+	require.Equal(t, 2, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+}
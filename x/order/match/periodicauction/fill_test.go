@@ -639,7 +639,7 @@ func TestFillOrder(t *testing.T) {
 	feeParams := types.DefaultTestParams()
 
 	for _, order := range orders {
-		retDeals := fillOrder(order, ctx, keeper, fillPrice, fillQuantity, &feeParams)
+		retDeals := FillOrder(order, ctx, keeper, fillPrice, fillQuantity, &feeParams)
 		require.NotEmpty(t, retDeals)
 	}
 }
@@ -0,0 +1,81 @@
+package periodicauction
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+)
+
+// getFOKProducts returns the products which have FOK orders to be matched in the block
+func getFOKProducts(ctx sdk.Context, k keeper.Keeper) map[string]bool {
+	products := make(map[string]bool)
+	k.IterateImmediateOrders(ctx, func(orderID string) bool {
+		order := k.GetOrder(ctx, orderID)
+		if order != nil && order.TimeInForce == types.TimeInForceFOK {
+			products[order.Product] = true
+		}
+		return false
+	})
+	return products
+}
+
+// killPartialFilledFOKOrder cancels the first FOK order which would be partially filled at the best price,
+// it returns false if all the FOK orders would be filled or not filled at all
+func killPartialFilledFOKOrder(ctx sdk.Context, k keeper.Keeper, product string, book *types.DepthBook,
+	bestPrice, maxExecution sdk.Dec) bool {
+	order := findPartialFilledFOKOrder(ctx, k, product, book, bestPrice, maxExecution)
+	if order == nil || k.IsProductLocked(ctx, product) {
+		return false
+	}
+
+	logger := ctx.Logger().With("module", "order")
+	k.CancelOrder(ctx, order, logger)
+	k.DropImmediateOrder(ctx, order.OrderID)
+	logger.Info(fmt.Sprintf("FOK order(%s) is cancelled, it can't be filled at %s", order.OrderID, bestPrice))
+	return true
+}
+
+// findPartialFilledFOKOrder walks the orders in the same order as fillDepthBook fills them
+func findPartialFilledFOKOrder(ctx sdk.Context, k keeper.Keeper, product string, book *types.DepthBook,
+	bestPrice, maxExecution sdk.Dec) *types.Order {
+
+	// buy orders, prices from high to low
+	buyRemain := maxExecution
+	for i := 0; i < len(book.Items) && book.Items[i].Price.GTE(bestPrice) && buyRemain.IsPositive(); i++ {
+		key := types.FormatOrderIDsKey(product, book.Items[i].Price, types.BuyOrder)
+		if order := findPartialFilledFOKOrderByKey(ctx, k, key, &buyRemain); order != nil {
+			return order
+		}
+	}
+
+	// sell orders, prices from low to high
+	sellRemain := maxExecution
+	for i := len(book.Items) - 1; i >= 0 && book.Items[i].Price.LTE(bestPrice) && sellRemain.IsPositive(); i-- {
+		key := types.FormatOrderIDsKey(product, book.Items[i].Price, types.SellOrder)
+		if order := findPartialFilledFOKOrderByKey(ctx, k, key, &sellRemain); order != nil {
+			return order
+		}
+	}
+	return nil
+}
+
+func findPartialFilledFOKOrderByKey(ctx sdk.Context, k keeper.Keeper, key string, remain *sdk.Dec) *types.Order {
+	for _, orderID := range k.GetProductPriceOrderIDs(key) {
+		if !remain.IsPositive() {
+			break
+		}
+		order := k.GetOrder(ctx, orderID)
+		if order == nil {
+			continue
+		}
+		fillAmount := sdk.MinDec(order.RemainQuantity, *remain)
+		if fillAmount.LT(order.RemainQuantity) && order.TimeInForce == types.TimeInForceFOK {
+			return order
+		}
+		*remain = remain.Sub(fillAmount)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package periodicauction
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/dex"
+	orderkeeper "github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcMatchPriceAndExecutionWithFOK(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	// the FOK order would be partially filled after the first buy order
+	orders := []*types.Order{
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "1.0"),
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "2.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "9.9", "2.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[0]
+	orders[1].TimeInForce = types.TimeInForceFOK
+	orders[2].Sender = testInput.TestAddrs[1]
+	for i := 0; i < 3; i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.Nil(t, err)
+	}
+
+	products := keeper.GetDiskCache().GetUpdatedDepthbookKeys()
+	updatedProductsBasePrice := calcMatchPriceAndExecution(ctx, keeper, products)
+	matchResult, ok := updatedProductsBasePrice[types.TestTokenPair]
+	require.True(t, ok)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), matchResult.Quantity)
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"),
+		keeper.GetDepthBookCopy(types.TestTokenPair).CrossingQuantity(types.SellOrder, sdk.MustNewDecFromStr("9.9")))
+
+	// a FOK order filled in full is kept
+	order := mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0")
+	order.Sender = testInput.TestAddrs[1]
+	order.TimeInForce = types.TimeInForceFOK
+	err = keeper.PlaceOrder(ctx, order)
+	require.Nil(t, err)
+	updatedProductsBasePrice = calcMatchPriceAndExecution(ctx, keeper, products)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), updatedProductsBasePrice[types.TestTokenPair].Quantity)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, order.OrderID).Status)
+}
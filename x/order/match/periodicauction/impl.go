@@ -4,6 +4,7 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
 	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
 )
 
 // PaEngine is the periodic auction match engine
@@ -16,3 +17,12 @@ func (e *PaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
 	cleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
 	matchOrders(ctx, keeper)
 }
+
+// MatchOrder leaves the order in the depth book, it's matched along with the others at the end of the block
+func (e *PaEngine) MatchOrder(ctx sdk.Context, keeper keeper.Keeper, order *types.Order) {
+}
+
+// CountFills returns 0, the order isn't filled when it's placed
+func (e *PaEngine) CountFills(ctx sdk.Context, keeper keeper.Keeper, order *types.Order) int {
+	return 0
+}
@@ -265,14 +265,15 @@ func cleanupExpiredOrders(ctx sdk.Context, keeper keeper.Keeper) {
 func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	blockHeight := ctx.BlockHeight()
 	orderNum := keeper.GetBlockOrderNum(ctx, blockHeight)
-	// no new orders in this block & no product lock in previous blocks, skip match
-	if orderNum == 0 && !keeper.AnyProductLocked(ctx) {
+	// no new orders or triggered stop orders in this block & no product lock in previous blocks, skip match
+	if orderNum == 0 && len(keeper.GetDiskCache().GetNewDepthbookKeys()) == 0 && !keeper.AnyProductLocked(ctx) {
 		return
 	}
 
 	// step0: get active products
 	products := keeper.GetDiskCache().GetNewDepthbookKeys()
 	products = keeper.FilterDelistedProducts(ctx, products)
+	products = filterPeriodicAuctionProducts(ctx, keeper, products)
 	keeper.GetDexKeeper().SortProducts(ctx, products) // sort products
 
 	// step1: calc best price and max execution for every active product, save latest price
@@ -289,19 +290,26 @@ func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	// step2: execute match results, fill orders in match results, transfer tokens and collect fees
 	executeMatch(ctx, keeper, products, updatedProductsBasePrice, lockMap)
 
-	// step3: save match results for querying
-	if len(updatedProductsBasePrice) > 0 {
-		blockMatchResult := &types.BlockMatchResult{
-			BlockHeight: blockHeight,
-			ResultMap:   updatedProductsBasePrice,
-			TimeStamp:   ctx.BlockHeader().Time.Unix(),
+	// step3: save match results for querying, along with the ones of the continuous auction products
+	for product, matchResult := range updatedProductsBasePrice {
+		keeper.AddMatchResult(ctx, product, matchResult)
+	}
+}
+
+// filterPeriodicAuctionProducts drops the products whose orders are matched at once by other engines
+func filterPeriodicAuctionProducts(ctx sdk.Context, keeper keeper.Keeper, products []string) []string {
+	var periodicProducts []string
+	for _, product := range products {
+		if !keeper.GetDexKeeper().GetProductParams(ctx, product).IsContinuousAuction() {
+			periodicProducts = append(periodicProducts, product)
 		}
-		keeper.SetBlockMatchResult(blockMatchResult)
 	}
+	return periodicProducts
 }
 
 func calcMatchPriceAndExecution(ctx sdk.Context, k keeper.Keeper, products []string) map[string]types.MatchResult {
 	resultMap := make(map[string]types.MatchResult)
+	fokProducts := getFOKProducts(ctx, k)
 
 	for _, product := range products {
 		tokenPair := k.GetDexKeeper().GetTokenPair(ctx, product)
@@ -311,6 +319,13 @@ func calcMatchPriceAndExecution(ctx sdk.Context, k keeper.Keeper, products []str
 		book := k.GetDepthBookCopy(product)
 		bestPrice, maxExecution := periodicAuctionMatchPrice(book, tokenPair.MaxPriceDigit,
 			k.GetLastPrice(ctx, product))
+		// the FOK orders which would be partially filled are cancelled, then calc again without them
+		for fokProducts[product] && maxExecution.IsPositive() &&
+			killPartialFilledFOKOrder(ctx, k, product, book, bestPrice, maxExecution) {
+			book = k.GetDepthBookCopy(product)
+			bestPrice, maxExecution = periodicAuctionMatchPrice(book, tokenPair.MaxPriceDigit,
+				k.GetLastPrice(ctx, product))
+		}
 		if maxExecution.IsPositive() {
 			k.SetLastPrice(ctx, product, bestPrice)
 			resultMap[product] = types.MatchResult{BlockHeight: ctx.BlockHeight(), Price: bestPrice,
@@ -102,6 +102,17 @@ func (depthBook *DepthBook) RemoveOrder(order *Order) {
 	}
 }
 
+// ReduceOrder : subtract the reduced quantity of an order from depth book
+func (depthBook *DepthBook) ReduceOrder(order *Order, quantity sdk.Dec) {
+	index := sort.Search(len(depthBook.Items), func(i int) bool {
+		return order.Price.GTE(depthBook.Items[i].Price)
+	})
+	if index < len(depthBook.Items) && depthBook.Items[index].Price.Equal(order.Price) {
+		depthBook.Sub(index, quantity, order.Side)
+		depthBook.RemoveIfEmpty(index)
+	}
+}
+
 // Sub : subtract the buy or sell quantity
 func (depthBook *DepthBook) Sub(index int, num sdk.Dec, side string) {
 	if side == BuyOrder {
@@ -126,3 +137,16 @@ func (depthBook *DepthBook) Copy() *DepthBook {
 	itemList = append(itemList, depthBook.Items...)
 	return &DepthBook{Items: itemList}
 }
+
+// CrossingQuantity : the quantity of the opposite side that an order of the side & price would match
+func (depthBook *DepthBook) CrossingQuantity(side string, price sdk.Dec) sdk.Dec {
+	quantity := sdk.ZeroDec()
+	for _, item := range depthBook.Items {
+		if side == BuyOrder && item.Price.LTE(price) {
+			quantity = quantity.Add(item.SellQuantity)
+		} else if side == SellOrder && item.Price.GTE(price) {
+			quantity = quantity.Add(item.BuyQuantity)
+		}
+	}
+	return quantity
+}
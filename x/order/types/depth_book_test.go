@@ -96,3 +96,16 @@ func TestCopy(t *testing.T) {
 	require.EqualValues(t, 1, len(depthBook.Items))
 	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), depthBook.Items[0].Price)
 }
+
+func TestCrossingQuantity(t *testing.T) {
+	depthBook := &DepthBook{}
+	depthBook.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "10.2", "1.0"))
+	depthBook.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "10.1", "2.0"))
+	depthBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "9.9", "3.0"))
+
+	require.EqualValues(t, sdk.ZeroDec(), depthBook.CrossingQuantity(BuyOrder, sdk.MustNewDecFromStr("10.0")))
+	require.EqualValues(t, sdk.MustNewDecFromStr("2.0"), depthBook.CrossingQuantity(BuyOrder, sdk.MustNewDecFromStr("10.1")))
+	require.EqualValues(t, sdk.MustNewDecFromStr("3.0"), depthBook.CrossingQuantity(BuyOrder, sdk.MustNewDecFromStr("10.5")))
+	require.EqualValues(t, sdk.MustNewDecFromStr("3.0"), depthBook.CrossingQuantity(SellOrder, sdk.MustNewDecFromStr("9.9")))
+	require.EqualValues(t, sdk.ZeroDec(), depthBook.CrossingQuantity(SellOrder, sdk.MustNewDecFromStr("10.0")))
+}
@@ -38,6 +38,13 @@ const (
 	CodeNotOrderOwner                         uint32 = 63026
 	CodeProductIsEmpty                        uint32 = 63027
 	CodeAllOrderFailedToExecute               uint32 = 63028
+	CodeInvalidOrderType                      uint32 = 63029
+	CodeInvalidTimeInForce                    uint32 = 63030
+	CodeInvalidSlippage                       uint32 = 63031
+	CodeInvalidStopPrice                      uint32 = 63032
+	CodeNoMarketPrice                         uint32 = 63033
+	CodePostOnlyOrderWouldMatch               uint32 = 63034
+	CodeOrderIsNotAmended                     uint32 = 63035
 )
 
 func ErrInvalidAddress(address string) sdk.EnvelopedErr {
@@ -152,3 +159,35 @@ func ErrNotOrderOwner(orderID string) sdk.EnvelopedErr {
 func ErrAllOrderFailedToExecute() sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeAllOrderFailedToExecute, "all order items failed to execute")}
 }
+
+func ErrInvalidOrderType(orderType string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidOrderType, fmt.Sprintf("invalid order type: %s", orderType))}
+}
+
+func ErrInvalidTimeInForce(timeInForce string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidTimeInForce, fmt.Sprintf("invalid time in force: %s", timeInForce))}
+}
+
+func ErrInvalidTimeInForceOfOrderType(timeInForce, orderType string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidTimeInForce, fmt.Sprintf("time in force %s is not allowed for %s orders", timeInForce, orderType))}
+}
+
+func ErrInvalidSlippage() sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidSlippage, "slippage should be between 0 and 1")}
+}
+
+func ErrInvalidStopPrice() sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidStopPrice, "stop price should be positive")}
+}
+
+func ErrNoMarketPrice(product string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeNoMarketPrice, fmt.Sprintf("no market price of %s to place a market order", product))}
+}
+
+func ErrPostOnlyOrderWouldMatch(product string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodePostOnlyOrderWouldMatch, fmt.Sprintf("post only order of %s would match at once", product))}
+}
+
+func ErrOrderIsNotAmended(orderID string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeOrderIsNotAmended, fmt.Sprintf("order(%s) has the same price and quantity", orderID))}
+}
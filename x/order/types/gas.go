@@ -0,0 +1,38 @@
+package types
+
+import (
+	"context"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+)
+
+// FillGasUnit is the gas charged for each fill of a new order of the continuous auction, which is matched in the tx
+// placing it
+const FillGasUnit = 20000
+
+type txGasMeterKey struct{}
+
+// WithTxGasMeter keeps the gas meter of the tx in the context, the order handler runs with an infinite gas meter
+// but the fills of the continuous auction are charged on the gas meter of the tx
+func WithTxGasMeter(ctx sdk.Context, gasMeter sdk.GasMeter) sdk.Context {
+	return ctx.WithContext(context.WithValue(ctx.Context(), txGasMeterKey{}, gasMeter))
+}
+
+// ConsumeTxGas consumes the gas on the gas meter of the tx kept in the context. Nothing is charged without it,
+// e.g. the stop orders triggered in the end blocker. It returns an error instead of running out of gas, so the order
+// fails before anything is changed
+func ConsumeTxGas(ctx sdk.Context, gas uint64, descriptor string) error {
+	if ctx.Context() == nil {
+		return nil
+	}
+	gasMeter, ok := ctx.Context().Value(txGasMeterKey{}).(sdk.GasMeter)
+	if !ok {
+		return nil
+	}
+	if gasMeter.Limit() > 0 && (gasMeter.IsPastLimit() || gas > gasMeter.Limit()-gasMeter.GasConsumed()) {
+		return sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "%s needs %d gas", descriptor, gas)
+	}
+	gasMeter.ConsumeGas(gas, descriptor)
+	return nil
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
@@ -46,6 +47,10 @@ var (
 	LastExpiredBlockHeightKey = []byte{0x18}
 	OpenOrderNumKey           = []byte{0x19}
 	StoreOrderNumKey          = []byte{0x20}
+
+	// iterator keys
+	StopOrderKey      = []byte{0x21}
+	ImmediateOrderKey = []byte{0x22}
 )
 
 // nolint
@@ -87,3 +92,50 @@ func FormatOrderIDsKey(product string, price sdk.Dec, side string) string {
 func GetKey(it sdk.Iterator) string {
 	return string(it.Key()[1:])
 }
+
+// GetStopOrderProductKey returns the prefix of the trigger index entries of the product,
+// the product is terminated by a zero byte
+func GetStopOrderProductKey(product string) []byte {
+	key := make([]byte, 0, len(StopOrderKey)+len(product)+1)
+	key = append(key, StopOrderKey...)
+	key = append(key, product...)
+	return append(key, 0x00)
+}
+
+// GetStopOrderSideKey returns the prefix of the trigger index entries of the product at the side
+func GetStopOrderSideKey(product, side string) []byte {
+	sideByte := byte(0x01)
+	if side == SellOrder {
+		sideByte = 0x02
+	}
+	return append(GetStopOrderProductKey(product), sideByte)
+}
+
+// GetStopOrderPriceKey returns the prefix of the trigger index entries of the product at the side
+// and the stop price. The entries of a side are sorted by the stop price.
+func GetStopOrderPriceKey(product, side string, stopPrice sdk.Dec) []byte {
+	return append(GetStopOrderSideKey(product, side), sortablePriceBytes(stopPrice)...)
+}
+
+// GetStopOrderKey returns the key of the trigger index entry: product | side | stop price | order id
+func GetStopOrderKey(trigger StopOrderTrigger) []byte {
+	return append(GetStopOrderPriceKey(trigger.Product, trigger.Side, trigger.StopPrice), trigger.OrderID...)
+}
+
+// SplitStopOrderProduct returns the product of the trigger index entry
+func SplitStopOrderProduct(key []byte) string {
+	product := key[len(StopOrderKey):]
+	return string(product[:bytes.IndexByte(product, 0x00)])
+}
+
+// sortablePriceBytes encodes the positive price as the length and the big endian bytes of its
+// integer form, so the bytes are sorted as the prices are
+func sortablePriceBytes(price sdk.Dec) []byte {
+	bz := price.BigInt().Bytes()
+	return append([]byte{byte(len(bz))}, bz...)
+}
+
+// nolint
+func GetImmediateOrderKey(orderID string) []byte {
+	return append(ImmediateOrderKey, []byte(orderID)...)
+}
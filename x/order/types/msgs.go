@@ -19,6 +19,11 @@ type MsgNewOrder struct {
 	Side     string         `json:"side"`     // BUY/SELL
 	Price    sdk.Dec        `json:"price"`    // price of the order
 	Quantity sdk.Dec        `json:"quantity"` // quantity of the order
+
+	Type        string  `json:"type"`          // LIMIT/MARKET/STOP/STOP_LIMIT
+	TimeInForce string  `json:"time_in_force"` // GTC/IOC/FOK/POST_ONLY
+	StopPrice   sdk.Dec `json:"stop_price"`    // stop price of the stop orders
+	Slippage    sdk.Dec `json:"slippage"`      // slippage of the market orders
 }
 
 // NewMsgNewOrder is a constructor function for MsgNewOrder
@@ -66,6 +71,11 @@ type OrderItem struct {
 	Side     string  `json:"side"`     // BUY/SELL
 	Price    sdk.Dec `json:"price"`    // price of the order
 	Quantity sdk.Dec `json:"quantity"` // quantity of the order
+
+	Type        string  `json:"type,omitempty"`          // LIMIT by default, or MARKET/STOP/STOP_LIMIT
+	TimeInForce string  `json:"time_in_force,omitempty"` // GTC by default, or IOC/FOK/POST_ONLY
+	StopPrice   sdk.Dec `json:"stop_price,omitempty"`    // the last price which triggers the stop orders
+	Slippage    sdk.Dec `json:"slippage,omitempty"`      // the max slippage of the market orders from the last price
 }
 
 // nolint
@@ -118,8 +128,8 @@ func (msg MsgNewOrders) ValidateBasic() sdk.Error {
 		if item.Side != BuyOrder && item.Side != SellOrder {
 			return ErrOrderItemSideIsNotBuyAndSell()
 		}
-		if !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
-			return ErrOrderItemPriceOrQuantityIsNotPositive()
+		if err := item.validateOrderType(); err != nil {
+			return err
 		}
 	}
 
@@ -214,6 +224,61 @@ func (msg MsgCancelOrders) CalculateGas(gasUnit uint64) uint64 {
 	return uint64(len(msg.OrderIDs)) * gasUnit
 }
 
+// MsgAmendOrder changes the price or the remaining quantity of an open order. A smaller quantity at the
+// same price is reduced in place and keeps its priority, otherwise the order is cancelled and replaced.
+type MsgAmendOrder struct {
+	Sender   sdk.AccAddress `json:"sender"`   // order maker address
+	OrderID  string         `json:"order_id"` // id of the order to amend
+	Price    sdk.Dec        `json:"price"`    // new price of the order
+	Quantity sdk.Dec        `json:"quantity"` // new remaining quantity of the order
+}
+
+// NewMsgAmendOrder is a constructor function for MsgAmendOrder
+func NewMsgAmendOrder(sender sdk.AccAddress, orderID string, price, quantity sdk.Dec) MsgAmendOrder {
+	return MsgAmendOrder{
+		Sender:   sender,
+		OrderID:  orderID,
+		Price:    price,
+		Quantity: quantity,
+	}
+}
+
+// nolint
+func (msg MsgAmendOrder) Route() string { return "order" }
+
+// nolint
+func (msg MsgAmendOrder) Type() string { return "amend" }
+
+// nolint
+func (msg MsgAmendOrder) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.OrderID == "" {
+		return ErrUserInputOrderIDIsEmpty()
+	}
+	if msg.Price.IsNil() || msg.Quantity.IsNil() || !(msg.Price.IsPositive() && msg.Quantity.IsPositive()) {
+		return ErrOrderItemPriceOrQuantityIsNotPositive()
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgAmendOrder) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgAmendOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// Calculate customize gas
+func (msg MsgAmendOrder) CalculateGas(gasUnit uint64) uint64 {
+	return gasUnit
+}
+
 // nolint
 type OrderResult struct {
 	Error   error  `json:"error"`
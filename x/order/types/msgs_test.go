@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"testing"
 
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/common"
 
 	"github.com/stretchr/testify/require"
@@ -313,3 +314,20 @@ func TestHasDuplicatedID(t *testing.T) {
 	result2 := hasDuplicatedID(ids2)
 	require.EqualValues(t, true, result2)
 }
+
+func TestMsgAmendOrder(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	price, quantity := sdk.MustNewDecFromStr(testPrice), sdk.MustNewDecFromStr(testQuantity)
+
+	msg := NewMsgAmendOrder(addr, testOrderID, price, quantity)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, "order", msg.Route())
+	require.Equal(t, "amend", msg.Type())
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+
+	require.NotNil(t, NewMsgAmendOrder(nil, testOrderID, price, quantity).ValidateBasic())
+	require.NotNil(t, NewMsgAmendOrder(addr, "", price, quantity).ValidateBasic())
+	require.NotNil(t, NewMsgAmendOrder(addr, testOrderID, sdk.ZeroDec(), quantity).ValidateBasic())
+	require.NotNil(t, NewMsgAmendOrder(addr, testOrderID, price, sdk.Dec{}).ValidateBasic())
+}
@@ -20,6 +20,7 @@ const (
 	Expired
 	PartialFilledCancelled
 	PartialFilledExpired
+	Untriggered
 )
 
 func (p OrderStatus) String() string {
@@ -36,6 +37,8 @@ func (p OrderStatus) String() string {
 		return "PartialFilledCancelled"
 	case PartialFilledExpired:
 		return "PartialFilledExpired"
+	case Untriggered:
+		return "Untriggered"
 	default:
 		return "Unknown"
 	}
@@ -49,7 +52,7 @@ const (
 	OrderStatusExpired                = 3
 	OrderStatusPartialFilledCancelled = 4
 	OrderStatusPartialFilledExpired   = 5
-	//OrderStatusPartialFilled          = 6
+	OrderStatusUntriggered            = 6
 )
 
 // nolint
@@ -77,7 +80,9 @@ type Order struct {
 	Timestamp         int64          `json:"timestamp"`        // created timestamp
 	OrderExpireBlocks int64          `json:"order_expire_blocks"`
 	FeePerBlock       sdk.SysCoin    `json:"fee_per_block"`
-	ExtraInfo         string         `json:"extra_info"` // extra info of order in json format
+	ExtraInfo         string         `json:"extra_info"`              // extra info of order in json format
+	Type              string         `json:"type,omitempty"`          // order type, see OrderTypeXXX
+	TimeInForce       string         `json:"time_in_force,omitempty"` // time in force, see TimeInForceXXX
 }
 
 // nolint
@@ -198,6 +203,21 @@ func (order *Order) Fill(price, fillAmount sdk.Dec) {
 	}
 }
 
+// Reduce reduces the remaining quantity of the order, and returns the coins which are no longer locked
+func (order *Order) Reduce(quantity sdk.Dec) sdk.SysCoins {
+	order.Quantity = order.Quantity.Sub(quantity)
+	order.RemainQuantity = order.RemainQuantity.Sub(quantity)
+	if order.Side == BuyOrder {
+		token := strings.Split(order.Product, "_")[1]
+		amount := order.Price.Mul(quantity)
+		order.RemainLocked = order.RemainLocked.Sub(amount)
+		return sdk.SysCoins{{Denom: token, Amount: amount}}
+	}
+	token := strings.Split(order.Product, "_")[0]
+	order.RemainLocked = order.RemainLocked.Sub(quantity)
+	return sdk.SysCoins{{Denom: token, Amount: quantity}}
+}
+
 // nolint
 func (order *Order) Cancel() {
 	if order.RemainQuantity.Equal(order.Quantity) {
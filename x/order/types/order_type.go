@@ -0,0 +1,157 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// nolint
+const (
+	OrderTypeLimit     = "LIMIT"
+	OrderTypeMarket    = "MARKET"
+	OrderTypeStop      = "STOP"
+	OrderTypeStopLimit = "STOP_LIMIT"
+)
+
+// nolint
+const (
+	TimeInForceGTC      = "GTC"
+	TimeInForceIOC      = "IOC"
+	TimeInForceFOK      = "FOK"
+	TimeInForcePostOnly = "POST_ONLY"
+)
+
+// OrderExtraInfoKeyStopPrice is the extra info key of the stop price of stop orders
+const OrderExtraInfoKeyStopPrice = "stopPrice"
+
+// GetOrderType returns the order type of the order item, LIMIT by default
+func (item OrderItem) GetOrderType() string {
+	if item.Type == "" {
+		return OrderTypeLimit
+	}
+	return item.Type
+}
+
+// GetTimeInForce returns the time in force of the order item, GTC by default
+func (item OrderItem) GetTimeInForce() string {
+	if item.TimeInForce == "" {
+		return TimeInForceGTC
+	}
+	return item.TimeInForce
+}
+
+// validateOrderType checks the fields which depend on the order type and the time in force
+func (item OrderItem) validateOrderType() error {
+	orderType, timeInForce := item.GetOrderType(), item.GetTimeInForce()
+	switch timeInForce {
+	case TimeInForceGTC, TimeInForceIOC, TimeInForceFOK, TimeInForcePostOnly:
+	default:
+		return ErrInvalidTimeInForce(item.TimeInForce)
+	}
+
+	switch orderType {
+	case OrderTypeLimit, OrderTypeStopLimit:
+		if !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
+			return ErrOrderItemPriceOrQuantityIsNotPositive()
+		}
+	case OrderTypeMarket, OrderTypeStop:
+		// market orders are priced by the slippage, they never rest in the depth book
+		if !item.Quantity.IsPositive() {
+			return ErrOrderItemPriceOrQuantityIsNotPositive()
+		}
+		if item.Slippage.IsNil() || !item.Slippage.IsPositive() || item.Slippage.GTE(sdk.OneDec()) {
+			return ErrInvalidSlippage()
+		}
+		if timeInForce != TimeInForceIOC && timeInForce != TimeInForceFOK {
+			return ErrInvalidTimeInForceOfOrderType(timeInForce, orderType)
+		}
+	default:
+		return ErrInvalidOrderType(item.Type)
+	}
+
+	if orderType == OrderTypeStop || orderType == OrderTypeStopLimit {
+		if item.StopPrice.IsNil() || !item.StopPrice.IsPositive() {
+			return ErrInvalidStopPrice()
+		}
+	}
+	return nil
+}
+
+// GetSlippagePrice returns the worst price a market order accepts around the reference price,
+// rounded to the price digit of the product
+func GetSlippagePrice(side string, refPrice, slippage sdk.Dec, priceDigit int64) sdk.Dec {
+	if side == BuyOrder {
+		return refPrice.Mul(sdk.OneDec().Add(slippage)).RoundDecimal(priceDigit)
+	}
+	return refPrice.Mul(sdk.OneDec().Sub(slippage)).RoundDecimal(priceDigit)
+}
+
+// GetOrderType returns the order type of the order, LIMIT by default
+func (order *Order) GetOrderType() string {
+	if order.Type == "" {
+		return OrderTypeLimit
+	}
+	return order.Type
+}
+
+// GetTimeInForce returns the time in force of the order, GTC by default
+func (order *Order) GetTimeInForce() string {
+	if order.TimeInForce == "" {
+		return TimeInForceGTC
+	}
+	return order.TimeInForce
+}
+
+// IsStopOrder returns true if the order waits for its stop price before entering the depth book
+func (order *Order) IsStopOrder() bool {
+	return order.Type == OrderTypeStop || order.Type == OrderTypeStopLimit
+}
+
+// IsImmediateOrder returns true if the rest of the order is cancelled once it is matched
+func (order *Order) IsImmediateOrder() bool {
+	return order.TimeInForce == TimeInForceIOC || order.TimeInForce == TimeInForceFOK
+}
+
+// RecordStopPrice records the stop price of a stop order
+func (order *Order) RecordStopPrice(stopPrice sdk.Dec) {
+	order.setExtraInfoWithKeyValue(OrderExtraInfoKeyStopPrice, stopPrice.String())
+}
+
+// GetStopPrice returns the stop price of a stop order, zero for the others
+func (order *Order) GetStopPrice() sdk.Dec {
+	stopPrice, err := sdk.NewDecFromStr(order.GetExtraInfoWithKey(OrderExtraInfoKeyStopPrice))
+	if err != nil {
+		return sdk.ZeroDec()
+	}
+	return stopPrice
+}
+
+// Trigger opens the stop order, it enters the depth book afterwards
+func (order *Order) Trigger() {
+	order.Status = OrderStatusOpen
+}
+
+// StopOrderTrigger is the trigger index entry of an untriggered stop order
+type StopOrderTrigger struct {
+	OrderID   string  `json:"order_id"`
+	Product   string  `json:"product"`
+	Side      string  `json:"side"`
+	StopPrice sdk.Dec `json:"stop_price"`
+}
+
+// NewStopOrderTrigger creates the trigger index entry of the stop order
+func NewStopOrderTrigger(order *Order) StopOrderTrigger {
+	return StopOrderTrigger{
+		OrderID:   order.OrderID,
+		Product:   order.Product,
+		Side:      order.Side,
+		StopPrice: order.GetStopPrice(),
+	}
+}
+
+// IsTriggered returns true if the last price reaches the stop price
+func (trigger StopOrderTrigger) IsTriggered(lastPrice sdk.Dec) bool {
+	if trigger.Side == BuyOrder {
+		return lastPrice.GTE(trigger.StopPrice)
+	}
+	return lastPrice.LTE(trigger.StopPrice)
+}
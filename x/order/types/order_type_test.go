@@ -0,0 +1,95 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderItemValidateOrderType(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	product := "btc_" + common.NativeToken
+
+	testCases := []struct {
+		name    string
+		item    OrderItem
+		isValid bool
+	}{
+		{"default limit", NewOrderItem(product, BuyOrder, testPrice, testQuantity), true},
+		{"limit post only", OrderItem{Product: product, Side: BuyOrder, Price: sdk.MustNewDecFromStr(testPrice),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: OrderTypeLimit, TimeInForce: TimeInForcePostOnly}, true},
+		{"unknown time in force", OrderItem{Product: product, Side: BuyOrder, Price: sdk.MustNewDecFromStr(testPrice),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), TimeInForce: "GTD"}, false},
+		{"unknown order type", OrderItem{Product: product, Side: BuyOrder, Price: sdk.MustNewDecFromStr(testPrice),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: "ICEBERG"}, false},
+		{"market ioc", OrderItem{Product: product, Side: BuyOrder, Price: sdk.ZeroDec(),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: OrderTypeMarket, TimeInForce: TimeInForceIOC,
+			Slippage: sdk.MustNewDecFromStr("0.05")}, true},
+		{"market gtc", OrderItem{Product: product, Side: BuyOrder, Price: sdk.ZeroDec(),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: OrderTypeMarket,
+			Slippage: sdk.MustNewDecFromStr("0.05")}, false},
+		{"market without slippage", OrderItem{Product: product, Side: BuyOrder, Price: sdk.ZeroDec(),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: OrderTypeMarket, TimeInForce: TimeInForceFOK}, false},
+		{"market with slippage of 1", OrderItem{Product: product, Side: SellOrder, Price: sdk.ZeroDec(),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: OrderTypeMarket, TimeInForce: TimeInForceFOK,
+			Slippage: sdk.OneDec()}, false},
+		{"stop market", OrderItem{Product: product, Side: SellOrder, Price: sdk.ZeroDec(),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: OrderTypeStop, TimeInForce: TimeInForceIOC,
+			StopPrice: sdk.MustNewDecFromStr("0.09"), Slippage: sdk.MustNewDecFromStr("0.05")}, true},
+		{"stop limit", OrderItem{Product: product, Side: SellOrder, Price: sdk.MustNewDecFromStr(testPrice),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: OrderTypeStopLimit,
+			StopPrice: sdk.MustNewDecFromStr("0.09")}, true},
+		{"stop limit without stop price", OrderItem{Product: product, Side: SellOrder, Price: sdk.MustNewDecFromStr(testPrice),
+			Quantity: sdk.MustNewDecFromStr(testQuantity), Type: OrderTypeStopLimit}, false},
+	}
+
+	for _, tc := range testCases {
+		msg := NewMsgNewOrders(addr, []OrderItem{tc.item})
+		if tc.isValid {
+			require.Nil(t, msg.ValidateBasic(), tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), tc.name)
+		}
+	}
+}
+
+func TestOrderItemSignBytes(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	// the limit orders are signed as before
+	msg := NewMsgNewOrders(addr, []OrderItem{NewOrderItem("btc_"+common.NativeToken, BuyOrder, testPrice, testQuantity)})
+	require.NotContains(t, string(msg.GetSignBytes()), "time_in_force")
+	require.NotContains(t, string(msg.GetSignBytes()), "stop_price")
+}
+
+func TestGetSlippagePrice(t *testing.T) {
+	refPrice := sdk.MustNewDecFromStr("10.0")
+	slippage := sdk.MustNewDecFromStr("0.0333")
+	require.Equal(t, sdk.MustNewDecFromStr("10.33"), GetSlippagePrice(BuyOrder, refPrice, slippage, 2))
+	require.Equal(t, sdk.MustNewDecFromStr("9.67"), GetSlippagePrice(SellOrder, refPrice, slippage, 2))
+}
+
+func TestStopOrder(t *testing.T) {
+	order := MockOrder(FormatOrderID(10, 1), TestTokenPair, BuyOrder, "10.0", "1.0")
+	require.False(t, order.IsStopOrder())
+	require.True(t, order.GetStopPrice().IsZero())
+
+	order.Type = OrderTypeStopLimit
+	order.RecordStopPrice(sdk.MustNewDecFromStr("9.5"))
+	require.True(t, order.IsStopOrder())
+	require.Equal(t, sdk.MustNewDecFromStr("9.5"), order.GetStopPrice())
+
+	// buy stop orders are triggered when the price rises to the stop price
+	trigger := NewStopOrderTrigger(order)
+	require.False(t, trigger.IsTriggered(sdk.MustNewDecFromStr("9.4")))
+	require.True(t, trigger.IsTriggered(sdk.MustNewDecFromStr("9.5")))
+
+	// sell stop orders are triggered when the price falls to the stop price
+	trigger.Side = SellOrder
+	require.True(t, trigger.IsTriggered(sdk.MustNewDecFromStr("9.4")))
+	require.False(t, trigger.IsTriggered(sdk.MustNewDecFromStr("9.6")))
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/okex/exchain/x/common"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/version"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
 	"github.com/okex/exchain/x/staking/types"
@@ -40,6 +42,7 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 			GetCmdCreateValidator(cdc),
 			GetCmdDestroyValidator(cdc),
 			GetCmdEditValidator(cdc),
+			GetCmdEditValidatorCommissionRate(cdc),
 			GetCmdDeposit(cdc),
 			GetCmdWithdraw(cdc),
 			GetCmdAddShares(cdc),
@@ -134,6 +137,39 @@ func GetCmdEditValidator(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdEditValidatorCommissionRate gets the edit validator commission rate command
+func GetCmdEditValidatorCommissionRate(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit-validator-commission-rate [commission-rate]",
+		Args:  cobra.ExactArgs(1),
+		Short: "edit the commission rate of an existing validator",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Edit the commission rate of an existing validator. The commission rate is the proportion of the
+rewards allocated to the validator that it keeps, the rest is distributed to the delegators who add shares to it.
+It can be changed once in 24 hours, and raised by the max change rate of the validator at most, or by 0.01 if it's 0.
+
+Example:
+$ %s tx staking edit-validator-commission-rate 0.2 --from mykey
+`,
+				version.ClientName),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			rate, err := sdk.NewDecFromStr(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid commission rate: %s", args[0])
+			}
+
+			valAddr := cliCtx.GetFromAddress()
+			msg := types.NewMsgEditValidatorCommissionRate(sdk.ValAddress(valAddr), rate)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
 //__________________________________________________________
 
 var (
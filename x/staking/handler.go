@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 	"github.com/okex/exchain/x/staking/keeper"
 	"github.com/okex/exchain/x/staking/types"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
@@ -20,6 +21,8 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgCreateValidator(ctx, msg, k)
 		case types.MsgEditValidator:
 			return handleMsgEditValidator(ctx, msg, k)
+		case types.MsgEditValidatorCommissionRate:
+			return handleMsgEditValidatorCommissionRate(ctx, msg, k)
 		case types.MsgDeposit:
 			return handleMsgDeposit(ctx, msg, k)
 		case types.MsgWithdraw:
@@ -178,3 +181,39 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+func handleMsgEditValidatorCommissionRate(ctx sdk.Context, msg types.MsgEditValidatorCommissionRate,
+	k keeper.Keeper) (*sdk.Result, error) {
+	// the commission rate only takes effect with the delegator rewards, which are distributed after the venus height
+	if !sdk.HigherThanVenus(ctx.BlockHeight()) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "MsgEditValidatorCommissionRate is not allowed.")
+	}
+
+	// validator must already be registered
+	validator, found := k.GetValidator(ctx, msg.ValidatorAddress)
+	if !found {
+		return nil, ErrNoValidatorFound(msg.ValidatorAddress.String())
+	}
+
+	blockTime := ctx.BlockHeader().Time
+	if err := validator.Commission.ValidateEditedRate(msg.CommissionRate, blockTime); err != nil {
+		return nil, err
+	}
+
+	// the commission rate takes effect in the rewards allocated from the next block
+	validator.Commission.Rate = msg.CommissionRate
+	validator.Commission.UpdateTime = blockTime
+	k.SetValidator(ctx, validator)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(types.EventTypeEditValidator,
+			sdk.NewAttribute(types.AttributeKeyCommissionRate, validator.Commission.String()),
+		),
+		sdk.NewEvent(sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.ValidatorAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
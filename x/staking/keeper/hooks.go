@@ -49,3 +49,24 @@ func (k Keeper) AfterValidatorDestroyed(ctx sdk.Context, consAddr sdk.ConsAddres
 		k.hooks.AfterValidatorDestroyed(ctx, consAddr, valAddr)
 	}
 }
+
+// BeforeDelegationCreated - call hook if registered
+func (k Keeper) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.BeforeDelegationCreated(ctx, delAddr, valAddr)
+	}
+}
+
+// BeforeDelegationSharesModified - call hook if registered
+func (k Keeper) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.BeforeDelegationSharesModified(ctx, delAddr, valAddr)
+	}
+}
+
+// AfterDelegationModified - call hook if registered
+func (k Keeper) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.AfterDelegationModified(ctx, delAddr, valAddr)
+	}
+}
@@ -34,12 +34,7 @@ func DelegatorAddSharesInvariant(k Keeper) sdk.Invariant {
 
 			valTotalShares := validator.GetDelegatorShares()
 
-			var totalShares sdk.Dec
-			if validator.MinSelfDelegation.Equal(sdk.ZeroDec()) && validator.Jailed {
-				totalShares = sdk.ZeroDec()
-			} else {
-				totalShares = k.getSharesFromDefaultMinSelfDelegation()
-			}
+			totalShares := k.GetMinSelfDelegationShares(validator)
 
 			allShares := k.GetValidatorAllShares(ctx, validator.GetOperator())
 			for _, shares := range allShares {
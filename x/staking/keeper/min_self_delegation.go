@@ -4,6 +4,7 @@ import (
 	"time"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/staking/exported"
 	"github.com/okex/exchain/x/staking/types"
 )
 
@@ -21,7 +22,8 @@ func (k Keeper) WithdrawMinSelfDelegation(ctx sdk.Context, delAddr sdk.AccAddres
 		return completionTime, types.ErrMoreMinSelfDelegation(validator.OperatorAddress.String())
 	}
 
-	// 2.unbond msd
+	// 2.unbond msd, the hooks are called before the shares of the validator change
+	k.BeforeValidatorModified(ctx, validator.OperatorAddress)
 	k.bondedTokensToNotBonded(ctx, sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, validator.MinSelfDelegation))
 	completionTime = ctx.BlockHeader().Time.Add(k.UnbondingTime(ctx))
 	minSelfUndelegation := types.NewUndelegationInfo(delAddr, validator.MinSelfDelegation, completionTime)
@@ -88,3 +90,12 @@ func (k Keeper) addSharesAsDefaultMinSelfDelegation(ctx sdk.Context, pValidator
 func (k Keeper) getSharesFromDefaultMinSelfDelegation() sdk.Dec {
 	return sdk.OneDec()
 }
+
+// GetMinSelfDelegationShares returns the shares of the msd on a validator, which aren't added by any delegator. They
+// are removed once the msd is withdrawn and the validator is jailed
+func (k Keeper) GetMinSelfDelegationShares(validator exported.ValidatorI) sdk.Dec {
+	if validator.GetMinSelfDelegation().IsZero() && validator.IsJailed() {
+		return sdk.ZeroDec()
+	}
+	return k.getSharesFromDefaultMinSelfDelegation()
+}
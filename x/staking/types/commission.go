@@ -121,3 +121,33 @@ func (c Commission) ValidateNewRate(newRate sdk.Dec, blockTime time.Time) sdk.Er
 
 	return nil
 }
+
+// ValidateEditedRate performs the sanity checks of a new commission rate edited by the validator. All the validators
+// on okexchain are created with the commission rate 100% and the max change rate 0, so the rate can be raised by
+// DefaultMaxCommissionChangeRate at most once in 24 hours unless the validator has a positive max change rate
+func (c Commission) ValidateEditedRate(newRate sdk.Dec, blockTime time.Time) sdk.Error {
+	maxChangeRate := c.MaxChangeRate
+	if maxChangeRate.IsNil() || !maxChangeRate.IsPositive() {
+		maxChangeRate = DefaultMaxCommissionChangeRate
+	}
+
+	switch {
+	case blockTime.Sub(c.UpdateTime).Hours() < DefaultValidateRateUpdateInterval:
+		// new rate cannot be changed more than once within 24 hours
+		return ErrCommissionUpdateTime()
+
+	case newRate.LT(sdk.ZeroDec()):
+		// new rate cannot be negative
+		return ErrCommissionNegative()
+
+	case newRate.GT(c.MaxRate):
+		// new rate cannot be greater than the max rate
+		return ErrCommissionGTMaxRate()
+
+	case newRate.Sub(c.Rate).GT(maxChangeRate):
+		// new rate % points rise cannot be greater than the max change rate
+		return ErrCommissionGTMaxChangeRate()
+	}
+
+	return nil
+}
@@ -70,3 +70,45 @@ func TestCommissionValidateNewRate(t *testing.T) {
 		)
 	}
 }
+
+func TestCommissionValidateEditedRate(t *testing.T) {
+	now := time.Now().UTC()
+	// the validators are created with the max change rate 0
+	c1 := NewCommissionWithTime(sdk.OneDec(), sdk.OneDec(), sdk.ZeroDec(), now)
+	c1.Rate = sdk.MustNewDecFromStr("0.10")
+	c2 := NewCommissionWithTime(sdk.MustNewDecFromStr("0.10"), sdk.OneDec(), sdk.MustNewDecFromStr("0.05"), now)
+
+	testCases := []struct {
+		input     Commission
+		newRate   sdk.Dec
+		blockTime time.Time
+		expectErr bool
+	}{
+		// invalid new commission rate; last update < 24h ago
+		{c1, sdk.MustNewDecFromStr("0.05"), now, true},
+		// invalid new commission rate; new rate < 0%
+		{c1, sdk.MustNewDecFromStr("-1.00"), now.Add(48 * time.Hour), true},
+		// invalid new commission rate; new rate > max rate
+		{c1, sdk.MustNewDecFromStr("1.10"), now.Add(48 * time.Hour), true},
+		// invalid new commission rate; new rate rises more than the default max change rate
+		{c1, sdk.MustNewDecFromStr("0.12"), now.Add(48 * time.Hour), true},
+		{c1, sdk.OneDec(), now.Add(48 * time.Hour), true},
+		// valid commission; new rate rises by the default max change rate
+		{c1, sdk.MustNewDecFromStr("0.11"), now.Add(48 * time.Hour), false},
+		// valid commission; new rate falls without limit
+		{c1, sdk.ZeroDec(), now.Add(48 * time.Hour), false},
+		// invalid new commission rate; new rate rises more than the max change rate of the validator
+		{c2, sdk.MustNewDecFromStr("0.16"), now.Add(48 * time.Hour), true},
+		// valid commission; new rate rises by the max change rate of the validator
+		{c2, sdk.MustNewDecFromStr("0.15"), now.Add(48 * time.Hour), false},
+	}
+
+	for i, tc := range testCases {
+		err := tc.input.ValidateEditedRate(tc.newRate, tc.blockTime)
+		require.Equal(
+			t, tc.expectErr, err != nil,
+			"unexpected result; tc #%d, input: %v, newRate: %s, blockTime: %s",
+			i, tc.input, tc.newRate, tc.blockTime,
+		)
+	}
+}
@@ -86,4 +86,10 @@ type StakingHooks interface {
 	// required by okexchain
 	// Must be called when a validator is destroyed by tx
 	AfterValidatorDestroyed(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress)
+	// Must be called before the shares are added to a validator by a delegator for the first time
+	BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress)
+	// Must be called before the shares added to a validator by a delegator are changed or withdrawn
+	BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress)
+	// Must be called after the shares added to a validator by a delegator are created or changed
+	AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress)
 }
@@ -55,3 +55,25 @@ func (h MultiStakingHooks) AfterValidatorDestroyed(ctx sdk.Context, consAddr sdk
 		h[i].AfterValidatorDestroyed(ctx, consAddr, valAddr)
 	}
 }
+
+// BeforeDelegationCreated handles the hooks before the shares are added to a validator by a delegator for the first time
+func (h MultiStakingHooks) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].BeforeDelegationCreated(ctx, delAddr, valAddr)
+	}
+}
+
+// BeforeDelegationSharesModified handles the hooks before the shares added to a validator by a delegator are changed
+func (h MultiStakingHooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].BeforeDelegationSharesModified(ctx, delAddr, valAddr)
+	}
+}
+
+// AfterDelegationModified handles the hooks after the shares added to a validator by a delegator are changed
+func (h MultiStakingHooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].AfterDelegationModified(ctx, delAddr, valAddr)
+	}
+}
@@ -12,6 +12,7 @@ import (
 var (
 	_ sdk.Msg = &MsgCreateValidator{}
 	_ sdk.Msg = &MsgEditValidator{}
+	_ sdk.Msg = &MsgEditValidatorCommissionRate{}
 )
 
 //______________________________________________________________________
@@ -167,3 +168,47 @@ func (msg MsgEditValidator) ValidateBasic() error {
 
 	return nil
 }
+
+// MsgEditValidatorCommissionRate - struct for editing the commission rate of a validator
+type MsgEditValidatorCommissionRate struct {
+	CommissionRate   sdk.Dec        `json:"commission_rate" yaml:"commission_rate"`
+	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+}
+
+// NewMsgEditValidatorCommissionRate creates a msg of edit-validator-commission-rate
+func NewMsgEditValidatorCommissionRate(valAddr sdk.ValAddress, newRate sdk.Dec) MsgEditValidatorCommissionRate {
+	return MsgEditValidatorCommissionRate{
+		CommissionRate:   newRate,
+		ValidatorAddress: valAddr,
+	}
+}
+
+// nolint
+func (msg MsgEditValidatorCommissionRate) Route() string { return RouterKey }
+func (msg MsgEditValidatorCommissionRate) Type() string  { return "edit_validator_commission_rate" }
+func (msg MsgEditValidatorCommissionRate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddress)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgEditValidatorCommissionRate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic gives a quick validity check
+func (msg MsgEditValidatorCommissionRate) ValidateBasic() error {
+	if msg.ValidatorAddress.Empty() {
+		return ErrNilValidatorAddr()
+	}
+
+	if msg.CommissionRate.IsNil() || msg.CommissionRate.IsNegative() {
+		return ErrCommissionNegative()
+	}
+
+	if msg.CommissionRate.GT(sdk.OneDec()) {
+		return ErrCommissionHuge()
+	}
+
+	return nil
+}
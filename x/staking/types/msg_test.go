@@ -129,6 +129,33 @@ func TestMsgEditValidator(t *testing.T) {
 	}
 }
 
+func TestMsgEditValidatorCommissionRate(t *testing.T) {
+	tests := []struct {
+		name          string
+		validatorAddr sdk.ValAddress
+		rate          sdk.Dec
+		expectPass    bool
+	}{
+		{"basic good", valAddr1, sdk.NewDecWithPrec(5, 1), true},
+		{"zero rate", valAddr1, sdk.ZeroDec(), true},
+		{"full rate", valAddr1, sdk.OneDec(), true},
+		{"empty address", emptyAddr, sdk.NewDecWithPrec(5, 1), false},
+		{"nil rate", valAddr1, sdk.Dec{}, false},
+		{"negative rate", valAddr1, sdk.NewDec(-1), false},
+		{"huge rate", valAddr1, sdk.NewDecWithPrec(11, 1), false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgEditValidatorCommissionRate(tc.validatorAddr, tc.rate)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+			checkMsg(t, msg, "edit_validator_commission_rate")
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
 func checkMsg(t *testing.T, msg sdk.Msg, expType string) {
 	require.Contains(t, msg.Route(), RouterKey)
 	require.Contains(t, msg.Type(), expType)
@@ -35,6 +35,9 @@ const (
 )
 
 var (
+	// DefaultMaxCommissionChangeRate is the max rise of the commission rate edited by the validator created with the
+	// max change rate 0
+	DefaultMaxCommissionChangeRate = sdk.NewDecWithPrec(1, 2)
 	// DefaultMinDelegation is the limit value of delegation or undelegation
 	DefaultMinDelegation = sdk.NewDecWithPrec(1, 4)
 	// DefaultMinSelfDelegation is the default value of each validator's msd (hard code)
@@ -19,6 +19,8 @@ const (
 	KeyLock = types.KeyLock
 	// KeyMint key for token mint store
 	KeyMint = types.KeyMint
+	// VestingAccountName is the name of the module account locking the vesting coins
+	VestingAccountName = types.VestingAccountName
 
 	// CodeInvalidAsset error code of invalid asset
 	CodeInvalidAsset = types.CodeInvalidAsset
@@ -37,6 +39,10 @@ type (
 	FeeDetail = types.FeeDetail
 	CoinsInfo = types.CoinsInfo
 	Token     = types.Token
+
+	MsgCreateVestingTransfer = types.MsgCreateVestingTransfer
+	VestingSchedule          = types.VestingSchedule
+	VestingSchedules         = types.VestingSchedules
 )
 
 var (
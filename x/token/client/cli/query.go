@@ -26,6 +26,7 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	queryCmd.AddCommand(flags.GetCommands(
 		getCmdQueryParams(queryRoute, cdc),
 		getCmdTokenInfo(queryRoute, cdc),
+		getCmdQueryVestingSchedules(queryRoute, cdc),
 		//getAccountCmd(queryRoute, cdc),
 	)...)
 
@@ -105,6 +106,32 @@ $ exchaincli query token params
 	}
 }
 
+// getCmdQueryVestingSchedules implements the query vesting schedules command.
+func getCmdQueryVestingSchedules(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "vesting [address]",
+		Short: "Query the vesting schedules transferred to an account",
+		Long: strings.TrimSpace(`Query the vesting schedules whose coins are not released to an account completely:
+
+$ exchaincli query token vesting okexchain1...
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryVestingSchedules, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var schedules types.VestingSchedules
+			cdc.MustUnmarshalJSON(bz, &schedules)
+			return cliCtx.PrintOutput(schedules)
+		},
+	}
+}
+
 // just for the object of []string could be inputted into cliCtx.PrintOutput(...)
 type Strings []string
 
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
 	"io/ioutil"
@@ -31,6 +32,9 @@ const (
 	Mintable      = "mintable"
 	Transfers     = "transfers"
 	TransfersFile = "transfers-file"
+	StartTime     = "start-time"
+	EndTime       = "end-time"
+	PeriodsFile   = "periods-file"
 )
 
 const (
@@ -49,6 +53,8 @@ var (
 	errTransfersFileNotValid  = errors.New("transfers file not valid")
 	errSign                   = errors.New("sign not succeed")
 	errParam                  = errors.New("can't get token desc or whole name")
+	errVestingTimeNotValid    = errors.New("vesting time not valid")
+	errPeriodsFileNotValid    = errors.New("periods file not valid")
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -69,6 +75,7 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		getCmdTransferOwnership(cdc),
 		getCmdConfirmOwnership(cdc),
 		getCmdTokenEdit(cdc),
+		getCmdCreateVestingTransfer(cdc),
 	)...)
 
 	return distTxCmd
@@ -460,3 +467,94 @@ func getCmdConfirmOwnership(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().StringP("symbol", "s", "", "symbol of the token to be transferred")
 	return cmd
 }
+
+// getCmdCreateVestingTransfer is the CLI command for sending a CreateVestingTransfer transaction
+func getCmdCreateVestingTransfer(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-vesting-transfer [to_address] [amount]",
+		Short: "transfer coins which vest continuously or periodically to an account",
+		Long: strings.TrimSpace(`Transfer coins to an account, which are locked and released to it as they vest.
+The coins vest linearly between --start-time and --end-time (unix seconds) and are released daily, or at the
+end of each period listed in --periods-file if it's set:
+
+$ exchaincli tx token create-vesting-transfer okexchain1... 1000okt --start-time 1640995200 --end-time 1672531200
+$ exchaincli tx token create-vesting-transfer okexchain1... 200okt --start-time 1640995200 --periods-file periods.json
+
+where periods.json contains:
+
+[{"length": 2592000, "amount": "100okt"}, {"length": 2592000, "amount": "100okt"}]
+`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := authTypes.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+			flags := cmd.Flags()
+
+			to, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid address：%s", args[0])
+			}
+			amount, err := sdk.ParseDecCoins(args[1])
+			if err != nil {
+				return errAmountNotValid
+			}
+
+			startTime, err := flags.GetInt64(StartTime)
+			if err != nil {
+				return errVestingTimeNotValid
+			}
+			endTime, err := flags.GetInt64(EndTime)
+			if err != nil {
+				return errVestingTimeNotValid
+			}
+			periodsFile, err := flags.GetString(PeriodsFile)
+			if err != nil {
+				return errPeriodsFileNotValid
+			}
+
+			var periods types.VestingPeriods
+			if periodsFile != "" {
+				periods, err = readVestingPeriods(periodsFile)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgCreateVestingTransfer(cliCtx.GetFromAddress(), to, amount, startTime, endTime, periods)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Int64(StartTime, 0, "the time when the coins start vesting, in unix seconds")
+	cmd.Flags().Int64(EndTime, 0, "the time when the coins finish vesting continuously, in unix seconds")
+	cmd.Flags().String(PeriodsFile, "", `File of vesting periods, format: [{"length": 2592000, "amount": "100okt"}, ...]`)
+	return cmd
+}
+
+func readVestingPeriods(file string) (types.VestingPeriods, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var periodStrs []struct {
+		Length int64  `json:"length"`
+		Amount string `json:"amount"`
+	}
+	if err := json.Unmarshal(bz, &periodStrs); err != nil {
+		return nil, errPeriodsFileNotValid
+	}
+
+	periods := make(types.VestingPeriods, len(periodStrs))
+	for i, periodStr := range periodStrs {
+		amount, err := sdk.ParseDecCoins(periodStr.Amount)
+		if err != nil {
+			return nil, err
+		}
+		periods[i] = types.VestingPeriod{Length: periodStr.Length, Amount: amount}
+	}
+	return periods, nil
+}
@@ -18,6 +18,8 @@ type GenesisState struct {
 	Tokens       []types.Token    `json:"tokens"`
 	LockedAssets []types.AccCoins `json:"locked_assets"`
 	LockedFees   []types.AccCoins `json:"locked_fees"`
+
+	VestingSchedules types.VestingSchedules `json:"vesting_schedules"`
 }
 
 // default GenesisState used by Cosmos Hub
@@ -93,6 +95,19 @@ func initGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 			panic(err)
 		}
 	}
+
+	// the vesting schedules are queued to release in the first block
+	var lastVestingID uint64
+	for _, schedule := range data.VestingSchedules {
+		keeper.setVestingSchedule(ctx, schedule)
+		keeper.queueVestingSchedule(ctx, ctx.BlockTime().Unix(), schedule.ID)
+		if schedule.ID > lastVestingID {
+			lastVestingID = schedule.ID
+		}
+	}
+	if lastVestingID != 0 {
+		keeper.setVestingScheduleID(ctx, lastVestingID)
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -113,10 +128,17 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data GenesisState) {
 		return false
 	})
 
+	var vestingSchedules types.VestingSchedules
+	keeper.IterateVestingSchedules(ctx, func(schedule types.VestingSchedule) bool {
+		vestingSchedules = append(vestingSchedules, schedule)
+		return false
+	})
+
 	return GenesisState{
-		Params:       params,
-		Tokens:       tokens,
-		LockedAssets: lockedAsset,
-		LockedFees:   lockedFees,
+		Params:           params,
+		Tokens:           tokens,
+		LockedAssets:     lockedAsset,
+		LockedFees:       lockedFees,
+		VestingSchedules: vestingSchedules,
 	}
 }
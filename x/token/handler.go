@@ -68,6 +68,12 @@ func NewTokenHandler(keeper Keeper, protocolVersion version.ProtocolVersionType)
 			handlerFun = func() (*sdk.Result, error) {
 				return handleMsgTokenModify(ctx, keeper, msg, logger)
 			}
+
+		case types.MsgCreateVestingTransfer:
+			name = "handleMsgCreateVestingTransfer"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgCreateVestingTransfer(ctx, keeper, msg, logger)
+			}
 		default:
 			errMsg := fmt.Sprintf("Unrecognized token Msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -318,6 +324,39 @@ func handleMsgSend(ctx sdk.Context, keeper Keeper, msg types.MsgSend, logger log
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+func handleMsgCreateVestingTransfer(ctx sdk.Context, keeper Keeper, msg types.MsgCreateVestingTransfer,
+	logger log.Logger) (*sdk.Result, error) {
+	if !keeper.bankKeeper.GetSendEnabled(ctx) {
+		return types.ErrSendDisabled().Result()
+	}
+
+	schedule, err := keeper.CreateVestingTransfer(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var name = "handleMsgCreateVestingTransfer"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<From:%s,To:%s,Amount:%s,StartTime:%d,EndTime:%d>\n"+
+			"                           result<Vesting schedule %d created>\n",
+			ctx.BlockHeight(), name,
+			msg.FromAddress, msg.ToAddress, msg.Amount, msg.StartTime, schedule.EndTime, schedule.ID))
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCreateVestingTransfer,
+			sdk.NewAttribute(types.AttributeKeyVestingID, sdk.NewUint(schedule.ID).String()),
+			sdk.NewAttribute(types.AttributeKeyRecipient, msg.ToAddress.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+		),
+		sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName)),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
 func handleMsgTransferOwnership(ctx sdk.Context, keeper Keeper, msg types.MsgTransferOwnership, logger log.Logger) (*sdk.Result, error) {
 	tokenInfo := keeper.GetTokenInfo(ctx, msg.Symbol)
 
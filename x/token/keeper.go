@@ -346,7 +346,7 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 // GetCoinsInfo gets all of the coin info by addr
 func (k Keeper) GetCoinsInfo(ctx sdk.Context, addr sdk.AccAddress) (coinsInfo types.CoinsInfo) {
 	availableCoins := k.GetCoins(ctx, addr)
-	lockedCoins := k.GetLockedCoins(ctx, addr)
+	lockedCoins := k.GetLockedCoins(ctx, addr).Add2(k.GetVestingLockedCoins(ctx, addr))
 
 	// merge coins
 	coinsInfo = types.MergeCoinInfo(availableCoins, lockedCoins)
@@ -32,6 +32,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryTokensV2(ctx, path[1:], req, keeper)
 		case types.QueryTokenV2:
 			return queryTokenV2(ctx, path[1:], req, keeper)
+		case types.QueryVestingSchedules:
+			return queryVestingSchedules(ctx, path[1:], keeper)
 		case types.UploadAccount:
 			return uploadAccount(ctx, keeper)
 		default:
@@ -151,6 +153,20 @@ func queryAccount(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 	return bz, nil
 }
 
+func queryVestingSchedules(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, common.ErrCreateAddrFromBech32Failed(path[0], err.Error())
+	}
+
+	schedules := keeper.GetVestingSchedulesByRecipient(ctx, addr)
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, schedules)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
+
 func queryParameters(ctx sdk.Context, keeper Keeper) ([]byte, sdk.Error) {
 	params := keeper.GetParams(ctx)
 	res, err := codec.MarshalJSONIndent(keeper.cdc, params)
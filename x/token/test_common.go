@@ -60,8 +60,9 @@ func CreateParam(t *testing.T, isCheckTx bool) (sdk.Context, Keeper, *sdk.KVStor
 		blacklistedAddrs,
 	)
 	maccPerms := map[string][]string{
-		auth.FeeCollectorName: nil,
-		types.ModuleName:      nil,
+		auth.FeeCollectorName:    nil,
+		types.ModuleName:         nil,
+		types.VestingAccountName: nil,
 	}
 	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bk, maccPerms)
 	tk := NewKeeper(bk,
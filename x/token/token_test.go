@@ -76,8 +76,9 @@ func getMockDexApp(t *testing.T, numGenAccs int) (mockDexApp *MockDexApp, keeper
 	)
 
 	maccPerms := map[string][]string{
-		auth.FeeCollectorName: nil,
-		types.ModuleName:      {supply.Minter, supply.Burner},
+		auth.FeeCollectorName:    nil,
+		types.ModuleName:         {supply.Minter, supply.Burner},
+		types.VestingAccountName: nil,
 	}
 	mockDexApp.supplyKeeper = supply.NewKeeper(mockDexApp.Cdc, mockDexApp.keySupply, mockDexApp.AccountKeeper, mockDexApp.bankKeeper, maccPerms)
 	mockDexApp.tokenKeeper = NewKeeper(
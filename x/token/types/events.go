@@ -0,0 +1,10 @@
+package types
+
+// token module event types
+const (
+	EventTypeCreateVestingTransfer = "create_vesting_transfer"
+	EventTypeReleaseVestedCoins    = "release_vested_coins"
+
+	AttributeKeyVestingID = "vesting_id"
+	AttributeKeyRecipient = "recipient"
+)
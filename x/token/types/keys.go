@@ -1,6 +1,8 @@
 package types
 
 import (
+	"encoding/binary"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
@@ -23,6 +25,9 @@ const (
 	KeyLock = "lock"
 	KeyMint = "mint"
 
+	// VestingAccountName is the name of the module account holding the vesting coins
+	VestingAccountName = "token_vesting_account"
+
 	// query endpoints supported by the governance Querier
 	QueryInfo       = "info"
 	QueryTokens     = "tokens"
@@ -35,6 +40,8 @@ const (
 	QueryTokensV2  = "tokensV2"
 	QueryTokenV2   = "tokenV2"
 
+	QueryVestingSchedules = "vesting"
+
 	UploadAccount = "upload"
 )
 
@@ -45,6 +52,10 @@ var (
 	PrefixUserTokenKey        = []byte{0x03} // the address prefix of the user-token relationship
 	LockedFeeKey              = []byte{0x04} // the address prefix of the locked order fee coins
 	PrefixConfirmOwnershipKey = []byte{0x05} // the prefix of the confirm ownership key
+	VestingScheduleKey        = []byte{0x06} // the prefix of the vesting schedule key
+	PrefixVestingRecipientKey = []byte{0x07} // the prefix of the recipient-vesting relationship
+	VestingQueueKey           = []byte{0x08} // the prefix of the vesting schedules queued by the next release time
+	VestingScheduleIDKey      = []byte{0x09} // key for the latest vesting schedule id
 )
 
 func GetUserTokenPrefix(owner sdk.AccAddress) []byte {
@@ -71,3 +82,32 @@ func GetLockFeeAddress(addr sdk.AccAddress) []byte {
 func GetConfirmOwnershipKey(symbol string) []byte {
 	return append(PrefixConfirmOwnershipKey, []byte(symbol)...)
 }
+
+func GetVestingScheduleKey(id uint64) []byte {
+	return append(VestingScheduleKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+func GetVestingRecipientPrefix(to sdk.AccAddress) []byte {
+	return append(PrefixVestingRecipientKey, to.Bytes()...)
+}
+
+func GetVestingRecipientKey(to sdk.AccAddress, id uint64) []byte {
+	return append(GetVestingRecipientPrefix(to), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetVestingQueueTimePrefix gets the prefix of the vesting schedules queued to release at the time
+func GetVestingQueueTimePrefix(releaseTime int64) []byte {
+	return append(VestingQueueKey, sdk.Uint64ToBigEndian(uint64(releaseTime))...)
+}
+
+// GetVestingQueueKey gets the key of a vesting schedule queued to release at the time
+func GetVestingQueueKey(releaseTime int64, id uint64) []byte {
+	return append(GetVestingQueueTimePrefix(releaseTime), sdk.Uint64ToBigEndian(id)...)
+}
+
+// SplitVestingQueueKey splits the vesting queue key and returns the release time and the vesting schedule id
+func SplitVestingQueueKey(key []byte) (releaseTime int64, id uint64) {
+	releaseTime = int64(binary.BigEndian.Uint64(key[1:9]))
+	id = binary.BigEndian.Uint64(key[9:])
+	return
+}
@@ -2,19 +2,22 @@
 package types
 
 import (
+	"fmt"
+	"math"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/x/common"
 )
 
 const (
-	DescLenLimit   = 256
-	MultiSendLimit = 1000
+	DescLenLimit        = 256
+	MultiSendLimit      = 1000
+	VestingPeriodsLimit = 120
 
 	// 90 billion
 	TotalSupplyUpperbound = int64(9 * 1e10)
 )
 
-//
 type MsgTokenIssue struct {
 	Description    string         `json:"description"`
 	Symbol         string         `json:"symbol"`
@@ -398,3 +401,98 @@ func (msg MsgConfirmOwnership) GetSignBytes() []byte {
 func (msg MsgConfirmOwnership) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Address}
 }
+
+// MsgCreateVestingTransfer - high level transaction of the coin module
+type MsgCreateVestingTransfer struct {
+	FromAddress sdk.AccAddress `json:"from_address"`
+	ToAddress   sdk.AccAddress `json:"to_address"`
+	Amount      sdk.SysCoins   `json:"amount"`
+	StartTime   int64          `json:"start_time"`
+	EndTime     int64          `json:"end_time"`
+	Periods     VestingPeriods `json:"periods"`
+}
+
+// NewMsgCreateVestingTransfer creates a new instance of MsgCreateVestingTransfer. The coins vest linearly between
+// the start time and the end time if there are no periods, otherwise they vest at the end of each period
+func NewMsgCreateVestingTransfer(from, to sdk.AccAddress, coins sdk.SysCoins, startTime, endTime int64,
+	periods VestingPeriods) MsgCreateVestingTransfer {
+	return MsgCreateVestingTransfer{
+		FromAddress: from,
+		ToAddress:   to,
+		Amount:      coins,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Periods:     periods,
+	}
+}
+
+func (msg MsgCreateVestingTransfer) Route() string { return RouterKey }
+
+func (msg MsgCreateVestingTransfer) Type() string { return "create_vesting_transfer" }
+
+func (msg MsgCreateVestingTransfer) ValidateBasic() sdk.Error {
+	if msg.FromAddress.Empty() {
+		return ErrAddressIsRequired()
+	}
+	if msg.ToAddress.Empty() {
+		return ErrAddressIsRequired()
+	}
+	if !msg.Amount.IsValid() {
+		return ErrInvalidCoins(msg.Amount.String())
+	}
+	if !msg.Amount.IsAllPositive() {
+		return common.ErrInsufficientCoins(DefaultParamspace, msg.Amount.String())
+	}
+	if msg.StartTime < 0 {
+		return ErrInvalidVestingSchedule("start time can not be negative")
+	}
+
+	// continuous vesting
+	if len(msg.Periods) == 0 {
+		if msg.EndTime <= msg.StartTime {
+			return ErrInvalidVestingSchedule("end time must be after start time")
+		}
+		return nil
+	}
+
+	// periodic vesting
+	if msg.EndTime != 0 {
+		return ErrInvalidVestingSchedule("end time must be empty with vesting periods")
+	}
+	if len(msg.Periods) > VestingPeriodsLimit {
+		return ErrInvalidVestingSchedule("too many vesting periods")
+	}
+	endTime := msg.StartTime
+	for _, period := range msg.Periods {
+		if period.Length <= 0 {
+			return ErrInvalidVestingSchedule("length of vesting period must be positive")
+		}
+		if period.Length > math.MaxInt64-endTime {
+			return ErrInvalidVestingSchedule("end time of vesting periods overflows")
+		}
+		endTime += period.Length
+		if !period.Amount.IsValid() || !period.Amount.IsAllPositive() {
+			return ErrInvalidVestingSchedule(fmt.Sprintf("invalid amount of vesting period: %s", period.Amount))
+		}
+	}
+	// compare the amount of each denom, IsEqual panics on different denoms
+	totalAmount := msg.Periods.TotalAmount()
+	if len(totalAmount) != len(msg.Amount) {
+		return ErrInvalidVestingSchedule("total amount of vesting periods must be equal to the amount")
+	}
+	for _, coin := range msg.Amount {
+		if !totalAmount.AmountOf(coin.Denom).Equal(coin.Amount) {
+			return ErrInvalidVestingSchedule("total amount of vesting periods must be equal to the amount")
+		}
+	}
+	return nil
+}
+
+func (msg MsgCreateVestingTransfer) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgCreateVestingTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
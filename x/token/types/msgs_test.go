@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math"
 	"strconv"
 	"testing"
 
@@ -375,3 +376,47 @@ bnbbbbbbbbbbbnbbbbbbbbbbnbbbbbbbbbbbnbbbbbbbbb1234`, "bnbbbbbbbbbb", false, fals
 	err := tokenEditMsg.ValidateBasic()
 	require.NoError(t, err)
 }
+
+func TestMsgCreateVestingTransfer(t *testing.T) {
+	from := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	to := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	amount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(100))
+	halfAmount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(50))
+	periods := VestingPeriods{{Length: 100, Amount: halfAmount}, {Length: 100, Amount: halfAmount}}
+
+	testCases := []struct {
+		msg     MsgCreateVestingTransfer
+		success bool
+	}{
+		{NewMsgCreateVestingTransfer(from, to, amount, 1000, 2000, nil), true},
+		{NewMsgCreateVestingTransfer(from, to, amount, 1000, 0, periods), true},
+		{NewMsgCreateVestingTransfer(sdk.AccAddress{}, to, amount, 1000, 2000, nil), false},
+		{NewMsgCreateVestingTransfer(from, sdk.AccAddress{}, amount, 1000, 2000, nil), false},
+		{NewMsgCreateVestingTransfer(from, to, sdk.SysCoins{}, 1000, 2000, nil), false},
+		{NewMsgCreateVestingTransfer(from, to, amount, -1, 2000, nil), false},
+		{NewMsgCreateVestingTransfer(from, to, amount, 2000, 2000, nil), false},
+		{NewMsgCreateVestingTransfer(from, to, amount, 1000, 2000, periods), false},
+		{NewMsgCreateVestingTransfer(from, to, halfAmount, 1000, 0, periods), false},
+		{NewMsgCreateVestingTransfer(from, to, halfAmount, 1000, 0,
+			VestingPeriods{{Length: 0, Amount: halfAmount}}), false},
+		// the denoms of the periods differ from the amount
+		{NewMsgCreateVestingTransfer(from, to, amount, 1000, 0,
+			VestingPeriods{{Length: 100, Amount: sdk.NewDecCoinsFromDec("xxb", sdk.NewDec(100))}}), false},
+		{NewMsgCreateVestingTransfer(from, to, amount.Add(sdk.NewDecCoinsFromDec("xxb", sdk.NewDec(100))...), 1000, 0,
+			periods), false},
+		// the end time of the periods overflows
+		{NewMsgCreateVestingTransfer(from, to, amount, 1000, 0,
+			VestingPeriods{{Length: math.MaxInt64 - 1000, Amount: halfAmount}, {Length: 1, Amount: halfAmount}}), false},
+	}
+
+	for i, tc := range testCases {
+		err := tc.msg.ValidateBasic()
+		require.Equal(t, tc.success, err == nil, "test case #%d", i)
+	}
+
+	msg := testCases[0].msg
+	require.EqualValues(t, []sdk.AccAddress{from}, msg.GetSigners())
+	require.EqualValues(t, sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg)), msg.GetSignBytes())
+	require.EqualValues(t, "token", msg.Route())
+	require.EqualValues(t, "create_vesting_transfer", msg.Type())
+}
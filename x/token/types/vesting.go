@@ -0,0 +1,148 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// VestingReleaseInterval is the interval in seconds of releasing the continuous vesting coins, so the schedules are
+// processed once a day instead of every block
+const VestingReleaseInterval = 24 * 60 * 60
+
+// VestingPeriod is a period of a periodic vesting schedule, whose amount vests at the end of it
+type VestingPeriod struct {
+	Length int64        `json:"length"` // e.g. 2592000 (seconds)
+	Amount sdk.SysCoins `json:"amount"` // e.g. 100.00000000okt
+}
+
+// VestingPeriods is the slice of VestingPeriod
+type VestingPeriods []VestingPeriod
+
+// TotalAmount returns the sum of the amount of all the periods
+func (periods VestingPeriods) TotalAmount() (total sdk.SysCoins) {
+	for _, period := range periods {
+		total = total.Add2(period.Amount)
+	}
+	return total
+}
+
+// VestingSchedule is the schedule of the coins transferred to an account, which are locked in the vesting module
+// account and released to the account as they vest. The coins vest linearly between the start time and the end
+// time and are released daily if there are no periods, otherwise they vest at the end of each period
+type VestingSchedule struct {
+	ID             uint64         `json:"id"`
+	FromAddress    sdk.AccAddress `json:"from_address"`
+	ToAddress      sdk.AccAddress `json:"to_address"`
+	OriginalAmount sdk.SysCoins   `json:"original_amount"`
+	ReleasedAmount sdk.SysCoins   `json:"released_amount"`
+	StartTime      int64          `json:"start_time"` // unix seconds
+	EndTime        int64          `json:"end_time"`   // unix seconds
+	Periods        VestingPeriods `json:"periods"`
+}
+
+// NewVestingSchedule creates a new instance of VestingSchedule
+func NewVestingSchedule(id uint64, from, to sdk.AccAddress, amount sdk.SysCoins, startTime, endTime int64,
+	periods VestingPeriods) VestingSchedule {
+	// the end time of periodic vesting is the end of the last period
+	if len(periods) != 0 {
+		endTime = startTime
+		for _, period := range periods {
+			endTime += period.Length
+		}
+	}
+
+	return VestingSchedule{
+		ID:             id,
+		FromAddress:    from,
+		ToAddress:      to,
+		OriginalAmount: amount,
+		ReleasedAmount: sdk.SysCoins{},
+		StartTime:      startTime,
+		EndTime:        endTime,
+		Periods:        periods,
+	}
+}
+
+// IsPeriodic returns whether the coins vest periodically
+func (vs VestingSchedule) IsPeriodic() bool {
+	return len(vs.Periods) != 0
+}
+
+// VestedCoins returns the coins vested until the block time
+func (vs VestingSchedule) VestedCoins(blockTime int64) sdk.SysCoins {
+	if blockTime <= vs.StartTime {
+		return sdk.SysCoins{}
+	}
+	if blockTime >= vs.EndTime {
+		return vs.OriginalAmount
+	}
+
+	if vs.IsPeriodic() {
+		var vested sdk.SysCoins
+		periodEnd := vs.StartTime
+		for _, period := range vs.Periods {
+			periodEnd += period.Length
+			if blockTime < periodEnd {
+				break
+			}
+			vested = vested.Add2(period.Amount)
+		}
+		return vested
+	}
+
+	fraction := sdk.NewDec(blockTime - vs.StartTime).QuoInt64(vs.EndTime - vs.StartTime)
+	return vs.OriginalAmount.MulDecTruncate(fraction)
+}
+
+// LockedCoins returns the coins not released yet
+func (vs VestingSchedule) LockedCoins() sdk.SysCoins {
+	return vs.OriginalAmount.Sub(vs.ReleasedAmount)
+}
+
+// NextReleaseTime returns the time when the coins are released next after the block time, and false if all the
+// coins have vested. The continuous vesting coins are released every VestingReleaseInterval from the start time
+func (vs VestingSchedule) NextReleaseTime(blockTime int64) (int64, bool) {
+	if blockTime >= vs.EndTime {
+		return 0, false
+	}
+
+	if vs.IsPeriodic() {
+		periodEnd := vs.StartTime
+		for _, period := range vs.Periods {
+			periodEnd += period.Length
+			if blockTime < periodEnd {
+				break
+			}
+		}
+		return periodEnd, true
+	}
+
+	if blockTime < vs.StartTime {
+		blockTime = vs.StartTime
+	}
+	releaseTime := vs.StartTime + ((blockTime-vs.StartTime)/VestingReleaseInterval+1)*VestingReleaseInterval
+	if releaseTime > vs.EndTime {
+		releaseTime = vs.EndTime
+	}
+	return releaseTime, true
+}
+
+func (vs VestingSchedule) String() string {
+	b, err := json.Marshal(vs)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// VestingSchedules is the slice of VestingSchedule
+type VestingSchedules []VestingSchedule
+
+func (schedules VestingSchedules) String() string {
+	b, err := json.Marshal(schedules)
+	if err != nil {
+		return "[{}]"
+	}
+	return string(b)
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/secp256k1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/common"
+)
+
+func TestVestingSchedule_Continuous(t *testing.T) {
+	from := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	to := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	amount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(100))
+
+	schedule := NewVestingSchedule(1, from, to, amount, 1000, 2000, nil)
+	require.False(t, schedule.IsPeriodic())
+	require.True(t, schedule.VestedCoins(1000).IsZero())
+	require.Equal(t, sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(25)), schedule.VestedCoins(1250))
+	require.Equal(t, amount, schedule.VestedCoins(2500))
+
+	// the schedule shorter than the release interval is released at the end
+	releaseTime, ok := schedule.NextReleaseTime(500)
+	require.True(t, ok)
+	require.Equal(t, int64(2000), releaseTime)
+	releaseTime, ok = schedule.NextReleaseTime(1250)
+	require.True(t, ok)
+	require.Equal(t, int64(2000), releaseTime)
+	_, ok = schedule.NextReleaseTime(2000)
+	require.False(t, ok)
+
+	// the coins are released every release interval from the start time
+	day := int64(VestingReleaseInterval)
+	schedule = NewVestingSchedule(1, from, to, amount, 1000, 1000+3*day+10, nil)
+	releaseTime, ok = schedule.NextReleaseTime(500)
+	require.True(t, ok)
+	require.Equal(t, 1000+day, releaseTime)
+	releaseTime, ok = schedule.NextReleaseTime(1000 + day - 1)
+	require.True(t, ok)
+	require.Equal(t, 1000+day, releaseTime)
+	releaseTime, ok = schedule.NextReleaseTime(1000 + day)
+	require.True(t, ok)
+	require.Equal(t, 1000+2*day, releaseTime)
+	releaseTime, ok = schedule.NextReleaseTime(1000 + 3*day)
+	require.True(t, ok)
+	require.Equal(t, 1000+3*day+10, releaseTime)
+
+	schedule.ReleasedAmount = sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(30))
+	require.Equal(t, sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(70)), schedule.LockedCoins())
+}
+
+func TestVestingSchedule_Periodic(t *testing.T) {
+	from := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	to := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	periodAmount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(50))
+	periods := VestingPeriods{{Length: 100, Amount: periodAmount}, {Length: 200, Amount: periodAmount}}
+
+	schedule := NewVestingSchedule(1, from, to, periods.TotalAmount(), 1000, 0, periods)
+	require.True(t, schedule.IsPeriodic())
+	require.Equal(t, int64(1300), schedule.EndTime)
+	require.True(t, schedule.VestedCoins(1099).IsZero())
+	require.Equal(t, periodAmount, schedule.VestedCoins(1100))
+	require.Equal(t, periodAmount, schedule.VestedCoins(1299))
+	require.Equal(t, periods.TotalAmount(), schedule.VestedCoins(1300))
+
+	releaseTime, ok := schedule.NextReleaseTime(0)
+	require.True(t, ok)
+	require.Equal(t, int64(1100), releaseTime)
+	releaseTime, ok = schedule.NextReleaseTime(1100)
+	require.True(t, ok)
+	require.Equal(t, int64(1300), releaseTime)
+	_, ok = schedule.NextReleaseTime(1300)
+	require.False(t, ok)
+}
@@ -0,0 +1,179 @@
+package token
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/token/types"
+)
+
+// CreateVestingTransfer locks the coins of the sender in the vesting module account, which are released to the
+// recipient as they vest
+func (k Keeper) CreateVestingTransfer(ctx sdk.Context, msg types.MsgCreateVestingTransfer) (types.VestingSchedule, error) {
+	if k.bankKeeper.BlacklistedAddr(msg.ToAddress) {
+		return types.VestingSchedule{}, types.ErrBlockedRecipient(msg.ToAddress.String())
+	}
+	if k.IsContractAddress(ctx, msg.ToAddress) {
+		return types.VestingSchedule{}, types.ErrBlockedContractRecipient(msg.ToAddress.String())
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, msg.FromAddress, types.VestingAccountName,
+		msg.Amount); err != nil {
+		return types.VestingSchedule{}, types.ErrSendCoinsFromAccountToModuleFailed(err.Error())
+	}
+
+	schedule := types.NewVestingSchedule(k.getNextVestingScheduleID(ctx), msg.FromAddress, msg.ToAddress,
+		msg.Amount, msg.StartTime, msg.EndTime, msg.Periods)
+	k.setVestingScheduleID(ctx, schedule.ID)
+
+	// release the coins vested already
+	if err := k.releaseVestedCoins(ctx, schedule); err != nil {
+		return types.VestingSchedule{}, err
+	}
+	return schedule, nil
+}
+
+// ReleaseVestedCoins releases the coins of the vesting schedules queued to release until the block time. A schedule
+// failing to release is skipped and stays in the queue, which is retried in the next block
+func (k Keeper) ReleaseVestedCoins(ctx sdk.Context) {
+	blockTime := ctx.BlockTime().Unix()
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := store.Iterator(types.VestingQueueKey, sdk.PrefixEndBytes(types.GetVestingQueueTimePrefix(blockTime)))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		_, id := types.SplitVestingQueueKey(key)
+		schedule, found := k.GetVestingSchedule(ctx, id)
+		if !found {
+			store.Delete(key)
+			continue
+		}
+
+		cacheCtx, writeCache := ctx.CacheContext()
+		if err := k.releaseVestedCoins(cacheCtx, schedule); err != nil {
+			ctx.Logger().With("module", types.ModuleName).Error("failed to release the vested coins",
+				"id", id, "err", err)
+			continue
+		}
+		store.Delete(key)
+		writeCache()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+}
+
+// releaseVestedCoins sends the coins vested but not released yet to the recipient, and queues the schedule to the
+// next release time or removes it if all the coins are released
+func (k Keeper) releaseVestedCoins(ctx sdk.Context, schedule types.VestingSchedule) error {
+	blockTime := ctx.BlockTime().Unix()
+	vested := schedule.VestedCoins(blockTime)
+	releasing, isNegative := vested.SafeSub(schedule.ReleasedAmount)
+	if isNegative {
+		releasing = sdk.SysCoins{}
+	}
+
+	if !releasing.IsZero() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.VestingAccountName, schedule.ToAddress,
+			releasing); err != nil {
+			return types.ErrSendCoinsFromModuleToAccountFailed(err.Error())
+		}
+		schedule.ReleasedAmount = schedule.ReleasedAmount.Add2(releasing)
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeReleaseVestedCoins,
+			sdk.NewAttribute(types.AttributeKeyVestingID, sdk.NewUint(schedule.ID).String()),
+			sdk.NewAttribute(types.AttributeKeyRecipient, schedule.ToAddress.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, releasing.String()),
+		))
+	}
+
+	nextReleaseTime, ok := schedule.NextReleaseTime(blockTime)
+	if !ok {
+		k.deleteVestingSchedule(ctx, schedule)
+		return nil
+	}
+
+	k.setVestingSchedule(ctx, schedule)
+	k.queueVestingSchedule(ctx, nextReleaseTime, schedule.ID)
+	return nil
+}
+
+func (k Keeper) queueVestingSchedule(ctx sdk.Context, releaseTime int64, id uint64) {
+	ctx.KVStore(k.tokenStoreKey).Set(types.GetVestingQueueKey(releaseTime, id), []byte{})
+}
+
+// GetVestingSchedule gets the vesting schedule by id
+func (k Keeper) GetVestingSchedule(ctx sdk.Context, id uint64) (schedule types.VestingSchedule, found bool) {
+	bz := ctx.KVStore(k.tokenStoreKey).Get(types.GetVestingScheduleKey(id))
+	if bz == nil {
+		return schedule, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &schedule)
+	return schedule, true
+}
+
+// GetVestingSchedulesByRecipient gets all of the vesting schedules of an account
+func (k Keeper) GetVestingSchedulesByRecipient(ctx sdk.Context, to sdk.AccAddress) types.VestingSchedules {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetVestingRecipientPrefix(to))
+	defer iter.Close()
+
+	schedules := types.VestingSchedules{}
+	prefixLen := len(types.GetVestingRecipientPrefix(to))
+	for ; iter.Valid(); iter.Next() {
+		id := binary.BigEndian.Uint64(iter.Key()[prefixLen:])
+		if schedule, found := k.GetVestingSchedule(ctx, id); found {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules
+}
+
+// GetVestingLockedCoins gets the coins of an account which don't vest yet
+func (k Keeper) GetVestingLockedCoins(ctx sdk.Context, to sdk.AccAddress) (coins sdk.SysCoins) {
+	for _, schedule := range k.GetVestingSchedulesByRecipient(ctx, to) {
+		coins = coins.Add2(schedule.LockedCoins())
+	}
+	return coins
+}
+
+// IterateVestingSchedules iterates over all the vesting schedules
+func (k Keeper) IterateVestingSchedules(ctx sdk.Context, cb func(schedule types.VestingSchedule) (stop bool)) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.tokenStoreKey), types.VestingScheduleKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var schedule types.VestingSchedule
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &schedule)
+		if cb(schedule) {
+			break
+		}
+	}
+}
+
+func (k Keeper) setVestingSchedule(ctx sdk.Context, schedule types.VestingSchedule) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.GetVestingScheduleKey(schedule.ID), k.cdc.MustMarshalBinaryBare(schedule))
+	store.Set(types.GetVestingRecipientKey(schedule.ToAddress, schedule.ID), []byte{})
+}
+
+func (k Keeper) deleteVestingSchedule(ctx sdk.Context, schedule types.VestingSchedule) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Delete(types.GetVestingScheduleKey(schedule.ID))
+	store.Delete(types.GetVestingRecipientKey(schedule.ToAddress, schedule.ID))
+}
+
+func (k Keeper) getNextVestingScheduleID(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.tokenStoreKey).Get(types.VestingScheduleIDKey)
+	if bz == nil {
+		return 1
+	}
+	return binary.BigEndian.Uint64(bz) + 1
+}
+
+func (k Keeper) setVestingScheduleID(ctx sdk.Context, id uint64) {
+	ctx.KVStore(k.tokenStoreKey).Set(types.VestingScheduleIDKey, sdk.Uint64ToBigEndian(id))
+}
@@ -0,0 +1,179 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/secp256k1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/token/types"
+)
+
+func TestKeeper_VestingTransfer(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	from, to := createVestingTestAccounts(t, ctx, keeper)
+
+	startTime := time.Now().Unix()
+	ctx = ctx.WithBlockTime(time.Unix(startTime, 0))
+	amount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(100))
+	halfAmount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(50))
+
+	// continuous vesting
+	day := int64(types.VestingReleaseInterval)
+	continuous, err := keeper.CreateVestingTransfer(ctx,
+		types.NewMsgCreateVestingTransfer(from, to, amount, startTime, startTime+4*day, nil))
+	require.NoError(t, err)
+	// periodic vesting
+	periods := types.VestingPeriods{{Length: 50, Amount: halfAmount}, {Length: 50, Amount: halfAmount}}
+	periodic, err := keeper.CreateVestingTransfer(ctx,
+		types.NewMsgCreateVestingTransfer(from, to, amount, startTime, 0, periods))
+	require.NoError(t, err)
+	require.Equal(t, continuous.ID+1, periodic.ID)
+
+	// the coins are locked in the vesting module account
+	require.Equal(t, sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(800)), keeper.bankKeeper.GetCoins(ctx, from))
+	require.True(t, keeper.bankKeeper.GetCoins(ctx, to).IsZero())
+	require.Equal(t, amount.Add2(amount), keeper.GetVestingLockedCoins(ctx, to))
+	require.Len(t, keeper.GetVestingSchedulesByRecipient(ctx, to), 2)
+	require.Equal(t, amount.Add2(amount),
+		keeper.supplyKeeper.GetModuleAccount(ctx, types.VestingAccountName).GetCoins())
+
+	// the first period of the periodic vesting ends, the continuous vesting coins aren't released within a day
+	ctx = ctx.WithBlockTime(time.Unix(startTime+50, 0))
+	keeper.ReleaseVestedCoins(ctx)
+	require.Equal(t, halfAmount, keeper.bankKeeper.GetCoins(ctx, to))
+
+	// a quarter of the continuous vesting coins are released after a day
+	ctx = ctx.WithBlockTime(time.Unix(startTime+day, 0))
+	keeper.ReleaseVestedCoins(ctx)
+	require.Equal(t, sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(125)), keeper.bankKeeper.GetCoins(ctx, to))
+	require.Equal(t, sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(75)), keeper.GetVestingLockedCoins(ctx, to))
+
+	// the schedules finished are removed
+	ctx = ctx.WithBlockTime(time.Unix(startTime+5*day, 0))
+	keeper.ReleaseVestedCoins(ctx)
+	require.Equal(t, amount.Add2(amount), keeper.bankKeeper.GetCoins(ctx, to))
+	require.Len(t, keeper.GetVestingSchedulesByRecipient(ctx, to), 0)
+	require.True(t, keeper.GetVestingLockedCoins(ctx, to).IsZero())
+	require.True(t, keeper.supplyKeeper.GetModuleAccount(ctx, types.VestingAccountName).GetCoins().IsZero())
+
+	// insufficient coins
+	_, err = keeper.CreateVestingTransfer(ctx,
+		types.NewMsgCreateVestingTransfer(from, to, sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(1000)),
+			startTime, startTime+100, nil))
+	require.Error(t, err)
+}
+
+func TestKeeper_ReleaseVestedCoinsPerBlock(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	from, to := createVestingTestAccounts(t, ctx, keeper)
+
+	startTime := time.Now().Unix()
+	day := int64(types.VestingReleaseInterval)
+	ctx = ctx.WithBlockTime(time.Unix(startTime, 0))
+	for i := 0; i < 10; i++ {
+		_, err := keeper.CreateVestingTransfer(ctx, types.NewMsgCreateVestingTransfer(from, to,
+			sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(10)), startTime, startTime+10*day, nil))
+		require.NoError(t, err)
+	}
+
+	// returns the number of the schedules released in the block
+	releaseInBlock := func(blockTime int64) int {
+		blockCtx := ctx.WithBlockTime(time.Unix(blockTime, 0)).WithEventManager(sdk.NewEventManager())
+		keeper.ReleaseVestedCoins(blockCtx)
+		released := 0
+		for _, event := range blockCtx.EventManager().Events() {
+			if event.Type == types.EventTypeReleaseVestedCoins {
+				released++
+			}
+		}
+		return released
+	}
+
+	// the continuous vesting schedules aren't processed in the blocks within a day
+	for blockTime := startTime + 3; blockTime < startTime+day; blockTime += 300 {
+		require.Equal(t, 0, releaseInBlock(blockTime))
+	}
+	require.True(t, keeper.bankKeeper.GetCoins(ctx, to).IsZero())
+
+	// all of them are processed once a day
+	require.Equal(t, 10, releaseInBlock(startTime+day))
+	require.Equal(t, 0, releaseInBlock(startTime+day+3))
+	require.Equal(t, sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(10)), keeper.bankKeeper.GetCoins(ctx, to))
+}
+
+func TestKeeper_ReleaseVestedCoinsFailure(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	from, to := createVestingTestAccounts(t, ctx, keeper)
+
+	startTime := time.Now().Unix()
+	day := int64(types.VestingReleaseInterval)
+	amount := sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(100))
+	ctx = ctx.WithBlockTime(time.Unix(startTime, 0))
+	_, err := keeper.CreateVestingTransfer(ctx,
+		types.NewMsgCreateVestingTransfer(from, to, amount, startTime, startTime+day, nil))
+	require.NoError(t, err)
+
+	// the coins of the vesting module account are gone
+	setVestingAccountCoins := func(coins sdk.SysCoins) {
+		acc := mapp.AccountKeeper.GetAccount(ctx, mapp.supplyKeeper.GetModuleAddress(types.VestingAccountName))
+		require.NoError(t, acc.SetCoins(coins))
+		mapp.AccountKeeper.SetAccount(ctx, acc)
+	}
+	setVestingAccountCoins(sdk.SysCoins{})
+
+	// the schedule failing to release is skipped instead of halting the chain
+	ctx = ctx.WithBlockTime(time.Unix(startTime+day, 0))
+	require.NotPanics(t, func() { keeper.ReleaseVestedCoins(ctx) })
+	require.True(t, keeper.bankKeeper.GetCoins(ctx, to).IsZero())
+	require.Len(t, keeper.GetVestingSchedulesByRecipient(ctx, to), 1)
+
+	// and it's released in the next block
+	setVestingAccountCoins(amount)
+	ctx = ctx.WithBlockTime(time.Unix(startTime+day+3, 0))
+	keeper.ReleaseVestedCoins(ctx)
+	require.Equal(t, amount, keeper.bankKeeper.GetCoins(ctx, to))
+	require.Len(t, keeper.GetVestingSchedulesByRecipient(ctx, to), 0)
+}
+
+func TestVestingGenesis(t *testing.T) {
+	mapp, keeper, _ := getMockDexApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	from, to := createVestingTestAccounts(t, ctx, keeper)
+
+	startTime := time.Now().Unix()
+	ctx = ctx.WithBlockTime(time.Unix(startTime, 0))
+	_, err := keeper.CreateVestingTransfer(ctx, types.NewMsgCreateVestingTransfer(from, to,
+		sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(100)), startTime, startTime+100, nil))
+	require.NoError(t, err)
+
+	keeper.SetParams(ctx, types.DefaultParams())
+	exported := ExportGenesis(ctx, keeper)
+	require.Len(t, exported.VestingSchedules, 1)
+
+	newApp, newKeeper, _ := getMockDexApp(t, 0)
+	newApp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	newCtx := newApp.BaseApp.NewContext(false, abci.Header{}).WithBlockTime(time.Unix(startTime, 0))
+	initGenesis(newCtx, newKeeper, exported)
+	require.Equal(t, exported.VestingSchedules, newKeeper.GetVestingSchedulesByRecipient(newCtx, to))
+	require.Equal(t, keeper.getNextVestingScheduleID(ctx), newKeeper.getNextVestingScheduleID(newCtx))
+}
+
+func createVestingTestAccounts(t *testing.T, ctx sdk.Context, keeper Keeper) (from, to sdk.AccAddress) {
+	from = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	to = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	_, err := keeper.bankKeeper.AddCoins(ctx, from, sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(1000)))
+	require.NoError(t, err)
+	return from, to
+}